@@ -1,6 +1,9 @@
 package accessor
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/concourse/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/mitchellh/mapstructure"
@@ -21,7 +24,8 @@ type Access interface {
 
 type access struct {
 	*jwt.Token
-	action string
+	action        string
+	actionRoleMap ActionRoleMap
 }
 
 func (a *access) HasToken() bool {
@@ -55,18 +59,7 @@ func (a *access) IsAuthorized(team string) bool {
 }
 
 func (a *access) HasPermission(role string) bool {
-	switch requiredRoles[a.action] {
-	case "owner":
-		return role == "owner"
-	case "member":
-		return role == "owner" || role == "member"
-	case "pipeline-operator":
-		return role == "owner" || role == "member" || role == "pipeline-operator"
-	case "viewer":
-		return role == "owner" || role == "member" || role == "pipeline-operator" || role == "viewer"
-	default:
-		return false
-	}
+	return atc.RoleGrants(role, a.actionRoleMap[a.action])
 }
 
 func (a *access) IsAdmin() bool {
@@ -137,9 +130,17 @@ func (a *access) UserName() string {
 	return ""
 }
 
-var requiredRoles = map[string]string{
+// ActionRoleMap maps each action (i.e. route name) to the minimum role a user
+// must have on a team to perform it.
+type ActionRoleMap map[string]string
+
+// DefaultRoles is the policy used unless it is customized.
+var DefaultRoles = ActionRoleMap{
 	atc.SaveConfig:                    "member",
 	atc.GetConfig:                     "viewer",
+	atc.ListPipelineConfigHistory:     "viewer",
+	atc.GetPipelineConfigVersion:      "viewer",
+	atc.DiffPipelineConfigVersion:     "viewer",
 	atc.GetCC:                         "viewer",
 	atc.GetBuild:                      "viewer",
 	atc.GetBuildPlan:                  "viewer",
@@ -148,6 +149,7 @@ var requiredRoles = map[string]string{
 	atc.BuildEvents:                   "viewer",
 	atc.BuildResources:                "viewer",
 	atc.AbortBuild:                    "pipeline-operator",
+	atc.ApproveBuild:                  "member",
 	atc.GetBuildPreparation:           "viewer",
 	atc.GetJob:                        "viewer",
 	atc.CreateJobBuild:                "pipeline-operator",
@@ -156,6 +158,7 @@ var requiredRoles = map[string]string{
 	atc.ListJobBuilds:                 "viewer",
 	atc.ListJobInputs:                 "viewer",
 	atc.GetJobBuild:                   "viewer",
+	atc.SearchJobBuildLogs:            "viewer",
 	atc.PauseJob:                      "pipeline-operator",
 	atc.UnpauseJob:                    "pipeline-operator",
 	atc.GetVersionsDB:                 "viewer",
@@ -183,6 +186,7 @@ var requiredRoles = map[string]string{
 	atc.ListPipelines:                 "viewer",
 	atc.GetPipeline:                   "viewer",
 	atc.DeletePipeline:                "member",
+	atc.ArchivePipeline:               "member",
 	atc.OrderPipelines:                "member",
 	atc.PausePipeline:                 "pipeline-operator",
 	atc.UnpausePipeline:               "pipeline-operator",
@@ -199,11 +203,14 @@ var requiredRoles = map[string]string{
 	atc.HeartbeatWorker:               "member",
 	atc.ListWorkers:                   "viewer",
 	atc.DeleteWorker:                  "member",
+	atc.GetWorkerDemand:               "viewer",
+	atc.ListBuildQueue:                "viewer",
 	atc.SetLogLevel:                   "member",
 	atc.GetLogLevel:                   "viewer",
 	atc.DownloadCLI:                   "viewer",
 	atc.GetInfo:                       "viewer",
 	atc.GetInfoCreds:                  "viewer",
+	atc.GetInfoRBAC:                   "viewer",
 	atc.ListContainers:                "viewer",
 	atc.GetContainer:                  "viewer",
 	atc.HijackContainer:               "member",
@@ -218,7 +225,60 @@ var requiredRoles = map[string]string{
 	atc.RenameTeam:                    "owner",
 	atc.DestroyTeam:                   "owner",
 	atc.ListTeamBuilds:                "viewer",
+	atc.GetTeamUsage:                  "viewer",
 	atc.CreateArtifact:                "member",
 	atc.GetArtifact:                   "member",
 	atc.ListBuildArtifacts:            "viewer",
+	atc.SetWebhook:                    "member",
+	atc.DestroyWebhook:                "member",
+	atc.ReceiveWebhook:                "pipeline-operator",
+	atc.ListSecrets:                   "member",
+	atc.SetSecret:                     "member",
+	atc.DestroySecret:                 "member",
+	atc.SetPipelineSecret:             "member",
+	atc.DestroyPipelineSecret:         "member",
+	atc.ListSecretAccesses:            "viewer",
+	atc.PurgeSecretCache:              "member",
+}
+
+// CustomActionRoleMap is the format of an RBAC policy file: it maps each role
+// to the actions which should require it.
+type CustomActionRoleMap map[string][]string
+
+// Customize returns a copy of the map with the custom roles applied. Actions
+// which are not listed keep their role. It errors if an unknown role or
+// action is given, or if an action is listed under more than one role.
+func (m ActionRoleMap) Customize(custom CustomActionRoleMap) (ActionRoleMap, error) {
+	customized := ActionRoleMap{}
+	for action, role := range m {
+		customized[action] = role
+	}
+
+	var roles []string
+	for role := range custom {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	customizedRoles := map[string]string{}
+	for _, role := range roles {
+		if !atc.IsValidRole(role) {
+			return nil, fmt.Errorf("unknown role '%s'", role)
+		}
+
+		for _, action := range custom[role] {
+			if _, found := m[action]; !found {
+				return nil, fmt.Errorf("unknown action '%s' for role '%s'", action, role)
+			}
+
+			if otherRole, found := customizedRoles[action]; found {
+				return nil, fmt.Errorf("action '%s' is given for both role '%s' and role '%s'", action, otherRole, role)
+			}
+
+			customizedRoles[action] = role
+			customized[action] = role
+		}
+	}
+
+	return customized, nil
 }
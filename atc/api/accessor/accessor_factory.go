@@ -16,12 +16,14 @@ type AccessFactory interface {
 }
 
 type accessFactory struct {
-	publicKey *rsa.PublicKey
+	publicKey     *rsa.PublicKey
+	actionRoleMap ActionRoleMap
 }
 
-func NewAccessFactory(key *rsa.PublicKey) AccessFactory {
+func NewAccessFactory(key *rsa.PublicKey, actionRoleMap ActionRoleMap) AccessFactory {
 	return &accessFactory{
-		publicKey: key,
+		publicKey:     key,
+		actionRoleMap: actionRoleMap,
 	}
 }
 
@@ -29,19 +31,19 @@ func (a *accessFactory) Create(r *http.Request, action string) Access {
 
 	header := r.Header.Get("Authorization")
 	if header == "" {
-		return &access{nil, action}
+		return &access{nil, action, a.actionRoleMap}
 	}
 
 	if len(header) < 7 || strings.ToUpper(header[0:6]) != "BEARER" {
-		return &access{&jwt.Token{}, action}
+		return &access{&jwt.Token{}, action, a.actionRoleMap}
 	}
 
 	token, err := jwt.Parse(header[7:], a.validate)
 	if err != nil {
-		return &access{&jwt.Token{}, action}
+		return &access{&jwt.Token{}, action, a.actionRoleMap}
 	}
 
-	return &access{token, action}
+	return &access{token, action, a.actionRoleMap}
 }
 
 func (a *accessFactory) validate(token *jwt.Token) (interface{}, error) {
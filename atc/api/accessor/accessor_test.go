@@ -35,7 +35,7 @@ var _ = Describe("Accessor", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		publicKey := &key.PublicKey
-		accessorFactory = accessor.NewAccessFactory(publicKey)
+		accessorFactory = accessor.NewAccessFactory(publicKey, accessor.DefaultRoles)
 
 	})
 
@@ -462,6 +462,21 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetConfig, atc.GetConfig, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetConfig, atc.GetConfig, "viewer", true),
 
+		Entry("owner :: "+atc.ListPipelineConfigHistory, atc.ListPipelineConfigHistory, "owner", true),
+		Entry("member :: "+atc.ListPipelineConfigHistory, atc.ListPipelineConfigHistory, "member", true),
+		Entry("pipeline-operator :: "+atc.ListPipelineConfigHistory, atc.ListPipelineConfigHistory, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListPipelineConfigHistory, atc.ListPipelineConfigHistory, "viewer", true),
+
+		Entry("owner :: "+atc.GetPipelineConfigVersion, atc.GetPipelineConfigVersion, "owner", true),
+		Entry("member :: "+atc.GetPipelineConfigVersion, atc.GetPipelineConfigVersion, "member", true),
+		Entry("pipeline-operator :: "+atc.GetPipelineConfigVersion, atc.GetPipelineConfigVersion, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetPipelineConfigVersion, atc.GetPipelineConfigVersion, "viewer", true),
+
+		Entry("owner :: "+atc.DiffPipelineConfigVersion, atc.DiffPipelineConfigVersion, "owner", true),
+		Entry("member :: "+atc.DiffPipelineConfigVersion, atc.DiffPipelineConfigVersion, "member", true),
+		Entry("pipeline-operator :: "+atc.DiffPipelineConfigVersion, atc.DiffPipelineConfigVersion, "pipeline-operator", true),
+		Entry("viewer :: "+atc.DiffPipelineConfigVersion, atc.DiffPipelineConfigVersion, "viewer", true),
+
 		Entry("owner :: "+atc.GetCC, atc.GetCC, "owner", true),
 		Entry("member :: "+atc.GetCC, atc.GetCC, "member", true),
 		Entry("pipeline-operator :: "+atc.GetCC, atc.GetCC, "pipeline-operator", true),
@@ -502,6 +517,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.AbortBuild, atc.AbortBuild, "pipeline-operator", true),
 		Entry("viewer :: "+atc.AbortBuild, atc.AbortBuild, "viewer", false),
 
+		Entry("owner :: "+atc.ApproveBuild, atc.ApproveBuild, "owner", true),
+		Entry("member :: "+atc.ApproveBuild, atc.ApproveBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.ApproveBuild, atc.ApproveBuild, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ApproveBuild, atc.ApproveBuild, "viewer", false),
+
 		Entry("owner :: "+atc.GetBuildPreparation, atc.GetBuildPreparation, "owner", true),
 		Entry("member :: "+atc.GetBuildPreparation, atc.GetBuildPreparation, "member", true),
 		Entry("pipeline-operator :: "+atc.GetBuildPreparation, atc.GetBuildPreparation, "pipeline-operator", true),
@@ -542,6 +562,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetJobBuild, atc.GetJobBuild, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetJobBuild, atc.GetJobBuild, "viewer", true),
 
+		Entry("owner :: "+atc.SearchJobBuildLogs, atc.SearchJobBuildLogs, "owner", true),
+		Entry("member :: "+atc.SearchJobBuildLogs, atc.SearchJobBuildLogs, "member", true),
+		Entry("pipeline-operator :: "+atc.SearchJobBuildLogs, atc.SearchJobBuildLogs, "pipeline-operator", true),
+		Entry("viewer :: "+atc.SearchJobBuildLogs, atc.SearchJobBuildLogs, "viewer", true),
+
 		Entry("owner :: "+atc.PauseJob, atc.PauseJob, "owner", true),
 		Entry("member :: "+atc.PauseJob, atc.PauseJob, "member", true),
 		Entry("pipeline-operator :: "+atc.PauseJob, atc.PauseJob, "pipeline-operator", true),
@@ -677,6 +702,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.UnpausePipeline, atc.UnpausePipeline, "pipeline-operator", true),
 		Entry("viewer :: "+atc.UnpausePipeline, atc.UnpausePipeline, "viewer", false),
 
+		Entry("owner :: "+atc.ArchivePipeline, atc.ArchivePipeline, "owner", true),
+		Entry("member :: "+atc.ArchivePipeline, atc.ArchivePipeline, "member", true),
+		Entry("pipeline-operator :: "+atc.ArchivePipeline, atc.ArchivePipeline, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ArchivePipeline, atc.ArchivePipeline, "viewer", false),
+
 		Entry("owner :: "+atc.ExposePipeline, atc.ExposePipeline, "owner", true),
 		Entry("member :: "+atc.ExposePipeline, atc.ExposePipeline, "member", true),
 		Entry("pipeline-operator :: "+atc.ExposePipeline, atc.ExposePipeline, "pipeline-operator", false),
@@ -737,11 +767,21 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.ListWorkers, atc.ListWorkers, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListWorkers, atc.ListWorkers, "viewer", true),
 
+		Entry("owner :: "+atc.GetWorkerDemand, atc.GetWorkerDemand, "owner", true),
+		Entry("member :: "+atc.GetWorkerDemand, atc.GetWorkerDemand, "member", true),
+		Entry("pipeline-operator :: "+atc.GetWorkerDemand, atc.GetWorkerDemand, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetWorkerDemand, atc.GetWorkerDemand, "viewer", true),
+
 		Entry("owner :: "+atc.DeleteWorker, atc.DeleteWorker, "owner", true),
 		Entry("member :: "+atc.DeleteWorker, atc.DeleteWorker, "member", true),
 		Entry("pipeline-operator :: "+atc.DeleteWorker, atc.DeleteWorker, "pipeline-operator", false),
 		Entry("viewer :: "+atc.DeleteWorker, atc.DeleteWorker, "viewer", false),
 
+		Entry("owner :: "+atc.ListBuildQueue, atc.ListBuildQueue, "owner", true),
+		Entry("member :: "+atc.ListBuildQueue, atc.ListBuildQueue, "member", true),
+		Entry("pipeline-operator :: "+atc.ListBuildQueue, atc.ListBuildQueue, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListBuildQueue, atc.ListBuildQueue, "viewer", true),
+
 		Entry("owner :: "+atc.SetLogLevel, atc.SetLogLevel, "owner", true),
 		Entry("member :: "+atc.SetLogLevel, atc.SetLogLevel, "member", true),
 		Entry("pipeline-operator :: "+atc.SetLogLevel, atc.SetLogLevel, "pipeline-operator", false),
@@ -767,6 +807,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetInfoCreds, atc.GetInfoCreds, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetInfoCreds, atc.GetInfoCreds, "viewer", true),
 
+		Entry("owner :: "+atc.GetInfoRBAC, atc.GetInfoRBAC, "owner", true),
+		Entry("member :: "+atc.GetInfoRBAC, atc.GetInfoRBAC, "member", true),
+		Entry("pipeline-operator :: "+atc.GetInfoRBAC, atc.GetInfoRBAC, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetInfoRBAC, atc.GetInfoRBAC, "viewer", true),
+
 		Entry("owner :: "+atc.ListContainers, atc.ListContainers, "owner", true),
 		Entry("member :: "+atc.ListContainers, atc.ListContainers, "member", true),
 		Entry("pipeline-operator :: "+atc.ListContainers, atc.ListContainers, "pipeline-operator", true),
@@ -836,6 +881,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "viewer", true),
 
+		Entry("owner :: "+atc.GetTeamUsage, atc.GetTeamUsage, "owner", true),
+		Entry("member :: "+atc.GetTeamUsage, atc.GetTeamUsage, "member", true),
+		Entry("pipeline-operator :: "+atc.GetTeamUsage, atc.GetTeamUsage, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetTeamUsage, atc.GetTeamUsage, "viewer", true),
+
 		Entry("owner :: "+atc.CreateArtifact, atc.CreateArtifact, "owner", true),
 		Entry("member :: "+atc.CreateArtifact, atc.CreateArtifact, "member", true),
 		Entry("pipeline-operator :: "+atc.CreateArtifact, atc.CreateArtifact, "pipeline-operator", false),
@@ -850,5 +900,174 @@ var _ = Describe("Accessor", func() {
 		Entry("member :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "member", true),
 		Entry("pipeline-operator :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "viewer", true),
+
+		Entry("owner :: "+atc.SetWebhook, atc.SetWebhook, "owner", true),
+		Entry("member :: "+atc.SetWebhook, atc.SetWebhook, "member", true),
+		Entry("pipeline-operator :: "+atc.SetWebhook, atc.SetWebhook, "pipeline-operator", false),
+		Entry("viewer :: "+atc.SetWebhook, atc.SetWebhook, "viewer", false),
+
+		Entry("owner :: "+atc.DestroyWebhook, atc.DestroyWebhook, "owner", true),
+		Entry("member :: "+atc.DestroyWebhook, atc.DestroyWebhook, "member", true),
+		Entry("pipeline-operator :: "+atc.DestroyWebhook, atc.DestroyWebhook, "pipeline-operator", false),
+		Entry("viewer :: "+atc.DestroyWebhook, atc.DestroyWebhook, "viewer", false),
+
+		Entry("owner :: "+atc.ReceiveWebhook, atc.ReceiveWebhook, "owner", true),
+		Entry("member :: "+atc.ReceiveWebhook, atc.ReceiveWebhook, "member", true),
+		Entry("pipeline-operator :: "+atc.ReceiveWebhook, atc.ReceiveWebhook, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ReceiveWebhook, atc.ReceiveWebhook, "viewer", false),
+
+		Entry("owner :: "+atc.ListSecrets, atc.ListSecrets, "owner", true),
+		Entry("member :: "+atc.ListSecrets, atc.ListSecrets, "member", true),
+		Entry("pipeline-operator :: "+atc.ListSecrets, atc.ListSecrets, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ListSecrets, atc.ListSecrets, "viewer", false),
+
+		Entry("owner :: "+atc.SetSecret, atc.SetSecret, "owner", true),
+		Entry("member :: "+atc.SetSecret, atc.SetSecret, "member", true),
+		Entry("pipeline-operator :: "+atc.SetSecret, atc.SetSecret, "pipeline-operator", false),
+		Entry("viewer :: "+atc.SetSecret, atc.SetSecret, "viewer", false),
+
+		Entry("owner :: "+atc.DestroySecret, atc.DestroySecret, "owner", true),
+		Entry("member :: "+atc.DestroySecret, atc.DestroySecret, "member", true),
+		Entry("pipeline-operator :: "+atc.DestroySecret, atc.DestroySecret, "pipeline-operator", false),
+		Entry("viewer :: "+atc.DestroySecret, atc.DestroySecret, "viewer", false),
+
+		Entry("owner :: "+atc.SetPipelineSecret, atc.SetPipelineSecret, "owner", true),
+		Entry("member :: "+atc.SetPipelineSecret, atc.SetPipelineSecret, "member", true),
+		Entry("pipeline-operator :: "+atc.SetPipelineSecret, atc.SetPipelineSecret, "pipeline-operator", false),
+		Entry("viewer :: "+atc.SetPipelineSecret, atc.SetPipelineSecret, "viewer", false),
+
+		Entry("owner :: "+atc.DestroyPipelineSecret, atc.DestroyPipelineSecret, "owner", true),
+		Entry("member :: "+atc.DestroyPipelineSecret, atc.DestroyPipelineSecret, "member", true),
+		Entry("pipeline-operator :: "+atc.DestroyPipelineSecret, atc.DestroyPipelineSecret, "pipeline-operator", false),
+		Entry("viewer :: "+atc.DestroyPipelineSecret, atc.DestroyPipelineSecret, "viewer", false),
+
+		Entry("owner :: "+atc.ListSecretAccesses, atc.ListSecretAccesses, "owner", true),
+		Entry("member :: "+atc.ListSecretAccesses, atc.ListSecretAccesses, "member", true),
+		Entry("pipeline-operator :: "+atc.ListSecretAccesses, atc.ListSecretAccesses, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListSecretAccesses, atc.ListSecretAccesses, "viewer", true),
+
+		Entry("owner :: "+atc.PurgeSecretCache, atc.PurgeSecretCache, "owner", true),
+		Entry("member :: "+atc.PurgeSecretCache, atc.PurgeSecretCache, "member", true),
+		Entry("pipeline-operator :: "+atc.PurgeSecretCache, atc.PurgeSecretCache, "pipeline-operator", false),
+		Entry("viewer :: "+atc.PurgeSecretCache, atc.PurgeSecretCache, "viewer", false),
 	)
+
+	Describe("customized roles", func() {
+		var customRoles accessor.CustomActionRoleMap
+
+		BeforeEach(func() {
+			customRoles = accessor.CustomActionRoleMap{
+				"owner":  {atc.SaveConfig},
+				"viewer": {atc.PausePipeline},
+			}
+		})
+
+		JustBeforeEach(func() {
+			actionRoleMap, err := accessor.DefaultRoles.Customize(customRoles)
+			Expect(err).NotTo(HaveOccurred())
+
+			accessorFactory = accessor.NewAccessFactory(&key.PublicKey, actionRoleMap)
+		})
+
+		DescribeTable("role actions",
+			func(action, role string, authorized bool) {
+				claims := &jwt.MapClaims{"teams": map[string][]string{"some-team": {role}}}
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+				tokenString, err := token.SignedString(key)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+				access := accessorFactory.Create(req, action)
+
+				Expect(access.IsAuthorized("some-team")).To(Equal(authorized))
+			},
+			Entry("owner :: "+atc.SaveConfig, atc.SaveConfig, "owner", true),
+			Entry("member :: "+atc.SaveConfig, atc.SaveConfig, "member", false),
+
+			Entry("pipeline-operator :: "+atc.PausePipeline, atc.PausePipeline, "pipeline-operator", true),
+			Entry("viewer :: "+atc.PausePipeline, atc.PausePipeline, "viewer", true),
+
+			Entry("member :: "+atc.DeletePipeline, atc.DeletePipeline, "member", true),
+			Entry("pipeline-operator :: "+atc.DeletePipeline, atc.DeletePipeline, "pipeline-operator", false),
+		)
+	})
+})
+
+var _ = Describe("ActionRoleMap", func() {
+	Describe("Customize", func() {
+		var (
+			customRoles   accessor.CustomActionRoleMap
+			actionRoleMap accessor.ActionRoleMap
+			err           error
+		)
+
+		JustBeforeEach(func() {
+			actionRoleMap, err = accessor.DefaultRoles.Customize(customRoles)
+		})
+
+		Context("when no roles are customized", func() {
+			BeforeEach(func() {
+				customRoles = nil
+			})
+
+			It("returns the same roles", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actionRoleMap).To(Equal(accessor.DefaultRoles))
+			})
+		})
+
+		Context("when an action is given a different role", func() {
+			BeforeEach(func() {
+				customRoles = accessor.CustomActionRoleMap{
+					"owner": {atc.SaveConfig},
+				}
+			})
+
+			It("overrides the role for the action", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actionRoleMap[atc.SaveConfig]).To(Equal("owner"))
+				Expect(actionRoleMap[atc.GetConfig]).To(Equal(accessor.DefaultRoles[atc.GetConfig]))
+			})
+
+			It("does not modify the original map", func() {
+				Expect(accessor.DefaultRoles[atc.SaveConfig]).To(Equal("member"))
+			})
+		})
+
+		Context("when an unknown role is given", func() {
+			BeforeEach(func() {
+				customRoles = accessor.CustomActionRoleMap{
+					"some-role": {atc.SaveConfig},
+				}
+			})
+
+			It("errors", func() {
+				Expect(err).To(MatchError("unknown role 'some-role'"))
+			})
+		})
+
+		Context("when an unknown action is given", func() {
+			BeforeEach(func() {
+				customRoles = accessor.CustomActionRoleMap{
+					"owner": {"SomeAction"},
+				}
+			})
+
+			It("errors", func() {
+				Expect(err).To(MatchError("unknown action 'SomeAction' for role 'owner'"))
+			})
+		})
+
+		Context("when an action is given for multiple roles", func() {
+			BeforeEach(func() {
+				customRoles = accessor.CustomActionRoleMap{
+					"owner":  {atc.SaveConfig},
+					"viewer": {atc.SaveConfig},
+				}
+			})
+
+			It("errors", func() {
+				Expect(err).To(MatchError("action 'SaveConfig' is given for both role 'owner' and role 'viewer'"))
+			})
+		})
+	})
 })
@@ -15,7 +15,10 @@ import (
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/containerserver/containerserverfakes"
+	"github.com/concourse/concourse/atc/api/jobserver"
+	"github.com/concourse/concourse/atc/api/queueserver/queueserverfakes"
 	"github.com/concourse/concourse/atc/api/resourceserver/resourceserverfakes"
+	"github.com/concourse/concourse/atc/api/workerserver/workerserverfakes"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
@@ -34,32 +37,39 @@ var (
 	externalURL = "https://example.com"
 	clusterName = "Test Cluster"
 
-	fakeWorkerClient        *workerfakes.FakeClient
-	fakeVolumeRepository    *dbfakes.FakeVolumeRepository
-	fakeContainerRepository *dbfakes.FakeContainerRepository
-	fakeDestroyer           *gcfakes.FakeDestroyer
-	dbTeamFactory           *dbfakes.FakeTeamFactory
-	dbPipelineFactory       *dbfakes.FakePipelineFactory
-	dbJobFactory            *dbfakes.FakeJobFactory
-	dbResourceFactory       *dbfakes.FakeResourceFactory
-	dbResourceConfigFactory *dbfakes.FakeResourceConfigFactory
-	fakePipeline            *dbfakes.FakePipeline
-	fakeAccess              *accessorfakes.FakeAccess
-	fakeAccessor            *accessorfakes.FakeAccessFactory
-	dbWorkerFactory         *dbfakes.FakeWorkerFactory
-	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
-	build                   *dbfakes.FakeBuild
-	dbBuildFactory          *dbfakes.FakeBuildFactory
-	dbTeam                  *dbfakes.FakeTeam
-	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
-	fakeSecretManager       *credsfakes.FakeSecrets
-	credsManagers           creds.Managers
-	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
-	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
-	expire                  time.Duration
-	isTLSEnabled            bool
-	cliDownloadsDir         string
-	logger                  *lagertest.TestLogger
+	fakeWorkerClient             *workerfakes.FakeClient
+	fakeVolumeRepository         *dbfakes.FakeVolumeRepository
+	fakeContainerRepository      *dbfakes.FakeContainerRepository
+	fakeDestroyer                *gcfakes.FakeDestroyer
+	dbTeamFactory                *dbfakes.FakeTeamFactory
+	dbPipelineFactory            *dbfakes.FakePipelineFactory
+	dbJobFactory                 *dbfakes.FakeJobFactory
+	dbResourceFactory            *dbfakes.FakeResourceFactory
+	dbResourceConfigFactory      *dbfakes.FakeResourceConfigFactory
+	dbSecretAccessRepository     *dbfakes.FakeSecretAccessRepository
+	dbSecretCachePurgeRepository *dbfakes.FakeSecretCachePurgeRepository
+	fakePipeline                 *dbfakes.FakePipeline
+	fakeAccess                   *accessorfakes.FakeAccess
+	fakeAccessor                 *accessorfakes.FakeAccessFactory
+	dbWorkerFactory              *dbfakes.FakeWorkerFactory
+	dbWorkerLifecycle            *dbfakes.FakeWorkerLifecycle
+	build                        *dbfakes.FakeBuild
+	dbBuildFactory               *dbfakes.FakeBuildFactory
+	dbTeam                       *dbfakes.FakeTeam
+	fakeScannerFactory           *resourceserverfakes.FakeScannerFactory
+	fakeBuildQueue               *queueserverfakes.FakeBuildQueue
+	fakeWorkerDemand             *workerserverfakes.FakeWorkerDemand
+	fakeSecretManager            *credsfakes.FakeSecrets
+	fakeVarSourcePool            *credsfakes.FakeVarSourcePool
+	credsManagers                creds.Managers
+	actionRoleMap                accessor.ActionRoleMap
+	interceptTimeoutFactory      *containerserverfakes.FakeInterceptTimeoutFactory
+	logSearchLimits              jobserver.LogSearchLimits
+	interceptTimeout             *containerserverfakes.FakeInterceptTimeout
+	expire                       time.Duration
+	isTLSEnabled                 bool
+	cliDownloadsDir              string
+	logger                       *lagertest.TestLogger
 
 	constructedEventHandler *fakeEventHandlerFactory
 
@@ -93,12 +103,20 @@ var _ = BeforeEach(func() {
 	dbJobFactory = new(dbfakes.FakeJobFactory)
 	dbResourceFactory = new(dbfakes.FakeResourceFactory)
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
+	dbSecretAccessRepository = new(dbfakes.FakeSecretAccessRepository)
+	dbSecretCachePurgeRepository = new(dbfakes.FakeSecretCachePurgeRepository)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
 	interceptTimeoutFactory.NewInterceptTimeoutReturns(interceptTimeout)
 
+	logSearchLimits = jobserver.LogSearchLimits{
+		MaxBuilds:  10,
+		Timeout:    time.Minute,
+		MaxMatches: 3,
+	}
+
 	dbTeam = new(dbfakes.FakeTeam)
 	dbTeam.IDReturns(734)
 	dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
@@ -117,13 +135,20 @@ var _ = BeforeEach(func() {
 	fakeWorkerClient = new(workerfakes.FakeClient)
 
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
+	fakeBuildQueue = new(queueserverfakes.FakeBuildQueue)
+	fakeWorkerDemand = new(workerserverfakes.FakeWorkerDemand)
 
 	fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
 	fakeDestroyer = new(gcfakes.FakeDestroyer)
 
 	fakeSecretManager = new(credsfakes.FakeSecrets)
+	fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
 	credsManagers = make(creds.Managers)
+	actionRoleMap = accessor.ActionRoleMap{
+		"some-action":  "member",
+		"other-action": "viewer",
+	}
 	var err error
 
 	cliDownloadsDir, err = ioutil.TempDir("", "cli-downloads")
@@ -172,6 +197,8 @@ var _ = BeforeEach(func() {
 		fakeDestroyer,
 		dbBuildFactory,
 		dbResourceConfigFactory,
+		dbSecretAccessRepository,
+		dbSecretCachePurgeRepository,
 
 		constructedEventHandler.Construct,
 
@@ -179,6 +206,9 @@ var _ = BeforeEach(func() {
 
 		fakeScannerFactory,
 
+		fakeBuildQueue,
+		fakeWorkerDemand,
+
 		sink,
 
 		isTLSEnabled,
@@ -187,8 +217,11 @@ var _ = BeforeEach(func() {
 		"1.2.3",
 		"4.5.6",
 		fakeSecretManager,
+		fakeVarSourcePool,
 		credsManagers,
+		actionRoleMap,
 		interceptTimeoutFactory,
+		logSearchLimits,
 	)
 
 	Expect(err).NotTo(HaveOccurred())
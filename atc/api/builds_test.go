@@ -1033,6 +1033,224 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/approvals/:plan_id", func() {
+		var (
+			body     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			body = `{"approved":true}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approvals/some-plan-id", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.UserNameReturns("some-user")
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					build.IsRunningReturns(true)
+					build.PrivatePlanReturns(atc.Plan{
+						ID: "some-timeout-id",
+						Timeout: &atc.TimeoutPlan{
+							Duration: "1h",
+							Step: atc.Plan{
+								ID:      "some-plan-id",
+								Approve: &atc.ApprovePlan{Name: "deploy-prod"},
+							},
+						},
+					})
+					build.ApproveReturns(true, nil)
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not approve the build", func() {
+						Expect(build.ApproveCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+
+					It("records the decision and who made it", func() {
+						Expect(build.ApproveCallCount()).To(Equal(1))
+						planID, approved, approvedBy := build.ApproveArgsForCall(0)
+						Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+						Expect(approved).To(BeTrue())
+						Expect(approvedBy).To(Equal("some-user"))
+					})
+
+					Context("when rejecting", func() {
+						BeforeEach(func() {
+							body = `{"approved":false}`
+						})
+
+						It("records the rejection", func() {
+							_, approved, _ := build.ApproveArgsForCall(0)
+							Expect(approved).To(BeFalse())
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							body = `{`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when the approve step is a combination of an across step", func() {
+						var acrossPlan atc.Plan
+
+						BeforeEach(func() {
+							acrossPlan = atc.Plan{
+								ID: "some-across-id",
+								Across: &atc.AcrossPlan{
+									Vars: []atc.AcrossVar{{Var: "env", ValuesVar: "envs"}},
+									Step: &atc.Plan{
+										ID:      "some-approve-id",
+										Approve: &atc.ApprovePlan{Name: "deploy-prod"},
+									},
+								},
+							}
+						})
+
+						Context("once the build has saved its combinations", func() {
+							BeforeEach(func() {
+								acrossPlan.Across.Steps = []atc.VarScopedPlan{
+									{
+										Step: atc.Plan{
+											ID:      "some-plan-id",
+											Approve: &atc.ApprovePlan{Name: "deploy-prod"},
+										},
+										Values: []interface{}{"prod"},
+									},
+								}
+
+								build.PrivatePlanReturns(acrossPlan)
+							})
+
+							It("records the decision", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+								Expect(build.ApproveCallCount()).To(Equal(1))
+
+								planID, _, _ := build.ApproveArgsForCall(0)
+								Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+							})
+						})
+
+						Context("before the build has saved its combinations", func() {
+							BeforeEach(func() {
+								build.PrivatePlanReturns(acrossPlan)
+							})
+
+							It("returns 404", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+							})
+						})
+					})
+
+					Context("when the plan is not an approve step", func() {
+						BeforeEach(func() {
+							build.PrivatePlanReturns(atc.Plan{
+								ID:   "some-plan-id",
+								Task: &atc.TaskPlan{Name: "some-task"},
+							})
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when the build is not running", func() {
+						BeforeEach(func() {
+							build.IsRunningReturns(false)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not approve the build", func() {
+							Expect(build.ApproveCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the step has already been decided", func() {
+						BeforeEach(func() {
+							build.ApproveReturns(false, nil)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when approving fails", func() {
+						BeforeEach(func() {
+							build.ApproveReturns(false, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1047,10 +1265,11 @@ var _ = Describe("Builds API", func() {
 
 			BeforeEach(func() {
 				buildPrep = db.BuildPreparation{
-					BuildID:          42,
-					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
-					PausedJob:        db.BuildPreparationStatusNotBlocking,
-					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					BuildID:              42,
+					PausedPipeline:       db.BuildPreparationStatusNotBlocking,
+					PausedJob:            db.BuildPreparationStatusNotBlocking,
+					MaxRunningBuilds:     db.BuildPreparationStatusBlocking,
+					TeamMaxRunningBuilds: db.BuildPreparationStatusBlocking,
 					Inputs: map[string]db.BuildPreparationStatus{
 						"foo": db.BuildPreparationStatusUnknown,
 						"bar": db.BuildPreparationStatusBlocking,
@@ -1185,6 +1404,7 @@ var _ = Describe("Builds API", func() {
 					"paused_pipeline": "not_blocking",
 					"paused_job": "not_blocking",
 					"max_running_builds": "blocking",
+					"team_max_running_builds": "blocking",
 					"inputs": {
 						"foo": "unknown",
 						"bar": "blocking"
@@ -0,0 +1,69 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// ApproveBuild decides one of a running build's approve steps, recording the
+// requester as the approver. Only the first decision on a step counts.
+func (s *Server) ApproveBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		planID := atc.PlanID(rata.Param(r, "plan_id"))
+
+		aLog := s.logger.Session("approve", lager.Data{
+			"build": build.ID(),
+			"plan":  planID,
+		})
+
+		var approval atc.BuildApproval
+		err := json.NewDecoder(r.Body).Decode(&approval)
+		if err != nil {
+			aLog.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var found bool
+		build.PrivatePlan().Each(func(plan atc.Plan) {
+			if plan.ID == planID && plan.Approve != nil {
+				found = true
+			}
+		})
+
+		if !found {
+			aLog.Debug("approve-step-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !build.IsRunning() {
+			aLog.Debug("build-not-running")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		approvedBy := accessor.GetAccessor(r).UserName()
+
+		recorded, err := build.Approve(planID, approval.Approved, approvedBy)
+		if err != nil {
+			aLog.Error("failed-to-approve-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !recorded {
+			aLog.Debug("already-decided")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
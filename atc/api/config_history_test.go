@@ -0,0 +1,261 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config History API", func() {
+	var (
+		requestGenerator *rata.RequestGenerator
+		fakeaccess       *accessorfakes.FakeAccess
+
+		savedAt time.Time
+		configs map[db.ConfigVersion]atc.Config
+
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		requestGenerator = rata.NewRequestGenerator(server.URL, atc.Routes)
+
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.IsAuthenticatedReturns(true)
+		fakeaccess.IsAuthorizedReturns(true)
+
+		savedAt = time.Unix(1565187265, 0)
+
+		configs = map[db.ConfigVersion]atc.Config{
+			3: {
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			},
+			5: {
+				Jobs: atc.JobConfigs{{Name: "some-job", Public: true}},
+			},
+		}
+
+		fakePipeline.ConfigHistoryReturns([]db.PipelineConfigVersion{
+			{Version: 5, Author: "some-other-user", CreatedAt: savedAt.Add(time.Minute)},
+			{Version: 3, Author: "some-user", CreatedAt: savedAt},
+		}, nil)
+
+		fakePipeline.ConfigAtVersionStub = func(version db.ConfigVersion) (db.PipelineConfigVersion, bool, error) {
+			config, found := configs[version]
+			if !found {
+				return db.PipelineConfigVersion{}, false, nil
+			}
+
+			return db.PipelineConfigVersion{
+				Version:   version,
+				Author:    "some-user",
+				CreatedAt: savedAt,
+				Config:    config,
+			}, true, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/history", func() {
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.ListPipelineConfigHistory, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the saved versions without their configs", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			var history []atc.PipelineConfigVersion
+			err := json.NewDecoder(response.Body).Decode(&history)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(history).To(Equal([]atc.PipelineConfigVersion{
+				{Version: 5, Author: "some-other-user", CreatedAt: savedAt.Add(time.Minute).Unix()},
+				{Version: 3, Author: "some-user", CreatedAt: savedAt.Unix()},
+			}))
+		})
+
+		Context("when getting the history fails", func() {
+			BeforeEach(func() {
+				fakePipeline.ConfigHistoryReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/history/:config_version", func() {
+		var version string
+
+		BeforeEach(func() {
+			version = "3"
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetPipelineConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": version,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the version with its config", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var configVersion atc.PipelineConfigVersion
+			err := json.NewDecoder(response.Body).Decode(&configVersion)
+			Expect(err).NotTo(HaveOccurred())
+
+			config := configs[3]
+			Expect(configVersion).To(Equal(atc.PipelineConfigVersion{
+				Version:   3,
+				Author:    "some-user",
+				CreatedAt: savedAt.Unix(),
+				Config:    &config,
+			}))
+		})
+
+		Context("when the version is not in the history", func() {
+			BeforeEach(func() {
+				version = "4"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the version is not a number", func() {
+			BeforeEach(func() {
+				version = "latest"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/history/:config_version/diff", func() {
+		var (
+			version string
+			from    string
+		)
+
+		BeforeEach(func() {
+			version = "5"
+			from = ""
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.DiffPipelineConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": version,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if from != "" {
+				req.URL.RawQuery = atc.PipelineConfigDiffQueryFrom + "=" + from
+			}
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("diffs the version against the one saved before it", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var diff atc.PipelineConfigDiff
+			err := json.NewDecoder(response.Body).Decode(&diff)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(diff.From).To(Equal(3))
+			Expect(diff.To).To(Equal(5))
+			Expect(diff.Changes).To(HaveLen(1))
+			Expect(diff.Changes[0].Kind).To(Equal("job"))
+			Expect(diff.Changes[0].Name).To(Equal("some-job"))
+			Expect(diff.Changes[0].After).To(ContainSubstring("public: true"))
+		})
+
+		Context("when the version is the first in the history", func() {
+			BeforeEach(func() {
+				version = "3"
+			})
+
+			It("diffs it against an empty config", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var diff atc.PipelineConfigDiff
+				err := json.NewDecoder(response.Body).Decode(&diff)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(diff.From).To(Equal(0))
+				Expect(diff.Changes).To(HaveLen(1))
+				Expect(diff.Changes[0].Before).To(BeEmpty())
+			})
+		})
+
+		Context("when diffing from a given version", func() {
+			BeforeEach(func() {
+				from = "5"
+			})
+
+			It("diffs against it", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var diff atc.PipelineConfigDiff
+				err := json.NewDecoder(response.Body).Decode(&diff)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(diff.From).To(Equal(5))
+				Expect(diff.Changes).To(BeEmpty())
+			})
+		})
+
+		Context("when the version to diff from is not in the history", func() {
+			BeforeEach(func() {
+				from = "4"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
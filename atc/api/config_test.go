@@ -429,7 +429,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -457,7 +457,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -482,18 +482,65 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							_, pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
+						Context("when the user is logged in", func() {
+							BeforeEach(func() {
+								fakeaccess.UserNameReturns("some-user")
+							})
+
+							It("records them as the config's author", func() {
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+								author, _, _, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
+								Expect(author).To(Equal("some-user"))
+							})
+						})
+
+						Context("when instance vars are given", func() {
+							BeforeEach(func() {
+								query := request.URL.Query()
+								query.Add("instance_vars", `{"branch":"feature"}`)
+								request.URL.RawQuery = query.Encode()
+							})
+
+							It("saves the pipeline instance", func() {
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+								_, pipelineRef, _, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
+								Expect(pipelineRef).To(Equal(atc.PipelineRef{
+									Name:         "a-pipeline",
+									InstanceVars: atc.InstanceVars{"branch": "feature"},
+								}))
+							})
+						})
+
+						Context("when the instance vars are malformed", func() {
+							BeforeEach(func() {
+								query := request.URL.Query()
+								query.Add("instance_vars", "bogus")
+								request.URL.RawQuery = query.Encode()
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not save anything", func() {
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -508,7 +555,7 @@ var _ = Describe("Config API", func() {
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -542,7 +589,99 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the config has passed constraints on jobs in other pipelines", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs[0].Plan[0].Passed = []string{"other-pipeline/other-job"}
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							Context("when the job exists", func() {
+								BeforeEach(func() {
+									fakePipeline.JobReturns(new(dbfakes.FakeJob), true, nil)
+								})
+
+								It("saves it without warnings", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{}`))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+								})
+
+								It("looks up the job in the team's pipeline", func() {
+									Expect(dbTeam.PipelineCallCount()).To(Equal(1))
+									Expect(dbTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "other-pipeline"}))
+									Expect(fakePipeline.JobArgsForCall(0)).To(Equal("other-job"))
+								})
+							})
+
+							Context("when the job does not exist", func() {
+								BeforeEach(func() {
+									fakePipeline.JobReturns(nil, false, nil)
+								})
+
+								It("saves it with a warning", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"warnings": [{
+											"type": "pipeline",
+											"message": "jobs.some-job.get.some-input.passed references a job that does not exist ('other-pipeline/other-job')"
+										}]
+									}`))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when the pipeline does not exist", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineReturns(nil, false, nil)
+								})
+
+								It("saves it with a warning", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"warnings": [{
+											"type": "pipeline",
+											"message": "jobs.some-job.get.some-input.passed references a pipeline that does not exist ('other-pipeline')"
+										}]
+									}`))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+								})
+
+								Context("when the pipeline only exists with instance vars", func() {
+									BeforeEach(func() {
+										instance := new(dbfakes.FakePipeline)
+										instance.NameReturns("other-pipeline")
+										instance.InstanceVarsReturns(atc.InstanceVars{"branch": "feature"})
+										dbTeam.PipelinesReturns([]db.Pipeline{instance}, nil)
+									})
+
+									It("saves it with a warning that instances cannot be referenced", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusOK))
+										Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+											"warnings": [{
+												"type": "pipeline",
+												"message": "jobs.some-job.get.some-input.passed references a pipeline which only exists with instance vars ('other-pipeline'); only pipelines without instance vars can be referenced"
+											}]
+										}`))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+									})
+								})
+							})
+
+							Context("when looking up the pipeline fails", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineReturns(nil, false, errors.New("nope"))
+								})
+
+								It("returns 500 without saving", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
+								})
 							})
 						})
 					})
@@ -566,19 +705,19 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							_, pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+							_, _, savedConfig, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -620,10 +759,10 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-								Expect(name).To(Equal("a-pipeline"))
+								_, pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
+								Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
 										{
@@ -685,7 +824,7 @@ jobs:
 									})
 
 									It("passes validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 									})
 
 									It("returns 200 ok", func() {
@@ -701,7 +840,7 @@ jobs:
 									})
 
 									It("fail validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 									})
 
 									It("returns 400", func() {
@@ -891,10 +1030,10 @@ jobs:
 									})
 
 									It("passes validation and saves it un-interpolated", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-										name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-										Expect(name).To(Equal("a-pipeline"))
+										_, pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
+										Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 
 										Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -942,7 +1081,7 @@ jobs:
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -952,7 +1091,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -990,7 +1129,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -1040,10 +1179,10 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-								Expect(name).To(Equal("a-pipeline"))
+								_, pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
+								Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
 								Expect(pipelineState).To(Equal(expectedDBValue))
@@ -1052,7 +1191,7 @@ jobs:
 							Context("when it's the first time the pipeline has been created", func() {
 								BeforeEach(func() {
 									returnedPipeline := new(dbfakes.FakePipeline)
-									dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+									dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 								})
 
 								It("returns 201", func() {
@@ -1062,7 +1201,7 @@ jobs:
 
 							Context("and saving it fails", func() {
 								BeforeEach(func() {
-									dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+									dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 								})
 
 								It("returns 500", func() {
@@ -1097,7 +1236,7 @@ jobs:
 								})
 
 								It("does not save it", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+									Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
 								})
 							})
 
@@ -1232,7 +1371,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 								})
 							})
 
@@ -1275,7 +1414,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 								})
 							})
 						})
@@ -1297,7 +1436,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 
@@ -1334,10 +1473,10 @@ jobs:
 					})
 
 					It("saves it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-						name, savedConfig, id, _ := dbTeam.SavePipelineArgsForCall(0)
-						Expect(name).To(Equal("a-pipeline"))
+						_, pipelineRef, savedConfig, id, _ := dbTeam.SavePipelineAsArgsForCall(0)
+						Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
 								{
@@ -1389,7 +1528,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -1417,7 +1556,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+					Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -1432,7 +1571,7 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 			})
 		})
 	})
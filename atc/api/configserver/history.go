@@ -0,0 +1,149 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigHistory(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-config-history")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		history, err := pipeline.ConfigHistory()
+		if err != nil {
+			logger.Error("failed-to-get-config-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(present.PipelineConfigHistory(history))
+		if err != nil {
+			logger.Error("failed-to-encode-config-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GetConfigVersion(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-config-version")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version, err := strconv.Atoi(rata.Param(r, "config_version"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		configVersion, found, err := pipeline.ConfigAtVersion(db.ConfigVersion(version))
+		if err != nil {
+			logger.Error("failed-to-get-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("config-version-not-found", lager.Data{"version": version})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		presented := present.PipelineConfigVersion(configVersion)
+		presented.Config = &configVersion.Config
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// DiffConfigVersion diffs a version against the version given by the 'from'
+// query param, defaulting to the version saved before it. A version with no
+// earlier version in the history is diffed against an empty config.
+func (s *Server) DiffConfigVersion(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("diff-config-version")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version, err := strconv.Atoi(rata.Param(r, "config_version"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var from int
+		if fromParam := r.URL.Query().Get(atc.PipelineConfigDiffQueryFrom); fromParam != "" {
+			from, err = strconv.Atoi(fromParam)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		} else {
+			history, err := pipeline.ConfigHistory()
+			if err != nil {
+				logger.Error("failed-to-get-config-history", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			for _, configVersion := range history {
+				if int(configVersion.Version) < version {
+					from = int(configVersion.Version)
+					break
+				}
+			}
+		}
+
+		after, found, err := pipeline.ConfigAtVersion(db.ConfigVersion(version))
+		if err != nil {
+			logger.Error("failed-to-get-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("config-version-not-found", lager.Data{"version": version})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var before db.PipelineConfigVersion
+		if from != 0 {
+			before, found, err = pipeline.ConfigAtVersion(db.ConfigVersion(from))
+			if err != nil {
+				logger.Error("failed-to-get-config-version", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				logger.Debug("config-version-not-found", lager.Data{"version": from})
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(atc.PipelineConfigDiff{
+			From:    from,
+			To:      version,
+			Changes: before.Config.Changes(after.Config),
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-config-diff", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -362,10 +362,51 @@ var _ = Describe("Containers API", func() {
 					_, err := client.Do(req)
 					Expect(err).NotTo(HaveOccurred())
 
-					pipelineName, resourceName, secretManager := dbTeam.FindCheckContainersArgsForCall(0)
-					Expect(pipelineName).To(Equal("some-pipeline"))
+					pipelineRef, resourceName, secretManager, varSourcePool := dbTeam.FindCheckContainersArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 					Expect(resourceName).To(Equal("some-resource"))
 					Expect(secretManager).To(Equal(fakeSecretManager))
+					Expect(varSourcePool).To(Equal(fakeVarSourcePool))
+				})
+
+				Context("when the pipeline is an instance", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"type":          []string{"check"},
+							"resource_name": []string{"some-resource"},
+							"pipeline_name": []string{"some-pipeline"},
+							"instance_vars": []string{`{"branch":"feature"}`},
+						}.Encode()
+					})
+
+					It("queries with the pipeline instance", func() {
+						_, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						pipelineRef, _, _, _ := dbTeam.FindCheckContainersArgsForCall(0)
+						Expect(pipelineRef).To(Equal(atc.PipelineRef{
+							Name:         "some-pipeline",
+							InstanceVars: atc.InstanceVars{"branch": "feature"},
+						}))
+					})
+				})
+
+				Context("when the instance vars are invalid", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"type":          []string{"check"},
+							"resource_name": []string{"some-resource"},
+							"pipeline_name": []string{"some-pipeline"},
+							"instance_vars": []string{`{`},
+						}.Encode()
+					})
+
+					It("returns 400 Bad Request", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
 				})
 			})
 		})
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/artifactserver"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/ccserver"
@@ -16,10 +17,13 @@ import (
 	"github.com/concourse/concourse/atc/api/jobserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
+	"github.com/concourse/concourse/atc/api/queueserver"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
+	"github.com/concourse/concourse/atc/api/secretserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
+	"github.com/concourse/concourse/atc/api/webhookserver"
 	"github.com/concourse/concourse/atc/api/workerserver"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -48,6 +52,8 @@ func NewHandler(
 	destroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
+	secretAccessRepository db.SecretAccessRepository,
+	secretCachePurgeRepository db.SecretCachePurgeRepository,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
 
@@ -55,6 +61,9 @@ func NewHandler(
 
 	scannerFactory resourceserver.ScannerFactory,
 
+	buildQueue queueserver.BuildQueue,
+	workerDemand workerserver.WorkerDemand,
+
 	sink *lager.ReconfigurableSink,
 
 	isTLSEnabled bool,
@@ -63,8 +72,11 @@ func NewHandler(
 	version string,
 	workerVersion string,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	credsManagers creds.Managers,
+	actionRoleMap accessor.ActionRoleMap,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
+	logSearchLimits jobserver.LogSearchLimits,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -77,26 +89,33 @@ func NewHandler(
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
 	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
-	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory, secretManager, dbResourceFactory, dbResourceConfigFactory)
+	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, logSearchLimits)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, secretManager, varSourcePool, dbResourceFactory, dbResourceConfigFactory)
 
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager, varSourcePool)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
-	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory)
+	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, workerDemand)
+	queueServer := queueserver.NewServer(logger, buildQueue)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
-	containerServer := containerserver.NewServer(logger, workerClient, secretManager, interceptTimeoutFactory, containerRepository, destroyer)
+	containerServer := containerserver.NewServer(logger, workerClient, secretManager, varSourcePool, interceptTimeoutFactory, containerRepository, destroyer)
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
 	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
-	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
+	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers, actionRoleMap)
 	artifactServer := artifactserver.NewServer(logger, workerClient)
+	webhookServer := webhookserver.NewServer(logger, dbTeamFactory, scannerFactory, secretManager, varSourcePool)
+	secretServer := secretserver.NewServer(logger, secretAccessRepository, secretCachePurgeRepository)
 
 	handlers := map[string]http.Handler{
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
 		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
 
+		atc.ListPipelineConfigHistory: pipelineHandlerFactory.HandlerFor(configServer.ListConfigHistory),
+		atc.GetPipelineConfigVersion:  pipelineHandlerFactory.HandlerFor(configServer.GetConfigVersion),
+		atc.DiffPipelineConfigVersion: pipelineHandlerFactory.HandlerFor(configServer.DiffConfigVersion),
+
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
@@ -104,21 +123,23 @@ func NewHandler(
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.ApproveBuild:        buildHandlerFactory.HandlerFor(buildServer.ApproveBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
 
-		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.ListAllJobs:        http.HandlerFunc(jobServer.ListAllJobs),
+		atc.ListJobs:           pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:             pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:      pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:      pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.GetJobBuild:        pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.SearchJobBuildLogs: pipelineHandlerFactory.HandlerFor(jobServer.SearchJobBuildLogs),
+		atc.CreateJobBuild:     pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:           pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:         pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.JobBadge:           pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 		atc.MainJobBadge: mainredirect.Handler{
 			Routes: atc.Routes,
 			Route:  atc.JobBadge,
@@ -133,6 +154,7 @@ func NewHandler(
 		atc.OrderPipelines:      http.HandlerFunc(pipelineServer.OrderPipelines),
 		atc.PausePipeline:       pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
 		atc.UnpausePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
+		atc.ArchivePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.ArchivePipeline),
 		atc.ExposePipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
 		atc.HidePipeline:        pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:       pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
@@ -167,6 +189,9 @@ func NewHandler(
 		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
 		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
 		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
+		atc.GetWorkerDemand: http.HandlerFunc(workerServer.GetWorkerDemand),
+
+		atc.ListBuildQueue: http.HandlerFunc(queueServer.ListBuildQueue),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -174,6 +199,7 @@ func NewHandler(
 		atc.DownloadCLI:  http.HandlerFunc(cliServer.Download),
 		atc.GetInfo:      http.HandlerFunc(infoServer.Info),
 		atc.GetInfoCreds: http.HandlerFunc(infoServer.Creds),
+		atc.GetInfoRBAC:  http.HandlerFunc(infoServer.RBAC),
 
 		atc.ListContainers:           teamHandlerFactory.HandlerFor(containerServer.ListContainers),
 		atc.GetContainer:             teamHandlerFactory.HandlerFor(containerServer.GetContainer),
@@ -191,9 +217,22 @@ func NewHandler(
 		atc.RenameTeam:     http.HandlerFunc(teamServer.RenameTeam),
 		atc.DestroyTeam:    http.HandlerFunc(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: http.HandlerFunc(teamServer.ListTeamBuilds),
+		atc.GetTeamUsage:   http.HandlerFunc(teamServer.GetTeamUsage),
 
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
+
+		atc.SetWebhook:     teamHandlerFactory.HandlerFor(webhookServer.SetWebhook),
+		atc.DestroyWebhook: teamHandlerFactory.HandlerFor(webhookServer.DestroyWebhook),
+		atc.ReceiveWebhook: http.HandlerFunc(webhookServer.ReceiveWebhook),
+
+		atc.ListSecrets:           teamHandlerFactory.HandlerFor(secretServer.ListSecrets),
+		atc.SetSecret:             teamHandlerFactory.HandlerFor(secretServer.SetSecret),
+		atc.DestroySecret:         teamHandlerFactory.HandlerFor(secretServer.DestroySecret),
+		atc.SetPipelineSecret:     teamHandlerFactory.HandlerFor(secretServer.SetSecret),
+		atc.DestroyPipelineSecret: teamHandlerFactory.HandlerFor(secretServer.DestroySecret),
+		atc.ListSecretAccesses:    http.HandlerFunc(secretServer.ListSecretAccesses),
+		atc.PurgeSecretCache:      teamHandlerFactory.HandlerFor(secretServer.PurgeSecretCache),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
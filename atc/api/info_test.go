@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"os"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -13,9 +14,11 @@ import (
 	awsssm "github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/concourse/concourse/atc/creds/credhub"
+	"github.com/concourse/concourse/atc/creds/dbsecrets"
 	"github.com/concourse/concourse/atc/creds/secretsmanager"
 	"github.com/concourse/concourse/atc/creds/ssm"
 	"github.com/concourse/concourse/atc/creds/vault"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	vaultapi "github.com/hashicorp/vault/api"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -70,6 +73,54 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/info/rbac", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/info/rbac")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("contains the roles and the role required for each action", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"roles": ["owner", "member", "pipeline-operator", "viewer"],
+					"actions": {
+						"some-action": "member",
+						"other-action": "viewer"
+					}
+				}`))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/info/creds", func() {
 		var (
 			response   *http.Response
@@ -457,5 +508,104 @@ var _ = Describe("Pipelines API", func() {
 			})
 
 		})
+
+		Context("db", func() {
+			var fakeTeamFactory *dbfakes.FakeTeamFactory
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+
+				credsManagers["db"] = &dbsecrets.DBManager{
+					Enabled:     true,
+					TeamFactory: fakeTeamFactory,
+				}
+			})
+
+			It("includes the db health info in json response", func() {
+				Expect(body).To(MatchJSON(`{
+					"db": {
+						"health": {
+							"response": {
+								"status": "UP"
+							},
+							"method": "FindTeam"
+						}
+					}
+				}`))
+			})
+
+			Context("when the database cannot be queried", func() {
+				BeforeEach(func() {
+					fakeTeamFactory.FindTeamReturns(nil, false, errors.New("some error occurred"))
+				})
+
+				It("includes the error in json response", func() {
+					Expect(body).To(MatchJSON(`{
+						"db": {
+							"health": {
+								"error": "some error occurred",
+								"method": "FindTeam"
+							}
+						}
+					}`))
+				})
+			})
+		})
+
+		Context("several credential managers", func() {
+			var caCertPath string
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				credsManagers["db"] = &dbsecrets.DBManager{
+					Enabled:     true,
+					TeamFactory: new(dbfakes.FakeTeamFactory),
+				}
+
+				caCert, err := ioutil.TempFile("", "ca-cert")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = caCert.WriteString("not-a-cert")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(caCert.Close()).To(Succeed())
+
+				caCertPath = caCert.Name()
+
+				credhubManager := &credhub.CredHubManager{
+					URL: "https://credhub.example.com",
+					TLS: credhub.TLS{CACerts: []string{caCertPath}},
+				}
+				Expect(credhubManager.Init(lager.NewLogger("credhub"))).To(Succeed())
+
+				credsManagers["credhub"] = credhubManager
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(caCertPath)).To(Succeed())
+			})
+
+			It("reports the health of each of them, even if one cannot be reported on", func() {
+				Expect(body).To(MatchJSON(`{
+					"credhub": {
+						"health": {
+							"error": "provided ca certs are invalid"
+						}
+					},
+					"db": {
+						"health": {
+							"response": {
+								"status": "UP"
+							},
+							"method": "FindTeam"
+						}
+					}
+				}`))
+			})
+		})
 	})
 })
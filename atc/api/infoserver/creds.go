@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/creds"
 )
 
-// Creds returns information on the credential manager attached to this instance of concourse.
+// Creds returns information on the credential managers attached to this instance of concourse.
 // If no credential manager is configured the response will be empty.
 // No actual credentials are shown in the response.
 func (s *Server) Creds(w http.ResponseWriter, r *http.Request) {
@@ -15,12 +16,34 @@ func (s *Server) Creds(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 
-	configuredManagers := make(creds.Managers)
+	configuredManagers := map[string]json.RawMessage{}
 
 	for name, manager := range s.credsManagers {
-		if manager.IsConfigured() {
-			configuredManagers[name] = manager
+		if !manager.IsConfigured() {
+			continue
 		}
+
+		// one unhealthy credential manager shouldn't hide the others, so
+		// report its error in place of its info
+		info, err := json.Marshal(manager)
+		if err != nil {
+			logger.Error("failed-to-encode-manager-info", err, lager.Data{"manager": name})
+
+			if marshalErr, ok := err.(*json.MarshalerError); ok {
+				err = marshalErr.Err
+			}
+
+			info, err = json.Marshal(map[string]interface{}{
+				"health": creds.HealthResponse{Error: err.Error()},
+			})
+			if err != nil {
+				logger.Error("failed-to-encode-manager-error", err, lager.Data{"manager": name})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		configuredManagers[name] = info
 	}
 
 	err := json.NewEncoder(w).Encode(configuredManagers)
@@ -0,0 +1,25 @@
+package infoserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// RBAC returns the role-based access control policy in effect, i.e. the
+// minimum role required for each action.
+func (s *Server) RBAC(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("rbac")
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(atc.RBACInfo{
+		Roles:   atc.Roles,
+		Actions: s.actionRoleMap,
+	})
+	if err != nil {
+		logger.Error("failed-to-encode-rbac", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -1396,6 +1397,337 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/search", func() {
+		var response *http.Response
+		var queryParams string
+
+		var build1, build2 *dbfakes.FakeBuild
+		var source1, source2 *dbfakes.FakeEventSource
+
+		logEvent := func(originID string, payload string) event.Envelope {
+			data, err := json.Marshal(event.Log{
+				Origin:  event.Origin{ID: event.OriginID(originID), Source: event.OriginSourceStdout},
+				Payload: payload,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			raw := json.RawMessage(data)
+			return event.Envelope{
+				Event:   event.EventTypeLog,
+				Version: "5.1",
+				Data:    &raw,
+			}
+		}
+
+		eventSource := func(envelopes ...event.Envelope) *dbfakes.FakeEventSource {
+			source := new(dbfakes.FakeEventSource)
+			for i, envelope := range envelopes {
+				source.NextReturnsOnCall(i, envelope, nil)
+			}
+			source.NextReturnsOnCall(len(envelopes), event.Envelope{}, db.ErrEndOfBuildEventStream)
+			return source
+		}
+
+		BeforeEach(func() {
+			queryParams = "?q=error"
+
+			plan := json.RawMessage(`{"id":"1","do":[{"id":"2","get":{"name":"some-input"}},{"id":"3","task":{"name":"some-task"}}]}`)
+
+			build1 = new(dbfakes.FakeBuild)
+			build1.IDReturns(4)
+			build1.NameReturns("2")
+			build1.StatusReturns(db.BuildStatusFailed)
+			build1.PublicPlanReturns(&plan)
+			source1 = eventSource(
+				logEvent("2", "fetching\n"),
+				logEvent("3", "an err"),
+				logEvent("3", "or occurred\nretrying\n"),
+				logEvent("3", "final error"),
+			)
+			build1.EventsReturns(source1, nil)
+
+			build2 = new(dbfakes.FakeBuild)
+			build2.IDReturns(2)
+			build2.NameReturns("1")
+			build2.StatusReturns(db.BuildStatusSucceeded)
+			source2 = eventSource(logEvent("3", "all good\n"))
+			build2.EventsReturns(source2, nil)
+
+			fakeJob.BuildsReturns([]db.Build{build1, build2}, db.Pagination{}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/search" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				Context("and the job is public", func() {
+					BeforeEach(func() {
+						fakeJob.PublicReturns(true)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("and the job is private", func() {
+					BeforeEach(func() {
+						fakeJob.PublicReturns(false)
+					})
+
+					Context("when authenticated", func() {
+						BeforeEach(func() {
+							fakeaccess.IsAuthenticatedReturns(true)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+
+					Context("when not authenticated", func() {
+						It("returns 401", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						})
+					})
+
+					It("does not search the logs", func() {
+						Expect(fakeJob.BuildsCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+				fakePipeline.JobReturns(fakeJob, true, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("searches up to the maximum number of builds", func() {
+				Expect(fakeJob.BuildsCallCount()).To(Equal(1))
+				Expect(fakeJob.BuildsArgsForCall(0)).To(Equal(db.Page{Limit: 10}))
+			})
+
+			It("returns the matching lines of each build, with the step they came from", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"builds": [
+						{
+							"build_id": 4,
+							"build_name": "2",
+							"status": "failed",
+							"matches": [
+								{
+									"origin": {"id": "3", "source": "stdout", "step_type": "task", "step_name": "some-task"},
+									"line": "an error occurred"
+								},
+								{
+									"origin": {"id": "3", "source": "stdout", "step_type": "task", "step_name": "some-task"},
+									"line": "final error"
+								}
+							]
+						}
+					],
+					"searched": 2
+				}`))
+			})
+
+			It("closes the event sources", func() {
+				Expect(source1.CloseCallCount()).ToNot(BeZero())
+				Expect(source2.CloseCallCount()).ToNot(BeZero())
+			})
+
+			Context("when a limit is given", func() {
+				BeforeEach(func() {
+					queryParams = "?q=error&limit=3"
+				})
+
+				It("searches that many builds", func() {
+					Expect(fakeJob.BuildsArgsForCall(0)).To(Equal(db.Page{Limit: 3}))
+				})
+			})
+
+			Context("when the limit is above the maximum", func() {
+				BeforeEach(func() {
+					queryParams = "?q=error&limit=50"
+				})
+
+				It("searches the maximum number of builds", func() {
+					Expect(fakeJob.BuildsArgsForCall(0)).To(Equal(db.Page{Limit: 10}))
+				})
+			})
+
+			Context("when searching by regular expression", func() {
+				BeforeEach(func() {
+					queryParams = "?q=%5Eall%7Cfetch&regex=true"
+				})
+
+				It("returns the lines matching the expression", func() {
+					var results atc.BuildLogSearchResults
+					Expect(json.NewDecoder(response.Body).Decode(&results)).To(Succeed())
+
+					Expect(results.Builds).To(HaveLen(2))
+					Expect(results.Builds[0].Matches).To(Equal([]atc.BuildLogMatch{
+						{
+							Origin: atc.BuildLogMatchOrigin{ID: "2", Source: "stdout", StepType: "get", StepName: "some-input"},
+							Line:   "fetching",
+						},
+					}))
+					Expect(results.Builds[1].BuildName).To(Equal("1"))
+					Expect(results.Builds[1].Matches[0].Line).To(Equal("all good"))
+				})
+			})
+
+			Context("when the regular expression is invalid", func() {
+				BeforeEach(func() {
+					queryParams = "?q=%28&regex=true"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when no query is given", func() {
+				BeforeEach(func() {
+					queryParams = ""
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when a build is running", func() {
+				BeforeEach(func() {
+					build1.IsRunningReturns(true)
+				})
+
+				It("skips it", func() {
+					Expect(build1.EventsCallCount()).To(BeZero())
+
+					var results atc.BuildLogSearchResults
+					Expect(json.NewDecoder(response.Body).Decode(&results)).To(Succeed())
+					Expect(results.Searched).To(Equal(1))
+					Expect(results.Builds).To(BeEmpty())
+				})
+			})
+
+			Context("when there are more matches than the maximum", func() {
+				BeforeEach(func() {
+					queryParams = "?q=e"
+				})
+
+				It("returns the maximum number of matches, and says that there are more", func() {
+					var results atc.BuildLogSearchResults
+					Expect(json.NewDecoder(response.Body).Decode(&results)).To(Succeed())
+
+					Expect(results.Truncated).To(BeTrue())
+					Expect(results.Searched).To(Equal(1))
+					Expect(results.Builds).To(HaveLen(1))
+					Expect(results.Builds[0].Matches).To(HaveLen(3))
+					Expect(results.Builds[0].Matches[2].Line).To(Equal("retrying"))
+					Expect(build2.EventsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when there are exactly the maximum number of matches", func() {
+				BeforeEach(func() {
+					queryParams = "?q=%5E%28fetch%7Can%7Cfinal%29&regex=true"
+					fakeJob.BuildsReturns([]db.Build{build1}, db.Pagination{}, nil)
+				})
+
+				It("does not say that there are more", func() {
+					var results atc.BuildLogSearchResults
+					Expect(json.NewDecoder(response.Body).Decode(&results)).To(Succeed())
+
+					Expect(results.Builds[0].Matches).To(HaveLen(3))
+					Expect(results.Truncated).To(BeFalse())
+				})
+			})
+
+			Context("when the search times out", func() {
+				BeforeEach(func() {
+					source := new(dbfakes.FakeEventSource)
+					source.NextReturns(event.Envelope{}, db.ErrBuildEventStreamClosed)
+					build1.EventsReturns(source, nil)
+				})
+
+				It("returns the results so far", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var results atc.BuildLogSearchResults
+					Expect(json.NewDecoder(response.Body).Decode(&results)).To(Succeed())
+					Expect(results.TimedOut).To(BeTrue())
+					Expect(results.Searched).To(BeZero())
+					Expect(build2.EventsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when reading the events fails", func() {
+				BeforeEach(func() {
+					build1.EventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the builds fails", func() {
+				BeforeEach(func() {
+					fakeJob.BuildsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", func() {
 		var request *http.Request
 		var response *http.Response
@@ -0,0 +1,328 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// LogSearchLimits bounds the work done by a single build log search.
+type LogSearchLimits struct {
+	// MaxBuilds is the maximum number of builds searched by one request, and
+	// the number searched when no limit is given.
+	MaxBuilds int
+
+	// Timeout is how long a search may run before returning the matches
+	// found so far.
+	Timeout time.Duration
+
+	// MaxMatches is the maximum number of matching lines returned by one
+	// request, after which the search stops.
+	MaxMatches int
+}
+
+func (s *Server) SearchJobBuildLogs(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("search-job-build-logs")
+
+		jobName := r.FormValue(":job_name")
+		teamName := r.FormValue(":team_name")
+
+		pattern := r.FormValue(atc.BuildLogSearchQueryPattern)
+		if pattern == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "missing query parameter '%s'", atc.BuildLogSearchQueryPattern)
+			return
+		}
+
+		var match func(string) bool
+		if r.FormValue(atc.BuildLogSearchQueryRegex) == "true" {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "invalid regular expression: %s", err)
+				return
+			}
+
+			match = re.MatchString
+		} else {
+			match = func(line string) bool {
+				return strings.Contains(line, pattern)
+			}
+		}
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.BuildLogSearchQueryLimit))
+		if limit <= 0 || limit > s.logSearchLimits.MaxBuilds {
+			limit = s.logSearchLimits.MaxBuilds
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// like build events, the logs of a private job in a public pipeline
+		// are only visible to the team
+		acc := accessor.GetAccessor(r)
+		if !job.Public() && !acc.IsAuthorized(teamName) {
+			if acc.IsAuthenticated() {
+				s.rejector.Forbidden(w, r)
+				return
+			}
+
+			s.rejector.Unauthorized(w, r)
+			return
+		}
+
+		builds, _, err := job.Builds(db.Page{Limit: limit})
+		if err != nil {
+			logger.Error("failed-to-get-job-builds", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), s.logSearchLimits.Timeout)
+		defer cancel()
+
+		results := atc.BuildLogSearchResults{
+			Builds: []atc.BuildLogMatches{},
+		}
+
+		remaining := s.logSearchLimits.MaxMatches
+
+		for _, build := range builds {
+			if remaining <= 0 {
+				results.Truncated = true
+				break
+			}
+
+			if ctx.Err() != nil {
+				results.TimedOut = true
+				break
+			}
+
+			// the logs of running builds are still being written, and reading
+			// them would wait for the build to finish
+			if build.IsRunning() {
+				continue
+			}
+
+			matches, truncated, err := searchBuildLogs(ctx, build, match, remaining)
+			if err == db.ErrBuildEventStreamClosed {
+				results.TimedOut = true
+				break
+			}
+
+			if err != nil {
+				logger.Error("failed-to-search-build-logs", err, lager.Data{"build": build.ID()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			results.Searched++
+			remaining -= len(matches)
+
+			if truncated {
+				results.Truncated = true
+			}
+
+			if len(matches) > 0 {
+				results.Builds = append(results.Builds, atc.BuildLogMatches{
+					BuildID:   build.ID(),
+					BuildName: build.Name(),
+					Status:    string(build.Status()),
+					Matches:   matches,
+				})
+			}
+
+			if truncated {
+				break
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(results)
+		if err != nil {
+			logger.Error("failed-to-encode-results", err)
+		}
+	})
+}
+
+// searchBuildLogs returns at most maxMatches matching lines from the build's
+// logs, and true if it stopped searching because there were more.
+func searchBuildLogs(ctx context.Context, build db.Build, match func(string) bool, maxMatches int) ([]atc.BuildLogMatch, bool, error) {
+	events, err := build.Events(0)
+	if err != nil {
+		return nil, false, err
+	}
+
+	done := make(chan struct{})
+	closed := make(chan struct{})
+
+	go func() {
+		defer close(closed)
+
+		select {
+		case <-ctx.Done():
+			// unblocks Next with ErrBuildEventStreamClosed
+			_ = events.Close()
+		case <-done:
+		}
+	}()
+
+	defer func() {
+		close(done)
+		<-closed
+		_ = events.Close()
+	}()
+
+	steps := planSteps(build.PublicPlan())
+
+	// log events carry arbitrary chunks of output, so lines are buffered per
+	// origin until they are complete
+	partial := map[event.Origin]string{}
+	var origins []event.Origin
+
+	var matches []atc.BuildLogMatch
+	truncated := false
+	matchLine := func(origin event.Origin, line string) {
+		if truncated || !match(line) {
+			return
+		}
+
+		if len(matches) == maxMatches {
+			truncated = true
+			return
+		}
+
+		step := steps[atc.PlanID(origin.ID)]
+
+		matches = append(matches, atc.BuildLogMatch{
+			Origin: atc.BuildLogMatchOrigin{
+				ID:       string(origin.ID),
+				Source:   string(origin.Source),
+				StepType: step.stepType,
+				StepName: step.name,
+			},
+			Line: line,
+		})
+	}
+
+	for !truncated {
+		envelope, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if envelope.Event != event.EventTypeLog || envelope.Data == nil {
+			continue
+		}
+
+		var log event.Log
+		err = json.Unmarshal(*envelope.Data, &log)
+		if err != nil {
+			// events from older versions may not decode; they are skipped
+			continue
+		}
+
+		buffered, seen := partial[log.Origin]
+		if !seen {
+			origins = append(origins, log.Origin)
+		}
+
+		lines := strings.Split(buffered+log.Payload, "\n")
+		for _, line := range lines[:len(lines)-1] {
+			matchLine(log.Origin, strings.TrimSuffix(line, "\r"))
+		}
+
+		partial[log.Origin] = lines[len(lines)-1]
+	}
+
+	for _, origin := range origins {
+		if partial[origin] != "" {
+			matchLine(origin, partial[origin])
+		}
+	}
+
+	return matches, truncated, nil
+}
+
+type planStep struct {
+	stepType string
+	name     string
+}
+
+var namedStepTypes = []string{"get", "put", "task", "set_pipeline", "load_var", "approve"}
+
+// planSteps maps the IDs of the named steps in a public build plan to their
+// type and name, so that log lines can be attributed to them.
+func planSteps(publicPlan *json.RawMessage) map[atc.PlanID]planStep {
+	steps := map[atc.PlanID]planStep{}
+
+	if publicPlan == nil {
+		return steps
+	}
+
+	var plan interface{}
+	err := json.Unmarshal(*publicPlan, &plan)
+	if err != nil {
+		return steps
+	}
+
+	collectPlanSteps(plan, steps)
+
+	return steps
+}
+
+func collectPlanSteps(node interface{}, steps map[atc.PlanID]planStep) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, child := range n {
+			collectPlanSteps(child, steps)
+		}
+
+	case map[string]interface{}:
+		if id, ok := n["id"].(string); ok {
+			for _, stepType := range namedStepTypes {
+				step, ok := n[stepType].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				name, _ := step["name"].(string)
+				steps[atc.PlanID(id)] = planStep{
+					stepType: stepType,
+					name:     name,
+				}
+			}
+		}
+
+		for _, child := range n {
+			collectPlanSteps(child, steps)
+		}
+	}
+}
@@ -14,6 +14,8 @@ type Server struct {
 	rejector      auth.Rejector
 	secretManager creds.Secrets
 	jobFactory    db.JobFactory
+
+	logSearchLimits LogSearchLimits
 }
 
 func NewServer(
@@ -21,6 +23,7 @@ func NewServer(
 	externalURL string,
 	secretManager creds.Secrets,
 	jobFactory db.JobFactory,
+	logSearchLimits LogSearchLimits,
 ) *Server {
 	return &Server{
 		logger:        logger,
@@ -28,5 +31,7 @@ func NewServer(
 		rejector:      auth.UnauthorizedRejector{},
 		secretManager: secretManager,
 		jobFactory:    jobFactory,
+
+		logSearchLimits: logSearchLimits,
 	}
 }
@@ -13,12 +13,13 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 	}
 
 	return atc.BuildPreparation{
-		BuildID:             preparation.BuildID,
-		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
-		PausedJob:           atc.BuildPreparationStatus(preparation.PausedJob),
-		MaxRunningBuilds:    atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
-		Inputs:              inputs,
-		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
-		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+		BuildID:              preparation.BuildID,
+		PausedPipeline:       atc.BuildPreparationStatus(preparation.PausedPipeline),
+		PausedJob:            atc.BuildPreparationStatus(preparation.PausedJob),
+		MaxRunningBuilds:     atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
+		TeamMaxRunningBuilds: atc.BuildPreparationStatus(preparation.TeamMaxRunningBuilds),
+		Inputs:               inputs,
+		InputsSatisfied:      atc.BuildPreparationStatus(preparation.InputsSatisfied),
+		MissingInputReasons:  atc.MissingInputReasons(preparation.MissingInputReasons),
 	}
 }
@@ -0,0 +1,22 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func PipelineConfigHistory(history []db.PipelineConfigVersion) []atc.PipelineConfigVersion {
+	presented := []atc.PipelineConfigVersion{}
+	for _, configVersion := range history {
+		presented = append(presented, PipelineConfigVersion(configVersion))
+	}
+	return presented
+}
+
+func PipelineConfigVersion(configVersion db.PipelineConfigVersion) atc.PipelineConfigVersion {
+	return atc.PipelineConfigVersion{
+		Version:   int(configVersion.Version),
+		Author:    configVersion.Author,
+		CreatedAt: configVersion.CreatedAt.Unix(),
+	}
+}
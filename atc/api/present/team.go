@@ -6,9 +6,16 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	presentedTeam := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	quotas := team.Quotas()
+	if quotas != (atc.TeamQuotas{}) {
+		presentedTeam.Quotas = &quotas
+	}
+
+	return presentedTeam
 }
@@ -27,6 +27,8 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		NoProxy:          workerInfo.NoProxy(),
 		ActiveContainers: workerInfo.ActiveContainers(),
 		ActiveVolumes:    workerInfo.ActiveVolumes(),
+		MaxContainers:    workerInfo.MaxContainers(),
+		ActiveTasks:      workerInfo.ActiveTasks(),
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
 		Tags:             workerInfo.Tags(),
@@ -36,5 +38,7 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		StartTime:        workerInfo.StartTime(),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Runtime:          workerInfo.Runtime(),
+		Namespace:        workerInfo.Namespace(),
 	}
 }
@@ -0,0 +1,141 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build Queue API", func() {
+	var (
+		fakeaccess *accessorfakes.FakeAccess
+	)
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/queue", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/queue", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not look up the queue", func() {
+				Expect(fakeBuildQueue.StatusCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedStub = func(teamName string) bool {
+					return teamName == "some-team"
+				}
+
+				fakeBuildQueue.StatusReturns(atc.BuildQueue{
+					Workers:             3,
+					WorkersWithCapacity: 0,
+					Entries: []atc.BuildQueueEntry{
+						{Position: 1, BuildID: 12, BuildName: "4", TeamName: "other-team", PipelineName: "other-pipeline", JobName: "other-job", Priority: 10, QueuedAt: 1565703016},
+						{Position: 2, BuildID: 7, BuildName: "2", TeamName: "some-team", PipelineName: "some-pipeline", JobName: "some-job", QueuedAt: 1565703000},
+					},
+				}, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns only the entries of the user's teams, keeping their positions", func() {
+				var queue atc.BuildQueue
+				err := json.NewDecoder(response.Body).Decode(&queue)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(queue).To(Equal(atc.BuildQueue{
+					Workers:             3,
+					WorkersWithCapacity: 0,
+					Entries: []atc.BuildQueueEntry{
+						{Position: 2, BuildID: 7, BuildName: "2", TeamName: "some-team", PipelineName: "some-pipeline", JobName: "some-job", QueuedAt: 1565703000},
+					},
+				}))
+			})
+
+			Context("when the user is an admin", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAdminReturns(true)
+				})
+
+				It("returns every entry", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"workers": 3,
+						"workers_with_capacity": 0,
+						"entries": [
+							{
+								"position": 1,
+								"build_id": 12,
+								"build_name": "4",
+								"team_name": "other-team",
+								"pipeline_name": "other-pipeline",
+								"job_name": "other-job",
+								"priority": 10,
+								"queued_at": 1565703016
+							},
+							{
+								"position": 2,
+								"build_id": 7,
+								"build_name": "2",
+								"team_name": "some-team",
+								"pipeline_name": "some-pipeline",
+								"job_name": "some-job",
+								"priority": 0,
+								"queued_at": 1565703000
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when getting the queue fails", func() {
+				BeforeEach(func() {
+					fakeBuildQueue.StatusReturns(atc.BuildQueue{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package queueserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+func (s *Server) ListBuildQueue(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-build-queue")
+
+	status, err := s.buildQueue.Status(logger)
+	if err != nil {
+		logger.Error("failed-to-get-build-queue", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	if !acc.IsAdmin() {
+		visibleEntries := []atc.BuildQueueEntry{}
+		for _, entry := range status.Entries {
+			if acc.IsAuthorized(entry.TeamName) {
+				visibleEntries = append(visibleEntries, entry)
+			}
+		}
+
+		status.Entries = visibleEntries
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(status)
+	if err != nil {
+		logger.Error("failed-to-encode-build-queue", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package queueserverfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/queueserver"
+)
+
+type FakeBuildQueue struct {
+	StatusStub        func(lager.Logger) (atc.BuildQueue, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	statusReturns struct {
+		result1 atc.BuildQueue
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 atc.BuildQueue
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildQueue) Status(arg1 lager.Logger) (atc.BuildQueue, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("Status", []interface{}{arg1})
+	fake.statusMutex.Unlock()
+	if fake.StatusStub != nil {
+		return fake.StatusStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.statusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildQueue) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeBuildQueue) StatusCalls(stub func(lager.Logger) (atc.BuildQueue, error)) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *FakeBuildQueue) StatusArgsForCall(i int) lager.Logger {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	argsForCall := fake.statusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildQueue) StatusReturns(result1 atc.BuildQueue, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 atc.BuildQueue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildQueue) StatusReturnsOnCall(i int, result1 atc.BuildQueue, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildQueue
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 atc.BuildQueue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildQueue) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildQueue) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ queueserver.BuildQueue = new(FakeBuildQueue)
@@ -0,0 +1,28 @@
+package queueserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . BuildQueue
+
+type BuildQueue interface {
+	Status(logger lager.Logger) (atc.BuildQueue, error)
+}
+
+type Server struct {
+	logger lager.Logger
+
+	buildQueue BuildQueue
+}
+
+func NewServer(
+	logger lager.Logger,
+	buildQueue BuildQueue,
+) *Server {
+	return &Server{
+		logger:     logger,
+		buildQueue: buildQueue,
+	}
+}
@@ -580,6 +580,20 @@ var _ = Describe("Resources API", func() {
 				fakeaccess.IsAuthorizedReturns(true)
 			})
 
+			Context("when the pipeline is archived", func() {
+				BeforeEach(func() {
+					fakePipeline.ArchivedReturns(true)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when looking up the resource fails", func() {
 				BeforeEach(func() {
 					fakePipeline.ResourceReturns(nil, false, errors.New("nope"))
@@ -607,8 +621,8 @@ var _ = Describe("Resources API", func() {
 
 				It("injects the proper pipelineDB", func() {
 					Expect(dbTeam.PipelineCallCount()).To(Equal(1))
-					pipelineName := dbTeam.PipelineArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := dbTeam.PipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				})
 
 				It("tries to scan with no version specified", func() {
@@ -1354,6 +1368,20 @@ var _ = Describe("Resources API", func() {
 				fakeResource.ResourceConfigScopeIDReturns(2)
 			})
 
+			Context("when the pipeline is archived", func() {
+				BeforeEach(func() {
+					fakePipeline.ArchivedReturns(true)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
 			It("injects the proper pipelineDB", func() {
 				Expect(dbTeam.PipelineCallCount()).To(Equal(1))
 				resourceName := fakePipeline.ResourceArgsForCall(0)
@@ -18,6 +18,12 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resourceName := rata.Param(r, "resource_name")
 
+		if dbPipeline.Archived() {
+			logger.Info("pipeline-archived", lager.Data{"pipeline": dbPipeline.Name()})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		var reqBody atc.CheckRequestBody
 		err := json.NewDecoder(r.Body).Decode(&reqBody)
 		if err != nil {
@@ -25,6 +25,12 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		if dbPipeline.Archived() {
+			logger.Info("pipeline-archived", lager.Data{"pipeline": dbPipeline.Name()})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		pipelineResource, found, err := dbPipeline.Resource(resourceName)
 		if err != nil {
 			logger.Error("database-error", err, lager.Data{"resource-name": resourceName})
@@ -38,7 +44,7 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		variables := creds.NewVariables(s.secretManager, dbPipeline.TeamName(), dbPipeline.Name())
+		variables := creds.NewPipelineVariables(s.secretManager, s.varSourcePool, dbPipeline.VarSources(), dbPipeline.TeamName(), dbPipeline.Name())
 		token, err := creds.NewString(variables, pipelineResource.WebhookToken()).Evaluate()
 		if token != webhookToken {
 			logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
@@ -18,6 +18,7 @@ type Server struct {
 	logger                lager.Logger
 	scannerFactory        ScannerFactory
 	secretManager         creds.Secrets
+	varSourcePool         creds.VarSourcePool
 	resourceFactory       db.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
 }
@@ -26,6 +27,7 @@ func NewServer(
 	logger lager.Logger,
 	scannerFactory ScannerFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	resourceFactory db.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 ) *Server {
@@ -33,6 +35,7 @@ func NewServer(
 		logger:                logger,
 		scannerFactory:        scannerFactory,
 		secretManager:         secretManager,
+		varSourcePool:         varSourcePool,
 		resourceFactory:       resourceFactory,
 		resourceConfigFactory: resourceConfigFactory,
 	}
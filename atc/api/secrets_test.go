@@ -0,0 +1,507 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secrets API", func() {
+	var fakeaccess *accessorfakes.FakeAccess
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/secrets", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/secrets")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+
+				dbTeam.SecretsReturns([]atc.Secret{
+					{Name: "some-secret", UpdatedAt: 1565957019},
+					{Name: "some-secret", PipelineName: "some-pipeline", UpdatedAt: 1565957020},
+				}, nil)
+			})
+
+			It("returns the secrets without their values", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"name": "some-secret", "updated_at": 1565957019},
+					{"name": "some-secret", "pipeline_name": "some-pipeline", "updated_at": 1565957020}
+				]`))
+			})
+
+			Context("when getting the secrets fails", func() {
+				BeforeEach(func() {
+					dbTeam.SecretsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/secrets/:secret_name", func() {
+		var path string
+		var body string
+		var response *http.Response
+
+		BeforeEach(func() {
+			path = "/api/v1/teams/some-team/secrets/some-secret"
+			body = `{"value":"some-value"}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+path, bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+			})
+
+			It("saves the team secret", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(dbTeam.SaveSecretCallCount()).To(Equal(1))
+				Expect(dbTeam.SaveSecretArgsForCall(0)).To(Equal(atc.Secret{
+					Name:  "some-secret",
+					Value: "some-value",
+				}))
+			})
+
+			Context("when setting a pipeline secret", func() {
+				BeforeEach(func() {
+					path = "/api/v1/teams/some-team/pipelines/some-pipeline/secrets/some-secret"
+				})
+
+				It("saves the pipeline secret", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					Expect(dbTeam.SaveSecretCallCount()).To(Equal(1))
+					Expect(dbTeam.SaveSecretArgsForCall(0)).To(Equal(atc.Secret{
+						Name:         "some-secret",
+						PipelineName: "some-pipeline",
+						Value:        "some-value",
+					}))
+				})
+			})
+
+			Context("when the value is empty", func() {
+				BeforeEach(func() {
+					body = `{}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SaveSecretCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when saving the secret fails", func() {
+				BeforeEach(func() {
+					dbTeam.SaveSecretReturns(errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/secrets/:secret_name", func() {
+		var path string
+		var response *http.Response
+
+		BeforeEach(func() {
+			path = "/api/v1/teams/some-team/secrets/some-secret"
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+			})
+
+			Context("when the secret exists", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteSecretReturns(true, nil)
+				})
+
+				It("deletes it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(dbTeam.DeleteSecretCallCount()).To(Equal(1))
+
+					pipelineName, secretName := dbTeam.DeleteSecretArgsForCall(0)
+					Expect(pipelineName).To(BeEmpty())
+					Expect(secretName).To(Equal("some-secret"))
+				})
+
+				Context("when deleting a pipeline secret", func() {
+					BeforeEach(func() {
+						path = "/api/v1/teams/some-team/pipelines/some-pipeline/secrets/some-secret"
+					})
+
+					It("deletes it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+						pipelineName, secretName := dbTeam.DeleteSecretArgsForCall(0)
+						Expect(pipelineName).To(Equal("some-pipeline"))
+						Expect(secretName).To(Equal("some-secret"))
+					})
+				})
+			})
+
+			Context("when the secret does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteSecretReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting the secret fails", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteSecretReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/secret-accesses", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/secret-accesses" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not look up the secret accesses", func() {
+				Expect(dbSecretAccessRepository.SecretAccessesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+
+				dbSecretAccessRepository.SecretAccessesReturns([]atc.SecretAccess{
+					{
+						ID:           2,
+						Path:         "/concourse/some-team/some-secret",
+						Manager:      "vault",
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						BuildID:      42,
+						AccessedAt:   1566129420,
+					},
+					{
+						ID:           1,
+						Path:         "/concourse/some-team/some-secret",
+						Manager:      "vault",
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						UserName:     "some-user",
+						AccessedAt:   1566129419,
+					},
+				}, nil)
+			})
+
+			It("returns the secret accesses", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 2,
+						"path": "/concourse/some-team/some-secret",
+						"manager": "vault",
+						"team_name": "some-team",
+						"pipeline_name": "some-pipeline",
+						"build_id": 42,
+						"accessed_at": 1566129420
+					},
+					{
+						"id": 1,
+						"path": "/concourse/some-team/some-secret",
+						"manager": "vault",
+						"team_name": "some-team",
+						"pipeline_name": "some-pipeline",
+						"user_name": "some-user",
+						"accessed_at": 1566129419
+					}
+				]`))
+			})
+
+			It("does not filter the secret accesses", func() {
+				Expect(dbSecretAccessRepository.SecretAccessesCallCount()).To(Equal(1))
+				Expect(dbSecretAccessRepository.SecretAccessesArgsForCall(0)).To(Equal(db.SecretAccessFilter{}))
+			})
+
+			Context("when filtering by path, build and limit", func() {
+				BeforeEach(func() {
+					query = "?path=/concourse/some-team/some-secret&build_id=42&limit=10"
+				})
+
+				It("filters the secret accesses", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbSecretAccessRepository.SecretAccessesCallCount()).To(Equal(1))
+					Expect(dbSecretAccessRepository.SecretAccessesArgsForCall(0)).To(Equal(db.SecretAccessFilter{
+						Path:    "/concourse/some-team/some-secret",
+						BuildID: 42,
+						Limit:   10,
+					}))
+				})
+			})
+
+			Context("when the build id is not a number", func() {
+				BeforeEach(func() {
+					query = "?build_id=nope"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the limit is negative", func() {
+				BeforeEach(func() {
+					query = "?limit=-1"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the secret accesses fails", func() {
+				BeforeEach(func() {
+					dbSecretAccessRepository.SecretAccessesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/secret-cache", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/secret-cache"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not purge the secret cache", func() {
+				Expect(dbSecretCachePurgeRepository.PurgeSecretCacheCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+				dbTeam.NameReturns("some-team")
+			})
+
+			It("purges the team's cached secrets", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(dbSecretCachePurgeRepository.PurgeSecretCacheCallCount()).To(Equal(1))
+				Expect(dbSecretCachePurgeRepository.PurgeSecretCacheArgsForCall(0)).To(Equal(atc.SecretCachePurge{
+					TeamName: "some-team",
+				}))
+			})
+
+			Context("when a pipeline and path are given", func() {
+				BeforeEach(func() {
+					query = "?pipeline_name=some-pipeline&path=some-secret"
+				})
+
+				It("purges the cached secret of the pipeline", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(dbSecretCachePurgeRepository.PurgeSecretCacheCallCount()).To(Equal(1))
+					Expect(dbSecretCachePurgeRepository.PurgeSecretCacheArgsForCall(0)).To(Equal(atc.SecretCachePurge{
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						Path:         "some-secret",
+					}))
+				})
+			})
+
+			Context("when purging the secret cache fails", func() {
+				BeforeEach(func() {
+					dbSecretCachePurgeRepository.PurgeSecretCacheReturns(errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
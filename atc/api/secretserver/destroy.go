@@ -0,0 +1,31 @@
+package secretserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) DestroySecret(team db.Team) http.Handler {
+	logger := s.logger.Session("destroy-secret")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secretName := r.FormValue(":secret_name")
+		pipelineName := r.FormValue(":pipeline_name")
+
+		deleted, err := team.DeleteSecret(pipelineName, secretName)
+		if err != nil {
+			logger.Error("failed-to-delete-secret", err, lager.Data{"pipeline": pipelineName, "secret": secretName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,30 @@
+package secretserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListSecrets(team db.Team) http.Handler {
+	logger := s.logger.Session("list-secrets")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secrets, err := team.Secrets()
+		if err != nil {
+			logger.Error("failed-to-get-secrets", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(secrets)
+		if err != nil {
+			logger.Error("failed-to-encode-secrets", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
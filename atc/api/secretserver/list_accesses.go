@@ -0,0 +1,65 @@
+package secretserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListSecretAccesses(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-secret-accesses")
+
+	buildID, err := parseIntParam(r, "build_id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit, err := parseIntParam(r, "limit")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if limit < 0 {
+		http.Error(w, fmt.Sprintf("negative 'limit' param (%d)", limit), http.StatusBadRequest)
+		return
+	}
+
+	accesses, err := s.secretAccessRepository.SecretAccesses(db.SecretAccessFilter{
+		Path:    r.URL.Query().Get("path"),
+		BuildID: buildID,
+		Limit:   uint64(limit),
+	})
+	if err != nil {
+		logger.Error("failed-to-get-secret-accesses", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(accesses)
+	if err != nil {
+		logger.Error("failed-to-encode-secret-accesses", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func parseIntParam(r *http.Request, name string) (int, error) {
+	var val int
+	param := r.URL.Query().Get(name)
+	if len(param) != 0 {
+		var err error
+		val, err = strconv.Atoi(param)
+		if err != nil {
+			return 0, fmt.Errorf("non-numeric '%s' param (%s): %s", name, param, err)
+		}
+	}
+
+	return val, nil
+}
@@ -0,0 +1,30 @@
+package secretserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) PurgeSecretCache(team db.Team) http.Handler {
+	logger := s.logger.Session("purge-secret-cache")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		purge := atc.SecretCachePurge{
+			TeamName:     team.Name(),
+			PipelineName: r.URL.Query().Get("pipeline_name"),
+			Path:         r.URL.Query().Get("path"),
+		}
+
+		err := s.secretCachePurgeRepository.PurgeSecretCache(purge)
+		if err != nil {
+			logger.Error("failed-to-purge-secret-cache", err, lager.Data{"pipeline": purge.PipelineName, "path": purge.Path})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,20 @@
+package secretserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger                     lager.Logger
+	secretAccessRepository     db.SecretAccessRepository
+	secretCachePurgeRepository db.SecretCachePurgeRepository
+}
+
+func NewServer(logger lager.Logger, secretAccessRepository db.SecretAccessRepository, secretCachePurgeRepository db.SecretCachePurgeRepository) *Server {
+	return &Server{
+		logger:                     logger,
+		secretAccessRepository:     secretAccessRepository,
+		secretCachePurgeRepository: secretCachePurgeRepository,
+	}
+}
@@ -0,0 +1,48 @@
+package secretserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// SetSecret saves a team secret, or a pipeline secret when routed with a
+// pipeline name. The pipeline does not need to exist yet.
+func (s *Server) SetSecret(team db.Team) http.Handler {
+	logger := s.logger.Session("set-secret")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secretName := r.FormValue(":secret_name")
+		pipelineName := r.FormValue(":pipeline_name")
+
+		var secret atc.Secret
+		err := json.NewDecoder(r.Body).Decode(&secret)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		secret.Name = secretName
+		secret.PipelineName = pipelineName
+
+		if secret.Value == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "secret value must not be empty")
+			return
+		}
+
+		err = team.SaveSecret(secret)
+		if err != nil {
+			logger.Error("failed-to-save-secret", err, lager.Data{"pipeline": pipelineName, "secret": secretName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -330,6 +330,10 @@ var _ = Describe("Teams API", func() {
 					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
 				})
 
+				It("does not update the quotas", func() {
+					Expect(fakeTeam.UpdateQuotasCallCount()).To(BeZero())
+				})
+
 				Context("when updating provider auth fails", func() {
 					BeforeEach(func() {
 						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
@@ -339,6 +343,25 @@ var _ = Describe("Teams API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when the quotas are unchanged", func() {
+					BeforeEach(func() {
+						fakeTeam.QuotasReturns(atc.TeamQuotas{MaxRunningBuilds: 5})
+						atcTeam.Quotas = &atc.TeamQuotas{MaxRunningBuilds: 5}
+					})
+
+					It("returns 200 OK with the quotas", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`{
+							"id": 5,
+							"name": "some-team",
+							"quotas": {"max_running_builds": 5}
+						}`))
+					})
+				})
 			})
 		}
 
@@ -351,6 +374,29 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when changing the quotas of the team", func() {
+				BeforeEach(func() {
+					atcTeam.Quotas = &atc.TeamQuotas{MaxRunningBuilds: 5, MaxContainers: 50}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the quotas", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateQuotasCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateQuotasArgsForCall(0)).To(Equal(*atcTeam.Quotas))
+				})
+
+				Context("when updating the quotas fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateQuotasReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -387,6 +433,19 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when changing the quotas of the team", func() {
+				BeforeEach(func() {
+					atcTeam.Quotas = &atc.TeamQuotas{MaxRunningBuilds: 500}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden without updating the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					Expect(fakeTeam.UpdateQuotasCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -778,4 +837,97 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/usage", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/usage")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					fakeTeam.UsageReturns(atc.TeamUsage{
+						Quotas:        atc.TeamQuotas{MaxRunningBuilds: 5, MaxContainers: 50},
+						RunningBuilds: 3,
+						Containers:    20,
+						Volumes:       40,
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the usage of the team", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"quotas": {
+							"max_running_builds": 5,
+							"max_containers": 50
+						},
+						"running_builds": 3,
+						"containers": 20,
+						"volumes": 40
+					}`))
+				})
+
+				Context("when getting the usage fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UsageReturns(atc.TeamUsage{}, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,40 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+func (s *Server) GetTeamUsage(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("get-team-usage")
+
+	teamName := r.FormValue(":team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err, lager.Data{"teamName": teamName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	usage, err := team.Usage()
+	if err != nil {
+		hLog.Error("failed-to-get-team-usage", err, lager.Data{"teamName": teamName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(usage)
+	if err != nil {
+		hLog.Error("failed-to-encode-team-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
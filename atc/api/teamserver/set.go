@@ -42,6 +42,12 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if found {
+		if atcTeam.Quotas != nil && *atcTeam.Quotas != team.Quotas() && !acc.IsAdmin() {
+			hLog.Debug("not-allowed-to-change-quotas")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		hLog.Debug("updating-credentials")
 		err = team.UpdateProviderAuth(atcTeam.Auth)
 		if err != nil {
@@ -50,6 +56,16 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if atcTeam.Quotas != nil {
+			hLog.Debug("updating-quotas")
+			err = team.UpdateQuotas(*atcTeam.Quotas)
+			if err != nil {
+				hLog.Error("failed-to-update-team-quotas", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
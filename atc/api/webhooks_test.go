@@ -0,0 +1,394 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/api/webhookserver"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/radar/radarfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks API", func() {
+	var fakeaccess *accessorfakes.FakeAccess
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var body string
+		var response *http.Response
+
+		BeforeEach(func() {
+			body = `{"type":"github","secret":"some-secret"}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/webhooks/some-webhook", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+			})
+
+			It("saves the webhook", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(dbTeam.SaveWebhookCallCount()).To(Equal(1))
+				Expect(dbTeam.SaveWebhookArgsForCall(0)).To(Equal(atc.Webhook{
+					Name:   "some-webhook",
+					Type:   "github",
+					Secret: "some-secret",
+				}))
+			})
+
+			Context("when the type is unknown", func() {
+				BeforeEach(func() {
+					body = `{"type":"bogus","secret":"some-secret"}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("unknown webhook type 'bogus'"))
+					Expect(dbTeam.SaveWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the secret is empty", func() {
+				BeforeEach(func() {
+					body = `{"type":"github"}`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SaveWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when saving the webhook fails", func() {
+				BeforeEach(func() {
+					dbTeam.SaveWebhookReturns(errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/webhooks/some-webhook", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+			})
+
+			Context("when the webhook exists", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(true, nil)
+				})
+
+				It("deletes it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(dbTeam.DeleteWebhookCallCount()).To(Equal(1))
+					Expect(dbTeam.DeleteWebhookArgsForCall(0)).To(Equal("some-webhook"))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting the webhook fails", func() {
+				BeforeEach(func() {
+					dbTeam.DeleteWebhookReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var (
+			payload   string
+			signature string
+			headers   http.Header
+			response  *http.Response
+
+			fakeScanner *radarfakes.FakeScanner
+
+			matchingResource *dbfakes.FakeResource
+			otherResource    *dbfakes.FakeResource
+			fakePipeline     *dbfakes.FakePipeline
+		)
+
+		sign := func(payload string) string {
+			mac := hmac.New(sha256.New, []byte("some-secret"))
+			mac.Write([]byte(payload))
+			return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		}
+
+		BeforeEach(func() {
+			payload = `{"repository":{"clone_url":"https://github.com/some-org/some-repo.git","html_url":"https://github.com/some-org/some-repo"}}`
+			signature = sign(payload)
+			headers = http.Header{"X-Github-Event": {"push"}}
+
+			dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+			dbTeam.NameReturns("some-team")
+			dbTeam.WebhookReturns(atc.Webhook{
+				Name:   "some-webhook",
+				Type:   atc.WebhookTypeGitHub,
+				Secret: "some-secret",
+			}, true, nil)
+
+			matchingResource = new(dbfakes.FakeResource)
+			matchingResource.IDReturns(1)
+			matchingResource.NameReturns("some-resource")
+			matchingResource.SourceReturns(atc.Source{"uri": "https://github.com/some-org/some-repo", "branch": "master"})
+
+			otherResource = new(dbfakes.FakeResource)
+			otherResource.IDReturns(2)
+			otherResource.NameReturns("other-resource")
+			otherResource.SourceReturns(atc.Source{"uri": "https://github.com/some-org/other-repo.git"})
+
+			fakePipeline = new(dbfakes.FakePipeline)
+			fakePipeline.NameReturns("some-pipeline")
+			fakePipeline.ResourcesReturns(db.Resources{matchingResource, otherResource}, nil)
+
+			dbTeam.PipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/webhooks/some-webhook", bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header = headers
+			request.Header.Set("X-Hub-Signature-256", signature)
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("checks the resources matching the payload", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var checked []atc.WebhookCheckedResource
+			Expect(json.NewDecoder(response.Body).Decode(&checked)).To(Succeed())
+			Expect(checked).To(Equal([]atc.WebhookCheckedResource{
+				{PipelineName: "some-pipeline", ResourceName: "some-resource"},
+			}))
+
+			Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+			_, resourceID, fromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+			Expect(resourceID).To(Equal(1))
+			Expect(fromVersion).To(BeNil())
+
+			Expect(fakeScannerFactory.NewResourceScannerArgsForCall(0)).To(Equal(fakePipeline))
+		})
+
+		Context("when the pipeline is archived", func() {
+			BeforeEach(func() {
+				fakePipeline.ArchivedReturns(true)
+			})
+
+			It("does not check its resources", func() {
+				var checked []atc.WebhookCheckedResource
+				Expect(json.NewDecoder(response.Body).Decode(&checked)).To(Succeed())
+				Expect(checked).To(BeEmpty())
+				Expect(fakePipeline.ResourcesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the event is a ping", func() {
+			BeforeEach(func() {
+				headers.Set("X-GitHub-Event", "ping")
+			})
+
+			It("does not check any resources", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbTeam.PipelinesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the signature is wrong", func() {
+			BeforeEach(func() {
+				signature = sign("some-other-payload")
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeam.PipelinesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the signature is missing", func() {
+			BeforeEach(func() {
+				signature = ""
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when the payload is too large", func() {
+			BeforeEach(func() {
+				payload = strings.Repeat("x", webhookserver.MaxPayloadSize+1)
+				signature = sign(payload)
+			})
+
+			It("returns 413 Request Entity Too Large", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+				Expect(dbTeam.PipelinesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the payload is malformed", func() {
+			BeforeEach(func() {
+				payload = `{`
+				signature = sign(payload)
+			})
+
+			It("returns 400 Bad Request", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the webhook is generic", func() {
+			BeforeEach(func() {
+				dbTeam.WebhookReturns(atc.Webhook{
+					Name:   "some-webhook",
+					Type:   atc.WebhookTypeGeneric,
+					Secret: "some-secret",
+				}, true, nil)
+
+				payload = `{"uri":"https://github.com/some-org/other-repo.git"}`
+				headers.Set("X-Concourse-Signature", sign(payload))
+			})
+
+			It("checks the resources whose source contains the payload", func() {
+				var checked []atc.WebhookCheckedResource
+				Expect(json.NewDecoder(response.Body).Decode(&checked)).To(Succeed())
+				Expect(checked).To(Equal([]atc.WebhookCheckedResource{
+					{PipelineName: "some-pipeline", ResourceName: "other-resource"},
+				}))
+			})
+		})
+
+		Context("when the webhook does not exist", func() {
+			BeforeEach(func() {
+				dbTeam.WebhookReturns(atc.Webhook{}, false, nil)
+			})
+
+			It("returns 404 Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the team does not exist", func() {
+			BeforeEach(func() {
+				dbTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			It("returns 404 Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the pipelines fails", func() {
+			BeforeEach(func() {
+				dbTeam.PipelinesReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package webhookserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) DestroyWebhook(team db.Team) http.Handler {
+	logger := s.logger.Session("destroy-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookName := r.FormValue(":webhook_name")
+
+		deleted, err := team.DeleteWebhook(webhookName)
+		if err != nil {
+			logger.Error("failed-to-delete-webhook", err, lager.Data{"webhook": webhookName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
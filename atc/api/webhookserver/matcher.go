@@ -0,0 +1,208 @@
+package webhookserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// A Matcher understands the payloads of one type of webhook.
+type Matcher interface {
+	// Verify returns whether the payload was sent by someone knowing the
+	// webhook's secret.
+	Verify(r *http.Request, payload []byte, secret string) bool
+
+	// Sources returns the partial sources of the resources the payload is
+	// about. A resource matches if its source contains every field of any of
+	// them.
+	Sources(r *http.Request, payload []byte) ([]atc.Source, error)
+}
+
+var Matchers = map[string]Matcher{
+	atc.WebhookTypeGitHub:  GitHubMatcher{},
+	atc.WebhookTypeGitLab:  GitLabMatcher{},
+	atc.WebhookTypeGeneric: GenericMatcher{},
+}
+
+// GitHubMatcher matches resources whose 'uri' is one of the URLs of the
+// repository a GitHub event is about. Payloads are verified using the
+// 'X-Hub-Signature-256' header, or 'X-Hub-Signature' for older
+// installations.
+type GitHubMatcher struct{}
+
+func (GitHubMatcher) Verify(r *http.Request, payload []byte, secret string) bool {
+	if signature := r.Header.Get("X-Hub-Signature-256"); signature != "" {
+		return validSignature(sha256.New, "sha256=", signature, payload, secret)
+	}
+
+	return validSignature(sha1.New, "sha1=", r.Header.Get("X-Hub-Signature"), payload, secret)
+}
+
+func (GitHubMatcher) Sources(r *http.Request, payload []byte) ([]atc.Source, error) {
+	// sent when the webhook is created; nothing has changed
+	if r.Header.Get("X-GitHub-Event") == "ping" {
+		return nil, nil
+	}
+
+	var event struct {
+		Repository struct {
+			CloneURL string `json:"clone_url"`
+			SSHURL   string `json:"ssh_url"`
+			GitURL   string `json:"git_url"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	err := json.Unmarshal(payload, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := event.Repository
+
+	return uriSources(repo.CloneURL, repo.SSHURL, repo.GitURL, repo.HTMLURL), nil
+}
+
+// GitLabMatcher matches resources whose 'uri' is one of the URLs of the
+// project a GitLab event is about. GitLab does not sign payloads, so the
+// 'X-Gitlab-Token' header is compared with the secret instead.
+type GitLabMatcher struct{}
+
+func (GitLabMatcher) Verify(r *http.Request, payload []byte, secret string) bool {
+	token := r.Header.Get("X-Gitlab-Token")
+
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
+
+func (GitLabMatcher) Sources(r *http.Request, payload []byte) ([]atc.Source, error) {
+	var event struct {
+		Project struct {
+			GitHTTPURL string `json:"git_http_url"`
+			GitSSHURL  string `json:"git_ssh_url"`
+			WebURL     string `json:"web_url"`
+		} `json:"project"`
+	}
+
+	err := json.Unmarshal(payload, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	project := event.Project
+
+	return uriSources(project.GitHTTPURL, project.GitSSHURL, project.WebURL), nil
+}
+
+// GenericMatcher matches resources whose source contains every field of the
+// payload, which must be a JSON object. Payloads are verified using the
+// HMAC-SHA256 signature in the 'X-Concourse-Signature' header, formatted as
+// 'sha256=<hex digest>'.
+type GenericMatcher struct{}
+
+func (GenericMatcher) Verify(r *http.Request, payload []byte, secret string) bool {
+	return validSignature(sha256.New, "sha256=", r.Header.Get("X-Concourse-Signature"), payload, secret)
+}
+
+func (GenericMatcher) Sources(r *http.Request, payload []byte) ([]atc.Source, error) {
+	var source atc.Source
+	err := json.Unmarshal(payload, &source)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(source) == 0 {
+		return nil, errors.New("payload must contain at least one source field")
+	}
+
+	return []atc.Source{source}, nil
+}
+
+func validSignature(newHash func() hash.Hash, prefix string, signature string, payload []byte, secret string) bool {
+	if !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(payload)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// uriSources returns a source for each URL, as well as with and without a
+// '.git' suffix, as either may be configured for the same repository.
+func uriSources(urls ...string) []atc.Source {
+	var sources []atc.Source
+
+	seen := map[string]bool{}
+	for _, url := range urls {
+		if url == "" {
+			continue
+		}
+
+		trimmed := strings.TrimSuffix(url, ".git")
+
+		for _, uri := range []string{trimmed, trimmed + ".git"} {
+			if seen[uri] {
+				continue
+			}
+
+			seen[uri] = true
+			sources = append(sources, atc.Source{"uri": uri})
+		}
+	}
+
+	return sources
+}
+
+// matches returns whether the source contains every field of any of the
+// partial sources.
+func matches(source atc.Source, partials []atc.Source) bool {
+	for _, partial := range partials {
+		if contains(source, partial) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(source atc.Source, partial atc.Source) bool {
+	for key, value := range partial {
+		actual, found := source[key]
+		if !found {
+			return false
+		}
+
+		// the source may have come from YAML and the partial from JSON, so
+		// compare their JSON encodings rather than their types
+		actualJSON, err := json.Marshal(actual)
+		if err != nil {
+			return false
+		}
+
+		expectedJSON, err := json.Marshal(value)
+		if err != nil {
+			return false
+		}
+
+		if string(actualJSON) != string(expectedJSON) {
+			return false
+		}
+	}
+
+	return true
+}
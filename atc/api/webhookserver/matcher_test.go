@@ -0,0 +1,146 @@
+package webhookserver_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/webhookserver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matchers", func() {
+	var request *http.Request
+
+	sign := func(newHash func() hash.Hash, payload string) string {
+		mac := hmac.New(newHash, []byte("some-secret"))
+		mac.Write([]byte(payload))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("POST", "/", nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("GitHubMatcher", func() {
+		matcher := webhookserver.GitHubMatcher{}
+
+		Describe("Verify", func() {
+			It("accepts a valid SHA-256 signature", func() {
+				request.Header.Set("X-Hub-Signature-256", "sha256="+sign(sha256.New, "payload"))
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeTrue())
+			})
+
+			It("accepts a valid SHA-1 signature", func() {
+				request.Header.Set("X-Hub-Signature", "sha1="+sign(sha1.New, "payload"))
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeTrue())
+			})
+
+			It("rejects a signature made with another secret", func() {
+				request.Header.Set("X-Hub-Signature-256", "sha256="+sign(sha256.New, "payload"))
+				Expect(matcher.Verify(request, []byte("payload"), "other-secret")).To(BeFalse())
+			})
+
+			It("rejects a request without a signature", func() {
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeFalse())
+			})
+		})
+
+		Describe("Sources", func() {
+			It("returns the repository's URLs, with and without '.git'", func() {
+				sources, err := matcher.Sources(request, []byte(`{
+					"repository": {
+						"clone_url": "https://github.com/some-org/some-repo.git",
+						"ssh_url": "git@github.com:some-org/some-repo.git",
+						"html_url": "https://github.com/some-org/some-repo"
+					}
+				}`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sources).To(ConsistOf(
+					atc.Source{"uri": "https://github.com/some-org/some-repo"},
+					atc.Source{"uri": "https://github.com/some-org/some-repo.git"},
+					atc.Source{"uri": "git@github.com:some-org/some-repo"},
+					atc.Source{"uri": "git@github.com:some-org/some-repo.git"},
+				))
+			})
+
+			It("returns nothing for a ping", func() {
+				request.Header.Set("X-GitHub-Event", "ping")
+
+				sources, err := matcher.Sources(request, []byte(`{"repository":{"clone_url":"https://github.com/some-org/some-repo.git"}}`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sources).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GitLabMatcher", func() {
+		matcher := webhookserver.GitLabMatcher{}
+
+		Describe("Verify", func() {
+			It("accepts the secret as the token", func() {
+				request.Header.Set("X-Gitlab-Token", "some-secret")
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeTrue())
+			})
+
+			It("rejects any other token", func() {
+				request.Header.Set("X-Gitlab-Token", "other-secret")
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeFalse())
+			})
+
+			It("rejects a request without a token", func() {
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeFalse())
+			})
+		})
+
+		Describe("Sources", func() {
+			It("returns the project's URLs", func() {
+				sources, err := matcher.Sources(request, []byte(`{
+					"project": {
+						"git_http_url": "https://gitlab.com/some-group/some-project.git",
+						"git_ssh_url": "git@gitlab.com:some-group/some-project.git"
+					}
+				}`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sources).To(ContainElement(atc.Source{"uri": "https://gitlab.com/some-group/some-project.git"}))
+				Expect(sources).To(ContainElement(atc.Source{"uri": "git@gitlab.com:some-group/some-project.git"}))
+			})
+		})
+	})
+
+	Describe("GenericMatcher", func() {
+		matcher := webhookserver.GenericMatcher{}
+
+		Describe("Verify", func() {
+			It("accepts a valid signature", func() {
+				request.Header.Set("X-Concourse-Signature", "sha256="+sign(sha256.New, "payload"))
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeTrue())
+			})
+
+			It("rejects a signature without the algorithm", func() {
+				request.Header.Set("X-Concourse-Signature", sign(sha256.New, "payload"))
+				Expect(matcher.Verify(request, []byte("payload"), "some-secret")).To(BeFalse())
+			})
+		})
+
+		Describe("Sources", func() {
+			It("returns the payload as a source", func() {
+				sources, err := matcher.Sources(request, []byte(`{"uri":"some-uri","branch":"master"}`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sources).To(Equal([]atc.Source{{"uri": "some-uri", "branch": "master"}}))
+			})
+
+			It("errors if the payload is empty", func() {
+				_, err := matcher.Sources(request, []byte(`{}`))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
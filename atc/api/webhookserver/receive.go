@@ -0,0 +1,156 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// MaxPayloadSize is the largest payload a webhook will accept, matching the
+// limit GitHub puts on the payloads it sends.
+const MaxPayloadSize = 25 * 1024 * 1024
+
+// ReceiveWebhook checks every resource in the team's pipelines whose source
+// matches the payload. It is not authenticated; instead the payload is
+// verified using the webhook's secret.
+func (s *Server) ReceiveWebhook(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	webhookName := r.FormValue(":webhook_name")
+
+	logger := s.logger.Session("receive-webhook", lager.Data{
+		"team":    teamName,
+		"webhook": webhookName,
+	})
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	webhook, found, err := team.Webhook(webhookName)
+	if err != nil {
+		logger.Error("failed-to-find-webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	matcher, found := Matchers[webhook.Type]
+	if !found {
+		logger.Info("unknown-webhook-type", lager.Data{"type": webhook.Type})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// reading one byte more than the limit tells an oversized payload apart
+	// from one of exactly the maximum size
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxPayloadSize+1))
+	if err != nil {
+		logger.Error("failed-to-read-payload", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(payload) > MaxPayloadSize {
+		logger.Info("payload-too-large")
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !matcher.Verify(r, payload, webhook.Secret) {
+		logger.Info("invalid-signature")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	sources, err := matcher.Sources(r, payload)
+	if err != nil {
+		logger.Info("malformed-payload", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	checked := []atc.WebhookCheckedResource{}
+
+	if len(sources) > 0 {
+		pipelines, err := team.Pipelines()
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for _, pipeline := range pipelines {
+			if pipeline.Archived() {
+				continue
+			}
+
+			resources, err := pipeline.Resources()
+			if err != nil {
+				logger.Error("failed-to-get-resources", err, lager.Data{"pipeline": pipeline.Name()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			variables := creds.NewPipelineVariables(s.secretManager, s.varSourcePool, pipeline.VarSources(), team.Name(), pipeline.Name())
+			scanner := s.scannerFactory.NewResourceScanner(pipeline)
+
+			for _, resource := range resources {
+				source, err := creds.NewSource(variables, resource.Source()).Evaluate()
+				if err != nil {
+					logger.Info("failed-to-evaluate-source", lager.Data{
+						"pipeline": pipeline.Name(),
+						"resource": resource.Name(),
+						"error":    err.Error(),
+					})
+					continue
+				}
+
+				if !matches(source, sources) {
+					continue
+				}
+
+				checked = append(checked, atc.WebhookCheckedResource{
+					PipelineName: pipeline.Name(),
+					ResourceName: resource.Name(),
+				})
+
+				resourceID := resource.ID()
+				go func() {
+					// checks from the latest version, like the per-resource
+					// webhook
+					err := scanner.ScanFromVersion(logger, resourceID, nil)
+					if err != nil {
+						logger.Error("failed-to-check-resource", err, lager.Data{"resource-id": resourceID})
+					}
+				}()
+			}
+		}
+	}
+
+	logger.Info("checking-resources", lager.Data{"resources": len(checked)})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(checked)
+	if err != nil {
+		logger.Error("failed-to-encode-checked-resources", err)
+	}
+}
@@ -0,0 +1,33 @@
+package webhookserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/resourceserver"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	teamFactory    db.TeamFactory
+	scannerFactory resourceserver.ScannerFactory
+	secretManager  creds.Secrets
+	varSourcePool  creds.VarSourcePool
+}
+
+func NewServer(
+	logger lager.Logger,
+	teamFactory db.TeamFactory,
+	scannerFactory resourceserver.ScannerFactory,
+	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+) *Server {
+	return &Server{
+		logger:         logger,
+		teamFactory:    teamFactory,
+		scannerFactory: scannerFactory,
+		secretManager:  secretManager,
+		varSourcePool:  varSourcePool,
+	}
+}
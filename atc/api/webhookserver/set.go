@@ -0,0 +1,50 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) SetWebhook(team db.Team) http.Handler {
+	logger := s.logger.Session("set-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookName := r.FormValue(":webhook_name")
+
+		var webhook atc.Webhook
+		err := json.NewDecoder(r.Body).Decode(&webhook)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		webhook.Name = webhookName
+
+		if !atc.IsValidWebhookType(webhook.Type) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown webhook type '%s'", webhook.Type)
+			return
+		}
+
+		if webhook.Secret == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "webhook secret must not be empty")
+			return
+		}
+
+		err = team.SaveWebhook(webhook)
+		if err != nil {
+			logger.Error("failed-to-save-webhook", err, lager.Data{"webhook": webhookName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,13 @@
+package webhookserver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWebhookserver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Webhookserver Suite")
+}
@@ -994,4 +994,108 @@ var _ = Describe("Workers API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/workers/demand", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/demand", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not report the demand", func() {
+				Expect(fakeWorkerDemand.ReportCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedStub = func(teamName string) bool {
+					return teamName == "some-team"
+				}
+
+				fakeWorkerDemand.ReportReturns(atc.WorkerDemand{
+					ContainersPerWorker: 50,
+					Groups: []atc.WorkerDemandGroup{
+						{Platform: "linux", Workers: 2, RunningSteps: 60, PendingBuilds: 3, WaitingSteps: 1, RecommendedWorkers: 2},
+						{Team: "other-team", Tags: []string{"gpu"}, Platform: "linux", WaitingSteps: 2, RecommendedWorkers: 1},
+						{Team: "some-team", Platform: "linux", Workers: 1, RunningSteps: 4, RecommendedWorkers: 1},
+					},
+				}, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the shared workers' demand and that of the user's teams", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"containers_per_worker": 50,
+					"groups": [
+						{
+							"platform": "linux",
+							"workers": 2,
+							"running_steps": 60,
+							"pending_builds": 3,
+							"waiting_steps": 1,
+							"recommended_workers": 2
+						},
+						{
+							"team": "some-team",
+							"platform": "linux",
+							"workers": 1,
+							"running_steps": 4,
+							"pending_builds": 0,
+							"waiting_steps": 0,
+							"recommended_workers": 1
+						}
+					]
+				}`))
+			})
+
+			Context("when the user is an admin", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAdminReturns(true)
+				})
+
+				It("returns the demand of every team", func() {
+					var demand atc.WorkerDemand
+					err := json.NewDecoder(response.Body).Decode(&demand)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(demand.Groups).To(HaveLen(3))
+				})
+			})
+
+			Context("when reporting the demand fails", func() {
+				BeforeEach(func() {
+					fakeWorkerDemand.ReportReturns(atc.WorkerDemand{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
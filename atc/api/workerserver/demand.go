@@ -0,0 +1,40 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+func (s *Server) GetWorkerDemand(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-worker-demand")
+
+	demand, err := s.workerDemand.Report(logger)
+	if err != nil {
+		logger.Error("failed-to-report-worker-demand", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	if !acc.IsAdmin() {
+		visibleGroups := []atc.WorkerDemandGroup{}
+		for _, group := range demand.Groups {
+			if group.Team == "" || acc.IsAuthorized(group.Team) {
+				visibleGroups = append(visibleGroups, group)
+			}
+		}
+
+		demand.Groups = visibleGroups
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(demand)
+	if err != nil {
+		logger.Error("failed-to-encode-worker-demand", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
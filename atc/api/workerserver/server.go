@@ -2,25 +2,34 @@ package workerserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
+//go:generate counterfeiter . WorkerDemand
+
+type WorkerDemand interface {
+	Report(logger lager.Logger) (atc.WorkerDemand, error)
+}
+
 type Server struct {
 	logger lager.Logger
 
 	teamFactory     db.TeamFactory
 	dbWorkerFactory db.WorkerFactory
+	workerDemand    WorkerDemand
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	dbWorkerFactory db.WorkerFactory,
-
+	workerDemand WorkerDemand,
 ) *Server {
 	return &Server{
 		logger:          logger,
 		teamFactory:     teamFactory,
 		dbWorkerFactory: dbWorkerFactory,
+		workerDemand:    workerDemand,
 	}
 }
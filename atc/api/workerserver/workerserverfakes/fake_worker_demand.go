@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package workerserverfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/workerserver"
+)
+
+type FakeWorkerDemand struct {
+	ReportStub        func(lager.Logger) (atc.WorkerDemand, error)
+	reportMutex       sync.RWMutex
+	reportArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	reportReturns struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}
+	reportReturnsOnCall map[int]struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerDemand) Report(arg1 lager.Logger) (atc.WorkerDemand, error) {
+	fake.reportMutex.Lock()
+	ret, specificReturn := fake.reportReturnsOnCall[len(fake.reportArgsForCall)]
+	fake.reportArgsForCall = append(fake.reportArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("Report", []interface{}{arg1})
+	fake.reportMutex.Unlock()
+	if fake.ReportStub != nil {
+		return fake.ReportStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.reportReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerDemand) ReportCallCount() int {
+	fake.reportMutex.RLock()
+	defer fake.reportMutex.RUnlock()
+	return len(fake.reportArgsForCall)
+}
+
+func (fake *FakeWorkerDemand) ReportCalls(stub func(lager.Logger) (atc.WorkerDemand, error)) {
+	fake.reportMutex.Lock()
+	defer fake.reportMutex.Unlock()
+	fake.ReportStub = stub
+}
+
+func (fake *FakeWorkerDemand) ReportArgsForCall(i int) lager.Logger {
+	fake.reportMutex.RLock()
+	defer fake.reportMutex.RUnlock()
+	argsForCall := fake.reportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerDemand) ReportReturns(result1 atc.WorkerDemand, result2 error) {
+	fake.reportMutex.Lock()
+	defer fake.reportMutex.Unlock()
+	fake.ReportStub = nil
+	fake.reportReturns = struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerDemand) ReportReturnsOnCall(i int, result1 atc.WorkerDemand, result2 error) {
+	fake.reportMutex.Lock()
+	defer fake.reportMutex.Unlock()
+	fake.ReportStub = nil
+	if fake.reportReturnsOnCall == nil {
+		fake.reportReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerDemand
+			result2 error
+		})
+	}
+	fake.reportReturnsOnCall[i] = struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerDemand) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.reportMutex.RLock()
+	defer fake.reportMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWorkerDemand) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ workerserver.WorkerDemand = new(FakeWorkerDemand)
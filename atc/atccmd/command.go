@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -23,9 +24,11 @@ import (
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/jobserver"
 	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dbsecrets"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/encryption"
@@ -33,6 +36,7 @@ import (
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/builder"
+	"github.com/concourse/concourse/atc/eventstore"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lockrunner"
 	"github.com/concourse/concourse/atc/metric"
@@ -43,6 +47,7 @@ import (
 	"github.com/concourse/concourse/atc/syslog"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
+	kubernetesworker "github.com/concourse/concourse/atc/worker/kubernetes"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/concourse/concourse/skymarshal"
 	"github.com/concourse/concourse/skymarshal/skycmd"
@@ -59,6 +64,7 @@ import (
 	"github.com/tedsuo/ifrit/sigmon"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
+	"gopkg.in/yaml.v2"
 
 	// dynamically registered metric emitters
 	_ "github.com/concourse/concourse/atc/metric/emitter"
@@ -109,15 +115,26 @@ type RunCommand struct {
 
 	InterceptIdleTimeout time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
 
+	BuildLogSearchMaxBuilds  int           `long:"build-log-search-max-builds"  default:"25"   description:"Maximum number of a job's most recent builds whose logs are searched by a single build log search."`
+	BuildLogSearchTimeout    time.Duration `long:"build-log-search-timeout"     default:"30s"  description:"Time limit on a single build log search, after which the matches found so far are returned."`
+	BuildLogSearchMaxMatches int           `long:"build-log-search-max-matches" default:"1000" description:"Maximum number of matching lines returned by a single build log search, after which the search stops."`
+
 	EnableGlobalResources bool `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
 
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceTypeCheckingInterval time.Duration `long:"resource-type-checking-interval" default:"1m" description:"Interval on which to check for new versions of resource types."`
 
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" description:"Method by which a worker is selected during container placement."`
+	ContainerPlacementStrategy        []string      `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" description:"Method by which a worker is selected during container placement. If specified multiple times, the strategies are applied in order, each choosing among the workers the previous one considered equally good."`
+	MaxActiveTasksPerWorker           int           `long:"max-active-tasks-per-worker" default:"0" description:"Maximum number of tasks to run on a worker at once with the limit-active-tasks placement strategy. 0 means no limit."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 
+	TargetContainersPerWorker int `long:"target-containers-per-worker" default:"50" description:"Number of build containers each worker should run, used to recommend a number of workers in the worker demand report for autoscalers. 0 means the current number of workers is recommended."`
+
+	WaitForWorkerTimeout time.Duration `long:"wait-for-worker-timeout" default:"0" description:"How long a build step waits for a compatible worker with capacity before erroring, e.g. while workers are scaled up. 0 means steps error straight away."`
+
+	MaxActiveContainersPerWorker int `long:"max-active-containers-per-worker" default:"0" description:"Number of active containers at which a worker is considered at capacity. While every worker is at capacity, pending builds are queued and started in order of job priority, shared fairly between teams. 0 means workers are never at capacity."`
+
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -130,6 +147,8 @@ type RunCommand struct {
 		ResourceTypes   map[string]string `long:"resource"         description:"A resource type to advertise for the worker. Can be specified multiple times." value-name:"TYPE:IMAGE"`
 	} `group:"Static Worker (optional)" namespace:"worker"`
 
+	KubernetesWorker kubernetesworker.WorkerConfig `group:"Kubernetes Worker (optional)" namespace:"kubernetes-worker"`
+
 	Metrics struct {
 		HostName            string            `long:"metrics-host-name" description:"Host string to attach to emitted metrics."`
 		Attributes          map[string]string `long:"metrics-attribute" description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
@@ -148,8 +167,14 @@ type RunCommand struct {
 
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
 		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
+
+		PipelineConfigHistory int `long:"pipeline-config-history" default:"20" description:"Number of saved configs to keep in each pipeline's config history. Set to 0 to keep every config."`
+
+		SecretAccessRetention time.Duration `long:"secret-access-retention" default:"720h" description:"Period for which to keep secret lookups in the secret access audit trail. Set to 0 to keep every lookup."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	BuildEvents eventstore.Config `group:"Build Event Storage" namespace:"build-events"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
@@ -187,6 +212,8 @@ type RunCommand struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
 	} `group:"Authentication"`
+
+	ConfigRBAC flag.File `long:"config-rbac" description:"YAML file mapping roles to the actions which should require them, overriding the default role for each action."`
 }
 
 var HelpError = errors.New("must specify one of `--current-db-version`, `--supported-db-version`, or `--migrate-db-to-version`")
@@ -400,12 +427,17 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 
 	lockFactory := lock.NewLockFactory(lockConn, metric.LogLockAcquired, metric.LogLockReleased)
 
-	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "api", lockFactory)
+	eventStore, err := cmd.BuildEvents.NewStore(logger.Session("build-events"))
+	if err != nil {
+		return nil, err
+	}
+
+	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "api", lockFactory, eventStore)
 	if err != nil {
 		return nil, err
 	}
 
-	backendConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "backend", lockFactory)
+	backendConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "backend", lockFactory, eventStore)
 	if err != nil {
 		return nil, err
 	}
@@ -415,12 +447,29 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
-	secretManager, err := cmd.secretManager(logger)
+	if dbSecrets, ok := cmd.CredentialManagers["db"].(*dbsecrets.DBManager); ok {
+		dbSecrets.TeamFactory = db.NewTeamFactory(backendConn, lockFactory)
+		dbSecrets.EncryptionStrategy = backendConn.EncryptionStrategy()
+	}
+
+	secretAccessBatcher := creds.NewSecretAccessBatcher(
+		logger.Session("secret-access-batcher"),
+		db.NewSecretAccessRepository(backendConn),
+		10*time.Second,
+		clock.NewClock(),
+		&metric.SecretAccessesDropped,
+	)
+
+	secretCaches := creds.NewSecretCaches(cmd.CredentialManagement.CacheConfig, &metric.SecretCacheHits, &metric.SecretCacheMisses)
+
+	secretManager, err := cmd.secretManager(logger, secretAccessBatcher, secretCaches)
 	if err != nil {
 		return nil, err
 	}
 
-	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, backendConn, storage, lockFactory, secretManager)
+	varSourcePool := creds.NewVarSourcePool(logger.Session("var-source-pool"), cmd.CredentialManagement, secretAccessBatcher, secretCaches)
+
+	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, backendConn, storage, lockFactory, secretManager, varSourcePool)
 	if err != nil {
 		return nil, err
 	}
@@ -433,6 +482,21 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		),
 	})
 
+	members = append(members, grouper.Member{
+		Name:   "secret-access-batcher",
+		Runner: secretAccessBatcher,
+	})
+
+	members = append(members, grouper.Member{
+		Name: "secret-cache-purger",
+		Runner: creds.SecretCachePurgeRunner{
+			Logger:        logger.Session("secret-cache-purger"),
+			Caches:        secretCaches,
+			Purges:        db.NewSecretCachePurgeRepository(backendConn),
+			Notifications: backendConn.Bus(),
+		},
+	})
+
 	onReady := func() {
 		logData := lager.Data{
 			"http":  cmd.nonTLSBindAddr(),
@@ -463,6 +527,7 @@ func (cmd *RunCommand) constructMembers(
 	storage storage.Storage,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 ) ([]grouper.Member, error) {
 	if cmd.TelemetryOptIn {
 		url := fmt.Sprintf("http://telemetry.concourse-ci.org/?version=%s", concourse.Version)
@@ -474,12 +539,12 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, storage, lockFactory, secretManager)
+	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, storage, lockFactory, secretManager, varSourcePool)
 	if err != nil {
 		return nil, err
 	}
 
-	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager)
+	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager, varSourcePool)
 	if err != nil {
 		return nil, err
 	}
@@ -494,6 +559,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	storage storage.Storage,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 ) ([]grouper.Member, error) {
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 
@@ -545,6 +611,11 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	runtimes, err := cmd.workerRuntimes()
+	if err != nil {
+		return nil, err
+	}
+
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
@@ -559,9 +630,11 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		runtimes,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	waitingSteps := db.NewWaitingSteps(dbConn)
+	pool := worker.NewPool(workerProvider, teamFactory, clock.NewClock(), cmd.WaitForWorkerTimeout, waitingSteps)
 	workerClient := worker.NewClient(pool, workerProvider)
 
 	checkContainerStrategy := worker.NewRandomPlacementStrategy()
@@ -574,6 +647,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
 		secretManager,
+		varSourcePool,
 		checkContainerStrategy,
 	)
 
@@ -584,7 +658,17 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
-	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey())
+	dbSecretAccessRepository := db.NewSecretAccessRepository(dbConn)
+	dbSecretCachePurgeRepository := db.NewSecretCachePurgeRepository(dbConn)
+	buildQueue := scheduler.NewBuildQueue(db.NewBuildQueue(dbConn), dbWorkerFactory, cmd.MaxActiveContainersPerWorker)
+	workerDemand := worker.NewWorkerDemand(dbWorkerFactory, dbBuildFactory, waitingSteps, cmd.TargetContainersPerWorker)
+
+	actionRoleMap, err := cmd.parseActionRoleMap()
+	if err != nil {
+		return nil, err
+	}
+
+	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey(), actionRoleMap)
 
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
@@ -599,11 +683,17 @@ func (cmd *RunCommand) constructAPIMembers(
 		gcContainerDestroyer,
 		dbBuildFactory,
 		dbResourceConfigFactory,
+		dbSecretAccessRepository,
+		dbSecretCachePurgeRepository,
 		workerClient,
 		radarScannerFactory,
+		buildQueue,
+		workerDemand,
 		secretManager,
+		varSourcePool,
 		credsManagers,
 		accessFactory,
+		actionRoleMap,
 	)
 
 	if err != nil {
@@ -688,6 +778,7 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbConn db.Conn,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 ) ([]grouper.Member, error) {
 
 	if cmd.Syslog.Address != "" && cmd.Syslog.Transport == "" {
@@ -723,6 +814,11 @@ func (cmd *RunCommand) constructBackendMembers(
 		return nil, err
 	}
 
+	runtimes, err := cmd.workerRuntimes()
+	if err != nil {
+		return nil, err
+	}
+
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
@@ -737,9 +833,11 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		runtimes,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	waitingSteps := db.NewWaitingSteps(dbConn)
+	pool := worker.NewPool(workerProvider, teamFactory, clock.NewClock(), cmd.WaitForWorkerTimeout, waitingSteps)
 	workerClient := worker.NewClient(pool, workerProvider)
 
 	defaultLimits, err := cmd.parseDefaultLimits()
@@ -757,6 +855,8 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
 		secretManager,
+		varSourcePool,
+		teamFactory,
 		defaultLimits,
 		buildContainerStrategy,
 		resourceFactory,
@@ -766,6 +866,8 @@ func (cmd *RunCommand) constructBackendMembers(
 		pool,
 		resourceFactory,
 		dbResourceConfigFactory,
+		teamFactory,
+		scheduler.NewBuildQueue(db.NewBuildQueue(dbConn), dbWorkerFactory, cmd.MaxActiveContainersPerWorker),
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
 		checkContainerStrategy,
@@ -776,6 +878,7 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbArtifactLifecycle := db.NewArtifactLifecycle(dbConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(dbConn)
+	dbPipelineConfigLifecycle := db.NewPipelineConfigLifecycle(dbConn)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
 	bus := dbConn.Bus()
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -786,6 +889,7 @@ func (cmd *RunCommand) constructBackendMembers(
 				dbPipelineFactory,
 				radarSchedulerFactory,
 				secretManager,
+				varSourcePool,
 				bus,
 			),
 			Interval: 10 * time.Second,
@@ -814,6 +918,8 @@ func (cmd *RunCommand) constructBackendMembers(
 				gc.NewVolumeCollector(
 					dbVolumeRepository,
 					cmd.GC.MissingGracePeriod,
+					dbWorkerFactory,
+					runtimes,
 				),
 				gc.NewContainerCollector(
 					dbContainerRepository,
@@ -823,10 +929,26 @@ func (cmd *RunCommand) constructBackendMembers(
 						time.Minute,
 					),
 					cmd.GC.MissingGracePeriod,
+					dbWorkerFactory,
+					runtimes,
 				),
 				gc.NewResourceConfigCheckSessionCollector(
 					resourceConfigCheckSessionLifecycle,
 				),
+				gc.NewPipelineConfigCollector(
+					dbPipelineConfigLifecycle,
+					cmd.GC.PipelineConfigHistory,
+				),
+				gc.NewTeamUsageCollector(teamFactory),
+				gc.NewWorkerDemandCollector(
+					worker.NewWorkerDemand(dbWorkerFactory, dbBuildFactory, waitingSteps, cmd.TargetContainersPerWorker),
+				),
+				gc.NewSecretAccessCollector(
+					db.NewSecretAccessRepository(dbConn),
+					cmd.GC.SecretAccessRetention,
+					clock.NewClock(),
+				),
+				gc.NewBuildEventCollector(db.NewBuildEventLifecycle(dbConn), 500),
 			),
 			"collector",
 			lockFactory,
@@ -877,19 +999,52 @@ func (cmd *RunCommand) constructBackendMembers(
 	if cmd.Worker.GardenURL.URL != nil {
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
 	}
+	if cmd.KubernetesWorker.IsConfigured() {
+		members = append(members, grouper.Member{
+			Name: "kubernetes-worker",
+			Runner: kubernetesworker.NewRegistrar(
+				logger.Session("kubernetes-worker"),
+				dbWorkerFactory,
+				clock.NewClock(),
+				cmd.KubernetesWorker.Worker(concourse.WorkerVersion),
+			),
+		})
+	}
 	return members, nil
 }
 
+func (cmd *RunCommand) workerRuntimes() (worker.RuntimeFactories, error) {
+	runtimes := worker.RuntimeFactories{}
+
+	if cmd.KubernetesWorker.IsConfigured() {
+		runtime, err := cmd.KubernetesWorker.Runtime()
+		if err != nil {
+			return nil, err
+		}
+
+		runtimes[atc.WorkerRuntimeKubernetes] = runtime
+	}
+
+	return runtimes, nil
+}
+
 func workerVersion() (version.Version, error) {
 	return version.NewVersionFromString(concourse.WorkerVersion)
 }
 
-func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error) {
-	var secretsFactory creds.SecretsFactory = noop.NewNoopFactory()
-	for name, manager := range cmd.CredentialManagers {
-		if !manager.IsConfigured() {
-			continue
-		}
+func (cmd *RunCommand) secretManager(logger lager.Logger, secretAccessBatcher *creds.SecretAccessBatcher, secretCaches *creds.SecretCaches) (creds.Secrets, error) {
+	names, err := cmd.CredentialManagers.Configured(cmd.CredentialManagement.LookupOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return cmd.CredentialManagement.WrapSecrets(noop.NewNoopFactory().NewSecrets(), secretCaches), nil
+	}
+
+	chain := []creds.NamedSecrets{}
+	for _, name := range names {
+		manager := cmd.CredentialManagers[name]
 
 		credsLogger := logger.Session("credential-manager", lager.Data{
 			"name": name,
@@ -907,20 +1062,28 @@ func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error)
 			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
 		}
 
-		secretsFactory, err = manager.NewSecretsFactory(credsLogger)
+		secretsFactory, err := manager.NewSecretsFactory(credsLogger)
 		if err != nil {
 			return nil, err
 		}
 
-		break
+		chain = append(chain, creds.NamedSecrets{
+			Name: name,
+			Secrets: cmd.CredentialManagement.AuditSecrets(
+				name,
+				cmd.CredentialManagement.WrapSecrets(secretsFactory.NewSecrets(), secretCaches),
+				secretAccessBatcher,
+			),
+		})
 	}
 
-	result := secretsFactory.NewSecrets()
-	result = creds.NewRetryableSecrets(result, cmd.CredentialManagement.RetryConfig)
-	if cmd.CredentialManagement.CacheConfig.Enabled {
-		result = creds.NewCachedSecrets(result, cmd.CredentialManagement.CacheConfig)
+	if len(chain) == 1 {
+		return chain[0].Secrets, nil
 	}
-	return result, nil
+
+	logger.Info("chained-credential-managers", lager.Data{"lookup-order": names})
+
+	return creds.NewChainedSecrets(logger.Session("chained-secrets"), chain), nil
 }
 
 func (cmd *RunCommand) newKey() *encryption.Key {
@@ -1045,6 +1208,30 @@ func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
 	})
 }
 
+func (cmd *RunCommand) parseActionRoleMap() (accessor.ActionRoleMap, error) {
+	if cmd.ConfigRBAC == "" {
+		return accessor.DefaultRoles, nil
+	}
+
+	content, err := ioutil.ReadFile(cmd.ConfigRBAC.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	var customRoles accessor.CustomActionRoleMap
+	err = yaml.UnmarshalStrict(content, &customRoles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RBAC config: %s", err)
+	}
+
+	actionRoleMap, err := accessor.DefaultRoles.Customize(customRoles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RBAC config: %s", err)
+	}
+
+	return actionRoleMap, nil
+}
+
 func (cmd *RunCommand) defaultBindIP() net.IP {
 	URL := cmd.BindIP.String()
 	if URL == "0.0.0.0" {
@@ -1118,6 +1305,13 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	if cmd.KubernetesWorker.IsConfigured() {
+		err := cmd.KubernetesWorker.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1148,8 +1342,9 @@ func (cmd *RunCommand) constructDBConn(
 	maxConn int,
 	connectionName string,
 	lockFactory lock.LockFactory,
+	eventStore db.EventStore,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory)
+	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), eventStore, connectionName, lockFactory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -1187,17 +1382,28 @@ func (cmd *RunCommand) constructLockConn(driverName string) (*sql.DB, error) {
 }
 
 func (cmd *RunCommand) chooseBuildContainerStrategy() worker.ContainerPlacementStrategy {
-	var strategy worker.ContainerPlacementStrategy
-	switch cmd.ContainerPlacementStrategy {
-	case "random":
-		strategy = worker.NewRandomPlacementStrategy()
-	case "fewest-build-containers":
-		strategy = worker.NewFewestBuildContainersPlacementStrategy()
-	default:
-		strategy = worker.NewVolumeLocalityPlacementStrategy()
+	filters := []worker.ContainerPlacementFilter{}
+	for _, name := range cmd.ContainerPlacementStrategy {
+		switch name {
+		case "random":
+			filters = append(filters, worker.NewRandomPlacementStrategy())
+		case "fewest-build-containers":
+			filters = append(filters, worker.NewFewestBuildContainersPlacementStrategy())
+		case "limit-active-tasks":
+			filters = append(filters, worker.NewLimitActiveTasksPlacementStrategy(cmd.MaxActiveTasksPerWorker))
+		default:
+			filters = append(filters, worker.NewVolumeLocalityPlacementStrategy())
+		}
 	}
 
-	return strategy
+	switch len(filters) {
+	case 0:
+		return worker.NewVolumeLocalityPlacementStrategy()
+	case 1:
+		return filters[0]
+	default:
+		return worker.NewChainedPlacementStrategy(filters...)
+	}
 }
 
 func (cmd *RunCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) error {
@@ -1230,6 +1436,8 @@ func (cmd *RunCommand) constructEngine(
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	teamFactory db.TeamFactory,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
@@ -1242,6 +1450,7 @@ func (cmd *RunCommand) constructEngine(
 		resourceCacheFactory,
 		resourceConfigFactory,
 		secretManager,
+		teamFactory,
 		defaultLimits,
 		strategy,
 		resourceFactory,
@@ -1251,6 +1460,8 @@ func (cmd *RunCommand) constructEngine(
 		stepFactory,
 		builder.NewDelegateFactory(),
 		cmd.ExternalURL.String(),
+		varSourcePool,
+		!cmd.CredentialManagement.DisableRedactSecrets,
 	)
 
 	return engine.NewEngine(stepBuilder)
@@ -1300,11 +1511,17 @@ func (cmd *RunCommand) constructAPIHandler(
 	gcContainerDestroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	secretAccessRepository db.SecretAccessRepository,
+	secretCachePurgeRepository db.SecretCachePurgeRepository,
 	workerClient worker.Client,
 	radarScannerFactory radar.ScannerFactory,
+	buildQueue scheduler.BuildQueue,
+	workerDemand worker.WorkerDemand,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
+	actionRoleMap accessor.ActionRoleMap,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1352,12 +1569,17 @@ func (cmd *RunCommand) constructAPIHandler(
 		gcContainerDestroyer,
 		dbBuildFactory,
 		resourceConfigFactory,
+		secretAccessRepository,
+		secretCachePurgeRepository,
 
 		buildserver.NewEventHandler,
 
 		workerClient,
 		radarScannerFactory,
 
+		buildQueue,
+		workerDemand,
+
 		reconfigurableSink,
 
 		cmd.isTLSEnabled(),
@@ -1366,8 +1588,15 @@ func (cmd *RunCommand) constructAPIHandler(
 		concourse.Version,
 		concourse.WorkerVersion,
 		secretManager,
+		varSourcePool,
 		credsManagers,
+		actionRoleMap,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
+		jobserver.LogSearchLimits{
+			MaxBuilds:  cmd.BuildLogSearchMaxBuilds,
+			Timeout:    cmd.BuildLogSearchTimeout,
+			MaxMatches: cmd.BuildLogSearchMaxMatches,
+		},
 	)
 }
 
@@ -1397,13 +1626,14 @@ func (cmd *RunCommand) constructPipelineSyncer(
 	pipelineFactory db.PipelineFactory,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	bus db.NotificationsBus,
 ) *pipelines.Syncer {
 	return pipelines.NewSyncer(
 		logger,
 		pipelineFactory,
 		func(pipeline db.Pipeline) ifrit.Runner {
-			variables := creds.NewVariables(secretManager, pipeline.TeamName(), pipeline.Name())
+			variables := creds.NewPipelineVariables(secretManager, varSourcePool, pipeline.VarSources(), pipeline.TeamName(), pipeline.Name())
 			return grouper.NewParallel(os.Interrupt, grouper.Members{
 				{
 					Name: fmt.Sprintf("radar:%d", pipeline.ID()),
@@ -87,6 +87,9 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 var loggingLevels = map[string]string{
 	atc.SaveConfig:                    "EnableSystemAuditLog",
 	atc.GetConfig:                     "EnableSystemAuditLog",
+	atc.ListPipelineConfigHistory:     "EnableSystemAuditLog",
+	atc.GetPipelineConfigVersion:      "EnableSystemAuditLog",
+	atc.DiffPipelineConfigVersion:     "EnableSystemAuditLog",
 	atc.GetCC:                         "EnableSystemAuditLog",
 	atc.GetBuild:                      "EnableBuildAuditLog",
 	atc.GetBuildPlan:                  "EnableBuildAuditLog",
@@ -95,6 +98,7 @@ var loggingLevels = map[string]string{
 	atc.BuildEvents:                   "EnableBuildAuditLog",
 	atc.BuildResources:                "EnableBuildAuditLog",
 	atc.AbortBuild:                    "EnableBuildAuditLog",
+	atc.ApproveBuild:                  "EnableBuildAuditLog",
 	atc.GetBuildPreparation:           "EnableBuildAuditLog",
 	atc.GetJob:                        "EnableJobAuditLog",
 	atc.CreateJobBuild:                "EnableJobAuditLog",
@@ -103,6 +107,7 @@ var loggingLevels = map[string]string{
 	atc.ListJobBuilds:                 "EnableJobAuditLog",
 	atc.ListJobInputs:                 "EnableJobAuditLog",
 	atc.GetJobBuild:                   "EnableJobAuditLog",
+	atc.SearchJobBuildLogs:            "EnableJobAuditLog",
 	atc.PauseJob:                      "EnableJobAuditLog",
 	atc.UnpauseJob:                    "EnableJobAuditLog",
 	atc.GetVersionsDB:                 "EnableSystemAuditLog",
@@ -130,6 +135,7 @@ var loggingLevels = map[string]string{
 	atc.ListPipelines:                 "EnablePipelineAuditLog",
 	atc.GetPipeline:                   "EnablePipelineAuditLog",
 	atc.DeletePipeline:                "EnablePipelineAuditLog",
+	atc.ArchivePipeline:               "EnablePipelineAuditLog",
 	atc.OrderPipelines:                "EnablePipelineAuditLog",
 	atc.PausePipeline:                 "EnablePipelineAuditLog",
 	atc.UnpausePipeline:               "EnablePipelineAuditLog",
@@ -146,11 +152,14 @@ var loggingLevels = map[string]string{
 	atc.HeartbeatWorker:               "EnableWorkerAuditLog",
 	atc.ListWorkers:                   "EnableWorkerAuditLog",
 	atc.DeleteWorker:                  "EnableWorkerAuditLog",
+	atc.GetWorkerDemand:               "EnableWorkerAuditLog",
+	atc.ListBuildQueue:                "EnableBuildAuditLog",
 	atc.SetLogLevel:                   "EnableSystemAuditLog",
 	atc.GetLogLevel:                   "EnableSystemAuditLog",
 	atc.DownloadCLI:                   "EnableSystemAuditLog",
 	atc.GetInfo:                       "EnableSystemAuditLog",
 	atc.GetInfoCreds:                  "EnableSystemAuditLog",
+	atc.GetInfoRBAC:                   "EnableSystemAuditLog",
 	atc.ListContainers:                "EnableContainerAuditLog",
 	atc.GetContainer:                  "EnableContainerAuditLog",
 	atc.HijackContainer:               "EnableContainerAuditLog",
@@ -164,7 +173,18 @@ var loggingLevels = map[string]string{
 	atc.RenameTeam:                    "EnableTeamAuditLog",
 	atc.DestroyTeam:                   "EnableTeamAuditLog",
 	atc.ListTeamBuilds:                "EnableTeamAuditLog",
+	atc.GetTeamUsage:                  "EnableTeamAuditLog",
 	atc.CreateArtifact:                "EnableBuildAuditLog",
 	atc.GetArtifact:                   "EnableBuildAuditLog",
 	atc.ListBuildArtifacts:            "EnableBuildAuditLog",
+	atc.SetWebhook:                    "EnableTeamAuditLog",
+	atc.DestroyWebhook:                "EnableTeamAuditLog",
+	atc.ReceiveWebhook:                "EnableResourceAuditLog",
+	atc.ListSecrets:                   "EnableTeamAuditLog",
+	atc.SetSecret:                     "EnableTeamAuditLog",
+	atc.DestroySecret:                 "EnableTeamAuditLog",
+	atc.SetPipelineSecret:             "EnableTeamAuditLog",
+	atc.DestroyPipelineSecret:         "EnableTeamAuditLog",
+	atc.ListSecretAccesses:            "EnableSystemAuditLog",
+	atc.PurgeSecretCache:              "EnableTeamAuditLog",
 }
@@ -52,11 +52,17 @@ const (
 type MissingInputReasons map[string]string
 
 type BuildPreparation struct {
-	BuildID             int                               `json:"build_id"`
-	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
-	PausedJob           BuildPreparationStatus            `json:"paused_job"`
-	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
-	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
-	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
-	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	BuildID              int                               `json:"build_id"`
+	PausedPipeline       BuildPreparationStatus            `json:"paused_pipeline"`
+	PausedJob            BuildPreparationStatus            `json:"paused_job"`
+	MaxRunningBuilds     BuildPreparationStatus            `json:"max_running_builds"`
+	TeamMaxRunningBuilds BuildPreparationStatus            `json:"team_max_running_builds"`
+	Inputs               map[string]BuildPreparationStatus `json:"inputs"`
+	InputsSatisfied      BuildPreparationStatus            `json:"inputs_satisfied"`
+	MissingInputReasons  MissingInputReasons               `json:"missing_input_reasons"`
+}
+
+// BuildApproval is the decision on a build's approve step.
+type BuildApproval struct {
+	Approved bool `json:"approved"`
 }
@@ -0,0 +1,43 @@
+package atc
+
+const (
+	BuildLogSearchQueryPattern = "q"
+	BuildLogSearchQueryRegex   = "regex"
+	BuildLogSearchQueryLimit   = "limit"
+)
+
+type BuildLogSearchResults struct {
+	Builds []BuildLogMatches `json:"builds"`
+
+	// Searched is the number of builds whose logs were searched.
+	Searched int `json:"searched"`
+
+	// TimedOut is true if the search was cut short, in which case not all of
+	// the requested builds were searched.
+	TimedOut bool `json:"timed_out,omitempty"`
+
+	// Truncated is true if the search stopped once it had found the maximum
+	// number of matches, in which case the last build searched and any older
+	// builds may have more.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type BuildLogMatches struct {
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name"`
+	Status    string `json:"status"`
+
+	Matches []BuildLogMatch `json:"matches"`
+}
+
+type BuildLogMatch struct {
+	Origin BuildLogMatchOrigin `json:"origin"`
+	Line   string              `json:"line"`
+}
+
+type BuildLogMatchOrigin struct {
+	ID       string `json:"id"`
+	Source   string `json:"source,omitempty"`
+	StepType string `json:"step_type,omitempty"`
+	StepName string `json:"step_name,omitempty"`
+}
@@ -0,0 +1,21 @@
+package atc
+
+// BuildQueue is the cluster-wide queue of pending builds waiting for worker
+// capacity, in the order they will be started.
+type BuildQueue struct {
+	Workers             int `json:"workers"`
+	WorkersWithCapacity int `json:"workers_with_capacity"`
+
+	Entries []BuildQueueEntry `json:"entries"`
+}
+
+type BuildQueueEntry struct {
+	Position     int    `json:"position"`
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	TeamName     string `json:"team_name"`
+	PipelineName string `json:"pipeline_name"`
+	JobName      string `json:"job_name"`
+	Priority     int    `json:"priority"`
+	QueuedAt     int64  `json:"queued_at"`
+}
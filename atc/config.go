@@ -17,10 +17,33 @@ const DefaultTeamName = "main"
 type Tags []string
 
 type Config struct {
-	Groups        GroupConfigs    `yaml:"groups" json:"groups" mapstructure:"groups"`
-	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
-	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
-	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+	Groups        GroupConfigs     `yaml:"groups" json:"groups" mapstructure:"groups"`
+	Resources     ResourceConfigs  `yaml:"resources" json:"resources" mapstructure:"resources"`
+	ResourceTypes ResourceTypes    `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
+	Jobs          JobConfigs       `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+	VarSources    VarSourceConfigs `yaml:"var_sources,omitempty" json:"var_sources,omitempty" mapstructure:"var_sources"`
+}
+
+// VarSourceConfig names a credential manager which the pipeline's
+// ((source:path)) vars can be looked up in. Type is the name of any
+// registered credential manager and Config sets its flags, without their
+// namespace, e.g. "url" for vault's --vault-url.
+type VarSourceConfig struct {
+	Name   string                 `yaml:"name" json:"name" mapstructure:"name"`
+	Type   string                 `yaml:"type" json:"type" mapstructure:"type"`
+	Config map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+}
+
+type VarSourceConfigs []VarSourceConfig
+
+func (sources VarSourceConfigs) Lookup(name string) (VarSourceConfig, bool) {
+	for _, source := range sources {
+		if source.Name == name {
+			return source, true
+		}
+	}
+
+	return VarSourceConfig{}, false
 }
 
 type GroupConfig struct {
@@ -330,6 +353,25 @@ func (c *InParallelConfig) UnmarshalYAML(unmarshal func(interface{}) error) erro
 	return nil
 }
 
+// AcrossVarConfig is a var which a step is run across, once for each value.
+// The values are either listed statically or loaded when the step runs, from
+// a file in one of the build's artifacts or from a build-local var.
+type AcrossVarConfig struct {
+	Var    string        `yaml:"var" json:"var" mapstructure:"var"`
+	Values []interface{} `yaml:"values,omitempty" json:"values,omitempty" mapstructure:"values"`
+	// path to a json or yaml list of values, e.g. versions/go.yml
+	ValuesFile string `yaml:"values_file,omitempty" json:"values_file,omitempty" mapstructure:"values_file"`
+	// name of a build-local var holding a list of values, e.g. one set by
+	// load_var
+	ValuesVar   string `yaml:"values_var,omitempty" json:"values_var,omitempty" mapstructure:"values_var"`
+	MaxInFlight int    `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+}
+
+// ValuesKnown returns true if the values are listed statically.
+func (config AcrossVarConfig) ValuesKnown() bool {
+	return config.ValuesFile == "" && config.ValuesVar == ""
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
@@ -372,8 +414,11 @@ type PlanConfig struct {
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
 	// task config path, e.g. foo/build.yml
+	// also used by SetPipeline as the pipeline config path
+	// also used by LoadVar as the path to the file to load
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// task variables, if task is specified as external file via TaskConfigPath
+	// also used by SetPipeline for interpolating the pipeline config
 	TaskVars Params `yaml:"vars,omitempty" json:"vars,omitempty" mapstructure:"vars"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
@@ -382,6 +427,24 @@ type PlanConfig struct {
 	// used by Task for passing params to external task config
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure, e.g. deploy-prod
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+	// paths to var files for interpolating the pipeline config, e.g. foo/vars.yml
+	VarFiles []string `yaml:"var_files,omitempty" json:"var_files,omitempty" mapstructure:"var_files"`
+
+	// corresponds to a LoadVar plan
+	// name of the build-local variable to set, e.g. version
+	LoadVar string `yaml:"load_var,omitempty" json:"load_var,omitempty" mapstructure:"load_var"`
+	// format of the loaded file: raw, trim, json, or yaml
+	Format string `yaml:"format,omitempty" json:"format,omitempty" mapstructure:"format"`
+	// whether the loaded value should be redacted from build output
+	Sensitive bool `yaml:"sensitive,omitempty" json:"sensitive,omitempty" mapstructure:"sensitive"`
+
+	// corresponds to an Approve plan
+	// name of the decision the build waits on, e.g. deploy-prod
+	Approve string `yaml:"approve,omitempty" json:"approve,omitempty" mapstructure:"approve"`
+
 	// used to pass specific inputs/outputs as generic inputs/outputs in task config
 	InputMapping  map[string]string `yaml:"input_mapping,omitempty" json:"input_mapping,omitempty" mapstructure:"input_mapping"`
 	OutputMapping map[string]string `yaml:"output_mapping,omitempty" json:"output_mapping,omitempty" mapstructure:"output_mapping"`
@@ -416,6 +479,12 @@ type PlanConfig struct {
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to run it once for every combination of the given
+	// vars' values, referenced as ((.:var))
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+	// used by Across to stop running combinations once one of them fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+
 	// not present in yaml
 	DependentGet string `yaml:"-" json:"-"`
 
@@ -442,6 +511,18 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
+	if config.LoadVar != "" {
+		return config.LoadVar
+	}
+
+	if config.Approve != "" {
+		return config.Approve
+	}
+
 	return ""
 }
 
@@ -0,0 +1,13 @@
+package configvalidate_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config Validate Suite")
+}
@@ -0,0 +1,207 @@
+// Package configvalidate parses and validates pipeline configs before they
+// are saved, whether by fly set-pipeline or by a build's set_pipeline step,
+// so that both accept and reject exactly the same configs.
+package configvalidate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/mitchellh/mapstructure"
+)
+
+// ExtraKeysError is returned when a config has keys which don't correspond
+// to any of the config's fields, e.g. because of a typo.
+type ExtraKeysError struct {
+	ExtraKeys []string
+}
+
+func (eke ExtraKeysError) Error() string {
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintln(msg, "unknown/extra keys:")
+	for _, unusedKey := range eke.ExtraKeys {
+		fmt.Fprintf(msg, "  - %s\n", unusedKey)
+	}
+
+	return msg.String()
+}
+
+// Load decodes a pipeline config from its untyped form, as unmarshalled from
+// yaml or json, and validates it for saving to the team.
+//
+// Problems with the config are returned as error messages, with err only
+// being returned if the config could not be checked at all.
+func Load(team db.Team, configStructure interface{}) (atc.Config, []atc.ConfigWarning, []string, error) {
+	config, err := Decode(configStructure)
+	if err != nil {
+		if eke, ok := err.(ExtraKeysError); ok {
+			return atc.Config{}, nil, []string{eke.Error()}, nil
+		}
+
+		return atc.Config{}, nil, []string{"failed to decode config"}, nil
+	}
+
+	warnings, errorMessages, err := Validate(team, config)
+	if err != nil {
+		return atc.Config{}, nil, nil, err
+	}
+
+	return config, warnings, errorMessages, nil
+}
+
+// Decode decodes a pipeline config from its untyped form, rejecting any keys
+// nested within it which it doesn't know.
+func Decode(configStructure interface{}) (atc.Config, error) {
+	var config atc.Config
+	var md mapstructure.Metadata
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &md,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			atc.SanitizeDecodeHook,
+			atc.VersionConfigDecodeHook,
+			atc.InputsConfigDecodeHook,
+			atc.InParallelConfigDecodeHook,
+			atc.ContainerLimitsDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	err = decoder.Decode(configStructure)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	nestedUnused := []string{}
+	for _, unused := range md.Unused {
+		if strings.Contains(unused, ".") {
+			nestedUnused = append(nestedUnused, unused)
+		}
+	}
+
+	if len(nestedUnused) != 0 {
+		return atc.Config{}, ExtraKeysError{ExtraKeys: nestedUnused}
+	}
+
+	return config, nil
+}
+
+// Validate validates a decoded pipeline config, including what can only be
+// checked against the credential managers and the team's other pipelines.
+func Validate(team db.Team, config atc.Config) ([]atc.ConfigWarning, []string, error) {
+	warnings, errorMessages := config.Validate()
+	if len(errorMessages) > 0 {
+		return warnings, errorMessages, nil
+	}
+
+	errorMessages = validateVarSourceManagers(config.VarSources)
+	if len(errorMessages) > 0 {
+		return warnings, errorMessages, nil
+	}
+
+	passedWarnings, err := crossPipelinePassedWarnings(team, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(warnings, passedWarnings...), nil, nil
+}
+
+// The credential managers aren't known to the atc package, so the type and
+// config of each var source can only be checked here.
+func validateVarSourceManagers(varSources atc.VarSourceConfigs) []string {
+	errorMessages := []string{}
+	for _, varSource := range varSources {
+		_, err := creds.NewVarSourceManager(varSource.Type, varSource.Config)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("invalid var source '%s': %s", varSource.Name, err))
+		}
+	}
+
+	return errorMessages
+}
+
+// crossPipelinePassedWarnings warns about passed constraints referring to
+// pipelines or jobs which do not exist in the team. They are not errors, as
+// the other pipeline may simply not have been configured yet.
+func crossPipelinePassedWarnings(team db.Team, config atc.Config) ([]atc.ConfigWarning, error) {
+	warnings := []atc.ConfigWarning{}
+
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			for _, passed := range input.Passed {
+				passedPipelineName, passedJobName := atc.PassedJob(passed)
+				if passedPipelineName == "" {
+					continue
+				}
+
+				identifier := fmt.Sprintf("jobs.%s.get.%s.passed", job.Name, input.Name)
+
+				// passed constraints can only refer to the pipeline without
+				// instance vars, as there is no way to name an instance
+				pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: passedPipelineName})
+				if err != nil {
+					return nil, err
+				}
+
+				if !found {
+					instanced, err := hasInstances(team, passedPipelineName)
+					if err != nil {
+						return nil, err
+					}
+
+					message := fmt.Sprintf("%s references a pipeline that does not exist ('%s')", identifier, passedPipelineName)
+					if instanced {
+						message = fmt.Sprintf("%s references a pipeline which only exists with instance vars ('%s'); only pipelines without instance vars can be referenced", identifier, passedPipelineName)
+					}
+
+					warnings = append(warnings, atc.ConfigWarning{
+						Type:    "pipeline",
+						Message: message,
+					})
+
+					continue
+				}
+
+				_, found, err = pipeline.Job(passedJobName)
+				if err != nil {
+					return nil, err
+				}
+
+				if !found {
+					warnings = append(warnings, atc.ConfigWarning{
+						Type:    "pipeline",
+						Message: fmt.Sprintf("%s references a job that does not exist ('%s')", identifier, passed),
+					})
+				}
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+func hasInstances(team db.Team, pipelineName string) (bool, error) {
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		return false, err
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.Name() == pipelineName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
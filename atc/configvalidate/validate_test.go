@@ -0,0 +1,172 @@
+package configvalidate_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Load", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		payload  string
+
+		config        atc.Config
+		warnings      []atc.ConfigWarning
+		errorMessages []string
+		loadErr       error
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+
+		payload = `
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+	})
+
+	JustBeforeEach(func() {
+		var configStructure interface{}
+		err := yaml.Unmarshal([]byte(payload), &configStructure)
+		Expect(err).ToNot(HaveOccurred())
+
+		config, warnings, errorMessages, loadErr = configvalidate.Load(fakeTeam, configStructure)
+	})
+
+	It("decodes the config", func() {
+		Expect(loadErr).ToNot(HaveOccurred())
+		Expect(errorMessages).To(BeEmpty())
+		Expect(warnings).To(BeEmpty())
+		Expect(config.Resources[0].Source).To(Equal(atc.Source{"uri": "some-uri"}))
+		Expect(config.Jobs[0].Plan[0].Get).To(Equal("some-resource"))
+	})
+
+	Context("when the config has unknown keys", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    file: some-file
+    bogus: true
+`
+		})
+
+		It("rejects it", func() {
+			Expect(loadErr).ToNot(HaveOccurred())
+			Expect(errorMessages).To(ConsistOf(ContainSubstring("jobs[0].plan[0].bogus")))
+		})
+	})
+
+	Context("when the config cannot be decoded", func() {
+		BeforeEach(func() {
+			payload = `jobs: [[some-job]]`
+		})
+
+		It("rejects it", func() {
+			Expect(loadErr).ToNot(HaveOccurred())
+			Expect(errorMessages).To(Equal([]string{"failed to decode config"}))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("returns the errors", func() {
+			Expect(loadErr).ToNot(HaveOccurred())
+			Expect(errorMessages).To(ConsistOf(ContainSubstring("refers to a resource that does not exist")))
+		})
+	})
+
+	Context("when a var source uses an unknown credential manager", func() {
+		BeforeEach(func() {
+			payload = `
+var_sources:
+- name: some-source
+  type: bogus
+  config: {}
+`
+		})
+
+		It("rejects it", func() {
+			Expect(loadErr).ToNot(HaveOccurred())
+			Expect(errorMessages).To(ConsistOf(ContainSubstring("invalid var source 'some-source'")))
+		})
+	})
+
+	Context("when a passed constraint references another pipeline", func() {
+		BeforeEach(func() {
+			payload = `
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    passed: [other-pipeline/other-job]
+`
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				fakeTeam.PipelineReturns(nil, false, nil)
+			})
+
+			It("warns about it", func() {
+				Expect(loadErr).ToNot(HaveOccurred())
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(ConsistOf(atc.ConfigWarning{
+					Type:    "pipeline",
+					Message: "jobs.some-job.get.some-resource.passed references a pipeline that does not exist ('other-pipeline')",
+				}))
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				fakePipeline := new(dbfakes.FakePipeline)
+				fakePipeline.JobReturns(nil, false, nil)
+				fakeTeam.PipelineReturns(fakePipeline, true, nil)
+			})
+
+			It("warns about it", func() {
+				Expect(warnings).To(ConsistOf(atc.ConfigWarning{
+					Type:    "pipeline",
+					Message: "jobs.some-job.get.some-resource.passed references a job that does not exist ('other-pipeline/other-job')",
+				}))
+			})
+		})
+
+		Context("when looking up the pipeline fails", func() {
+			BeforeEach(func() {
+				fakeTeam.PipelineReturns(nil, false, errors.New("nope"))
+			})
+
+			It("errors", func() {
+				Expect(loadErr).To(MatchError("nope"))
+			})
+		})
+	})
+})
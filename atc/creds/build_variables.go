@@ -0,0 +1,247 @@
+package creds
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// LocalVarSource is the var source name used to reference build-local
+// variables, e.g. ((.:some-var)).
+const LocalVarSource = "."
+
+// BuildVariables holds the variables local to a single build, as set by steps
+// such as load_var. Values added with redact set are tracked so that they can
+// be scrubbed from build output, as are the values of secrets resolved
+// through its scopes once TrackSecrets has been called.
+type BuildVariables struct {
+	parent *BuildVariables
+
+	lock         sync.RWMutex
+	vars         map[string]interface{}
+	redacted     map[string]bool
+	varSources   *VarSources
+	trackSecrets bool
+	secrets      map[string]bool
+}
+
+func NewBuildVariables() *BuildVariables {
+	return &BuildVariables{
+		vars:     map[string]interface{}{},
+		redacted: map[string]bool{},
+		secrets:  map[string]bool{},
+	}
+}
+
+// NewLocalScope returns BuildVariables which see all of v's variables, but
+// whose own variables are not visible to v, e.g. for each combination of an
+// across step.
+func (v *BuildVariables) NewLocalScope() *BuildVariables {
+	scope := NewBuildVariables()
+	scope.parent = v
+	return scope
+}
+
+// AddLocalVar sets a build-local variable, replacing any existing value.
+func (v *BuildVariables) AddLocalVar(name string, val interface{}, redact bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.vars[name] = val
+
+	if redact {
+		v.redacted[name] = true
+	} else {
+		delete(v.redacted, name)
+	}
+}
+
+// Get looks up a build-local variable, either by its bare name or by a name
+// of the form ".:name".
+func (v *BuildVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	val, found := v.vars[strings.TrimPrefix(varDef.Name, LocalVarSource+":")]
+	if !found && v.parent != nil {
+		return v.parent.Get(varDef)
+	}
+
+	return val, found, nil
+}
+
+func (v *BuildVariables) List() ([]template.VariableDefinition, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	var defs []template.VariableDefinition
+	if v.parent != nil {
+		parentDefs, err := v.parent.List()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, def := range parentDefs {
+			if _, shadowed := v.vars[def.Name]; !shadowed {
+				defs = append(defs, def)
+			}
+		}
+	}
+
+	for name := range v.vars {
+		defs = append(defs, template.VariableDefinition{Name: name})
+	}
+
+	return defs, nil
+}
+
+// RedactedValues returns the string forms of all variables which must not
+// appear in build output.
+func (v *BuildVariables) RedactedValues() []string {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	var values []string
+	if v.parent != nil {
+		values = v.parent.RedactedValues()
+	}
+
+	for name := range v.redacted {
+		values = append(values, flattenValues(v.vars[name])...)
+	}
+
+	for value := range v.secrets {
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// TrackSecrets makes the build remember the value of every var resolved from
+// a credential manager through any of its scopes, so that RedactedValues
+// includes them.
+func (v *BuildVariables) TrackSecrets() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.trackSecrets = true
+}
+
+// addSecret records a resolved secret on the build as a whole, rather than on
+// the local scope it was resolved in, so that it is redacted from the output
+// of every step.
+func (v *BuildVariables) addSecret(val interface{}) {
+	if v.parent != nil {
+		v.parent.addSecret(val)
+		return
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.trackSecrets {
+		return
+	}
+
+	for _, value := range flattenValues(val) {
+		v.secrets[value] = true
+	}
+}
+
+// UseVarSources makes the build resolve ((source:path)) references against
+// the var sources of its pipeline.
+func (v *BuildVariables) UseVarSources(varSources *VarSources) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.varSources = varSources
+}
+
+func (v *BuildVariables) pipelineVarSources() *VarSources {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	if v.varSources == nil && v.parent != nil {
+		return v.parent.pipelineVarSources()
+	}
+
+	return v.varSources
+}
+
+// Scope returns Variables which resolve ((.:name)) references against the
+// build-local variables, ((source:path)) references against the pipeline's
+// var sources and everything else against parent.
+func (v *BuildVariables) Scope(parent Variables) Variables {
+	if varSources := v.pipelineVarSources(); varSources != nil {
+		parent = varSources.Scope(parent)
+	}
+
+	return buildScopedVariables{
+		local:  v,
+		parent: parent,
+	}
+}
+
+type buildScopedVariables struct {
+	local  *BuildVariables
+	parent Variables
+}
+
+func (s buildScopedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	if strings.HasPrefix(varDef.Name, LocalVarSource+":") {
+		return s.local.Get(varDef)
+	}
+
+	val, found, err := s.parent.Get(varDef)
+	if err != nil || !found {
+		return val, found, err
+	}
+
+	s.local.addSecret(val)
+
+	return val, true, nil
+}
+
+func (s buildScopedVariables) List() ([]template.VariableDefinition, error) {
+	return s.parent.List()
+}
+
+// minRedactedLength is the length below which values are not redacted, as
+// scrubbing short tokens such as "0" or "ok" from every line would make
+// the build output unreadable while hiding next to nothing.
+const minRedactedLength = 3
+
+// flattenValues returns the string values within val which are long enough
+// to be redacted. Numbers and bools are left alone, for the same reason as
+// short strings.
+func flattenValues(val interface{}) []string {
+	switch v := val.(type) {
+	case string:
+		if len(v) < minRedactedLength {
+			return nil
+		}
+
+		return []string{v}
+	case map[interface{}]interface{}:
+		var values []string
+		for _, sub := range v {
+			values = append(values, flattenValues(sub)...)
+		}
+		return values
+	case map[string]interface{}:
+		var values []string
+		for _, sub := range v {
+			values = append(values, flattenValues(sub)...)
+		}
+		return values
+	case []interface{}:
+		var values []string
+		for _, sub := range v {
+			values = append(values, flattenValues(sub)...)
+		}
+		return values
+	}
+
+	return nil
+}
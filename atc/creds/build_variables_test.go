@@ -0,0 +1,171 @@
+package creds_test
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildVariables", func() {
+	var buildVars *creds.BuildVariables
+
+	BeforeEach(func() {
+		buildVars = creds.NewBuildVariables()
+	})
+
+	Describe("Scope", func() {
+		var variables creds.Variables
+
+		BeforeEach(func() {
+			buildVars.AddLocalVar("some-var", "some-local-value", false)
+
+			variables = buildVars.Scope(template.StaticVariables{
+				"some-var": "some-cred-value",
+			})
+		})
+
+		It("resolves ((.:name)) against the build-local vars", func() {
+			params, err := creds.NewParams(variables, atc.Params{
+				"local": "((.:some-var))",
+				"cred":  "((some-var))",
+			}).Evaluate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(params).To(Equal(atc.Params{
+				"local": "some-local-value",
+				"cred":  "some-cred-value",
+			}))
+		})
+
+		It("fails if a build-local var is not set", func() {
+			_, err := creds.NewParams(variables, atc.Params{
+				"local": "((.:missing))",
+			}).Evaluate()
+			Expect(err).To(MatchError(ContainSubstring("Expected to find variables: .:missing")))
+		})
+	})
+
+	Describe("NewLocalScope", func() {
+		var scope *creds.BuildVariables
+
+		BeforeEach(func() {
+			buildVars.AddLocalVar("parent-var", "some-parent-value", true)
+			buildVars.AddLocalVar("shadowed-var", "some-parent-value", false)
+
+			scope = buildVars.NewLocalScope()
+			scope.AddLocalVar("shadowed-var", "some-scoped-value", false)
+			scope.AddLocalVar("scoped-var", "some-secret-value", true)
+		})
+
+		It("sees the parent's vars unless they are shadowed", func() {
+			val, found, err := scope.Get(template.VariableDefinition{Name: ".:parent-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("some-parent-value"))
+
+			val, found, err = scope.Get(template.VariableDefinition{Name: ".:shadowed-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("some-scoped-value"))
+		})
+
+		It("does not leak its vars to the parent", func() {
+			_, found, err := buildVars.Get(template.VariableDefinition{Name: ".:scoped-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("redacts both its own and the parent's sensitive values", func() {
+			Expect(scope.RedactedValues()).To(ConsistOf("some-parent-value", "some-secret-value"))
+		})
+	})
+
+	Describe("RedactedValues", func() {
+		It("returns the values of vars added with redact", func() {
+			buildVars.AddLocalVar("public", "some-public-value", false)
+			buildVars.AddLocalVar("secret", "some-secret-value", true)
+			buildVars.AddLocalVar("nested", map[interface{}]interface{}{
+				"a": "nested-value",
+				"b": []interface{}{"list-value"},
+			}, true)
+
+			Expect(buildVars.RedactedValues()).To(ConsistOf(
+				"some-secret-value",
+				"nested-value",
+				"list-value",
+			))
+		})
+
+		It("does not return values which are too short or not strings", func() {
+			buildVars.AddLocalVar("short", "no", true)
+			buildVars.AddLocalVar("long-enough", "abc", true)
+			buildVars.AddLocalVar("nested", map[string]interface{}{
+				"number": 12345,
+				"bool":   true,
+				"empty":  "",
+			}, true)
+
+			Expect(buildVars.RedactedValues()).To(ConsistOf("abc"))
+		})
+
+		It("stops redacting a var once it is overwritten without redact", func() {
+			buildVars.AddLocalVar("secret", "some-secret-value", true)
+			buildVars.AddLocalVar("secret", "some-public-value", false)
+
+			Expect(buildVars.RedactedValues()).To(BeEmpty())
+		})
+
+		Context("when tracking secrets", func() {
+			var secrets template.StaticVariables
+
+			BeforeEach(func() {
+				buildVars.TrackSecrets()
+
+				secrets = template.StaticVariables{
+					"some-secret":   "some-secret-value",
+					"nested-secret": map[interface{}]interface{}{"a": "nested-value"},
+					"number-secret": json.Number("12345"),
+					"bool-secret":   true,
+				}
+			})
+
+			It("returns the values of secrets resolved in any scope of the build", func() {
+				_, found, err := buildVars.Scope(secrets).Get(template.VariableDefinition{Name: "some-secret"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = buildVars.NewLocalScope().Scope(secrets).Get(template.VariableDefinition{Name: "nested-secret"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(buildVars.RedactedValues()).To(ConsistOf("some-secret-value", "nested-value"))
+			})
+
+			It("does not return the values of secrets which are not strings", func() {
+				_, found, err := buildVars.Scope(secrets).Get(template.VariableDefinition{Name: "number-secret"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = buildVars.Scope(secrets).Get(template.VariableDefinition{Name: "bool-secret"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(buildVars.RedactedValues()).To(BeEmpty())
+			})
+
+			It("does not return the values of build-local vars", func() {
+				buildVars.AddLocalVar("public", "some-public-value", false)
+
+				_, found, err := buildVars.Scope(secrets).Get(template.VariableDefinition{Name: ".:public"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(buildVars.RedactedValues()).To(BeEmpty())
+			})
+		})
+	})
+})
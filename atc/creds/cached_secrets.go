@@ -1,8 +1,11 @@
 package creds
 
 import (
-	"github.com/patrickmn/go-cache"
+	"strings"
 	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/patrickmn/go-cache"
 )
 
 type SecretCacheConfig struct {
@@ -11,10 +14,20 @@ type SecretCacheConfig struct {
 	PurgeInterval time.Duration `long:"secret-cache-purge-interval" default:"10m" description:"If the cache is enabled, expired items will be removed on this internal"`
 }
 
+//go:generate counterfeiter . Counter
+
+// Counter counts occurrences of an event, e.g. as a metric.Meter.
+type Counter interface {
+	Inc()
+}
+
 type CachedSecrets struct {
 	secrets     Secrets
 	cacheConfig SecretCacheConfig
 	cache       *cache.Cache
+
+	hits   Counter
+	misses Counter
 }
 
 type CacheEntry struct {
@@ -38,10 +51,14 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
+		cs.count(cs.hits)
+
 		result := entry.(CacheEntry)
 		return result.value, result.expiration, result.found, nil
 	}
 
+	cs.count(cs.misses)
+
 	// otherwise, let's make a request to the underlying secret manager
 	value, expiration, found, err := cs.secrets.Get(secretPath)
 
@@ -56,9 +73,16 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 
 	// take default cache ttl
 	duration := cs.cacheConfig.Duration
+
 	if expiration != nil {
 		// if secret lease time expires sooner, make duration smaller than default duration
 		itemDuration := expiration.Sub(time.Now())
+		if itemDuration <= 0 {
+			// the lease has already expired, so there's nothing worth caching
+			// (and a negative duration would make the entry never expire)
+			return value, expiration, found, nil
+		}
+
 		if itemDuration < duration {
 			duration = itemDuration
 		}
@@ -71,3 +95,57 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []SecretLookupPath {
 	return cs.secrets.NewSecretLookupPaths(teamName, pipelineName)
 }
+
+// Purge removes the cached entries the team's, or pipeline's, lookup paths
+// map the purge's path to or, if it has no path, every cached entry under
+// those lookup paths. It returns the number of entries removed.
+//
+// As lookup paths may be shared between teams, e.g. vault's shared path,
+// more entries than strictly necessary may be removed.
+func (cs *CachedSecrets) Purge(purge atc.SecretCachePurge) int {
+	var paths, prefixes []string
+	for _, lookupPath := range cs.secrets.NewSecretLookupPaths(purge.TeamName, purge.PipelineName) {
+		secretPath, err := lookupPath.VariableToSecretPath(purge.Path)
+		if err != nil {
+			continue
+		}
+
+		if purge.Path == "" {
+			prefixes = append(prefixes, secretPath)
+		} else {
+			paths = append(paths, secretPath)
+		}
+	}
+
+	purged := 0
+	for secretPath := range cs.cache.Items() {
+		if purgesPath(secretPath, paths, prefixes) {
+			cs.cache.Delete(secretPath)
+			purged++
+		}
+	}
+
+	return purged
+}
+
+func (cs *CachedSecrets) count(counter Counter) {
+	if counter != nil {
+		counter.Inc()
+	}
+}
+
+func purgesPath(secretPath string, paths []string, prefixes []string) bool {
+	for _, path := range paths {
+		if secretPath == path {
+			return true
+		}
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(secretPath, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
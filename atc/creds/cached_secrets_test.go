@@ -2,9 +2,11 @@ package creds_test
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
-	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -133,4 +135,94 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	It("should cache secrets for no longer than their lease", func() {
+		expiration := time.Now().Add(500 * time.Millisecond)
+		secretManager.GetStub = makeGetStub("foo", "value", &expiration, true, nil, &underlyingReads, &underlyingMisses)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(1))
+
+		time.Sleep(time.Second)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(2))
+	})
+
+	It("should not cache secrets whose lease has already expired", func() {
+		expiration := time.Now().Add(-time.Second)
+		secretManager.GetStub = makeGetStub("foo", "value", &expiration, true, nil, &underlyingReads, &underlyingMisses)
+
+		value, _, found, err := cachedSecretManager.Get("foo")
+		Expect(value).To(BeIdenticalTo("value"))
+		Expect(found).To(BeTrue())
+		Expect(err).To(BeNil())
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(2))
+	})
+
+	Describe("Purge", func() {
+		BeforeEach(func() {
+			secretManager.NewSecretLookupPathsStub = func(teamName string, pipelineName string) []creds.SecretLookupPath {
+				lookupPaths := []creds.SecretLookupPath{}
+				if pipelineName != "" {
+					lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix("/concourse/"+teamName+"/"+pipelineName+"/"))
+				}
+				return append(lookupPaths, creds.NewSecretLookupWithPrefix("/concourse/"+teamName+"/"))
+			}
+			secretManager.GetReturns("value", nil, true, nil)
+
+			for _, secretPath := range []string{
+				"/concourse/some-team/some-pipeline/foo",
+				"/concourse/some-team/some-pipeline/bar",
+				"/concourse/some-team/other-pipeline/foo",
+				"/concourse/some-team/foo",
+				"/concourse/other-team/foo",
+			} {
+				_, _, _, _ = cachedSecretManager.Get(secretPath)
+			}
+			Expect(secretManager.GetCallCount()).To(Equal(5))
+		})
+
+		isCached := func(secretPath string) bool {
+			calls := secretManager.GetCallCount()
+			_, _, _, _ = cachedSecretManager.Get(secretPath)
+			return secretManager.GetCallCount() == calls
+		}
+
+		It("purges the secret at a path for a pipeline", func() {
+			purged := cachedSecretManager.Purge(atc.SecretCachePurge{
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Path:         "foo",
+			})
+			Expect(purged).To(Equal(2))
+
+			Expect(isCached("/concourse/some-team/some-pipeline/foo")).To(BeFalse())
+			Expect(isCached("/concourse/some-team/foo")).To(BeFalse())
+
+			Expect(isCached("/concourse/some-team/some-pipeline/bar")).To(BeTrue())
+			Expect(isCached("/concourse/some-team/other-pipeline/foo")).To(BeTrue())
+			Expect(isCached("/concourse/other-team/foo")).To(BeTrue())
+		})
+
+		It("purges every secret of a pipeline", func() {
+			purged := cachedSecretManager.Purge(atc.SecretCachePurge{
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+			})
+			Expect(purged).To(Equal(4))
+
+			Expect(isCached("/concourse/other-team/foo")).To(BeTrue())
+		})
+
+		It("purges every secret of a team", func() {
+			purged := cachedSecretManager.Purge(atc.SecretCachePurge{TeamName: "some-team"})
+			Expect(purged).To(Equal(4))
+
+			Expect(isCached("/concourse/some-team/some-pipeline/foo")).To(BeFalse())
+			Expect(isCached("/concourse/other-team/foo")).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,136 @@
+package creds
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// NamedSecrets is the Secrets of a credential manager along with the name the
+// credential manager is registered under.
+type NamedSecrets struct {
+	Name    string
+	Secrets Secrets
+}
+
+// ChainedSecrets looks up secrets in several credential managers, trying each
+// of them in turn until one of them has the secret.
+//
+// An error from any of the credential managers ends the lookup, rather than
+// falling back to the next one, so that a var is never silently resolved to a
+// value from a credential manager further down the chain.
+type ChainedSecrets struct {
+	logger  lager.Logger
+	secrets []NamedSecrets
+}
+
+func NewChainedSecrets(logger lager.Logger, secrets []NamedSecrets) *ChainedSecrets {
+	return &ChainedSecrets{
+		logger:  logger,
+		secrets: secrets,
+	}
+}
+
+// Get retrieves the secret from the credential manager the secret path was
+// built for by one of the lookup paths, or from the first credential manager
+// which has it if the secret path was built by anything else.
+func (cs *ChainedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	name, path, routed := cs.route(secretPath)
+
+	for _, s := range cs.secrets {
+		if routed && s.Name != name {
+			continue
+		}
+
+		value, expiration, found, err := s.Secrets.Get(path)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("credential manager '%s': %s", s.Name, err)
+		}
+
+		if found {
+			cs.logger.Debug("resolved-secret", lager.Data{
+				"manager": s.Name,
+				"path":    path,
+			})
+
+			return value, expiration, true, nil
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
+// NewSecretLookupPaths returns the lookup paths of every credential manager,
+// in order, so that all of the paths of one credential manager are tried
+// before moving on to the next one.
+func (cs *ChainedSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []SecretLookupPath {
+	lookupPaths := []SecretLookupPath{}
+	for _, s := range cs.secrets {
+		paths := s.Secrets.NewSecretLookupPaths(teamName, pipelineName)
+		if len(paths) == 0 {
+			// same 1-to-1 var->secret mapping as when a single credential
+			// manager has no lookup paths
+			lookupPaths = append(lookupPaths, chainedSecretLookupPath{name: s.Name})
+			continue
+		}
+
+		for _, path := range paths {
+			lookupPaths = append(lookupPaths, chainedSecretLookupPath{name: s.Name, path: path})
+		}
+	}
+
+	return lookupPaths
+}
+
+// ForAccessor attributes the lookups of each credential manager in the chain
+// to accessor.
+func (cs *ChainedSecrets) ForAccessor(accessor SecretAccessor) Secrets {
+	secrets := make([]NamedSecrets, len(cs.secrets))
+	for i, s := range cs.secrets {
+		secrets[i] = NamedSecrets{
+			Name:    s.Name,
+			Secrets: SecretsFor(s.Secrets, accessor),
+		}
+	}
+
+	return NewChainedSecrets(cs.logger, secrets)
+}
+
+func (cs *ChainedSecrets) route(secretPath string) (string, string, bool) {
+	segs := strings.SplitN(secretPath, chainedSecretPathSeparator, 2)
+	if len(segs) != 2 {
+		return "", secretPath, false
+	}
+
+	for _, s := range cs.secrets {
+		if s.Name == segs[0] {
+			return segs[0], segs[1], true
+		}
+	}
+
+	return "", secretPath, false
+}
+
+const chainedSecretPathSeparator = ":"
+
+// chainedSecretLookupPath prefixes the secret path with the name of the
+// credential manager it is meant for.
+type chainedSecretLookupPath struct {
+	name string
+	path SecretLookupPath
+}
+
+func (sl chainedSecretLookupPath) VariableToSecretPath(varName string) (string, error) {
+	secretPath := varName
+	if sl.path != nil {
+		var err error
+		secretPath, err = sl.path.VariableToSecretPath(varName)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return sl.name + chainedSecretPathSeparator + secretPath, nil
+}
@@ -0,0 +1,196 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+func makeSecrets(prefix string, values map[string]interface{}) *credsfakes.FakeSecrets {
+	fakeSecrets := new(credsfakes.FakeSecrets)
+	fakeSecrets.NewSecretLookupPathsStub = func(teamName string, pipelineName string) []creds.SecretLookupPath {
+		return []creds.SecretLookupPath{
+			creds.NewSecretLookupWithPrefix(prefix + "/" + teamName + "/" + pipelineName + "/"),
+			creds.NewSecretLookupWithPrefix(prefix + "/" + teamName + "/"),
+		}
+	}
+	fakeSecrets.GetStub = func(secretPath string) (interface{}, *time.Time, bool, error) {
+		value, found := values[secretPath]
+		return value, nil, found, nil
+	}
+	return fakeSecrets
+}
+
+var _ = Describe("Chaining of secrets", func() {
+	var (
+		logger  *lagertest.TestLogger
+		vault   *credsfakes.FakeSecrets
+		credhub *credsfakes.FakeSecrets
+
+		variables template.Variables
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		vault = makeSecrets("/vault", map[string]interface{}{
+			"/vault/team/pipeline/foo": "vault-foo",
+			"/vault/team/bar":          "vault-bar",
+		})
+		credhub = makeSecrets("/credhub", map[string]interface{}{
+			"/credhub/team/pipeline/bar": "credhub-bar",
+			"/credhub/team/baz":          "credhub-baz",
+		})
+	})
+
+	JustBeforeEach(func() {
+		chainedSecrets := creds.NewChainedSecrets(logger, []creds.NamedSecrets{
+			{Name: "vault", Secrets: vault},
+			{Name: "credhub", Secrets: credhub},
+		})
+
+		variables = creds.NewVariables(chainedSecrets, "team", "pipeline")
+	})
+
+	It("finds vars in the first credential manager which has them", func() {
+		value, found, err := variables.Get(template.VariableDefinition{Name: "foo"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("vault-foo"))
+
+		Expect(credhub.GetCallCount()).To(BeZero())
+	})
+
+	It("tries every lookup path of a credential manager before the next one", func() {
+		value, found, err := variables.Get(template.VariableDefinition{Name: "bar"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("vault-bar"))
+
+		Expect(credhub.GetCallCount()).To(BeZero())
+	})
+
+	It("falls back to the next credential manager", func() {
+		value, found, err := variables.Get(template.VariableDefinition{Name: "baz"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("credhub-baz"))
+
+		Expect(vault.GetCallCount()).To(Equal(2))
+		Expect(vault.GetArgsForCall(0)).To(Equal("/vault/team/pipeline/baz"))
+		Expect(vault.GetArgsForCall(1)).To(Equal("/vault/team/baz"))
+
+		Expect(credhub.GetCallCount()).To(Equal(2))
+		Expect(credhub.GetArgsForCall(0)).To(Equal("/credhub/team/pipeline/baz"))
+		Expect(credhub.GetArgsForCall(1)).To(Equal("/credhub/team/baz"))
+	})
+
+	It("logs which credential manager resolved the var", func() {
+		_, _, err := variables.Get(template.VariableDefinition{Name: "baz"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(logger).To(gbytes.Say(`"manager":"credhub","path":"/credhub/team/baz"`))
+	})
+
+	It("does not find vars which no credential manager has", func() {
+		_, found, err := variables.Get(template.VariableDefinition{Name: "missing"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		Expect(vault.GetCallCount()).To(Equal(2))
+		Expect(credhub.GetCallCount()).To(Equal(2))
+	})
+
+	Context("when a credential manager fails", func() {
+		BeforeEach(func() {
+			vault.GetReturns(nil, nil, false, errors.New("sealed"))
+		})
+
+		It("returns the error instead of falling back", func() {
+			_, found, err := variables.Get(template.VariableDefinition{Name: "baz"})
+			Expect(err).To(MatchError("credential manager 'vault': sealed"))
+			Expect(found).To(BeFalse())
+
+			Expect(credhub.GetCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a credential manager has no lookup paths", func() {
+		BeforeEach(func() {
+			vault = new(credsfakes.FakeSecrets)
+			vault.GetStub = func(secretPath string) (interface{}, *time.Time, bool, error) {
+				if secretPath == "foo" {
+					return "vault-foo", nil, true, nil
+				}
+				return nil, nil, false, nil
+			}
+		})
+
+		It("looks up the var by its name", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("vault-foo"))
+		})
+
+		It("still falls back to the next credential manager", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "baz"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("credhub-baz"))
+
+			Expect(vault.GetCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when each credential manager is cached and retried", func() {
+		var cachedCredhub creds.Secrets
+
+		BeforeEach(func() {
+			attempts := 0
+			vault.GetStub = func(secretPath string) (interface{}, *time.Time, bool, error) {
+				attempts++
+				if attempts == 1 {
+					return nil, nil, false, errors.New("remote error: handshake failure")
+				}
+				return nil, nil, false, nil
+			}
+
+			cachedCredhub = creds.NewCachedSecrets(credhub, creds.SecretCacheConfig{
+				Duration:      time.Minute,
+				PurgeInterval: time.Minute,
+			})
+		})
+
+		JustBeforeEach(func() {
+			chainedSecrets := creds.NewChainedSecrets(logger, []creds.NamedSecrets{
+				{Name: "vault", Secrets: creds.NewRetryableSecrets(vault, creds.SecretRetryConfig{Attempts: 5, Interval: time.Millisecond})},
+				{Name: "credhub", Secrets: cachedCredhub},
+			})
+
+			variables = creds.NewVariables(chainedSecrets, "team", "pipeline")
+		})
+
+		It("retries a credential manager before falling back and caches per credential manager", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "baz"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("credhub-baz"))
+
+			value, found, err = variables.Get(template.VariableDefinition{Name: "baz"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("credhub-baz"))
+
+			Expect(vault.GetCallCount()).To(Equal(5))
+			Expect(credhub.GetCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package credhub
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -13,6 +14,8 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 )
 
+const DefaultPathPrefix = "/concourse"
+
 type CredHubManager struct {
 	URL string `long:"url" description:"CredHub server address used to access secrets."`
 
@@ -112,6 +115,28 @@ func (manager CredHubManager) Validate() error {
 	return nil
 }
 
+// ValidateVarSource rejects configs which would read files on the ATC or look
+// up secrets outside of the pipeline's team.
+func (manager CredHubManager) ValidateVarSource() error {
+	if len(manager.TLS.CACerts) != 0 {
+		return errors.New("ca cert files on the ATC cannot be used")
+	}
+
+	if manager.TLS.ClientCert != "" || manager.TLS.ClientKey != "" {
+		return errors.New("client cert files on the ATC cannot be used")
+	}
+
+	if manager.UAA.ClientId == "" || manager.UAA.ClientSecret == "" {
+		return errors.New("must provide client id and client secret")
+	}
+
+	if manager.PathPrefix != DefaultPathPrefix {
+		return errors.New("path prefix cannot be overridden")
+	}
+
+	return nil
+}
+
 func (manager CredHubManager) Health() (*creds.HealthResponse, error) {
 	healthResponse := &creds.HealthResponse{
 		Method: "/health",
@@ -0,0 +1,66 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeCounter struct {
+	IncStub        func()
+	incMutex       sync.RWMutex
+	incArgsForCall []struct {
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCounter) Inc() {
+	fake.incMutex.Lock()
+	fake.incArgsForCall = append(fake.incArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Inc", []interface{}{})
+	fake.incMutex.Unlock()
+	if fake.IncStub != nil {
+		fake.IncStub()
+	}
+}
+
+func (fake *FakeCounter) IncCallCount() int {
+	fake.incMutex.RLock()
+	defer fake.incMutex.RUnlock()
+	return len(fake.incArgsForCall)
+}
+
+func (fake *FakeCounter) IncCalls(stub func()) {
+	fake.incMutex.Lock()
+	defer fake.incMutex.Unlock()
+	fake.IncStub = stub
+}
+
+func (fake *FakeCounter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.incMutex.RLock()
+	defer fake.incMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCounter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.Counter = new(FakeCounter)
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeSecretAccessRecorder struct {
+	RecordSecretAccessesStub        func([]atc.SecretAccess) error
+	recordSecretAccessesMutex       sync.RWMutex
+	recordSecretAccessesArgsForCall []struct {
+		arg1 []atc.SecretAccess
+	}
+	recordSecretAccessesReturns struct {
+		result1 error
+	}
+	recordSecretAccessesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccesses(arg1 []atc.SecretAccess) error {
+	var arg1Copy []atc.SecretAccess
+	if arg1 != nil {
+		arg1Copy = make([]atc.SecretAccess, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.recordSecretAccessesMutex.Lock()
+	ret, specificReturn := fake.recordSecretAccessesReturnsOnCall[len(fake.recordSecretAccessesArgsForCall)]
+	fake.recordSecretAccessesArgsForCall = append(fake.recordSecretAccessesArgsForCall, struct {
+		arg1 []atc.SecretAccess
+	}{arg1Copy})
+	fake.recordInvocation("RecordSecretAccesses", []interface{}{arg1Copy})
+	fake.recordSecretAccessesMutex.Unlock()
+	if fake.RecordSecretAccessesStub != nil {
+		return fake.RecordSecretAccessesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.recordSecretAccessesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessesCallCount() int {
+	fake.recordSecretAccessesMutex.RLock()
+	defer fake.recordSecretAccessesMutex.RUnlock()
+	return len(fake.recordSecretAccessesArgsForCall)
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessesCalls(stub func([]atc.SecretAccess) error) {
+	fake.recordSecretAccessesMutex.Lock()
+	defer fake.recordSecretAccessesMutex.Unlock()
+	fake.RecordSecretAccessesStub = stub
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessesArgsForCall(i int) []atc.SecretAccess {
+	fake.recordSecretAccessesMutex.RLock()
+	defer fake.recordSecretAccessesMutex.RUnlock()
+	argsForCall := fake.recordSecretAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessesReturns(result1 error) {
+	fake.recordSecretAccessesMutex.Lock()
+	defer fake.recordSecretAccessesMutex.Unlock()
+	fake.RecordSecretAccessesStub = nil
+	fake.recordSecretAccessesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessesReturnsOnCall(i int, result1 error) {
+	fake.recordSecretAccessesMutex.Lock()
+	defer fake.recordSecretAccessesMutex.Unlock()
+	fake.RecordSecretAccessesStub = nil
+	if fake.recordSecretAccessesReturnsOnCall == nil {
+		fake.recordSecretAccessesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordSecretAccessesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordSecretAccessesMutex.RLock()
+	defer fake.recordSecretAccessesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretAccessRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.SecretAccessRecorder = new(FakeSecretAccessRecorder)
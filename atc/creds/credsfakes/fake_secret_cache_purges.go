@@ -0,0 +1,185 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeSecretCachePurges struct {
+	LatestSecretCachePurgeIDStub        func() (int, error)
+	latestSecretCachePurgeIDMutex       sync.RWMutex
+	latestSecretCachePurgeIDArgsForCall []struct {
+	}
+	latestSecretCachePurgeIDReturns struct {
+		result1 int
+		result2 error
+	}
+	latestSecretCachePurgeIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	SecretCachePurgesSinceStub        func(int) ([]atc.SecretCachePurge, error)
+	secretCachePurgesSinceMutex       sync.RWMutex
+	secretCachePurgesSinceArgsForCall []struct {
+		arg1 int
+	}
+	secretCachePurgesSinceReturns struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}
+	secretCachePurgesSinceReturnsOnCall map[int]struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretCachePurges) LatestSecretCachePurgeID() (int, error) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	ret, specificReturn := fake.latestSecretCachePurgeIDReturnsOnCall[len(fake.latestSecretCachePurgeIDArgsForCall)]
+	fake.latestSecretCachePurgeIDArgsForCall = append(fake.latestSecretCachePurgeIDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LatestSecretCachePurgeID", []interface{}{})
+	fake.latestSecretCachePurgeIDMutex.Unlock()
+	if fake.LatestSecretCachePurgeIDStub != nil {
+		return fake.LatestSecretCachePurgeIDStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.latestSecretCachePurgeIDReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretCachePurges) LatestSecretCachePurgeIDCallCount() int {
+	fake.latestSecretCachePurgeIDMutex.RLock()
+	defer fake.latestSecretCachePurgeIDMutex.RUnlock()
+	return len(fake.latestSecretCachePurgeIDArgsForCall)
+}
+
+func (fake *FakeSecretCachePurges) LatestSecretCachePurgeIDCalls(stub func() (int, error)) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	defer fake.latestSecretCachePurgeIDMutex.Unlock()
+	fake.LatestSecretCachePurgeIDStub = stub
+}
+
+func (fake *FakeSecretCachePurges) LatestSecretCachePurgeIDReturns(result1 int, result2 error) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	defer fake.latestSecretCachePurgeIDMutex.Unlock()
+	fake.LatestSecretCachePurgeIDStub = nil
+	fake.latestSecretCachePurgeIDReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurges) LatestSecretCachePurgeIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	defer fake.latestSecretCachePurgeIDMutex.Unlock()
+	fake.LatestSecretCachePurgeIDStub = nil
+	if fake.latestSecretCachePurgeIDReturnsOnCall == nil {
+		fake.latestSecretCachePurgeIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.latestSecretCachePurgeIDReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurges) SecretCachePurgesSince(arg1 int) ([]atc.SecretCachePurge, error) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	ret, specificReturn := fake.secretCachePurgesSinceReturnsOnCall[len(fake.secretCachePurgesSinceArgsForCall)]
+	fake.secretCachePurgesSinceArgsForCall = append(fake.secretCachePurgesSinceArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("SecretCachePurgesSince", []interface{}{arg1})
+	fake.secretCachePurgesSinceMutex.Unlock()
+	if fake.SecretCachePurgesSinceStub != nil {
+		return fake.SecretCachePurgesSinceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.secretCachePurgesSinceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretCachePurges) SecretCachePurgesSinceCallCount() int {
+	fake.secretCachePurgesSinceMutex.RLock()
+	defer fake.secretCachePurgesSinceMutex.RUnlock()
+	return len(fake.secretCachePurgesSinceArgsForCall)
+}
+
+func (fake *FakeSecretCachePurges) SecretCachePurgesSinceCalls(stub func(int) ([]atc.SecretCachePurge, error)) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	defer fake.secretCachePurgesSinceMutex.Unlock()
+	fake.SecretCachePurgesSinceStub = stub
+}
+
+func (fake *FakeSecretCachePurges) SecretCachePurgesSinceArgsForCall(i int) int {
+	fake.secretCachePurgesSinceMutex.RLock()
+	defer fake.secretCachePurgesSinceMutex.RUnlock()
+	argsForCall := fake.secretCachePurgesSinceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretCachePurges) SecretCachePurgesSinceReturns(result1 []atc.SecretCachePurge, result2 error) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	defer fake.secretCachePurgesSinceMutex.Unlock()
+	fake.SecretCachePurgesSinceStub = nil
+	fake.secretCachePurgesSinceReturns = struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurges) SecretCachePurgesSinceReturnsOnCall(i int, result1 []atc.SecretCachePurge, result2 error) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	defer fake.secretCachePurgesSinceMutex.Unlock()
+	fake.SecretCachePurgesSinceStub = nil
+	if fake.secretCachePurgesSinceReturnsOnCall == nil {
+		fake.secretCachePurgesSinceReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretCachePurge
+			result2 error
+		})
+	}
+	fake.secretCachePurgesSinceReturnsOnCall[i] = struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurges) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.latestSecretCachePurgeIDMutex.RLock()
+	defer fake.latestSecretCachePurgeIDMutex.RUnlock()
+	fake.secretCachePurgesSinceMutex.RLock()
+	defer fake.secretCachePurgesSinceMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretCachePurges) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.SecretCachePurges = new(FakeSecretCachePurges)
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeVarSourcePool struct {
+	FindOrCreateStub        func(atc.VarSourceConfig) (creds.Secrets, error)
+	findOrCreateMutex       sync.RWMutex
+	findOrCreateArgsForCall []struct {
+		arg1 atc.VarSourceConfig
+	}
+	findOrCreateReturns struct {
+		result1 creds.Secrets
+		result2 error
+	}
+	findOrCreateReturnsOnCall map[int]struct {
+		result1 creds.Secrets
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeVarSourcePool) FindOrCreate(arg1 atc.VarSourceConfig) (creds.Secrets, error) {
+	fake.findOrCreateMutex.Lock()
+	ret, specificReturn := fake.findOrCreateReturnsOnCall[len(fake.findOrCreateArgsForCall)]
+	fake.findOrCreateArgsForCall = append(fake.findOrCreateArgsForCall, struct {
+		arg1 atc.VarSourceConfig
+	}{arg1})
+	fake.recordInvocation("FindOrCreate", []interface{}{arg1})
+	fake.findOrCreateMutex.Unlock()
+	if fake.FindOrCreateStub != nil {
+		return fake.FindOrCreateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.findOrCreateReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateCallCount() int {
+	fake.findOrCreateMutex.RLock()
+	defer fake.findOrCreateMutex.RUnlock()
+	return len(fake.findOrCreateArgsForCall)
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateCalls(stub func(atc.VarSourceConfig) (creds.Secrets, error)) {
+	fake.findOrCreateMutex.Lock()
+	defer fake.findOrCreateMutex.Unlock()
+	fake.FindOrCreateStub = stub
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateArgsForCall(i int) atc.VarSourceConfig {
+	fake.findOrCreateMutex.RLock()
+	defer fake.findOrCreateMutex.RUnlock()
+	argsForCall := fake.findOrCreateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateReturns(result1 creds.Secrets, result2 error) {
+	fake.findOrCreateMutex.Lock()
+	defer fake.findOrCreateMutex.Unlock()
+	fake.FindOrCreateStub = nil
+	fake.findOrCreateReturns = struct {
+		result1 creds.Secrets
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateReturnsOnCall(i int, result1 creds.Secrets, result2 error) {
+	fake.findOrCreateMutex.Lock()
+	defer fake.findOrCreateMutex.Unlock()
+	fake.FindOrCreateStub = nil
+	if fake.findOrCreateReturnsOnCall == nil {
+		fake.findOrCreateReturnsOnCall = make(map[int]struct {
+			result1 creds.Secrets
+			result2 error
+		})
+	}
+	fake.findOrCreateReturnsOnCall[i] = struct {
+		result1 creds.Secrets
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVarSourcePool) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findOrCreateMutex.RLock()
+	defer fake.findOrCreateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeVarSourcePool) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.VarSourcePool = new(FakeVarSourcePool)
@@ -0,0 +1,118 @@
+package dbsecrets
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type DBSecrets struct {
+	log         lager.Logger
+	teamFactory db.TeamFactory
+}
+
+func NewDBSecrets(log lager.Logger, teamFactory db.TeamFactory) *DBSecrets {
+	return &DBSecrets{
+		log:         log,
+		teamFactory: teamFactory,
+	}
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
+func (s *DBSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	if len(pipelineName) > 0 {
+		lookupPaths = append(lookupPaths, NewSecretLookupPathDB(teamName, pipelineName))
+	}
+	lookupPaths = append(lookupPaths, NewSecretLookupPathDB(teamName, ""))
+	return lookupPaths
+}
+
+// Get retrieves the value of an individual secret. Secrets stored in the
+// database do not expire.
+func (s *DBSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	teamName, pipelineName, secretName, err := splitSecretPath(secretPath)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		s.log.Error("failed-to-find-team", err, lager.Data{"team": teamName})
+		return nil, nil, false, err
+	}
+
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	secret, found, err := team.Secret(pipelineName, secretName)
+	if err != nil {
+		s.log.Error("failed-to-find-secret", err, lager.Data{
+			"team":     teamName,
+			"pipeline": pipelineName,
+			"secret":   secretName,
+		})
+		return nil, nil, false, err
+	}
+
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return secret.Value, nil, true, nil
+}
+
+// SecretLookupPathDB renders paths of the form /team/pipeline/secret, or
+// /team/secret for team secrets, with each part escaped so that variables
+// containing slashes cannot be mistaken for a pipeline secret.
+type SecretLookupPathDB struct {
+	TeamName     string
+	PipelineName string
+}
+
+func NewSecretLookupPathDB(teamName string, pipelineName string) creds.SecretLookupPath {
+	return &SecretLookupPathDB{
+		TeamName:     teamName,
+		PipelineName: pipelineName,
+	}
+}
+
+func (sl SecretLookupPathDB) VariableToSecretPath(varName string) (string, error) {
+	parts := []string{url.PathEscape(sl.TeamName)}
+	if sl.PipelineName != "" {
+		parts = append(parts, url.PathEscape(sl.PipelineName))
+	}
+
+	parts = append(parts, url.PathEscape(varName))
+
+	return "/" + strings.Join(parts, "/"), nil
+}
+
+func splitSecretPath(secretPath string) (string, string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(secretPath, "/"), "/")
+	if len(parts) != 2 && len(parts) != 3 {
+		return "", "", "", fmt.Errorf("unable to split secret path into /[team]/[pipeline]/[secret]: %s", secretPath)
+	}
+
+	unescaped := []string{}
+	for _, part := range parts {
+		p, err := url.PathUnescape(part)
+		if err != nil {
+			return "", "", "", err
+		}
+
+		unescaped = append(unescaped, p)
+	}
+
+	if len(unescaped) == 2 {
+		return unescaped[0], "", unescaped[1], nil
+	}
+
+	return unescaped[0], unescaped[1], unescaped[2], nil
+}
@@ -0,0 +1,23 @@
+package dbsecrets
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type dbSecretsFactory struct {
+	log         lager.Logger
+	teamFactory db.TeamFactory
+}
+
+func NewDBSecretsFactory(log lager.Logger, teamFactory db.TeamFactory) *dbSecretsFactory {
+	return &dbSecretsFactory{
+		log:         log,
+		teamFactory: teamFactory,
+	}
+}
+
+func (factory *dbSecretsFactory) NewSecrets() creds.Secrets {
+	return NewDBSecrets(factory.log, factory.teamFactory)
+}
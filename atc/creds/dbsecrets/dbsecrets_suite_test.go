@@ -0,0 +1,13 @@
+package dbsecrets_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDBSecrets(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DB Secrets Suite")
+}
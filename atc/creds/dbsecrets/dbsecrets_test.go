@@ -0,0 +1,129 @@
+package dbsecrets_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dbsecrets"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DBSecrets", func() {
+	var (
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+
+		secrets creds.Secrets
+	)
+
+	BeforeEach(func() {
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		secrets = dbsecrets.NewDBSecretsFactory(lagertest.NewTestLogger("test"), fakeTeamFactory).NewSecrets()
+	})
+
+	Describe("NewSecretLookupPaths()", func() {
+		It("looks up pipeline secrets before team secrets", func() {
+			var paths []string
+			for _, p := range secrets.NewSecretLookupPaths("team", "pipeline") {
+				path, err := p.VariableToSecretPath("variable")
+				Expect(err).ToNot(HaveOccurred())
+				paths = append(paths, path)
+			}
+
+			Expect(paths).To(Equal([]string{"/team/pipeline/variable", "/team/variable"}))
+		})
+
+		It("only looks up team secrets without a pipeline", func() {
+			lookupPaths := secrets.NewSecretLookupPaths("team", "")
+			Expect(lookupPaths).To(HaveLen(1))
+
+			path, err := lookupPaths[0].VariableToSecretPath("variable")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/team/variable"))
+		})
+
+		It("escapes slashes in the variable name", func() {
+			path, err := secrets.NewSecretLookupPaths("team", "")[0].VariableToSecretPath("some/variable")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/team/some%2Fvariable"))
+		})
+	})
+
+	Describe("Get()", func() {
+		BeforeEach(func() {
+			fakeTeam.SecretReturns(atc.Secret{Name: "variable", Value: "some-value"}, true, nil)
+		})
+
+		It("finds pipeline secrets", func() {
+			value, expiration, found, err := secrets.Get("/team/pipeline/variable")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+			Expect(expiration).To(BeNil())
+
+			Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("team"))
+			pipelineName, secretName := fakeTeam.SecretArgsForCall(0)
+			Expect(pipelineName).To(Equal("pipeline"))
+			Expect(secretName).To(Equal("variable"))
+		})
+
+		It("finds team secrets", func() {
+			_, _, found, err := secrets.Get("/team/some%2Fvariable")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pipelineName, secretName := fakeTeam.SecretArgsForCall(0)
+			Expect(pipelineName).To(BeEmpty())
+			Expect(secretName).To(Equal("some/variable"))
+		})
+
+		It("fails on malformed paths", func() {
+			_, _, _, err := secrets.Get("/team")
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("when the secret does not exist", func() {
+			BeforeEach(func() {
+				fakeTeam.SecretReturns(atc.Secret{}, false, nil)
+			})
+
+			It("is not found", func() {
+				_, _, found, err := secrets.Get("/team/variable")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the team does not exist", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			It("is not found", func() {
+				_, _, found, err := secrets.Get("/team/variable")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the secret fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTeam.SecretReturns(atc.Secret{}, false, disaster)
+			})
+
+			It("returns the error", func() {
+				_, _, _, err := secrets.Get("/team/variable")
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -0,0 +1,76 @@
+package dbsecrets
+
+import (
+	"encoding/json"
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/encryption"
+)
+
+type DBManager struct {
+	Enabled bool `long:"enable-db-secrets" description:"Look up credentials in the secrets stored in the Concourse database with 'fly set-secret', encrypted with the --encryption-key."`
+
+	// TeamFactory and EncryptionStrategy are set once the database
+	// connection has been opened.
+	TeamFactory        db.TeamFactory
+	EncryptionStrategy encryption.Strategy
+}
+
+func (manager *DBManager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"health": health,
+	})
+}
+
+func (manager *DBManager) Init(log lager.Logger) error {
+	return nil
+}
+
+func (manager *DBManager) IsConfigured() bool {
+	return manager.Enabled
+}
+
+func (manager *DBManager) Validate() error {
+	if manager.TeamFactory == nil {
+		return errors.New("database connection not configured")
+	}
+
+	// secrets must never be stored in plain text
+	switch manager.EncryptionStrategy.(type) {
+	case nil, encryption.NoEncryption, *encryption.NoEncryption:
+		return errors.New("database secrets require an --encryption-key")
+	}
+
+	return nil
+}
+
+func (manager *DBManager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "FindTeam",
+	}
+
+	_, _, err := manager.TeamFactory.FindTeam(atc.DefaultTeamName)
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status": "UP",
+	}
+
+	return health, nil
+}
+
+func (manager *DBManager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	return NewDBSecretsFactory(log, manager.TeamFactory), nil
+}
@@ -0,0 +1,27 @@
+package dbsecrets
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type dbManagerFactory struct{}
+
+func init() {
+	creds.Register("db", NewDBManagerFactory())
+}
+
+func NewDBManagerFactory() creds.ManagerFactory {
+	return &dbManagerFactory{}
+}
+
+func (factory *dbManagerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &DBManager{}
+
+	_, err := group.AddGroup("Database Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	return manager
+}
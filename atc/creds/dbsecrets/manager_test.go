@@ -0,0 +1,86 @@
+package dbsecrets_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/creds/dbsecrets"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/encryption"
+	"github.com/concourse/concourse/atc/db/encryption/encryptionfakes"
+	flags "github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DBManager", func() {
+	var manager dbsecrets.DBManager
+
+	BeforeEach(func() {
+		manager = dbsecrets.DBManager{}
+	})
+
+	Describe("IsConfigured()", func() {
+		It("is not configured by default", func() {
+			_, err := flags.ParseArgs(&manager, []string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("is configured when enabled", func() {
+			_, err := flags.ParseArgs(&manager, []string{"--enable-db-secrets"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		It("fails without a database connection", func() {
+			Expect(manager.Validate()).To(HaveOccurred())
+		})
+
+		Context("with a database connection", func() {
+			BeforeEach(func() {
+				manager.TeamFactory = new(dbfakes.FakeTeamFactory)
+			})
+
+			It("passes when secrets are encrypted", func() {
+				manager.EncryptionStrategy = new(encryptionfakes.FakeStrategy)
+				Expect(manager.Validate()).To(Succeed())
+			})
+
+			It("fails when secrets would not be encrypted", func() {
+				manager.EncryptionStrategy = encryption.NewNoEncryption()
+				Expect(manager.Validate()).To(MatchError("database secrets require an --encryption-key"))
+			})
+
+			It("fails without an encryption strategy", func() {
+				Expect(manager.Validate()).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Health()", func() {
+		var fakeTeamFactory *dbfakes.FakeTeamFactory
+
+		BeforeEach(func() {
+			fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+			manager.TeamFactory = fakeTeamFactory
+		})
+
+		It("is up when the database can be queried", func() {
+			health, err := manager.Health()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Response).To(Equal(map[string]string{"status": "UP"}))
+			Expect(health.Error).To(BeEmpty())
+		})
+
+		It("reports errors querying the database", func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+
+			health, err := manager.Health()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Response).To(BeNil())
+			Expect(health.Error).To(Equal("nope"))
+		})
+	})
+})
@@ -3,7 +3,7 @@ package creds
 import (
 	"encoding/json"
 
-	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/template"
 	"gopkg.in/yaml.v2"
 )
 
@@ -15,7 +15,7 @@ func evaluate(variablesResolver Variables, in, out interface{}) error {
 
 	tpl := template.NewTemplate(byteParams)
 
-	bytes, err := tpl.Evaluate(variablesResolver, nil, template.EvaluateOpts{
+	bytes, err := tpl.Evaluate(variablesResolver, template.EvaluateOpts{
 		ExpectAllKeys: true,
 	})
 	if err != nil {
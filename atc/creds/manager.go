@@ -1,10 +1,16 @@
 package creds
 
 import (
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/jessevdk/go-flags"
 )
 
+//go:generate counterfeiter . Manager
+
 type Manager interface {
 	IsConfigured() bool
 	Validate() error
@@ -14,17 +20,97 @@ type Manager interface {
 	NewSecretsFactory(lager.Logger) (SecretsFactory, error)
 }
 
+// VarSourceManager is a Manager which pipelines may configure as a var
+// source. As a var source is configured by the pipeline's team rather than the
+// operator, ValidateVarSource must reject any config which would look up
+// secrets as the ATC rather than as the team, e.g. by falling back to the
+// ATC's ambient credentials, reading files on the ATC, or overriding the
+// paths under which the team's secrets are looked up.
+type VarSourceManager interface {
+	Manager
+
+	ValidateVarSource() error
+}
+
 type ManagerFactory interface {
 	AddConfig(*flags.Group) Manager
 }
 
 type Managers map[string]Manager
 
+// Configured returns the names of the configured credential managers in the
+// order vars are looked up in them. Without an explicit lookup order every
+// configured credential manager is used, in alphabetical order.
+func (managers Managers) Configured(lookupOrder []string) ([]string, error) {
+	configured := []string{}
+	for name, manager := range managers {
+		if manager.IsConfigured() {
+			configured = append(configured, name)
+		}
+	}
+
+	sort.Strings(configured)
+
+	if len(lookupOrder) == 0 {
+		return configured, nil
+	}
+
+	ordered := map[string]bool{}
+	for _, name := range lookupOrder {
+		manager, found := managers[name]
+		if !found {
+			return nil, fmt.Errorf("unknown credential manager '%s' in lookup order", name)
+		}
+
+		if !manager.IsConfigured() {
+			return nil, fmt.Errorf("credential manager '%s' in lookup order is not configured", name)
+		}
+
+		if ordered[name] {
+			return nil, fmt.Errorf("credential manager '%s' appears more than once in lookup order", name)
+		}
+
+		ordered[name] = true
+	}
+
+	for _, name := range configured {
+		if !ordered[name] {
+			return nil, fmt.Errorf("credential manager '%s' is configured but missing from lookup order", name)
+		}
+	}
+
+	return lookupOrder, nil
+}
+
 type CredentialManagementConfig struct {
+	LookupOrder []string `long:"credential-manager-lookup-order" description:"Name of a configured credential manager to look up vars in. Can be specified multiple times to try several credential managers in turn. Defaults to every configured credential manager, in alphabetical order."`
+
+	DisableRedactSecrets bool `long:"disable-redact-secrets" description:"Disable replacing the values of secrets resolved from credential managers with ((redacted)) in build logs."`
+
+	EnableSecretAccessAudit bool `long:"enable-secret-access-audit" description:"Record every lookup which finds a secret in a credential manager, along with the pipeline, build or user it was for, in the secret access audit trail."`
+
 	RetryConfig SecretRetryConfig
 	CacheConfig SecretCacheConfig
 }
 
+// WrapSecrets retries and, if enabled, caches the lookups of a single
+// credential manager, so that a credential manager further down a chain is
+// only tried once the ones before it have definitely not found the secret.
+func (config CredentialManagementConfig) WrapSecrets(secrets Secrets, caches *SecretCaches) Secrets {
+	return caches.Wrap(NewRetryableSecrets(secrets, config.RetryConfig))
+}
+
+// AuditSecrets records the lookups of a single credential manager's secrets
+// in the secret access audit trail, if enabled. It goes around WrapSecrets, so
+// that lookups answered from the cache are recorded too.
+func (config CredentialManagementConfig) AuditSecrets(manager string, secrets Secrets, batcher *SecretAccessBatcher) Secrets {
+	if !config.EnableSecretAccessAudit {
+		return secrets
+	}
+
+	return NewAuditedSecrets(secrets, manager, batcher, clock.NewClock())
+}
+
 type HealthResponse struct {
 	Response interface{} `json:"response,omitempty"`
 	Error    string      `json:"error,omitempty"`
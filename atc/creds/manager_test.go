@@ -0,0 +1,58 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managers", func() {
+	var managers creds.Managers
+
+	BeforeEach(func() {
+		vault := new(credsfakes.FakeManager)
+		vault.IsConfiguredReturns(true)
+
+		credhub := new(credsfakes.FakeManager)
+		credhub.IsConfiguredReturns(true)
+
+		ssm := new(credsfakes.FakeManager)
+
+		managers = creds.Managers{
+			"vault":   vault,
+			"credhub": credhub,
+			"ssm":     ssm,
+		}
+	})
+
+	Describe("Configured", func() {
+		It("defaults to every configured credential manager in alphabetical order", func() {
+			Expect(managers.Configured(nil)).To(Equal([]string{"credhub", "vault"}))
+		})
+
+		It("uses the lookup order", func() {
+			Expect(managers.Configured([]string{"vault", "credhub"})).To(Equal([]string{"vault", "credhub"}))
+		})
+
+		It("rejects unknown credential managers", func() {
+			_, err := managers.Configured([]string{"vault", "credhub", "bogus"})
+			Expect(err).To(MatchError("unknown credential manager 'bogus' in lookup order"))
+		})
+
+		It("rejects credential managers which are not configured", func() {
+			_, err := managers.Configured([]string{"vault", "credhub", "ssm"})
+			Expect(err).To(MatchError("credential manager 'ssm' in lookup order is not configured"))
+		})
+
+		It("rejects credential managers given more than once", func() {
+			_, err := managers.Configured([]string{"vault", "credhub", "vault"})
+			Expect(err).To(MatchError("credential manager 'vault' appears more than once in lookup order"))
+		})
+
+		It("rejects configured credential managers left out of the lookup order", func() {
+			_, err := managers.Configured([]string{"vault"})
+			Expect(err).To(MatchError("credential manager 'credhub' is configured but missing from lookup order"))
+		})
+	})
+})
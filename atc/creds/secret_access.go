@@ -0,0 +1,314 @@
+package creds
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/patrickmn/go-cache"
+)
+
+//go:generate counterfeiter . SecretAccessRecorder
+
+// SecretAccessRecorder stores the secret access audit trail.
+type SecretAccessRecorder interface {
+	RecordSecretAccesses([]atc.SecretAccess) error
+}
+
+// SecretAccessor identifies what secrets are being looked up for, i.e. the
+// pipeline and, if any, the build or user who needs them.
+type SecretAccessor struct {
+	TeamName     string
+	PipelineName string
+	BuildID      int
+	UserName     string
+}
+
+// With returns the accessor with each field which is set in other replaced.
+func (accessor SecretAccessor) With(other SecretAccessor) SecretAccessor {
+	if other.TeamName != "" {
+		accessor.TeamName = other.TeamName
+	}
+
+	if other.PipelineName != "" {
+		accessor.PipelineName = other.PipelineName
+	}
+
+	if other.BuildID != 0 {
+		accessor.BuildID = other.BuildID
+	}
+
+	if other.UserName != "" {
+		accessor.UserName = other.UserName
+	}
+
+	return accessor
+}
+
+// AccessorSecrets are Secrets which can attribute their lookups to an
+// accessor.
+type AccessorSecrets interface {
+	Secrets
+
+	ForAccessor(SecretAccessor) Secrets
+}
+
+// SecretsFor returns secrets which attribute their lookups to accessor, or
+// secrets itself if it doesn't record who looks up its secrets.
+func SecretsFor(secrets Secrets, accessor SecretAccessor) Secrets {
+	if accessorSecrets, ok := secrets.(AccessorSecrets); ok {
+		return accessorSecrets.ForAccessor(accessor)
+	}
+
+	return secrets
+}
+
+// AuditedSecrets records every lookup which finds one of a credential
+// manager's secrets, along with its accessor. The secret's value is never
+// recorded, and neither are the lookup paths which were tried and didn't
+// find it.
+type AuditedSecrets struct {
+	secrets  Secrets
+	manager  string
+	batcher  *SecretAccessBatcher
+	clock    clock.Clock
+	accessor SecretAccessor
+}
+
+func NewAuditedSecrets(secrets Secrets, manager string, batcher *SecretAccessBatcher, clock clock.Clock) *AuditedSecrets {
+	return &AuditedSecrets{
+		secrets: secrets,
+		manager: manager,
+		batcher: batcher,
+		clock:   clock,
+	}
+}
+
+func (as *AuditedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, found, err := as.secrets.Get(secretPath)
+	if err == nil && found {
+		as.batcher.Queue(atc.SecretAccess{
+			Path:         secretPath,
+			Manager:      as.manager,
+			TeamName:     as.accessor.TeamName,
+			PipelineName: as.accessor.PipelineName,
+			BuildID:      as.accessor.BuildID,
+			UserName:     as.accessor.UserName,
+			AccessedAt:   as.clock.Now().Unix(),
+		})
+	}
+
+	return value, expiration, found, err
+}
+
+func (as *AuditedSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []SecretLookupPath {
+	return as.secrets.NewSecretLookupPaths(teamName, pipelineName)
+}
+
+// ForAccessor returns AuditedSecrets which record lookups as made for
+// accessor, on top of any accessor they were already for.
+func (as *AuditedSecrets) ForAccessor(accessor SecretAccessor) Secrets {
+	audited := *as
+	audited.accessor = as.accessor.With(accessor)
+	return &audited
+}
+
+// buildSecretAccessExpiry is how long a build's lookup of a secret is
+// remembered for once recorded, so that its later lookups of the same secret
+// aren't recorded again.
+const buildSecretAccessExpiry = time.Hour
+
+// maxSecretAccessAttempts is how many batches a secret lookup is tried in
+// before it is dropped from the audit trail.
+const maxSecretAccessAttempts = 5
+
+// SecretAccessBatcher queues secret lookups so that they are recorded in the
+// audit trail in batches, rather than one at a time as secrets are looked up.
+//
+// A secret looked up more than once in a batch is only recorded once, and so
+// is a secret looked up more than once by the same build. Failing to record a
+// batch is logged rather than failing the lookups, so that an unavailable
+// audit trail doesn't fail every build. Its lookups are tried again with the
+// next batch, up to maxSecretAccessAttempts times, after which they are
+// dropped and counted.
+//
+// Lookups are only held in memory until they are recorded, so those queued
+// when the process dies without being signalled are lost; the interval
+// bounds how many that can be.
+type SecretAccessBatcher struct {
+	logger   lager.Logger
+	recorder SecretAccessRecorder
+	interval time.Duration
+	clock    clock.Clock
+	dropped  Counter
+
+	lock    sync.Mutex
+	pending []queuedSecretAccess
+	queued  map[atc.SecretAccess]bool
+
+	buildAccesses *cache.Cache
+}
+
+type queuedSecretAccess struct {
+	access   atc.SecretAccess
+	attempts int
+}
+
+func NewSecretAccessBatcher(logger lager.Logger, recorder SecretAccessRecorder, interval time.Duration, clock clock.Clock, dropped Counter) *SecretAccessBatcher {
+	return &SecretAccessBatcher{
+		logger:   logger,
+		recorder: recorder,
+		interval: interval,
+		clock:    clock,
+		dropped:  dropped,
+
+		queued: map[atc.SecretAccess]bool{},
+
+		buildAccesses: cache.New(buildSecretAccessExpiry, buildSecretAccessExpiry),
+	}
+}
+
+// Queue queues a secret lookup to be recorded with the next batch.
+func (batcher *SecretAccessBatcher) Queue(access atc.SecretAccess) {
+	if key, ok := buildAccessKey(access); ok {
+		if _, recorded := batcher.buildAccesses.Get(key); recorded {
+			return
+		}
+	}
+
+	batcher.lock.Lock()
+	defer batcher.lock.Unlock()
+
+	key := queuedAccessKey(access)
+	if batcher.queued[key] {
+		return
+	}
+
+	batcher.queued[key] = true
+	batcher.pending = append(batcher.pending, queuedSecretAccess{access: access})
+}
+
+// Flush records the queued secret lookups. If that fails they are queued
+// again in front of any lookups queued since, unless they have run out of
+// attempts.
+func (batcher *SecretAccessBatcher) Flush() {
+	batcher.flush()
+}
+
+func (batcher *SecretAccessBatcher) flush() error {
+	batcher.lock.Lock()
+	batch := batcher.pending
+	batcher.pending = nil
+	batcher.lock.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	accesses := make([]atc.SecretAccess, len(batch))
+	for i, queued := range batch {
+		accesses[i] = queued.access
+	}
+
+	err := batcher.recorder.RecordSecretAccesses(accesses)
+
+	batcher.lock.Lock()
+	defer batcher.lock.Unlock()
+
+	if err == nil {
+		for _, queued := range batch {
+			delete(batcher.queued, queuedAccessKey(queued.access))
+
+			if key, ok := buildAccessKey(queued.access); ok {
+				batcher.buildAccesses.SetDefault(key, nil)
+			}
+		}
+
+		return nil
+	}
+
+	batcher.logger.Error("failed-to-record-secret-accesses", err, lager.Data{
+		"accesses": len(batch),
+	})
+
+	var retries, dropped []queuedSecretAccess
+	for _, queued := range batch {
+		queued.attempts++
+
+		if queued.attempts < maxSecretAccessAttempts {
+			retries = append(retries, queued)
+		} else {
+			dropped = append(dropped, queued)
+		}
+	}
+
+	batcher.pending = append(retries, batcher.pending...)
+
+	batcher.drop(err, dropped)
+
+	return err
+}
+
+// drop gives up on recording accesses, which must have been removed from the
+// pending queue.
+func (batcher *SecretAccessBatcher) drop(err error, accesses []queuedSecretAccess) {
+	if len(accesses) == 0 {
+		return
+	}
+
+	batcher.logger.Error("dropped-secret-accesses", err, lager.Data{
+		"accesses": len(accesses),
+	})
+
+	for _, queued := range accesses {
+		delete(batcher.queued, queuedAccessKey(queued.access))
+
+		if batcher.dropped != nil {
+			batcher.dropped.Inc()
+		}
+	}
+}
+
+// Run flushes the queued secret lookups on every interval, and once more
+// when signalled, dropping any which that fails to record.
+func (batcher *SecretAccessBatcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ticker := batcher.clock.NewTicker(batcher.interval)
+	defer ticker.Stop()
+
+	close(ready)
+
+	for {
+		select {
+		case <-ticker.C():
+			batcher.Flush()
+
+		case <-signals:
+			err := batcher.flush()
+			if err != nil {
+				batcher.lock.Lock()
+				batcher.drop(err, batcher.pending)
+				batcher.pending = nil
+				batcher.lock.Unlock()
+			}
+
+			return nil
+		}
+	}
+}
+
+func queuedAccessKey(access atc.SecretAccess) atc.SecretAccess {
+	access.AccessedAt = 0
+	return access
+}
+
+func buildAccessKey(access atc.SecretAccess) (string, bool) {
+	if access.BuildID == 0 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%d/%s/%s", access.BuildID, access.Manager, access.Path), true
+}
@@ -0,0 +1,345 @@
+package creds_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("AuditedSecrets", func() {
+	var (
+		logger       *lagertest.TestLogger
+		fakeSecrets  *credsfakes.FakeSecrets
+		fakeRecorder *credsfakes.FakeSecretAccessRecorder
+		fakeClock    *fakeclock.FakeClock
+		fakeDropped  *credsfakes.FakeCounter
+
+		batcher *creds.SecretAccessBatcher
+		secrets creds.Secrets
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeSecrets = makeSecrets("/concourse", map[string]interface{}{
+			"/concourse/some-team/some-pipeline/some-secret": "some-value",
+		})
+		fakeRecorder = new(credsfakes.FakeSecretAccessRecorder)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1566129420, 0))
+		fakeDropped = new(credsfakes.FakeCounter)
+
+		batcher = creds.NewSecretAccessBatcher(logger, fakeRecorder, time.Minute, fakeClock, fakeDropped)
+		secrets = creds.NewAuditedSecrets(fakeSecrets, "vault", batcher, fakeClock)
+	})
+
+	recorded := func(recorder *credsfakes.FakeSecretAccessRecorder) []atc.SecretAccess {
+		var accesses []atc.SecretAccess
+		for i := 0; i < recorder.RecordSecretAccessesCallCount(); i++ {
+			accesses = append(accesses, recorder.RecordSecretAccessesArgsForCall(i)...)
+		}
+
+		return accesses
+	}
+
+	It("records the lookup without the secret's value once flushed", func() {
+		value, _, found, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-value"))
+
+		Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(BeZero())
+
+		batcher.Flush()
+
+		Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(Equal(1))
+		Expect(fakeRecorder.RecordSecretAccessesArgsForCall(0)).To(Equal([]atc.SecretAccess{
+			{
+				Path:       "/concourse/some-team/some-pipeline/some-secret",
+				Manager:    "vault",
+				AccessedAt: 1566129420,
+			},
+		}))
+	})
+
+	It("does not record lookups of secrets which are not found", func() {
+		_, _, found, err := secrets.Get("/concourse/some-team/missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		batcher.Flush()
+
+		Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(BeZero())
+	})
+
+	It("records a secret looked up more than once in a batch once", func() {
+		for i := 0; i < 3; i++ {
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			fakeClock.Increment(time.Second)
+		}
+
+		batcher.Flush()
+
+		accesses := recorded(fakeRecorder)
+		Expect(accesses).To(HaveLen(1))
+		Expect(accesses[0].AccessedAt).To(Equal(int64(1566129420)))
+	})
+
+	It("records a secret looked up again in a later batch again", func() {
+		_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		batcher.Flush()
+
+		_, _, _, err = secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		batcher.Flush()
+
+		Expect(recorded(fakeRecorder)).To(HaveLen(2))
+	})
+
+	It("records a secret looked up more than once by a build once", func() {
+		buildSecrets := creds.SecretsFor(secrets, creds.SecretAccessor{BuildID: 42})
+
+		_, _, _, err := buildSecrets.Get("/concourse/some-team/some-pipeline/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		batcher.Flush()
+
+		_, _, _, err = buildSecrets.Get("/concourse/some-team/some-pipeline/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, _, err = creds.SecretsFor(secrets, creds.SecretAccessor{BuildID: 43}).Get("/concourse/some-team/some-pipeline/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		batcher.Flush()
+
+		accesses := recorded(fakeRecorder)
+		Expect(accesses).To(HaveLen(2))
+		Expect(accesses[0].BuildID).To(Equal(42))
+		Expect(accesses[1].BuildID).To(Equal(43))
+	})
+
+	Context("when recording the lookups fails", func() {
+		BeforeEach(func() {
+			fakeRecorder.RecordSecretAccessesReturns(errors.New("nope"))
+		})
+
+		It("logs the failure and still looks up the secret", func() {
+			value, _, found, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			batcher.Flush()
+
+			Expect(logger).To(gbytes.Say("failed-to-record-secret-accesses"))
+		})
+
+		It("records the lookups with the next batch once recording succeeds", func() {
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			batcher.Flush()
+
+			fakeRecorder.RecordSecretAccessesReturns(nil)
+
+			_, _, _, err = creds.SecretsFor(secrets, creds.SecretAccessor{BuildID: 42}).Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			batcher.Flush()
+
+			Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(Equal(2))
+			Expect(fakeRecorder.RecordSecretAccessesArgsForCall(1)).To(Equal([]atc.SecretAccess{
+				{
+					Path:       "/concourse/some-team/some-pipeline/some-secret",
+					Manager:    "vault",
+					AccessedAt: 1566129420,
+				},
+				{
+					Path:       "/concourse/some-team/some-pipeline/some-secret",
+					Manager:    "vault",
+					BuildID:    42,
+					AccessedAt: 1566129420,
+				},
+			}))
+			Expect(fakeDropped.IncCallCount()).To(BeZero())
+		})
+
+		It("does not queue a lookup again while it is being retried", func() {
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			batcher.Flush()
+
+			_, _, _, err = secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			batcher.Flush()
+
+			Expect(fakeRecorder.RecordSecretAccessesArgsForCall(1)).To(HaveLen(1))
+		})
+
+		It("drops and counts the lookups once they have been tried in five batches", func() {
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 5; i++ {
+				batcher.Flush()
+			}
+
+			Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(Equal(5))
+			Expect(fakeDropped.IncCallCount()).To(Equal(1))
+			Expect(logger).To(gbytes.Say("dropped-secret-accesses"))
+
+			batcher.Flush()
+
+			Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(Equal(5))
+		})
+
+		It("records a build's lookup again after its earlier lookup was dropped", func() {
+			buildSecrets := creds.SecretsFor(secrets, creds.SecretAccessor{BuildID: 42})
+
+			_, _, _, err := buildSecrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 5; i++ {
+				batcher.Flush()
+			}
+
+			fakeRecorder.RecordSecretAccessesReturns(nil)
+
+			_, _, _, err = buildSecrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			batcher.Flush()
+
+			Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(Equal(6))
+			Expect(fakeRecorder.RecordSecretAccessesArgsForCall(5)).To(HaveLen(1))
+			Expect(fakeRecorder.RecordSecretAccessesArgsForCall(5)[0].BuildID).To(Equal(42))
+		})
+	})
+
+	Describe("running the batcher", func() {
+		var process ifrit.Process
+
+		BeforeEach(func() {
+			process = ifrit.Invoke(batcher)
+		})
+
+		AfterEach(func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+
+		It("records the lookups on every interval", func() {
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			Consistently(fakeRecorder.RecordSecretAccessesCallCount).Should(BeZero())
+
+			fakeClock.WaitForWatcherAndIncrement(time.Minute)
+
+			Eventually(fakeRecorder.RecordSecretAccessesCallCount).Should(Equal(1))
+		})
+
+		It("records the remaining lookups when signalled", func() {
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(Equal(1))
+		})
+
+		It("drops and counts the lookups it fails to record when signalled", func() {
+			fakeRecorder.RecordSecretAccessesReturns(errors.New("nope"))
+
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(fakeRecorder.RecordSecretAccessesCallCount()).To(Equal(1))
+			Expect(fakeDropped.IncCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("ForAccessor", func() {
+		It("records lookups along with the accessor, merged with any previous accessor", func() {
+			buildSecrets := creds.SecretsFor(secrets, creds.SecretAccessor{BuildID: 42})
+
+			variables := creds.NewVariables(buildSecrets, "some-team", "some-pipeline")
+			value, found, err := variables.Get(template.VariableDefinition{Name: "some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			batcher.Flush()
+
+			Expect(recorded(fakeRecorder)).To(ConsistOf(atc.SecretAccess{
+				Path:         "/concourse/some-team/some-pipeline/some-secret",
+				Manager:      "vault",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				BuildID:      42,
+				AccessedAt:   1566129420,
+			}))
+		})
+
+		It("does not change the secrets it was called on", func() {
+			creds.SecretsFor(secrets, creds.SecretAccessor{UserName: "some-user"})
+
+			_, _, _, err := secrets.Get("/concourse/some-team/some-pipeline/some-secret")
+			Expect(err).ToNot(HaveOccurred())
+
+			batcher.Flush()
+
+			Expect(recorded(fakeRecorder)[0].UserName).To(BeEmpty())
+		})
+	})
+
+	Context("when chained with other credential managers", func() {
+		BeforeEach(func() {
+			otherSecrets := creds.NewAuditedSecrets(makeSecrets("/other", nil), "credhub", batcher, fakeClock)
+
+			secrets = creds.NewChainedSecrets(logger, []creds.NamedSecrets{
+				{Name: "credhub", Secrets: otherSecrets},
+				{Name: "vault", Secrets: secrets},
+			})
+		})
+
+		It("attributes the lookup to the accessor and the manager which found it", func() {
+			userSecrets := creds.SecretsFor(secrets, creds.SecretAccessor{UserName: "some-user"})
+
+			variables := creds.NewVariables(userSecrets, "some-team", "some-pipeline")
+			_, found, err := variables.Get(template.VariableDefinition{Name: "some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			batcher.Flush()
+
+			Expect(recorded(fakeRecorder)).To(ConsistOf(atc.SecretAccess{
+				Path:         "/concourse/some-team/some-pipeline/some-secret",
+				Manager:      "vault",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				UserName:     "some-user",
+				AccessedAt:   1566129420,
+			}))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package creds
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+// SecretCachePurgesChannel is notified whenever a purge of the secret caches
+// is requested.
+const SecretCachePurgesChannel = "secret_cache_purges"
+
+//go:generate counterfeiter . SecretCachePurges
+
+// SecretCachePurges are the purges requested of the secret caches of every
+// ATC, in the order they were requested.
+type SecretCachePurges interface {
+	LatestSecretCachePurgeID() (int, error)
+	SecretCachePurgesSince(id int) ([]atc.SecretCachePurge, error)
+}
+
+//go:generate counterfeiter . Notifications
+
+type Notifications interface {
+	Listen(channel string) (chan bool, error)
+	Unlisten(channel string, notifier chan bool) error
+}
+
+// SecretCachePurgeRunner purges this ATC's secret caches whenever a purge is
+// requested of any ATC. Purges requested before it started are skipped, as
+// the caches were empty then.
+type SecretCachePurgeRunner struct {
+	Logger        lager.Logger
+	Caches        *SecretCaches
+	Purges        SecretCachePurges
+	Notifications Notifications
+}
+
+func (runner SecretCachePurgeRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	notifier, err := runner.Notifications.Listen(SecretCachePurgesChannel)
+	if err != nil {
+		return err
+	}
+
+	defer runner.Notifications.Unlisten(SecretCachePurgesChannel, notifier)
+
+	latestID, err := runner.Purges.LatestSecretCachePurgeID()
+	if err != nil {
+		return err
+	}
+
+	close(ready)
+
+	for {
+		select {
+		case <-notifier:
+			latestID = runner.purge(latestID)
+
+		case <-signals:
+			return nil
+		}
+	}
+}
+
+func (runner SecretCachePurgeRunner) purge(latestID int) int {
+	purges, err := runner.Purges.SecretCachePurgesSince(latestID)
+	if err != nil {
+		runner.Logger.Error("failed-to-get-secret-cache-purges", err)
+		return latestID
+	}
+
+	for _, purge := range purges {
+		purged := runner.Caches.Purge(purge)
+
+		runner.Logger.Info("purged-secret-cache", lager.Data{
+			"team":     purge.TeamName,
+			"pipeline": purge.PipelineName,
+			"path":     purge.Path,
+			"purged":   purged,
+		})
+
+		latestID = purge.ID
+	}
+
+	return latestID
+}
@@ -0,0 +1,119 @@
+package creds_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("SecretCachePurgeRunner", func() {
+	var (
+		fakePurges        *credsfakes.FakeSecretCachePurges
+		fakeNotifications *credsfakes.FakeNotifications
+		notifier          chan bool
+
+		vault   *credsfakes.FakeSecrets
+		secrets creds.Secrets
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakePurges = new(credsfakes.FakeSecretCachePurges)
+		fakePurges.LatestSecretCachePurgeIDReturns(41, nil)
+
+		notifier = make(chan bool, 1)
+		fakeNotifications = new(credsfakes.FakeNotifications)
+		fakeNotifications.ListenReturns(notifier, nil)
+
+		vault = makeSecrets("/vault", map[string]interface{}{
+			"/vault/some-team/foo": "foo",
+		})
+
+		caches := creds.NewSecretCaches(creds.SecretCacheConfig{
+			Enabled:       true,
+			Duration:      time.Minute,
+			PurgeInterval: time.Minute,
+		}, nil, nil)
+		secrets = caches.Wrap(vault)
+
+		_, _, _, err := secrets.Get("/vault/some-team/foo")
+		Expect(err).ToNot(HaveOccurred())
+
+		process = ifrit.Invoke(creds.SecretCachePurgeRunner{
+			Logger:        lagertest.NewTestLogger("test"),
+			Caches:        caches,
+			Purges:        fakePurges,
+			Notifications: fakeNotifications,
+		})
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("listens for purges", func() {
+		Expect(fakeNotifications.ListenCallCount()).To(Equal(1))
+		Expect(fakeNotifications.ListenArgsForCall(0)).To(Equal(creds.SecretCachePurgesChannel))
+	})
+
+	It("stops listening when signalled", func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		Expect(fakeNotifications.UnlistenCallCount()).To(Equal(1))
+	})
+
+	Context("when notified of purges", func() {
+		BeforeEach(func() {
+			fakePurges.SecretCachePurgesSinceReturnsOnCall(0, []atc.SecretCachePurge{
+				{ID: 42, TeamName: "other-team"},
+				{ID: 43, TeamName: "some-team", Path: "foo"},
+			}, nil)
+			fakePurges.SecretCachePurgesSinceReturnsOnCall(1, []atc.SecretCachePurge{}, nil)
+
+			notifier <- true
+		})
+
+		It("purges the caches of the purges since the last one it saw", func() {
+			Eventually(fakePurges.SecretCachePurgesSinceCallCount).Should(Equal(1))
+			Expect(fakePurges.SecretCachePurgesSinceArgsForCall(0)).To(Equal(41))
+
+			Eventually(func() int {
+				_, _, _, _ = secrets.Get("/vault/some-team/foo")
+				return vault.GetCallCount()
+			}).Should(Equal(2))
+
+			notifier <- true
+
+			Eventually(fakePurges.SecretCachePurgesSinceCallCount).Should(Equal(2))
+			Expect(fakePurges.SecretCachePurgesSinceArgsForCall(1)).To(Equal(43))
+		})
+	})
+
+	Context("when getting the purges fails", func() {
+		BeforeEach(func() {
+			fakePurges.SecretCachePurgesSinceReturnsOnCall(0, nil, errors.New("nope"))
+
+			notifier <- true
+		})
+
+		It("tries again from the same purge when notified again", func() {
+			Eventually(fakePurges.SecretCachePurgesSinceCallCount).Should(Equal(1))
+
+			notifier <- true
+
+			Eventually(fakePurges.SecretCachePurgesSinceCallCount).Should(Equal(2))
+			Expect(fakePurges.SecretCachePurgesSinceArgsForCall(1)).To(Equal(41))
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package creds
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// SecretCaches are the secret caches of every credential manager and var
+// source of an ATC, so that cached secrets can be purged from all of them at
+// once, and so that they share the cache hit and miss metrics.
+type SecretCaches struct {
+	config SecretCacheConfig
+	hits   Counter
+	misses Counter
+
+	lock   sync.Mutex
+	caches []*CachedSecrets
+}
+
+func NewSecretCaches(config SecretCacheConfig, hits Counter, misses Counter) *SecretCaches {
+	return &SecretCaches{
+		config: config,
+		hits:   hits,
+		misses: misses,
+	}
+}
+
+// Wrap caches the lookups of secrets, if the cache is enabled.
+func (caches *SecretCaches) Wrap(secrets Secrets) Secrets {
+	if !caches.config.Enabled {
+		return secrets
+	}
+
+	cached := NewCachedSecrets(secrets, caches.config)
+	cached.hits = caches.hits
+	cached.misses = caches.misses
+
+	caches.lock.Lock()
+	caches.caches = append(caches.caches, cached)
+	caches.lock.Unlock()
+
+	return cached
+}
+
+// Purge removes the entries matching the purge from every cache, returning
+// the number of entries removed.
+func (caches *SecretCaches) Purge(purge atc.SecretCachePurge) int {
+	caches.lock.Lock()
+	defer caches.lock.Unlock()
+
+	purged := 0
+	for _, cached := range caches.caches {
+		purged += cached.Purge(purge)
+	}
+
+	return purged
+}
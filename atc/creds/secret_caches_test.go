@@ -0,0 +1,99 @@
+package creds_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretCaches", func() {
+	var (
+		config creds.SecretCacheConfig
+		hits   *credsfakes.FakeCounter
+		misses *credsfakes.FakeCounter
+
+		vault   *credsfakes.FakeSecrets
+		credhub *credsfakes.FakeSecrets
+
+		caches *creds.SecretCaches
+	)
+
+	BeforeEach(func() {
+		config = creds.SecretCacheConfig{
+			Enabled:       true,
+			Duration:      time.Minute,
+			PurgeInterval: time.Minute,
+		}
+		hits = new(credsfakes.FakeCounter)
+		misses = new(credsfakes.FakeCounter)
+
+		vault = makeSecrets("/vault", map[string]interface{}{
+			"/vault/some-team/some-pipeline/foo": "vault-foo",
+		})
+		credhub = makeSecrets("/credhub", map[string]interface{}{
+			"/credhub/some-team/foo": "credhub-foo",
+		})
+	})
+
+	JustBeforeEach(func() {
+		caches = creds.NewSecretCaches(config, hits, misses)
+	})
+
+	It("caches lookups, counting the hits and misses", func() {
+		secrets := caches.Wrap(vault)
+
+		value, _, found, err := secrets.Get("/vault/some-team/some-pipeline/foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("vault-foo"))
+
+		_, _, _, err = secrets.Get("/vault/some-team/some-pipeline/foo")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(vault.GetCallCount()).To(Equal(1))
+		Expect(misses.IncCallCount()).To(Equal(1))
+		Expect(hits.IncCallCount()).To(Equal(1))
+	})
+
+	It("purges the entries of every cache", func() {
+		vaultSecrets := caches.Wrap(vault)
+		credhubSecrets := caches.Wrap(credhub)
+
+		_, _, _, err := vaultSecrets.Get("/vault/some-team/some-pipeline/foo")
+		Expect(err).ToNot(HaveOccurred())
+		_, _, _, err = credhubSecrets.Get("/credhub/some-team/foo")
+		Expect(err).ToNot(HaveOccurred())
+
+		purged := caches.Purge(atc.SecretCachePurge{
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			Path:         "foo",
+		})
+		Expect(purged).To(Equal(2))
+
+		_, _, _, err = vaultSecrets.Get("/vault/some-team/some-pipeline/foo")
+		Expect(err).ToNot(HaveOccurred())
+		_, _, _, err = credhubSecrets.Get("/credhub/some-team/foo")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(vault.GetCallCount()).To(Equal(2))
+		Expect(credhub.GetCallCount()).To(Equal(2))
+	})
+
+	Context("when the cache is disabled", func() {
+		BeforeEach(func() {
+			config.Enabled = false
+		})
+
+		It("does not cache lookups", func() {
+			secrets := caches.Wrap(vault)
+			Expect(secrets).To(BeIdenticalTo(vault))
+
+			Expect(caches.Purge(atc.SecretCachePurge{TeamName: "some-team"})).To(BeZero())
+		})
+	})
+})
@@ -11,7 +11,10 @@ type VariableLookupFromSecrets struct {
 
 func NewVariables(secrets Secrets, teamName string, pipelineName string) template.Variables {
 	return VariableLookupFromSecrets{
-		Secrets:     secrets,
+		Secrets: SecretsFor(secrets, SecretAccessor{
+			TeamName:     teamName,
+			PipelineName: pipelineName,
+		}),
 		LookupPaths: secrets.NewSecretLookupPaths(teamName, pipelineName),
 	}
 }
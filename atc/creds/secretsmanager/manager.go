@@ -137,6 +137,24 @@ func (manager *Manager) Validate() error {
 	return nil
 }
 
+// ValidateVarSource rejects configs which would look up secrets with the
+// ATC's own AWS credentials or outside of the pipeline's team.
+func (manager *Manager) ValidateVarSource() error {
+	if manager.AwsAccessKeyID == "" || manager.AwsSecretAccessKey == "" {
+		return errors.New("must provide aws access key id and secret access key")
+	}
+
+	if manager.PipelineSecretTemplate != DefaultPipelineSecretTemplate {
+		return errors.New("pipeline secret template cannot be overridden")
+	}
+
+	if manager.TeamSecretTemplate != DefaultTeamSecretTemplate {
+		return errors.New("team secret template cannot be overridden")
+	}
+
+	return nil
+}
+
 func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
 	config := &aws.Config{Region: &manager.AwsRegion}
 	if manager.AwsAccessKeyID != "" {
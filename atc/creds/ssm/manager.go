@@ -153,6 +153,24 @@ func (manager *SsmManager) Validate() error {
 	return nil
 }
 
+// ValidateVarSource rejects configs which would look up secrets with the
+// ATC's own AWS credentials or outside of the pipeline's team.
+func (manager *SsmManager) ValidateVarSource() error {
+	if manager.AwsAccessKeyID == "" || manager.AwsSecretAccessKey == "" {
+		return errors.New("must provide aws access key id and secret access key")
+	}
+
+	if manager.PipelineSecretTemplate != DefaultPipelineSecretTemplate {
+		return errors.New("pipeline secret template cannot be overridden")
+	}
+
+	if manager.TeamSecretTemplate != DefaultTeamSecretTemplate {
+		return errors.New("team secret template cannot be overridden")
+	}
+
+	return nil
+}
+
 func (manager *SsmManager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
 
 	session, err := manager.getSession()
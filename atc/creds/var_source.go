@@ -0,0 +1,131 @@
+package creds
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// NewVarSourceManager returns a Manager for a pipeline's var source, created
+// by the credential manager registered as managerType and configured exactly
+// as its flags would configure it. Each key of config names one of its flags
+// without the namespace, with either '-' or '_' between words, e.g. "url" or
+// "path_prefix" for vault's --vault-url and --vault-path-prefix.
+//
+// Only credential managers implementing VarSourceManager may be used as var
+// sources, and only with configs passing ValidateVarSource.
+func NewVarSourceManager(managerType string, config map[string]interface{}) (Manager, error) {
+	factory, found := ManagerFactories()[managerType]
+	if !found {
+		return nil, fmt.Errorf("unknown credential manager type '%s'", managerType)
+	}
+
+	parser := flags.NewParser(&struct{}{}, flags.None)
+
+	group, err := parser.AddGroup("Var Source", "", &struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	manager, ok := factory.AddConfig(group).(VarSourceManager)
+	if !ok {
+		return nil, fmt.Errorf("credential manager type '%s' cannot be used as a var source", managerType)
+	}
+
+	options := map[string]*flags.Option{}
+	collectOptions(group, options)
+
+	keys := []string{}
+	for key := range config {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	args := []string{}
+	for _, key := range keys {
+		option, found := options[strings.Replace(key, "_", "-", -1)]
+		if !found {
+			return nil, fmt.Errorf("unknown config '%s' for credential manager type '%s'", key, managerType)
+		}
+
+		flag := "--" + option.LongNameWithNamespace()
+
+		if option.Field().Type.Kind() == reflect.Bool {
+			enabled, ok := config[key].(bool)
+			if !ok {
+				return nil, fmt.Errorf("config '%s' must be true or false", key)
+			}
+
+			if enabled {
+				args = append(args, flag)
+			}
+
+			continue
+		}
+
+		for _, value := range flagValues(config[key]) {
+			args = append(args, flag+"="+value)
+		}
+	}
+
+	_, err = parser.ParseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.ValidateVarSource()
+	if err != nil {
+		return nil, fmt.Errorf("invalid var source config for credential manager type '%s': %s", managerType, err)
+	}
+
+	return manager, nil
+}
+
+func collectOptions(group *flags.Group, options map[string]*flags.Option) {
+	for _, option := range group.Options() {
+		if option.LongName != "" {
+			options[option.LongName] = option
+		}
+	}
+
+	for _, subGroup := range group.Groups() {
+		collectOptions(subGroup, options)
+	}
+}
+
+// flagValues renders a config value as the values of a flag, which may be
+// given multiple times for list and map flags.
+func flagValues(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		values := []string{}
+		for _, elem := range v {
+			values = append(values, flagValues(elem)...)
+		}
+		return values
+	case map[string]interface{}:
+		values := []string{}
+		for key, elem := range v {
+			for _, elemValue := range flagValues(elem) {
+				values = append(values, key+":"+elemValue)
+			}
+		}
+		sort.Strings(values)
+		return values
+	case map[interface{}]interface{}:
+		sanitized := map[string]interface{}{}
+		for key, elem := range v {
+			sanitized[fmt.Sprintf("%v", key)] = elem
+		}
+		return flagValues(sanitized)
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
@@ -0,0 +1,95 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . VarSourcePool
+
+// VarSourcePool creates the Secrets of pipelines' var sources.
+type VarSourcePool interface {
+	FindOrCreate(atc.VarSourceConfig) (Secrets, error)
+}
+
+// varSourcePool shares the Secrets of a var source between every pipeline,
+// build and check using a var source with the same type and config, so that
+// e.g. a vault var source only logs in to vault once. As credential managers
+// can't be shut down once initialized, the Secrets are kept for as long as
+// the ATC runs.
+type varSourcePool struct {
+	logger               lager.Logger
+	credentialManagement CredentialManagementConfig
+	batcher              *SecretAccessBatcher
+	caches               *SecretCaches
+
+	lock    sync.Mutex
+	secrets map[string]Secrets
+}
+
+func NewVarSourcePool(logger lager.Logger, credentialManagement CredentialManagementConfig, batcher *SecretAccessBatcher, caches *SecretCaches) VarSourcePool {
+	return &varSourcePool{
+		logger:               logger,
+		credentialManagement: credentialManagement,
+		batcher:              batcher,
+		caches:               caches,
+		secrets:              map[string]Secrets{},
+	}
+}
+
+func (pool *varSourcePool) FindOrCreate(varSource atc.VarSourceConfig) (Secrets, error) {
+	config, err := json.Marshal(varSource.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	key := varSource.Type + ":" + string(config)
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	secrets, found := pool.secrets[key]
+	if found {
+		return secrets, nil
+	}
+
+	logger := pool.logger.Session("var-source", lager.Data{
+		"name": varSource.Name,
+		"type": varSource.Type,
+	})
+
+	manager, err := NewVarSourceManager(varSource.Type, varSource.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.Init(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", varSource.Type, err)
+	}
+
+	secretsFactory, err := manager.NewSecretsFactory(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("created")
+
+	secrets = pool.credentialManagement.AuditSecrets(
+		varSource.Type,
+		pool.credentialManagement.WrapSecrets(secretsFactory.NewSecrets(), pool.caches),
+		pool.batcher,
+	)
+	pool.secrets[key] = secrets
+
+	return secrets, nil
+}
@@ -0,0 +1,347 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	_ "github.com/concourse/concourse/atc/creds/credhub"
+	_ "github.com/concourse/concourse/atc/creds/dbsecrets"
+	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
+	_ "github.com/concourse/concourse/atc/creds/ssm"
+	_ "github.com/concourse/concourse/atc/creds/vault"
+)
+
+type testManager struct {
+	URL        string            `long:"url"`
+	PathPrefix string            `long:"path-prefix" default:"/concourse"`
+	Insecure   bool              `long:"insecure-skip-verify"`
+	RetryMax   time.Duration     `long:"retry-max" default:"5m"`
+	CACerts    []string          `long:"ca-cert"`
+	Params     map[string]string `long:"auth-param"`
+
+	inits   int
+	secrets *credsfakes.FakeSecrets
+}
+
+func (manager *testManager) IsConfigured() bool { return manager.URL != "" }
+
+func (manager *testManager) Validate() error {
+	if manager.URL == "" {
+		return errors.New("no url")
+	}
+	return nil
+}
+
+func (manager *testManager) Health() (*creds.HealthResponse, error) { return nil, nil }
+
+func (manager *testManager) ValidateVarSource() error { return nil }
+
+func (manager *testManager) Init(lager.Logger) error {
+	manager.inits++
+	return nil
+}
+
+func (manager *testManager) NewSecretsFactory(lager.Logger) (creds.SecretsFactory, error) {
+	factory := new(credsfakes.FakeSecretsFactory)
+	factory.NewSecretsReturns(manager.secrets)
+	return factory, nil
+}
+
+type testManagerFactory struct {
+	managers []*testManager
+}
+
+func (factory *testManagerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &testManager{secrets: new(credsfakes.FakeSecrets)}
+
+	subGroup, err := group.AddGroup("Test Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "test"
+
+	factory.managers = append(factory.managers, manager)
+
+	return manager
+}
+
+var testFactory = &testManagerFactory{}
+
+func init() {
+	creds.Register("test", testFactory)
+}
+
+var _ = Describe("Var sources", func() {
+	BeforeEach(func() {
+		testFactory.managers = nil
+	})
+
+	Describe("NewVarSourceManager", func() {
+		It("configures the credential manager like its flags", func() {
+			manager, err := creds.NewVarSourceManager("test", map[string]interface{}{
+				"url":                  "https://vault.example.com",
+				"insecure_skip_verify": true,
+				"retry-max":            "10s",
+				"ca_cert":              []interface{}{"a.pem", "b.pem"},
+				"auth_param":           map[string]interface{}{"role_id": "some-role", "secret_id": "some-secret"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manager).To(Equal(&testManager{
+				URL:        "https://vault.example.com",
+				PathPrefix: "/concourse",
+				Insecure:   true,
+				RetryMax:   10 * time.Second,
+				CACerts:    []string{"a.pem", "b.pem"},
+				Params:     map[string]string{"role_id": "some-role", "secret_id": "some-secret"},
+				secrets:    testFactory.managers[0].secrets,
+			}))
+		})
+
+		It("rejects unknown credential manager types", func() {
+			_, err := creds.NewVarSourceManager("bogus", nil)
+			Expect(err).To(MatchError("unknown credential manager type 'bogus'"))
+		})
+
+		It("rejects unknown config", func() {
+			_, err := creds.NewVarSourceManager("test", map[string]interface{}{"bogus": "value"})
+			Expect(err).To(MatchError("unknown config 'bogus' for credential manager type 'test'"))
+		})
+
+		It("rejects invalid values", func() {
+			_, err := creds.NewVarSourceManager("test", map[string]interface{}{"retry_max": "forever"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects credential managers which cannot be used as var sources", func() {
+			_, err := creds.NewVarSourceManager("db", map[string]interface{}{"enable_db_secrets": true})
+			Expect(err).To(MatchError("credential manager type 'db' cannot be used as a var source"))
+		})
+
+		DescribeTable("validating configs which could look up secrets as the ATC",
+			func(managerType string, config map[string]interface{}, expectedErr string) {
+				_, err := creds.NewVarSourceManager(managerType, config)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError("invalid var source config for credential manager type '" + managerType + "': " + expectedErr))
+				}
+			},
+
+			Entry("ssm with static credentials", "ssm", map[string]interface{}{
+				"access_key": "some-key", "secret_key": "some-secret", "region": "us-east-1",
+			}, ""),
+			Entry("ssm without credentials", "ssm", map[string]interface{}{
+				"region": "us-east-1",
+			}, "must provide aws access key id and secret access key"),
+			Entry("ssm with only an access key", "ssm", map[string]interface{}{
+				"access_key": "some-key", "region": "us-east-1",
+			}, "must provide aws access key id and secret access key"),
+			Entry("ssm overriding the pipeline secret template", "ssm", map[string]interface{}{
+				"access_key": "some-key", "secret_key": "some-secret",
+				"pipeline_secret_template": "/concourse/other-team/{{.Pipeline}}/{{.Secret}}",
+			}, "pipeline secret template cannot be overridden"),
+			Entry("ssm overriding the team secret template", "ssm", map[string]interface{}{
+				"access_key": "some-key", "secret_key": "some-secret",
+				"team_secret_template": "/concourse/other-team/{{.Secret}}",
+			}, "team secret template cannot be overridden"),
+
+			Entry("secretsmanager with static credentials", "secretsmanager", map[string]interface{}{
+				"access_key": "some-key", "secret_key": "some-secret", "region": "us-east-1",
+			}, ""),
+			Entry("secretsmanager without credentials", "secretsmanager", map[string]interface{}{
+				"region": "us-east-1",
+			}, "must provide aws access key id and secret access key"),
+			Entry("secretsmanager overriding the pipeline secret template", "secretsmanager", map[string]interface{}{
+				"access_key": "some-key", "secret_key": "some-secret",
+				"pipeline_secret_template": "/concourse/other-team/{{.Pipeline}}/{{.Secret}}",
+			}, "pipeline secret template cannot be overridden"),
+			Entry("secretsmanager overriding the team secret template", "secretsmanager", map[string]interface{}{
+				"access_key": "some-key", "secret_key": "some-secret",
+				"team_secret_template": "/concourse/other-team/{{.Secret}}",
+			}, "team secret template cannot be overridden"),
+
+			Entry("vault with a client token", "vault", map[string]interface{}{
+				"url": "https://vault.example.com", "client_token": "some-token",
+			}, ""),
+			Entry("vault with a ca cert file", "vault", map[string]interface{}{
+				"url": "https://vault.example.com", "client_token": "some-token", "ca_cert": "/etc/ssl/ca.pem",
+			}, "ca cert files on the ATC cannot be used"),
+			Entry("vault with a ca path", "vault", map[string]interface{}{
+				"url": "https://vault.example.com", "client_token": "some-token", "ca_path": "/etc/ssl",
+			}, "ca cert files on the ATC cannot be used"),
+			Entry("vault with client cert files", "vault", map[string]interface{}{
+				"url": "https://vault.example.com", "auth_backend": "cert",
+				"client_cert": "/etc/atc/cert.pem", "client_key": "/etc/atc/key.pem",
+			}, "client cert files on the ATC cannot be used"),
+			Entry("vault overriding the path prefix", "vault", map[string]interface{}{
+				"url": "https://vault.example.com", "client_token": "some-token", "path_prefix": "/concourse/other-team",
+			}, "path prefix cannot be overridden"),
+			Entry("vault with a shared path", "vault", map[string]interface{}{
+				"url": "https://vault.example.com", "client_token": "some-token", "shared_path": "other-team",
+			}, "shared path cannot be configured"),
+
+			Entry("credhub with a uaa client", "credhub", map[string]interface{}{
+				"url": "https://credhub.example.com", "client_id": "some-client", "client_secret": "some-secret",
+			}, ""),
+			Entry("credhub without a uaa client", "credhub", map[string]interface{}{
+				"url": "https://credhub.example.com",
+			}, "must provide client id and client secret"),
+			Entry("credhub with a ca cert file", "credhub", map[string]interface{}{
+				"url": "https://credhub.example.com", "client_id": "some-client", "client_secret": "some-secret",
+				"ca_cert": "/etc/ssl/ca.pem",
+			}, "ca cert files on the ATC cannot be used"),
+			Entry("credhub with client cert files", "credhub", map[string]interface{}{
+				"url":         "https://credhub.example.com",
+				"client_cert": "/etc/atc/cert.pem", "client_key": "/etc/atc/key.pem",
+			}, "client cert files on the ATC cannot be used"),
+			Entry("credhub overriding the path prefix", "credhub", map[string]interface{}{
+				"url": "https://credhub.example.com", "client_id": "some-client", "client_secret": "some-secret",
+				"path_prefix": "/concourse/other-team",
+			}, "path prefix cannot be overridden"),
+		)
+	})
+
+	Describe("VarSourcePool", func() {
+		var pool creds.VarSourcePool
+
+		BeforeEach(func() {
+			pool = creds.NewVarSourcePool(lagertest.NewTestLogger("test"), creds.CredentialManagementConfig{
+				RetryConfig: creds.SecretRetryConfig{Attempts: 1},
+			}, nil, creds.NewSecretCaches(creds.SecretCacheConfig{}, nil, nil))
+		})
+
+		It("shares the secrets of var sources with the same config", func() {
+			secrets, err := pool.FindOrCreate(atc.VarSourceConfig{
+				Name:   "some-source",
+				Type:   "test",
+				Config: map[string]interface{}{"url": "https://vault.example.com"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			sameSecrets, err := pool.FindOrCreate(atc.VarSourceConfig{
+				Name:   "other-name",
+				Type:   "test",
+				Config: map[string]interface{}{"url": "https://vault.example.com"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sameSecrets).To(BeIdenticalTo(secrets))
+
+			otherSecrets, err := pool.FindOrCreate(atc.VarSourceConfig{
+				Name:   "some-source",
+				Type:   "test",
+				Config: map[string]interface{}{"url": "https://other-vault.example.com"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherSecrets).NotTo(BeIdenticalTo(secrets))
+
+			Expect(testFactory.managers).To(HaveLen(2))
+			Expect(testFactory.managers[0].inits).To(Equal(1))
+			Expect(testFactory.managers[1].inits).To(Equal(1))
+		})
+
+		It("returns an error if the credential manager is misconfigured", func() {
+			_, err := pool.FindOrCreate(atc.VarSourceConfig{
+				Name: "some-source",
+				Type: "test",
+			})
+			Expect(err).To(MatchError("credential manager 'test' misconfigured: no url"))
+		})
+	})
+
+	Describe("NewPipelineVariables", func() {
+		var (
+			fakePool       *credsfakes.FakeVarSourcePool
+			globalSecrets  *credsfakes.FakeSecrets
+			vaultSecrets   *credsfakes.FakeSecrets
+			variables      creds.Variables
+			someVarSources atc.VarSourceConfigs
+		)
+
+		BeforeEach(func() {
+			fakePool = new(credsfakes.FakeVarSourcePool)
+
+			globalSecrets = makeSecrets("/global", map[string]interface{}{
+				"/global/team/pipeline/foo": "global-foo",
+			})
+			vaultSecrets = makeSecrets("/vault", map[string]interface{}{
+				"/vault/team/foo": "vault-foo",
+			})
+			fakePool.FindOrCreateReturns(vaultSecrets, nil)
+
+			someVarSources = atc.VarSourceConfigs{
+				{Name: "some-vault", Type: "vault", Config: map[string]interface{}{"url": "https://vault.example.com"}},
+			}
+
+			variables = creds.NewPipelineVariables(globalSecrets, fakePool, someVarSources, "team", "pipeline")
+		})
+
+		It("looks up vars from a var source in it", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "some-vault:foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("vault-foo"))
+
+			Expect(fakePool.FindOrCreateArgsForCall(0)).To(Equal(someVarSources[0]))
+			Expect(globalSecrets.GetCallCount()).To(BeZero())
+		})
+
+		It("looks up other vars in the global secrets", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("global-foo"))
+
+			Expect(fakePool.FindOrCreateCallCount()).To(BeZero())
+		})
+
+		Context("when the var source cannot be created", func() {
+			BeforeEach(func() {
+				fakePool.FindOrCreateReturns(nil, errors.New("nope"))
+			})
+
+			It("returns an error", func() {
+				_, _, err := variables.Get(template.VariableDefinition{Name: "some-vault:foo"})
+				Expect(err).To(MatchError("var source 'some-vault': nope"))
+			})
+		})
+
+		Context("when used by a build", func() {
+			It("resolves vars from var sources in every scope of the build", func() {
+				buildVars := creds.NewBuildVariables()
+				buildVars.UseVarSources(creds.NewVarSources(fakePool, someVarSources, creds.SecretAccessor{TeamName: "team", PipelineName: "pipeline"}))
+
+				buildVars.AddLocalVar("bar", "local-bar", false)
+
+				scoped := buildVars.NewLocalScope().Scope(creds.NewVariables(globalSecrets, "team", "pipeline"))
+
+				value, found, err := scoped.Get(template.VariableDefinition{Name: "some-vault:foo"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("vault-foo"))
+
+				value, found, err = scoped.Get(template.VariableDefinition{Name: ".:bar"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("local-bar"))
+
+				value, found, err = scoped.Get(template.VariableDefinition{Name: "foo"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("global-foo"))
+			})
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package creds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+)
+
+// NewPipelineVariables returns the Variables of a pipeline: vars of the form
+// ((source:path)) are looked up in the pipeline's var source of that name,
+// and every other var in secrets.
+func NewPipelineVariables(secrets Secrets, pool VarSourcePool, varSources atc.VarSourceConfigs, teamName string, pipelineName string) Variables {
+	return NewPipelineVariablesFor(secrets, pool, varSources, SecretAccessor{
+		TeamName:     teamName,
+		PipelineName: pipelineName,
+	})
+}
+
+// NewPipelineVariablesFor is NewPipelineVariables for a build or user, whose
+// secret lookups are attributed to them in the secret access audit trail.
+func NewPipelineVariablesFor(secrets Secrets, pool VarSourcePool, varSources atc.VarSourceConfigs, accessor SecretAccessor) Variables {
+	return NewVarSources(pool, varSources, accessor).Scope(NewVariables(SecretsFor(secrets, accessor), accessor.TeamName, accessor.PipelineName))
+}
+
+// VarSources are the var sources of a pipeline.
+type VarSources struct {
+	pool       VarSourcePool
+	varSources atc.VarSourceConfigs
+	accessor   SecretAccessor
+}
+
+// NewVarSources returns the var sources of the accessor's pipeline.
+func NewVarSources(pool VarSourcePool, varSources atc.VarSourceConfigs, accessor SecretAccessor) *VarSources {
+	return &VarSources{
+		pool:       pool,
+		varSources: varSources,
+		accessor:   accessor,
+	}
+}
+
+// Scope returns Variables which resolve ((source:path)) references against
+// the var sources and everything else against parent.
+func (s *VarSources) Scope(parent Variables) Variables {
+	return varSourceScopedVariables{
+		sources: s,
+		parent:  parent,
+	}
+}
+
+type varSourceScopedVariables struct {
+	sources *VarSources
+	parent  Variables
+}
+
+func (v varSourceScopedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	segs := strings.SplitN(varDef.Name, ":", 2)
+	if len(segs) != 2 {
+		return v.parent.Get(varDef)
+	}
+
+	varSource, found := v.sources.varSources.Lookup(segs[0])
+	if !found {
+		return v.parent.Get(varDef)
+	}
+
+	secrets, err := v.sources.pool.FindOrCreate(varSource)
+	if err != nil {
+		return nil, false, fmt.Errorf("var source '%s': %s", varSource.Name, err)
+	}
+
+	accessor := v.sources.accessor
+
+	return NewVariables(SecretsFor(secrets, accessor), accessor.TeamName, accessor.PipelineName).Get(template.VariableDefinition{Name: segs[1]})
+}
+
+func (v varSourceScopedVariables) List() ([]template.VariableDefinition, error) {
+	return v.parent.List()
+}
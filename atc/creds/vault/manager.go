@@ -13,6 +13,8 @@ import (
 	vaultapi "github.com/hashicorp/vault/api"
 )
 
+const DefaultPathPrefix = "/concourse"
+
 type VaultManager struct {
 	URL string `long:"url" description:"Vault server address used to access secrets."`
 
@@ -105,6 +107,28 @@ func (manager VaultManager) Validate() error {
 	return errors.New("must configure client token or auth backend")
 }
 
+// ValidateVarSource rejects configs which would read files on the ATC or look
+// up secrets outside of the pipeline's team.
+func (manager VaultManager) ValidateVarSource() error {
+	if manager.TLS.CACert != "" || manager.TLS.CAPath != "" {
+		return errors.New("ca cert files on the ATC cannot be used")
+	}
+
+	if manager.TLS.ClientCert != "" || manager.TLS.ClientKey != "" {
+		return errors.New("client cert files on the ATC cannot be used")
+	}
+
+	if manager.PathPrefix != DefaultPathPrefix {
+		return errors.New("path prefix cannot be overridden")
+	}
+
+	if manager.SharedPath != "" {
+		return errors.New("shared path cannot be configured")
+	}
+
+	return nil
+}
+
 func (manager VaultManager) Health() (*creds.HealthResponse, error) {
 	health := &creds.HealthResponse{
 		Method: "/v1/sys/health",
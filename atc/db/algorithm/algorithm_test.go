@@ -36,6 +36,35 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("can fan-in from jobs in other pipelines", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				// pass a and the other pipeline's b
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "other-pipeline/simple-b", BuildID: 2, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+
+				// pass the other pipeline's b but not a
+				{Job: "other-pipeline/simple-b", BuildID: 3, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"simple-a", "other-pipeline/simple-b"},
+			},
+		},
+
+		// no v2 as it hasn't passed a
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
 	Entry("propagates resources together", Example{
 		DB: DB{
 			BuildOutputs: []DBRow{
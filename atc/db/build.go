@@ -43,7 +43,7 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.schedule_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -73,6 +73,7 @@ type Build interface {
 	EndTime() time.Time
 	ReapTime() time.Time
 	IsManuallyTriggered() bool
+	IsScheduleTriggered() bool
 	IsScheduled() bool
 	IsRunning() bool
 	IsCompleted() bool
@@ -85,6 +86,7 @@ type Build interface {
 	Preparation() (BuildPreparation, bool, error)
 
 	Start(atc.Plan) (bool, error)
+	ExpandAcross(planID atc.PlanID, steps []atc.VarScopedPlan) error
 	Finish(BuildStatus) error
 
 	SetInterceptible(bool) error
@@ -109,6 +111,10 @@ type Build interface {
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
 
+	Approve(planID atc.PlanID, approved bool, approvedBy string) (bool, error)
+	Approval(planID atc.PlanID) (BuildApproval, bool, error)
+	ApprovalNotifier(planID atc.PlanID) (Notifier, error)
+
 	IsDrained() bool
 	SetDrained(bool) error
 }
@@ -128,6 +134,7 @@ type build struct {
 	jobName      string
 
 	isManuallyTriggered bool
+	isScheduleTriggered bool
 
 	schema      string
 	privatePlan atc.Plan
@@ -167,6 +174,7 @@ func (b *build) PipelineName() string         { return b.pipelineName }
 func (b *build) TeamID() int                  { return b.teamID }
 func (b *build) TeamName() string             { return b.teamName }
 func (b *build) IsManuallyTriggered() bool    { return b.isManuallyTriggered }
+func (b *build) IsScheduleTriggered() bool    { return b.isScheduleTriggered }
 func (b *build) Schema() string               { return b.schema }
 func (b *build) PrivatePlan() atc.Plan        { return b.privatePlan }
 func (b *build) PublicPlan() *json.RawMessage { return b.publicPlan }
@@ -303,7 +311,7 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	err = b.conn.Bus().Notify(BuildEventsChannel(b.id))
 	if err != nil {
 		return false, err
 	}
@@ -311,6 +319,95 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 	return true, nil
 }
 
+// ExpandAcross saves the steps which the across step with the given ID runs
+// for each combination of its vars' values into the build's plan, once the
+// values are known, so that its sub-steps can be shown and approved like
+// those of any other step.
+func (b *build) ExpandAcross(planID atc.PlanID, steps []atc.VarScopedPlan) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var (
+		privatePlan sql.NullString
+		nonce       sql.NullString
+	)
+
+	// lock the build's plan, as its across steps may expand concurrently
+	err = psql.Select("private_plan", "nonce").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&privatePlan, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBuildDisappeared
+		}
+		return err
+	}
+
+	decryptedPlan := []byte(privatePlan.String)
+	if nonce.Valid {
+		decryptedPlan, err = b.conn.EncryptionStrategy().Decrypt(privatePlan.String, &nonce.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	var plan atc.Plan
+	err = json.Unmarshal(decryptedPlan, &plan)
+	if err != nil {
+		return err
+	}
+
+	expanded, found, err := plan.ExpandAcross(planID, steps)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("across step %s not found in the plan of build %d", planID, b.id)
+	}
+
+	metadata, err := json.Marshal(expanded)
+	if err != nil {
+		return err
+	}
+
+	encryptedPlan, newNonce, err := b.conn.EncryptionStrategy().Encrypt(metadata)
+	if err != nil {
+		return err
+	}
+
+	publicPlan := expanded.Public()
+
+	_, err = psql.Update("builds").
+		Set("private_plan", encryptedPlan).
+		Set("public_plan", publicPlan).
+		Set("nonce", newNonce).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.privatePlan = expanded
+	b.publicPlan = publicPlan
+
+	return nil
+}
+
 func (b *build) Finish(status BuildStatus) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -361,6 +458,11 @@ func (b *build) Finish(status BuildStatus) error {
 		if err != nil {
 			return err
 		}
+
+		err = bumpCacheIndexForPipelinesPassingJob(tx, b.jobID)
+		if err != nil {
+			return err
+		}
 	}
 
 	if b.jobID != 0 {
@@ -385,17 +487,17 @@ func (b *build) Finish(status BuildStatus) error {
 		}
 	}
 
-	err = tx.Commit()
+	err = b.conn.EventStore().Finalize(tx, b)
 	if err != nil {
 		return err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	err = tx.Commit()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return b.conn.Bus().Notify(BuildEventsChannel(b.id))
 }
 
 func (b *build) SetDrained(drained bool) error {
@@ -548,13 +650,14 @@ func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration)
 func (b *build) Preparation() (BuildPreparation, bool, error) {
 	if b.jobID == 0 || b.status != BuildStatusPending {
 		return BuildPreparation{
-			BuildID:             b.id,
-			PausedPipeline:      BuildPreparationStatusNotBlocking,
-			PausedJob:           BuildPreparationStatusNotBlocking,
-			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
-			Inputs:              map[string]BuildPreparationStatus{},
-			InputsSatisfied:     BuildPreparationStatusNotBlocking,
-			MissingInputReasons: MissingInputReasons{},
+			BuildID:              b.id,
+			PausedPipeline:       BuildPreparationStatusNotBlocking,
+			PausedJob:            BuildPreparationStatusNotBlocking,
+			MaxRunningBuilds:     BuildPreparationStatusNotBlocking,
+			TeamMaxRunningBuilds: BuildPreparationStatusNotBlocking,
+			Inputs:               map[string]BuildPreparationStatus{},
+			InputsSatisfied:      BuildPreparationStatusNotBlocking,
+			MissingInputReasons:  MissingInputReasons{},
 		}, true, nil
 	}
 
@@ -562,17 +665,26 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		pausedPipeline     bool
 		pausedJob          bool
 		maxInFlightReached bool
+		teamQuotaReached   bool
 		pipelineID         int
 		jobName            string
 	)
-	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name").
+	err := psql.Select(
+		"p.paused",
+		"j.paused",
+		"j.max_in_flight_reached",
+		"t.max_running_builds > 0 AND (SELECT COUNT(*) FROM builds tb WHERE tb.team_id = t.id AND tb.status = 'started') >= t.max_running_builds",
+		"j.pipeline_id",
+		"j.name",
+	).
 		From("builds b").
 		Join("jobs j ON b.job_id = j.id").
 		Join("pipelines p ON j.pipeline_id = p.id").
+		Join("teams t ON p.team_id = t.id").
 		Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
 		QueryRow().
-		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName)
+		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &teamQuotaReached, &pipelineID, &jobName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -595,17 +707,12 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	tf := NewTeamFactory(b.conn, b.lockFactory)
-	t, found, err := tf.FindTeam(b.teamName)
-	if err != nil {
-		return BuildPreparation{}, false, err
+	teamQuotaReachedStatus := BuildPreparationStatusNotBlocking
+	if teamQuotaReached {
+		teamQuotaReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	if !found {
-		return BuildPreparation{}, false, nil
-	}
-
-	pipeline, found, err := t.Pipeline(b.pipelineName)
+	pipeline, found, err := b.Pipeline()
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
@@ -638,7 +745,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 
 		inputsSatisfiedStatus = BuildPreparationStatusNotBlocking
 
-		if b.IsManuallyTriggered() {
+		if b.IsManuallyTriggered() || b.IsScheduleTriggered() {
 			for _, buildInput := range nextBuildInputs {
 				resource, _, err := pipeline.ResourceByID(buildInput.ResourceID)
 				if err != nil {
@@ -723,34 +830,29 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 	}
 
 	buildPreparation := BuildPreparation{
-		BuildID:             b.id,
-		PausedPipeline:      pausedPipelineStatus,
-		PausedJob:           pausedJobStatus,
-		MaxRunningBuilds:    maxInFlightReachedStatus,
-		Inputs:              inputs,
-		InputsSatisfied:     inputsSatisfiedStatus,
-		MissingInputReasons: missingInputReasons,
+		BuildID:              b.id,
+		PausedPipeline:       pausedPipelineStatus,
+		PausedJob:            pausedJobStatus,
+		MaxRunningBuilds:     maxInFlightReachedStatus,
+		TeamMaxRunningBuilds: teamQuotaReachedStatus,
+		Inputs:               inputs,
+		InputsSatisfied:      inputsSatisfiedStatus,
+		MissingInputReasons:  missingInputReasons,
 	}
 
 	return buildPreparation, true, nil
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
-	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
+	notifier, err := newConditionNotifier(b.conn.Bus(), BuildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newBuildEventSource(
-		b.id,
-		table,
+		b,
 		b.conn,
 		notifier,
 		from,
@@ -775,7 +877,7 @@ func (b *build) SaveEvent(event atc.Event) error {
 		return err
 	}
 
-	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+	return b.conn.Bus().Notify(BuildEventsChannel(b.id))
 }
 
 func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
@@ -1112,7 +1214,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		status                                                 string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.isScheduleTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed)
 	if err != nil {
 		return err
 	}
@@ -1164,21 +1266,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 }
 
 func (b *build) saveEvent(tx Tx, event atc.Event) error {
-	payload, err := json.Marshal(event)
-	if err != nil {
-		return err
-	}
-
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-	_, err = psql.Insert(table).
-		Columns("event_id", "build_id", "type", "version", "payload").
-		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
-		RunWith(tx).
-		Exec()
-	return err
+	return b.conn.EventStore().Put(b.conn, tx, b, event)
 }
 
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
@@ -1210,7 +1298,8 @@ func buildStartedChannel() string {
 	return fmt.Sprintf("build_started")
 }
 
-func buildEventsChannel(buildID int) string {
+// BuildEventsChannel is notified whenever events are saved for the build.
+func BuildEventsChannel(buildID int) string {
 	return fmt.Sprintf("build_events_%d", buildID)
 }
 
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// BuildApproval is the decision made on a build's approve step.
+type BuildApproval struct {
+	Approved   bool
+	ApprovedBy string
+	CreatedAt  time.Time
+}
+
+// Approve records the decision for the approve step with the given plan ID.
+// Only the first decision is kept; it returns false if the step has already
+// been decided. Decisions belong to the build, so a re-run of it has to be
+// decided again.
+func (b *build) Approve(planID atc.PlanID, approved bool, approvedBy string) (bool, error) {
+	result, err := psql.Insert("build_approvals").
+		SetMap(map[string]interface{}{
+			"build_id":    b.id,
+			"plan_id":     string(planID),
+			"approved":    approved,
+			"approved_by": approvedBy,
+		}).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	err = b.conn.Bus().Notify(buildApprovalChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Approval returns the decision made on the approve step with the given plan
+// ID, if there is one yet.
+func (b *build) Approval(planID atc.PlanID) (BuildApproval, bool, error) {
+	var approval BuildApproval
+	err := psql.Select("approved", "approved_by", "created_at").
+		From("build_approvals").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+		}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&approval.Approved, &approval.ApprovedBy, &approval.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildApproval{}, false, nil
+		}
+
+		return BuildApproval{}, false, err
+	}
+
+	return approval, true, nil
+}
+
+// ApprovalNotifier returns a Notifier that fires once the approve step with
+// the given plan ID has been decided.
+func (b *build) ApprovalNotifier(planID atc.PlanID) (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildApprovalChannel(b.id), func() (bool, error) {
+		_, found, err := b.Approval(planID)
+		return found, err
+	})
+}
+
+func buildApprovalChannel(buildID int) string {
+	return fmt.Sprintf("build_approval_%d", buildID)
+}
@@ -0,0 +1,99 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build approvals", func() {
+	var build db.Build
+
+	BeforeEach(func() {
+		team, err := teamFactory.CreateTeam(atc.Team{Name: "some-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		build, err = team.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("has no approval until one is recorded", func() {
+		_, found, err := build.Approval("some-plan-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Describe("Approve", func() {
+		It("records who decided", func() {
+			recorded, err := build.Approve("some-plan-id", true, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeTrue())
+
+			approval, found, err := build.Approval("some-plan-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(approval.Approved).To(BeTrue())
+			Expect(approval.ApprovedBy).To(Equal("some-user"))
+		})
+
+		It("keeps the first decision", func() {
+			recorded, err := build.Approve("some-plan-id", false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeTrue())
+
+			recorded, err = build.Approve("some-plan-id", true, "some-other-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeFalse())
+
+			approval, _, err := build.Approval("some-plan-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(approval.Approved).To(BeFalse())
+			Expect(approval.ApprovedBy).To(Equal("some-user"))
+		})
+
+		It("does not decide other steps", func() {
+			_, err := build.Approve("some-plan-id", true, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := build.Approval("some-other-plan-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not decide the same step in other builds, e.g. a re-run", func() {
+			_, err := build.Approve("some-plan-id", true, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			otherBuild, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := otherBuild.Approval("some-plan-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("ApprovalNotifier", func() {
+		var notifier db.Notifier
+
+		BeforeEach(func() {
+			var err error
+			notifier, err = build.ApprovalNotifier("some-plan-id")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(notifier.Close()).To(Succeed())
+		})
+
+		It("notifies once the step is decided", func() {
+			Consistently(notifier.Notify()).ShouldNot(Receive())
+
+			_, err := build.Approve("some-plan-id", true, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(notifier.Notify()).Should(Receive())
+		})
+	})
+})
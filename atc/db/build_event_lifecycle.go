@@ -0,0 +1,95 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . BuildEventLifecycle
+
+// BuildEventLifecycle removes the events of builds which have been deleted,
+// e.g. along with their pipeline or team. Deleted builds are recorded by a
+// trigger, as event stores outside of the database are not otherwise told.
+type BuildEventLifecycle interface {
+	RemoveOrphanedBuildEvents(batchSize int) error
+}
+
+type buildEventLifecycle struct {
+	conn Conn
+}
+
+func NewBuildEventLifecycle(conn Conn) BuildEventLifecycle {
+	return &buildEventLifecycle{
+		conn: conn,
+	}
+}
+
+func (lifecycle *buildEventLifecycle) RemoveOrphanedBuildEvents(batchSize int) error {
+	for {
+		removed, err := lifecycle.removeBatch(batchSize)
+		if err != nil {
+			return err
+		}
+
+		if removed < batchSize {
+			return nil
+		}
+	}
+}
+
+func (lifecycle *buildEventLifecycle) removeBatch(batchSize int) (int, error) {
+	tx, err := lifecycle.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("build_id").
+		From("orphaned_build_events").
+		OrderBy("build_id").
+		Limit(uint64(batchSize)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return 0, err
+	}
+
+	var buildIDs []int
+	for rows.Next() {
+		var buildID int
+		err = rows.Scan(&buildID)
+		if err != nil {
+			Close(rows)
+			return 0, err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	Close(rows)
+
+	if len(buildIDs) == 0 {
+		return 0, nil
+	}
+
+	err = lifecycle.conn.EventStore().Delete(tx, buildIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = psql.Delete("orphaned_build_events").
+		Where(sq.Eq{"build_id": buildIDs}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(buildIDs), nil
+}
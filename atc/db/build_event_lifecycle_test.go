@@ -0,0 +1,89 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildEventLifecycle", func() {
+	var buildEventLifecycle db.BuildEventLifecycle
+
+	BeforeEach(func() {
+		buildEventLifecycle = db.NewBuildEventLifecycle(dbConn)
+	})
+
+	orphanedBuildIDs := func() []int {
+		rows, err := dbConn.Query("SELECT build_id FROM orphaned_build_events ORDER BY build_id")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer db.Close(rows)
+
+		buildIDs := []int{}
+		for rows.Next() {
+			var buildID int
+			Expect(rows.Scan(&buildID)).To(Succeed())
+			buildIDs = append(buildIDs, buildID)
+		}
+
+		return buildIDs
+	}
+
+	Describe("RemoveOrphanedBuildEvents", func() {
+		var (
+			deletedBuilds []db.Build
+			keptBuild     db.Build
+		)
+
+		BeforeEach(func() {
+			deletedBuilds = nil
+			for i := 0; i < 3; i++ {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				deleted, err := build.Delete()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deleted).To(BeTrue())
+
+				deletedBuilds = append(deletedBuilds, build)
+			}
+
+			var err error
+			keptBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records deleted builds", func() {
+			Expect(orphanedBuildIDs()).To(Equal([]int{
+				deletedBuilds[0].ID(),
+				deletedBuilds[1].ID(),
+				deletedBuilds[2].ID(),
+			}))
+		})
+
+		It("removes the events of deleted builds in batches", func() {
+			err := buildEventLifecycle.RemoveOrphanedBuildEvents(2)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(orphanedBuildIDs()).To(BeEmpty())
+		})
+
+		It("keeps the events of builds which still exist", func() {
+			err := keptBuild.SaveEvent(event.Log{Payload: "hello"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = buildEventLifecycle.RemoveOrphanedBuildEvents(2)
+			Expect(err).ToNot(HaveOccurred())
+
+			events, err := keptBuild.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer db.Close(events)
+
+			ev, err := events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeLog))
+		})
+	})
+})
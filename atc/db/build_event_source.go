@@ -4,14 +4,21 @@ import (
 	"encoding/json"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 var ErrEndOfBuildEventStream = errors.New("end of build event stream")
 var ErrBuildEventStreamClosed = errors.New("build event stream closed")
 
+// Event stores may write the final events of a build just after it completes,
+// so a build which has only just completed is read until its final status
+// is, for at most this long.
+const finalEventsGrace = 10 * time.Second
+
 //go:generate counterfeiter . EventSource
 
 type EventSource interface {
@@ -20,8 +27,7 @@ type EventSource interface {
 }
 
 func newBuildEventSource(
-	buildID int,
-	table string,
+	build Build,
 	conn Conn,
 	notifier Notifier,
 	from uint,
@@ -29,8 +35,7 @@ func newBuildEventSource(
 	wg := new(sync.WaitGroup)
 
 	source := &buildEventSource{
-		buildID: buildID,
-		table:   table,
+		build: build,
 
 		conn: conn,
 
@@ -48,8 +53,7 @@ func newBuildEventSource(
 }
 
 type buildEventSource struct {
-	buildID int
-	table   string
+	build Build
 
 	conn     Conn
 	notifier Notifier
@@ -87,6 +91,8 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 
 	var batchSize = cap(source.events)
 
+	finished := false
+
 	for {
 		select {
 		case <-source.stop:
@@ -97,81 +103,62 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 
 		completed := false
+		var endTime pq.NullTime
 
 		err := source.conn.QueryRow(`
-			SELECT builds.completed
+			SELECT builds.completed, builds.end_time
 			FROM builds
 			WHERE builds.id = $1
-		`, source.buildID).Scan(&completed)
+		`, source.build.ID()).Scan(&completed, &endTime)
 		if err != nil {
 			source.err = err
 			close(source.events)
 			return
 		}
 
-		rows, err := source.conn.Query(`
-			SELECT type, version, payload
-			FROM `+source.table+`
-			WHERE build_id = $1
-			ORDER BY event_id ASC
-			OFFSET $2
-			LIMIT $3
-		`, source.buildID, cursor, batchSize)
+		events, err := source.conn.EventStore().Get(source.conn, source.build, cursor, batchSize)
 		if err != nil {
 			source.err = err
 			close(source.events)
 			return
 		}
 
-		rowsReturned := 0
-
-		for rows.Next() {
-			rowsReturned++
-
+		for _, ev := range events {
 			cursor++
 
-			var t, v, p string
-			err := rows.Scan(&t, &v, &p)
-			if err != nil {
-				_ = rows.Close()
-
-				source.err = err
-				close(source.events)
-				return
-			}
-
-			data := json.RawMessage(p)
-
-			ev := event.Envelope{
-				Data:    &data,
-				Event:   atc.EventType(t),
-				Version: atc.EventVersion(v),
+			if isFinalStatus(ev) {
+				finished = true
 			}
 
 			select {
 			case source.events <- ev:
 			case <-source.stop:
-				_ = rows.Close()
-
 				source.err = ErrBuildEventStreamClosed
 				close(source.events)
 				return
 			}
 		}
 
-		if rowsReturned == batchSize {
+		if len(events) == batchSize {
 			// still more events
 			continue
 		}
 
+		var timeout <-chan time.Time
 		if completed {
-			source.err = ErrEndOfBuildEventStream
-			close(source.events)
-			return
+			remaining := finalEventsGrace - time.Since(endTime.Time)
+			if finished || remaining <= 0 {
+				source.err = ErrEndOfBuildEventStream
+				close(source.events)
+				return
+			}
+
+			timeout = time.After(remaining)
 		}
 
 		select {
 		case <-source.notifier.Notify():
+		case <-timeout:
 		case <-source.stop:
 			source.err = ErrBuildEventStreamClosed
 			close(source.events)
@@ -179,3 +166,24 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 	}
 }
+
+// isFinalStatus returns true if the event is the status event saved along
+// with the build's completion.
+func isFinalStatus(ev event.Envelope) bool {
+	if ev.Event != event.EventTypeStatus || ev.Data == nil {
+		return false
+	}
+
+	var status event.Status
+	err := json.Unmarshal(*ev.Data, &status)
+	if err != nil {
+		return false
+	}
+
+	switch status.Status {
+	case atc.StatusSucceeded, atc.StatusFailed, atc.StatusErrored, atc.StatusAborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -18,6 +18,9 @@ type BuildFactory interface {
 	VisibleBuildsWithTime([]string, Page) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
+	// ReadyPendingBuildsCountPerTeam returns the number of pending builds of
+	// each team which nothing but the lack of a worker keeps from starting.
+	ReadyPendingBuildsCountPerTeam() (map[int]int, error)
 	GetDrainableBuilds() ([]Build, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
@@ -122,6 +125,59 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// ReadyPendingBuildsCountPerTeam counts the builds whose preparation has
+// nothing blocking, i.e. one-off builds and job builds whose pipeline and job
+// are not paused, which are within their job's max in flight and their team's
+// max running builds, and whose inputs are determined and, if the build was
+// triggered manually, checked since it was created.
+func (f *buildFactory) ReadyPendingBuildsCountPerTeam() (map[int]int, error) {
+	rows, err := psql.Select("b.team_id", "COUNT(*)").
+		From("builds b").
+		Join("teams t ON t.id = b.team_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{"b.status": string(BuildStatusPending)}).
+		Where(`(b.job_id IS NULL OR (
+			NOT p.paused
+			AND NOT j.paused
+			AND NOT j.max_in_flight_reached
+			AND j.inputs_determined
+			AND (
+				t.max_running_builds = 0
+				OR (SELECT COUNT(*) FROM builds tb WHERE tb.team_id = t.id AND tb.status = 'started') < t.max_running_builds
+			)
+			AND NOT ((b.manually_triggered OR b.schedule_triggered) AND EXISTS (
+				SELECT 1
+				FROM next_build_inputs i
+				JOIN resources r ON r.id = i.resource_id
+				LEFT JOIN resource_config_scopes rs ON rs.id = r.resource_config_scope_id
+				WHERE i.job_id = j.id
+				AND (rs.last_check_end_time IS NULL OR rs.last_check_end_time < b.create_time)
+			))
+		))`).
+		GroupBy("b.team_id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	counts := map[int]int{}
+	for rows.Next() {
+		var teamID, count int
+		err = rows.Scan(&teamID, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[teamID] = count
+	}
+
+	return counts, nil
+}
+
 func getBuilds(buildsQuery sq.SelectBuilder, conn Conn, lockFactory lock.LockFactory) ([]Build, error) {
 	rows, err := buildsQuery.RunWith(conn).Query()
 	if err != nil {
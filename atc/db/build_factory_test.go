@@ -114,7 +114,7 @@ var _ = Describe("BuildFactory", func() {
 				err = build2.Finish(db.BuildStatusErrored)
 				Expect(err).NotTo(HaveOccurred())
 
-				p, _, err := defaultTeam.SavePipeline("other-pipeline", atc.Config{
+				p, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-other-job",
@@ -221,7 +221,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			privatePipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "private-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -231,7 +231,7 @@ var _ = Describe("BuildFactory", func() {
 			build2, err = privateJob.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).NotTo(HaveOccurred())
 			err = publicPipeline.Expose()
 			Expect(err).NotTo(HaveOccurred())
@@ -268,7 +268,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			privatePipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "private-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -278,7 +278,7 @@ var _ = Describe("BuildFactory", func() {
 			_, err = privateJob.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).NotTo(HaveOccurred())
 			err = publicPipeline.Expose()
 			Expect(err).NotTo(HaveOccurred())
@@ -304,7 +304,7 @@ var _ = Describe("BuildFactory", func() {
 		var build2DB, build3DB, build4DB db.Build
 
 		BeforeEach(func() {
-			pipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -359,7 +359,7 @@ var _ = Describe("BuildFactory", func() {
 		var build2DB db.Build
 
 		BeforeEach(func() {
-			pipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -402,4 +402,72 @@ var _ = Describe("BuildFactory", func() {
 			Expect(builds).To(ConsistOf(build1DB, build2DB))
 		})
 	})
+
+	Describe("ReadyPendingBuildsCountPerTeam", func() {
+		BeforeEach(func() {
+			_, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			startedBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the pending builds of each team which nothing but the workers hold back", func() {
+			counts, err := buildFactory.ReadyPendingBuildsCountPerTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[int]int{team.ID(): 1}))
+		})
+
+		Context("when the job's inputs are determined", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE jobs SET inputs_determined = true WHERE id = $1`, defaultJob.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("counts the job's pending builds", func() {
+				counts, err := buildFactory.ReadyPendingBuildsCountPerTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts).To(Equal(map[int]int{team.ID(): 1, defaultTeam.ID(): 1}))
+			})
+
+			Context("when the job is paused", func() {
+				BeforeEach(func() {
+					Expect(defaultJob.Pause()).To(Succeed())
+				})
+
+				It("does not count the job's pending builds", func() {
+					counts, err := buildFactory.ReadyPendingBuildsCountPerTeam()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(counts).To(Equal(map[int]int{team.ID(): 1}))
+				})
+			})
+
+			Context("when the team has reached its max running builds", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`UPDATE teams SET max_running_builds = 1 WHERE id = $1`, defaultTeam.ID())
+					Expect(err).NotTo(HaveOccurred())
+
+					build, err := defaultTeam.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					started, err := build.Start(atc.Plan{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(started).To(BeTrue())
+				})
+
+				It("does not count the job's pending builds", func() {
+					counts, err := buildFactory.ReadyPendingBuildsCountPerTeam()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(counts).To(Equal(map[int]int{team.ID(): 1}))
+				})
+			})
+		})
+	})
 })
@@ -36,11 +36,12 @@ func (mir MissingInputReasons) RegisterPinnedVersionUnavailable(inputName string
 }
 
 type BuildPreparation struct {
-	BuildID             int
-	PausedPipeline      BuildPreparationStatus
-	PausedJob           BuildPreparationStatus
-	MaxRunningBuilds    BuildPreparationStatus
-	Inputs              map[string]BuildPreparationStatus
-	InputsSatisfied     BuildPreparationStatus
-	MissingInputReasons MissingInputReasons
+	BuildID              int
+	PausedPipeline       BuildPreparationStatus
+	PausedJob            BuildPreparationStatus
+	MaxRunningBuilds     BuildPreparationStatus
+	TeamMaxRunningBuilds BuildPreparationStatus
+	Inputs               map[string]BuildPreparationStatus
+	InputsSatisfied      BuildPreparationStatus
+	MissingInputReasons  MissingInputReasons
 }
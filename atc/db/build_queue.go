@@ -0,0 +1,135 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// BuildQueueEntry is a pending build waiting in the cluster-wide build queue.
+type BuildQueueEntry struct {
+	BuildID      int
+	BuildName    string
+	JobName      string
+	PipelineName string
+	TeamID       int
+	TeamName     string
+	Priority     int
+	QueuedAt     time.Time
+}
+
+//go:generate counterfeiter . BuildQueue
+
+// BuildQueue records pending builds that could not be started straight away
+// because the workers were at capacity.
+type BuildQueue interface {
+	Enqueue(buildID int) error
+	Dequeue(buildID int) error
+
+	// Entries returns the queued builds which are still pending and whose
+	// job and pipeline are not paused.
+	Entries() ([]BuildQueueEntry, error)
+
+	// RunningBuildsByTeam returns the number of started builds for each team
+	// ID that has any.
+	RunningBuildsByTeam() (map[int]int, error)
+}
+
+type buildQueue struct {
+	conn Conn
+}
+
+func NewBuildQueue(conn Conn) BuildQueue {
+	return &buildQueue{
+		conn: conn,
+	}
+}
+
+func (queue *buildQueue) Enqueue(buildID int) error {
+	_, err := psql.Insert("build_queue").
+		Columns("build_id").
+		Values(buildID).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(queue.conn).
+		Exec()
+	return err
+}
+
+func (queue *buildQueue) Dequeue(buildID int) error {
+	_, err := psql.Delete("build_queue").
+		Where(sq.Eq{"build_id": buildID}).
+		RunWith(queue.conn).
+		Exec()
+	return err
+}
+
+func (queue *buildQueue) Entries() ([]BuildQueueEntry, error) {
+	rows, err := psql.Select("b.id", "b.name", "j.name", "p.name", "t.id", "t.name", "j.priority", "q.queued_at").
+		From("build_queue q").
+		Join("builds b ON b.id = q.build_id").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Join("teams t ON t.id = b.team_id").
+		Where(sq.Eq{
+			"b.status": string(BuildStatusPending),
+			"j.paused": false,
+			"p.paused": false,
+		}).
+		OrderBy("b.id ASC").
+		RunWith(queue.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	entries := []BuildQueueEntry{}
+	for rows.Next() {
+		var entry BuildQueueEntry
+		err = rows.Scan(
+			&entry.BuildID,
+			&entry.BuildName,
+			&entry.JobName,
+			&entry.PipelineName,
+			&entry.TeamID,
+			&entry.TeamName,
+			&entry.Priority,
+			&entry.QueuedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (queue *buildQueue) RunningBuildsByTeam() (map[int]int, error) {
+	rows, err := psql.Select("team_id", "COUNT(*)").
+		From("builds").
+		Where(sq.Eq{"status": string(BuildStatusStarted)}).
+		GroupBy("team_id").
+		RunWith(queue.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	running := map[int]int{}
+	for rows.Next() {
+		var teamID, count int
+		err = rows.Scan(&teamID, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		running[teamID] = count
+	}
+
+	return running, nil
+}
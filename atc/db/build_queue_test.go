@@ -0,0 +1,136 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildQueue", func() {
+	var (
+		buildQueue db.BuildQueue
+
+		urgentJob   db.Job
+		urgentBuild db.Build
+		normalBuild db.Build
+	)
+
+	BeforeEach(func() {
+		buildQueue = db.NewBuildQueue(dbConn)
+
+		pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "queue-pipeline"}, atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "urgent-job", Priority: 10},
+				{Name: "normal-job"},
+			},
+		}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		var found bool
+		urgentJob, found, err = pipeline.Job("urgent-job")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		normalJob, found, err := pipeline.Job("normal-job")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		urgentBuild, err = urgentJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		normalBuild, err = normalJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(buildQueue.Enqueue(normalBuild.ID())).To(Succeed())
+		Expect(buildQueue.Enqueue(urgentBuild.ID())).To(Succeed())
+	})
+
+	Describe("Entries", func() {
+		It("returns the queued builds with their job's priority", func() {
+			entries, err := buildQueue.Entries()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+
+			Expect(entries[0].BuildID).To(Equal(urgentBuild.ID()))
+			Expect(entries[0].JobName).To(Equal("urgent-job"))
+			Expect(entries[0].PipelineName).To(Equal("queue-pipeline"))
+			Expect(entries[0].TeamID).To(Equal(defaultTeam.ID()))
+			Expect(entries[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(entries[0].Priority).To(Equal(10))
+			Expect(entries[0].QueuedAt).NotTo(BeZero())
+
+			Expect(entries[1].BuildID).To(Equal(normalBuild.ID()))
+			Expect(entries[1].Priority).To(Equal(0))
+		})
+
+		It("ignores builds that are queued twice", func() {
+			Expect(buildQueue.Enqueue(normalBuild.ID())).To(Succeed())
+
+			entries, err := buildQueue.Entries()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+		})
+
+		Context("when a build is no longer pending", func() {
+			BeforeEach(func() {
+				scheduled, err := urgentBuild.Schedule()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				started, err := urgentBuild.Start(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+
+			It("is left out", func() {
+				entries, err := buildQueue.Entries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(entries[0].BuildID).To(Equal(normalBuild.ID()))
+			})
+		})
+
+		Context("when a build's job is paused", func() {
+			BeforeEach(func() {
+				Expect(urgentJob.Pause()).To(Succeed())
+			})
+
+			It("is left out", func() {
+				entries, err := buildQueue.Entries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(entries[0].BuildID).To(Equal(normalBuild.ID()))
+			})
+		})
+	})
+
+	Describe("Dequeue", func() {
+		It("removes the build from the queue", func() {
+			Expect(buildQueue.Dequeue(urgentBuild.ID())).To(Succeed())
+
+			entries, err := buildQueue.Entries()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].BuildID).To(Equal(normalBuild.ID()))
+		})
+	})
+
+	Describe("RunningBuildsByTeam", func() {
+		BeforeEach(func() {
+			_, err := urgentBuild.Schedule()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := urgentBuild.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("counts the started builds of each team", func() {
+			running, err := buildQueue.RunningBuildsByTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(running).To(Equal(map[int]int{defaultTeam.ID(): 1}))
+		})
+	})
+})
@@ -169,6 +169,51 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("ExpandAcross", func() {
+		var (
+			build db.Build
+			steps []atc.VarScopedPlan
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start(atc.Plan{
+				ID: "1",
+				Across: &atc.AcrossPlan{
+					Vars: []atc.AcrossVar{{Var: "env", ValuesVar: "envs"}},
+					Step: &atc.Plan{ID: "2", Approve: &atc.ApprovePlan{Name: "deploy"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			steps = []atc.VarScopedPlan{
+				{Step: atc.Plan{ID: "2/0", Approve: &atc.ApprovePlan{Name: "deploy"}}, Values: []interface{}{"staging"}},
+				{Step: atc.Plan{ID: "2/1", Approve: &atc.ApprovePlan{Name: "deploy"}}, Values: []interface{}{"prod"}},
+			}
+		})
+
+		It("saves the steps in the build's private and public plans", func() {
+			err := build.ExpandAcross("1", steps)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(build.PrivatePlan().Across.Steps).To(Equal(steps))
+			Expect(string(*build.PublicPlan())).To(ContainSubstring(`"id":"2/1"`))
+		})
+
+		It("errors if the across step is not in the plan", func() {
+			err := build.ExpandAcross("2", steps)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Finish", func() {
 		var build db.Build
 		BeforeEach(func() {
@@ -391,7 +436,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -530,7 +575,7 @@ var _ = Describe("Build", func() {
 				},
 			}
 
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -626,7 +671,7 @@ var _ = Describe("Build", func() {
 		Context("when a job build", func() {
 			BeforeEach(func() {
 				var err error
-				createdPipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+				createdPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -671,13 +716,14 @@ var _ = Describe("Build", func() {
 		)
 		BeforeEach(func() {
 			expectedBuildPrep = db.BuildPreparation{
-				BuildID:             123456789,
-				PausedPipeline:      db.BuildPreparationStatusNotBlocking,
-				PausedJob:           db.BuildPreparationStatusNotBlocking,
-				MaxRunningBuilds:    db.BuildPreparationStatusNotBlocking,
-				Inputs:              map[string]db.BuildPreparationStatus{},
-				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
-				MissingInputReasons: db.MissingInputReasons{},
+				BuildID:              123456789,
+				PausedPipeline:       db.BuildPreparationStatusNotBlocking,
+				PausedJob:            db.BuildPreparationStatusNotBlocking,
+				MaxRunningBuilds:     db.BuildPreparationStatusNotBlocking,
+				TeamMaxRunningBuilds: db.BuildPreparationStatusNotBlocking,
+				Inputs:               map[string]db.BuildPreparationStatus{},
+				InputsSatisfied:      db.BuildPreparationStatusNotBlocking,
+				MissingInputReasons:  db.MissingInputReasons{},
 			}
 		})
 
@@ -724,7 +770,7 @@ var _ = Describe("Build", func() {
 
 			BeforeEach(func() {
 				var err error
-				pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Resources: atc.ResourceConfigs{
 						{
 							Name: "some-resource",
@@ -885,6 +931,29 @@ var _ = Describe("Build", func() {
 						})
 					})
 
+					Context("when the team's max running builds is reached", func() {
+						BeforeEach(func() {
+							err := team.UpdateQuotas(atc.TeamQuotas{MaxRunningBuilds: 1})
+							Expect(err).NotTo(HaveOccurred())
+
+							runningBuild, err := team.CreateOneOffBuild()
+							Expect(err).NotTo(HaveOccurred())
+
+							started, err := runningBuild.Start(atc.Plan{})
+							Expect(err).NotTo(HaveOccurred())
+							Expect(started).To(BeTrue())
+
+							expectedBuildPrep.TeamMaxRunningBuilds = db.BuildPreparationStatusBlocking
+						})
+
+						It("returns build preparation with the team's max running builds reached", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+
 					Context("when max running builds is de-reached", func() {
 						BeforeEach(func() {
 							err := job.SetMaxInFlightReached(true)
@@ -930,7 +999,7 @@ var _ = Describe("Build", func() {
 
 			Context("when inputs are not satisfied", func() {
 				BeforeEach(func() {
-					pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+					pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 						Resources: atc.ResourceConfigs{
 							{
 								Name: "some-resource",
@@ -998,7 +1067,7 @@ var _ = Describe("Build", func() {
 						},
 					}
 
-					pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(2), db.PipelineUnpaused)
+					pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(2), db.PipelineUnpaused)
 					Expect(err).ToNot(HaveOccurred())
 
 					setupTx, err := dbConn.Begin()
@@ -1081,7 +1150,7 @@ var _ = Describe("Build", func() {
 			)
 
 			BeforeEach(func() {
-				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -1163,7 +1232,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -41,6 +41,49 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	ApprovalStub        func(atc.PlanID) (db.BuildApproval, bool, error)
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalReturns struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	approvalReturnsOnCall map[int]struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	ApprovalNotifierStub        func(atc.PlanID) (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	approvalNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	ApproveStub        func(atc.PlanID, bool, string) (bool, error)
+	approveMutex       sync.RWMutex
+	approveArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+	}
+	approveReturns struct {
+		result1 bool
+		result2 error
+	}
+	approveReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -111,6 +154,18 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	ExpandAcrossStub        func(atc.PlanID, []atc.VarScopedPlan) error
+	expandAcrossMutex       sync.RWMutex
+	expandAcrossArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 []atc.VarScopedPlan
+	}
+	expandAcrossReturns struct {
+		result1 error
+	}
+	expandAcrossReturnsOnCall map[int]struct {
+		result1 error
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -204,6 +259,16 @@ type FakeBuild struct {
 	isRunningReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsScheduleTriggeredStub        func() bool
+	isScheduleTriggeredMutex       sync.RWMutex
+	isScheduleTriggeredArgsForCall []struct {
+	}
+	isScheduleTriggeredReturns struct {
+		result1 bool
+	}
+	isScheduleTriggeredReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsScheduledStub        func() bool
 	isScheduledMutex       sync.RWMutex
 	isScheduledArgsForCall []struct {
@@ -631,6 +696,200 @@ func (fake *FakeBuild) AcquireTrackingLockReturnsOnCall(i int, result1 lock.Lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Approval(arg1 atc.PlanID) (db.BuildApproval, bool, error) {
+	fake.approvalMutex.Lock()
+	ret, specificReturn := fake.approvalReturnsOnCall[len(fake.approvalArgsForCall)]
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("Approval", []interface{}{arg1})
+	fake.approvalMutex.Unlock()
+	if fake.ApprovalStub != nil {
+		return fake.ApprovalStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.approvalReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalCalls(stub func(atc.PlanID) (db.BuildApproval, bool, error)) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = stub
+}
+
+func (fake *FakeBuild) ApprovalArgsForCall(i int) atc.PlanID {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	argsForCall := fake.approvalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalReturns(result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalReturnsOnCall(i int, result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	if fake.approvalReturnsOnCall == nil {
+		fake.approvalReturnsOnCall = make(map[int]struct {
+			result1 db.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.approvalReturnsOnCall[i] = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalNotifier(arg1 atc.PlanID) (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	ret, specificReturn := fake.approvalNotifierReturnsOnCall[len(fake.approvalNotifierArgsForCall)]
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("ApprovalNotifier", []interface{}{arg1})
+	fake.approvalNotifierMutex.Unlock()
+	if fake.ApprovalNotifierStub != nil {
+		return fake.ApprovalNotifierStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.approvalNotifierReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalNotifierCalls(stub func(atc.PlanID) (db.Notifier, error)) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = stub
+}
+
+func (fake *FakeBuild) ApprovalNotifierArgsForCall(i int) atc.PlanID {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	argsForCall := fake.approvalNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	if fake.approvalNotifierReturnsOnCall == nil {
+		fake.approvalNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.approvalNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Approve(arg1 atc.PlanID, arg2 bool, arg3 string) (bool, error) {
+	fake.approveMutex.Lock()
+	ret, specificReturn := fake.approveReturnsOnCall[len(fake.approveArgsForCall)]
+	fake.approveArgsForCall = append(fake.approveArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Approve", []interface{}{arg1, arg2, arg3})
+	fake.approveMutex.Unlock()
+	if fake.ApproveStub != nil {
+		return fake.ApproveStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.approveReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApproveCallCount() int {
+	fake.approveMutex.RLock()
+	defer fake.approveMutex.RUnlock()
+	return len(fake.approveArgsForCall)
+}
+
+func (fake *FakeBuild) ApproveCalls(stub func(atc.PlanID, bool, string) (bool, error)) {
+	fake.approveMutex.Lock()
+	defer fake.approveMutex.Unlock()
+	fake.ApproveStub = stub
+}
+
+func (fake *FakeBuild) ApproveArgsForCall(i int) (atc.PlanID, bool, string) {
+	fake.approveMutex.RLock()
+	defer fake.approveMutex.RUnlock()
+	argsForCall := fake.approveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) ApproveReturns(result1 bool, result2 error) {
+	fake.approveMutex.Lock()
+	defer fake.approveMutex.Unlock()
+	fake.ApproveStub = nil
+	fake.approveReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApproveReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.approveMutex.Lock()
+	defer fake.approveMutex.Unlock()
+	fake.ApproveStub = nil
+	if fake.approveReturnsOnCall == nil {
+		fake.approveReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.approveReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -971,6 +1230,72 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ExpandAcross(arg1 atc.PlanID, arg2 []atc.VarScopedPlan) error {
+	var arg2Copy []atc.VarScopedPlan
+	if arg2 != nil {
+		arg2Copy = make([]atc.VarScopedPlan, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.expandAcrossMutex.Lock()
+	ret, specificReturn := fake.expandAcrossReturnsOnCall[len(fake.expandAcrossArgsForCall)]
+	fake.expandAcrossArgsForCall = append(fake.expandAcrossArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 []atc.VarScopedPlan
+	}{arg1, arg2Copy})
+	fake.recordInvocation("ExpandAcross", []interface{}{arg1, arg2Copy})
+	fake.expandAcrossMutex.Unlock()
+	if fake.ExpandAcrossStub != nil {
+		return fake.ExpandAcrossStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.expandAcrossReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ExpandAcrossCallCount() int {
+	fake.expandAcrossMutex.RLock()
+	defer fake.expandAcrossMutex.RUnlock()
+	return len(fake.expandAcrossArgsForCall)
+}
+
+func (fake *FakeBuild) ExpandAcrossCalls(stub func(atc.PlanID, []atc.VarScopedPlan) error) {
+	fake.expandAcrossMutex.Lock()
+	defer fake.expandAcrossMutex.Unlock()
+	fake.ExpandAcrossStub = stub
+}
+
+func (fake *FakeBuild) ExpandAcrossArgsForCall(i int) (atc.PlanID, []atc.VarScopedPlan) {
+	fake.expandAcrossMutex.RLock()
+	defer fake.expandAcrossMutex.RUnlock()
+	argsForCall := fake.expandAcrossArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) ExpandAcrossReturns(result1 error) {
+	fake.expandAcrossMutex.Lock()
+	defer fake.expandAcrossMutex.Unlock()
+	fake.ExpandAcrossStub = nil
+	fake.expandAcrossReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ExpandAcrossReturnsOnCall(i int, result1 error) {
+	fake.expandAcrossMutex.Lock()
+	defer fake.expandAcrossMutex.Unlock()
+	fake.ExpandAcrossStub = nil
+	if fake.expandAcrossReturnsOnCall == nil {
+		fake.expandAcrossReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.expandAcrossReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -1450,6 +1775,58 @@ func (fake *FakeBuild) IsRunningReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) IsScheduleTriggered() bool {
+	fake.isScheduleTriggeredMutex.Lock()
+	ret, specificReturn := fake.isScheduleTriggeredReturnsOnCall[len(fake.isScheduleTriggeredArgsForCall)]
+	fake.isScheduleTriggeredArgsForCall = append(fake.isScheduleTriggeredArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IsScheduleTriggered", []interface{}{})
+	fake.isScheduleTriggeredMutex.Unlock()
+	if fake.IsScheduleTriggeredStub != nil {
+		return fake.IsScheduleTriggeredStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isScheduleTriggeredReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsScheduleTriggeredCallCount() int {
+	fake.isScheduleTriggeredMutex.RLock()
+	defer fake.isScheduleTriggeredMutex.RUnlock()
+	return len(fake.isScheduleTriggeredArgsForCall)
+}
+
+func (fake *FakeBuild) IsScheduleTriggeredCalls(stub func() bool) {
+	fake.isScheduleTriggeredMutex.Lock()
+	defer fake.isScheduleTriggeredMutex.Unlock()
+	fake.IsScheduleTriggeredStub = stub
+}
+
+func (fake *FakeBuild) IsScheduleTriggeredReturns(result1 bool) {
+	fake.isScheduleTriggeredMutex.Lock()
+	defer fake.isScheduleTriggeredMutex.Unlock()
+	fake.IsScheduleTriggeredStub = nil
+	fake.isScheduleTriggeredReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsScheduleTriggeredReturnsOnCall(i int, result1 bool) {
+	fake.isScheduleTriggeredMutex.Lock()
+	defer fake.isScheduleTriggeredMutex.Unlock()
+	fake.IsScheduleTriggeredStub = nil
+	if fake.isScheduleTriggeredReturnsOnCall == nil {
+		fake.isScheduleTriggeredReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isScheduleTriggeredReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsScheduled() bool {
 	fake.isScheduledMutex.Lock()
 	ret, specificReturn := fake.isScheduledReturnsOnCall[len(fake.isScheduledArgsForCall)]
@@ -2955,6 +3332,12 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	fake.approveMutex.RLock()
+	defer fake.approveMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
@@ -2967,6 +3350,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.expandAcrossMutex.RLock()
+	defer fake.expandAcrossMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	fake.hasPlanMutex.RLock()
@@ -2985,6 +3370,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isManuallyTriggeredMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
+	fake.isScheduleTriggeredMutex.RLock()
+	defer fake.isScheduleTriggeredMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
 	defer fake.isScheduledMutex.RUnlock()
 	fake.jobIDMutex.RLock()
@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeBuildEventLifecycle struct {
+	RemoveOrphanedBuildEventsStub        func(int) error
+	removeOrphanedBuildEventsMutex       sync.RWMutex
+	removeOrphanedBuildEventsArgsForCall []struct {
+		arg1 int
+	}
+	removeOrphanedBuildEventsReturns struct {
+		result1 error
+	}
+	removeOrphanedBuildEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildEventLifecycle) RemoveOrphanedBuildEvents(arg1 int) error {
+	fake.removeOrphanedBuildEventsMutex.Lock()
+	ret, specificReturn := fake.removeOrphanedBuildEventsReturnsOnCall[len(fake.removeOrphanedBuildEventsArgsForCall)]
+	fake.removeOrphanedBuildEventsArgsForCall = append(fake.removeOrphanedBuildEventsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("RemoveOrphanedBuildEvents", []interface{}{arg1})
+	fake.removeOrphanedBuildEventsMutex.Unlock()
+	if fake.RemoveOrphanedBuildEventsStub != nil {
+		return fake.RemoveOrphanedBuildEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.removeOrphanedBuildEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildEventLifecycle) RemoveOrphanedBuildEventsCallCount() int {
+	fake.removeOrphanedBuildEventsMutex.RLock()
+	defer fake.removeOrphanedBuildEventsMutex.RUnlock()
+	return len(fake.removeOrphanedBuildEventsArgsForCall)
+}
+
+func (fake *FakeBuildEventLifecycle) RemoveOrphanedBuildEventsCalls(stub func(int) error) {
+	fake.removeOrphanedBuildEventsMutex.Lock()
+	defer fake.removeOrphanedBuildEventsMutex.Unlock()
+	fake.RemoveOrphanedBuildEventsStub = stub
+}
+
+func (fake *FakeBuildEventLifecycle) RemoveOrphanedBuildEventsArgsForCall(i int) int {
+	fake.removeOrphanedBuildEventsMutex.RLock()
+	defer fake.removeOrphanedBuildEventsMutex.RUnlock()
+	argsForCall := fake.removeOrphanedBuildEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildEventLifecycle) RemoveOrphanedBuildEventsReturns(result1 error) {
+	fake.removeOrphanedBuildEventsMutex.Lock()
+	defer fake.removeOrphanedBuildEventsMutex.Unlock()
+	fake.RemoveOrphanedBuildEventsStub = nil
+	fake.removeOrphanedBuildEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildEventLifecycle) RemoveOrphanedBuildEventsReturnsOnCall(i int, result1 error) {
+	fake.removeOrphanedBuildEventsMutex.Lock()
+	defer fake.removeOrphanedBuildEventsMutex.Unlock()
+	fake.RemoveOrphanedBuildEventsStub = nil
+	if fake.removeOrphanedBuildEventsReturnsOnCall == nil {
+		fake.removeOrphanedBuildEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeOrphanedBuildEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildEventLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.removeOrphanedBuildEventsMutex.RLock()
+	defer fake.removeOrphanedBuildEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildEventLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.BuildEventLifecycle = new(FakeBuildEventLifecycle)
@@ -72,6 +72,18 @@ type FakeBuildFactory struct {
 		result2 db.Pagination
 		result3 error
 	}
+	ReadyPendingBuildsCountPerTeamStub        func() (map[int]int, error)
+	readyPendingBuildsCountPerTeamMutex       sync.RWMutex
+	readyPendingBuildsCountPerTeamArgsForCall []struct {
+	}
+	readyPendingBuildsCountPerTeamReturns struct {
+		result1 map[int]int
+		result2 error
+	}
+	readyPendingBuildsCountPerTeamReturnsOnCall map[int]struct {
+		result1 map[int]int
+		result2 error
+	}
 	VisibleBuildsStub        func([]string, db.Page) ([]db.Build, db.Pagination, error)
 	visibleBuildsMutex       sync.RWMutex
 	visibleBuildsArgsForCall []struct {
@@ -402,6 +414,61 @@ func (fake *FakeBuildFactory) PublicBuildsReturnsOnCall(i int, result1 []db.Buil
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) ReadyPendingBuildsCountPerTeam() (map[int]int, error) {
+	fake.readyPendingBuildsCountPerTeamMutex.Lock()
+	ret, specificReturn := fake.readyPendingBuildsCountPerTeamReturnsOnCall[len(fake.readyPendingBuildsCountPerTeamArgsForCall)]
+	fake.readyPendingBuildsCountPerTeamArgsForCall = append(fake.readyPendingBuildsCountPerTeamArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReadyPendingBuildsCountPerTeam", []interface{}{})
+	fake.readyPendingBuildsCountPerTeamMutex.Unlock()
+	if fake.ReadyPendingBuildsCountPerTeamStub != nil {
+		return fake.ReadyPendingBuildsCountPerTeamStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.readyPendingBuildsCountPerTeamReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) ReadyPendingBuildsCountPerTeamCallCount() int {
+	fake.readyPendingBuildsCountPerTeamMutex.RLock()
+	defer fake.readyPendingBuildsCountPerTeamMutex.RUnlock()
+	return len(fake.readyPendingBuildsCountPerTeamArgsForCall)
+}
+
+func (fake *FakeBuildFactory) ReadyPendingBuildsCountPerTeamCalls(stub func() (map[int]int, error)) {
+	fake.readyPendingBuildsCountPerTeamMutex.Lock()
+	defer fake.readyPendingBuildsCountPerTeamMutex.Unlock()
+	fake.ReadyPendingBuildsCountPerTeamStub = stub
+}
+
+func (fake *FakeBuildFactory) ReadyPendingBuildsCountPerTeamReturns(result1 map[int]int, result2 error) {
+	fake.readyPendingBuildsCountPerTeamMutex.Lock()
+	defer fake.readyPendingBuildsCountPerTeamMutex.Unlock()
+	fake.ReadyPendingBuildsCountPerTeamStub = nil
+	fake.readyPendingBuildsCountPerTeamReturns = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) ReadyPendingBuildsCountPerTeamReturnsOnCall(i int, result1 map[int]int, result2 error) {
+	fake.readyPendingBuildsCountPerTeamMutex.Lock()
+	defer fake.readyPendingBuildsCountPerTeamMutex.Unlock()
+	fake.ReadyPendingBuildsCountPerTeamStub = nil
+	if fake.readyPendingBuildsCountPerTeamReturnsOnCall == nil {
+		fake.readyPendingBuildsCountPerTeamReturnsOnCall = make(map[int]struct {
+			result1 map[int]int
+			result2 error
+		})
+	}
+	fake.readyPendingBuildsCountPerTeamReturnsOnCall[i] = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page) ([]db.Build, db.Pagination, error) {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -559,6 +626,8 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
 	fake.publicBuildsMutex.RLock()
 	defer fake.publicBuildsMutex.RUnlock()
+	fake.readyPendingBuildsCountPerTeamMutex.RLock()
+	defer fake.readyPendingBuildsCountPerTeamMutex.RUnlock()
 	fake.visibleBuildsMutex.RLock()
 	defer fake.visibleBuildsMutex.RUnlock()
 	fake.visibleBuildsWithTimeMutex.RLock()
@@ -0,0 +1,321 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeBuildQueue struct {
+	DequeueStub        func(int) error
+	dequeueMutex       sync.RWMutex
+	dequeueArgsForCall []struct {
+		arg1 int
+	}
+	dequeueReturns struct {
+		result1 error
+	}
+	dequeueReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnqueueStub        func(int) error
+	enqueueMutex       sync.RWMutex
+	enqueueArgsForCall []struct {
+		arg1 int
+	}
+	enqueueReturns struct {
+		result1 error
+	}
+	enqueueReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EntriesStub        func() ([]db.BuildQueueEntry, error)
+	entriesMutex       sync.RWMutex
+	entriesArgsForCall []struct {
+	}
+	entriesReturns struct {
+		result1 []db.BuildQueueEntry
+		result2 error
+	}
+	entriesReturnsOnCall map[int]struct {
+		result1 []db.BuildQueueEntry
+		result2 error
+	}
+	RunningBuildsByTeamStub        func() (map[int]int, error)
+	runningBuildsByTeamMutex       sync.RWMutex
+	runningBuildsByTeamArgsForCall []struct {
+	}
+	runningBuildsByTeamReturns struct {
+		result1 map[int]int
+		result2 error
+	}
+	runningBuildsByTeamReturnsOnCall map[int]struct {
+		result1 map[int]int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildQueue) Dequeue(arg1 int) error {
+	fake.dequeueMutex.Lock()
+	ret, specificReturn := fake.dequeueReturnsOnCall[len(fake.dequeueArgsForCall)]
+	fake.dequeueArgsForCall = append(fake.dequeueArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Dequeue", []interface{}{arg1})
+	fake.dequeueMutex.Unlock()
+	if fake.DequeueStub != nil {
+		return fake.DequeueStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.dequeueReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildQueue) DequeueCallCount() int {
+	fake.dequeueMutex.RLock()
+	defer fake.dequeueMutex.RUnlock()
+	return len(fake.dequeueArgsForCall)
+}
+
+func (fake *FakeBuildQueue) DequeueCalls(stub func(int) error) {
+	fake.dequeueMutex.Lock()
+	defer fake.dequeueMutex.Unlock()
+	fake.DequeueStub = stub
+}
+
+func (fake *FakeBuildQueue) DequeueArgsForCall(i int) int {
+	fake.dequeueMutex.RLock()
+	defer fake.dequeueMutex.RUnlock()
+	argsForCall := fake.dequeueArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildQueue) DequeueReturns(result1 error) {
+	fake.dequeueMutex.Lock()
+	defer fake.dequeueMutex.Unlock()
+	fake.DequeueStub = nil
+	fake.dequeueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildQueue) DequeueReturnsOnCall(i int, result1 error) {
+	fake.dequeueMutex.Lock()
+	defer fake.dequeueMutex.Unlock()
+	fake.DequeueStub = nil
+	if fake.dequeueReturnsOnCall == nil {
+		fake.dequeueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.dequeueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildQueue) Enqueue(arg1 int) error {
+	fake.enqueueMutex.Lock()
+	ret, specificReturn := fake.enqueueReturnsOnCall[len(fake.enqueueArgsForCall)]
+	fake.enqueueArgsForCall = append(fake.enqueueArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Enqueue", []interface{}{arg1})
+	fake.enqueueMutex.Unlock()
+	if fake.EnqueueStub != nil {
+		return fake.EnqueueStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.enqueueReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildQueue) EnqueueCallCount() int {
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	return len(fake.enqueueArgsForCall)
+}
+
+func (fake *FakeBuildQueue) EnqueueCalls(stub func(int) error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = stub
+}
+
+func (fake *FakeBuildQueue) EnqueueArgsForCall(i int) int {
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	argsForCall := fake.enqueueArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildQueue) EnqueueReturns(result1 error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = nil
+	fake.enqueueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildQueue) EnqueueReturnsOnCall(i int, result1 error) {
+	fake.enqueueMutex.Lock()
+	defer fake.enqueueMutex.Unlock()
+	fake.EnqueueStub = nil
+	if fake.enqueueReturnsOnCall == nil {
+		fake.enqueueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enqueueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildQueue) Entries() ([]db.BuildQueueEntry, error) {
+	fake.entriesMutex.Lock()
+	ret, specificReturn := fake.entriesReturnsOnCall[len(fake.entriesArgsForCall)]
+	fake.entriesArgsForCall = append(fake.entriesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Entries", []interface{}{})
+	fake.entriesMutex.Unlock()
+	if fake.EntriesStub != nil {
+		return fake.EntriesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.entriesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildQueue) EntriesCallCount() int {
+	fake.entriesMutex.RLock()
+	defer fake.entriesMutex.RUnlock()
+	return len(fake.entriesArgsForCall)
+}
+
+func (fake *FakeBuildQueue) EntriesCalls(stub func() ([]db.BuildQueueEntry, error)) {
+	fake.entriesMutex.Lock()
+	defer fake.entriesMutex.Unlock()
+	fake.EntriesStub = stub
+}
+
+func (fake *FakeBuildQueue) EntriesReturns(result1 []db.BuildQueueEntry, result2 error) {
+	fake.entriesMutex.Lock()
+	defer fake.entriesMutex.Unlock()
+	fake.EntriesStub = nil
+	fake.entriesReturns = struct {
+		result1 []db.BuildQueueEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildQueue) EntriesReturnsOnCall(i int, result1 []db.BuildQueueEntry, result2 error) {
+	fake.entriesMutex.Lock()
+	defer fake.entriesMutex.Unlock()
+	fake.EntriesStub = nil
+	if fake.entriesReturnsOnCall == nil {
+		fake.entriesReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildQueueEntry
+			result2 error
+		})
+	}
+	fake.entriesReturnsOnCall[i] = struct {
+		result1 []db.BuildQueueEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildQueue) RunningBuildsByTeam() (map[int]int, error) {
+	fake.runningBuildsByTeamMutex.Lock()
+	ret, specificReturn := fake.runningBuildsByTeamReturnsOnCall[len(fake.runningBuildsByTeamArgsForCall)]
+	fake.runningBuildsByTeamArgsForCall = append(fake.runningBuildsByTeamArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RunningBuildsByTeam", []interface{}{})
+	fake.runningBuildsByTeamMutex.Unlock()
+	if fake.RunningBuildsByTeamStub != nil {
+		return fake.RunningBuildsByTeamStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.runningBuildsByTeamReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildQueue) RunningBuildsByTeamCallCount() int {
+	fake.runningBuildsByTeamMutex.RLock()
+	defer fake.runningBuildsByTeamMutex.RUnlock()
+	return len(fake.runningBuildsByTeamArgsForCall)
+}
+
+func (fake *FakeBuildQueue) RunningBuildsByTeamCalls(stub func() (map[int]int, error)) {
+	fake.runningBuildsByTeamMutex.Lock()
+	defer fake.runningBuildsByTeamMutex.Unlock()
+	fake.RunningBuildsByTeamStub = stub
+}
+
+func (fake *FakeBuildQueue) RunningBuildsByTeamReturns(result1 map[int]int, result2 error) {
+	fake.runningBuildsByTeamMutex.Lock()
+	defer fake.runningBuildsByTeamMutex.Unlock()
+	fake.RunningBuildsByTeamStub = nil
+	fake.runningBuildsByTeamReturns = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildQueue) RunningBuildsByTeamReturnsOnCall(i int, result1 map[int]int, result2 error) {
+	fake.runningBuildsByTeamMutex.Lock()
+	defer fake.runningBuildsByTeamMutex.Unlock()
+	fake.RunningBuildsByTeamStub = nil
+	if fake.runningBuildsByTeamReturnsOnCall == nil {
+		fake.runningBuildsByTeamReturnsOnCall = make(map[int]struct {
+			result1 map[int]int
+			result2 error
+		})
+	}
+	fake.runningBuildsByTeamReturnsOnCall[i] = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildQueue) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.dequeueMutex.RLock()
+	defer fake.dequeueMutex.RUnlock()
+	fake.enqueueMutex.RLock()
+	defer fake.enqueueMutex.RUnlock()
+	fake.entriesMutex.RLock()
+	defer fake.entriesMutex.RUnlock()
+	fake.runningBuildsByTeamMutex.RLock()
+	defer fake.runningBuildsByTeamMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildQueue) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.BuildQueue = new(FakeBuildQueue)
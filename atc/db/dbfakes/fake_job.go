@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -91,6 +92,21 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateScheduledBuildStub        func(time.Time) (db.Build, bool, error)
+	createScheduledBuildMutex       sync.RWMutex
+	createScheduledBuildArgsForCall []struct {
+		arg1 time.Time
+	}
+	createScheduledBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	createScheduledBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	DeleteNextInputMappingStub        func() error
 	deleteNextInputMappingMutex       sync.RWMutex
 	deleteNextInputMappingArgsForCall []struct {
@@ -221,6 +237,16 @@ type FakeJob struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	LastScheduledStub        func() time.Time
+	lastScheduledMutex       sync.RWMutex
+	lastScheduledArgsForCall []struct {
+	}
+	lastScheduledReturns struct {
+		result1 time.Time
+	}
+	lastScheduledReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -326,6 +352,17 @@ type FakeJob struct {
 	setHasNewInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetLastScheduledStub        func(time.Time) error
+	setLastScheduledMutex       sync.RWMutex
+	setLastScheduledArgsForCall []struct {
+		arg1 time.Time
+	}
+	setLastScheduledReturns struct {
+		result1 error
+	}
+	setLastScheduledReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetMaxInFlightReachedStub        func(bool) error
 	setMaxInFlightReachedMutex       sync.RWMutex
 	setMaxInFlightReachedArgsForCall []struct {
@@ -761,6 +798,72 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateScheduledBuild(arg1 time.Time) (db.Build, bool, error) {
+	fake.createScheduledBuildMutex.Lock()
+	ret, specificReturn := fake.createScheduledBuildReturnsOnCall[len(fake.createScheduledBuildArgsForCall)]
+	fake.createScheduledBuildArgsForCall = append(fake.createScheduledBuildArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("CreateScheduledBuild", []interface{}{arg1})
+	fake.createScheduledBuildMutex.Unlock()
+	if fake.CreateScheduledBuildStub != nil {
+		return fake.CreateScheduledBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createScheduledBuildReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) CreateScheduledBuildCallCount() int {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	return len(fake.createScheduledBuildArgsForCall)
+}
+
+func (fake *FakeJob) CreateScheduledBuildCalls(stub func(time.Time) (db.Build, bool, error)) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = stub
+}
+
+func (fake *FakeJob) CreateScheduledBuildArgsForCall(i int) time.Time {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	argsForCall := fake.createScheduledBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	fake.createScheduledBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	if fake.createScheduledBuildReturnsOnCall == nil {
+		fake.createScheduledBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createScheduledBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) DeleteNextInputMapping() error {
 	fake.deleteNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.deleteNextInputMappingReturnsOnCall[len(fake.deleteNextInputMappingArgsForCall)]
@@ -1386,6 +1489,58 @@ func (fake *FakeJob) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeJob) LastScheduled() time.Time {
+	fake.lastScheduledMutex.Lock()
+	ret, specificReturn := fake.lastScheduledReturnsOnCall[len(fake.lastScheduledArgsForCall)]
+	fake.lastScheduledArgsForCall = append(fake.lastScheduledArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LastScheduled", []interface{}{})
+	fake.lastScheduledMutex.Unlock()
+	if fake.LastScheduledStub != nil {
+		return fake.LastScheduledStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lastScheduledReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) LastScheduledCallCount() int {
+	fake.lastScheduledMutex.RLock()
+	defer fake.lastScheduledMutex.RUnlock()
+	return len(fake.lastScheduledArgsForCall)
+}
+
+func (fake *FakeJob) LastScheduledCalls(stub func() time.Time) {
+	fake.lastScheduledMutex.Lock()
+	defer fake.lastScheduledMutex.Unlock()
+	fake.LastScheduledStub = stub
+}
+
+func (fake *FakeJob) LastScheduledReturns(result1 time.Time) {
+	fake.lastScheduledMutex.Lock()
+	defer fake.lastScheduledMutex.Unlock()
+	fake.LastScheduledStub = nil
+	fake.lastScheduledReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) LastScheduledReturnsOnCall(i int, result1 time.Time) {
+	fake.lastScheduledMutex.Lock()
+	defer fake.lastScheduledMutex.Unlock()
+	fake.LastScheduledStub = nil
+	if fake.lastScheduledReturnsOnCall == nil {
+		fake.lastScheduledReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastScheduledReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1933,6 +2088,66 @@ func (fake *FakeJob) SetHasNewInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) SetLastScheduled(arg1 time.Time) error {
+	fake.setLastScheduledMutex.Lock()
+	ret, specificReturn := fake.setLastScheduledReturnsOnCall[len(fake.setLastScheduledArgsForCall)]
+	fake.setLastScheduledArgsForCall = append(fake.setLastScheduledArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("SetLastScheduled", []interface{}{arg1})
+	fake.setLastScheduledMutex.Unlock()
+	if fake.SetLastScheduledStub != nil {
+		return fake.SetLastScheduledStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setLastScheduledReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) SetLastScheduledCallCount() int {
+	fake.setLastScheduledMutex.RLock()
+	defer fake.setLastScheduledMutex.RUnlock()
+	return len(fake.setLastScheduledArgsForCall)
+}
+
+func (fake *FakeJob) SetLastScheduledCalls(stub func(time.Time) error) {
+	fake.setLastScheduledMutex.Lock()
+	defer fake.setLastScheduledMutex.Unlock()
+	fake.SetLastScheduledStub = stub
+}
+
+func (fake *FakeJob) SetLastScheduledArgsForCall(i int) time.Time {
+	fake.setLastScheduledMutex.RLock()
+	defer fake.setLastScheduledMutex.RUnlock()
+	argsForCall := fake.setLastScheduledArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) SetLastScheduledReturns(result1 error) {
+	fake.setLastScheduledMutex.Lock()
+	defer fake.setLastScheduledMutex.Unlock()
+	fake.SetLastScheduledStub = nil
+	fake.setLastScheduledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SetLastScheduledReturnsOnCall(i int, result1 error) {
+	fake.setLastScheduledMutex.Lock()
+	defer fake.setLastScheduledMutex.Unlock()
+	fake.SetLastScheduledStub = nil
+	if fake.setLastScheduledReturnsOnCall == nil {
+		fake.setLastScheduledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setLastScheduledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) SetMaxInFlightReached(arg1 bool) error {
 	fake.setMaxInFlightReachedMutex.Lock()
 	ret, specificReturn := fake.setMaxInFlightReachedReturnsOnCall[len(fake.setMaxInFlightReachedArgsForCall)]
@@ -2276,6 +2491,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2298,6 +2515,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.hasNewInputsMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.lastScheduledMutex.RLock()
+	defer fake.lastScheduledMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pauseMutex.RLock()
@@ -2318,6 +2537,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
+	fake.setLastScheduledMutex.RLock()
+	defer fake.setLastScheduledMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
 	fake.tagsMutex.RLock()
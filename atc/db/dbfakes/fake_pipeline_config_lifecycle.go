@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakePipelineConfigLifecycle struct {
+	PruneConfigHistoryStub        func(int) error
+	pruneConfigHistoryMutex       sync.RWMutex
+	pruneConfigHistoryArgsForCall []struct {
+		arg1 int
+	}
+	pruneConfigHistoryReturns struct {
+		result1 error
+	}
+	pruneConfigHistoryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePipelineConfigLifecycle) PruneConfigHistory(arg1 int) error {
+	fake.pruneConfigHistoryMutex.Lock()
+	ret, specificReturn := fake.pruneConfigHistoryReturnsOnCall[len(fake.pruneConfigHistoryArgsForCall)]
+	fake.pruneConfigHistoryArgsForCall = append(fake.pruneConfigHistoryArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("PruneConfigHistory", []interface{}{arg1})
+	fake.pruneConfigHistoryMutex.Unlock()
+	if fake.PruneConfigHistoryStub != nil {
+		return fake.PruneConfigHistoryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pruneConfigHistoryReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipelineConfigLifecycle) PruneConfigHistoryCallCount() int {
+	fake.pruneConfigHistoryMutex.RLock()
+	defer fake.pruneConfigHistoryMutex.RUnlock()
+	return len(fake.pruneConfigHistoryArgsForCall)
+}
+
+func (fake *FakePipelineConfigLifecycle) PruneConfigHistoryCalls(stub func(int) error) {
+	fake.pruneConfigHistoryMutex.Lock()
+	defer fake.pruneConfigHistoryMutex.Unlock()
+	fake.PruneConfigHistoryStub = stub
+}
+
+func (fake *FakePipelineConfigLifecycle) PruneConfigHistoryArgsForCall(i int) int {
+	fake.pruneConfigHistoryMutex.RLock()
+	defer fake.pruneConfigHistoryMutex.RUnlock()
+	argsForCall := fake.pruneConfigHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipelineConfigLifecycle) PruneConfigHistoryReturns(result1 error) {
+	fake.pruneConfigHistoryMutex.Lock()
+	defer fake.pruneConfigHistoryMutex.Unlock()
+	fake.PruneConfigHistoryStub = nil
+	fake.pruneConfigHistoryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineConfigLifecycle) PruneConfigHistoryReturnsOnCall(i int, result1 error) {
+	fake.pruneConfigHistoryMutex.Lock()
+	defer fake.pruneConfigHistoryMutex.Unlock()
+	fake.PruneConfigHistoryStub = nil
+	if fake.pruneConfigHistoryReturnsOnCall == nil {
+		fake.pruneConfigHistoryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pruneConfigHistoryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineConfigLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pruneConfigHistoryMutex.RLock()
+	defer fake.pruneConfigHistoryMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePipelineConfigLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.PipelineConfigLifecycle = new(FakePipelineConfigLifecycle)
@@ -0,0 +1,268 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretAccessRepository struct {
+	RecordSecretAccessesStub        func([]atc.SecretAccess) error
+	recordSecretAccessesMutex       sync.RWMutex
+	recordSecretAccessesArgsForCall []struct {
+		arg1 []atc.SecretAccess
+	}
+	recordSecretAccessesReturns struct {
+		result1 error
+	}
+	recordSecretAccessesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RemoveSecretAccessesBeforeStub        func(time.Time) error
+	removeSecretAccessesBeforeMutex       sync.RWMutex
+	removeSecretAccessesBeforeArgsForCall []struct {
+		arg1 time.Time
+	}
+	removeSecretAccessesBeforeReturns struct {
+		result1 error
+	}
+	removeSecretAccessesBeforeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SecretAccessesStub        func(db.SecretAccessFilter) ([]atc.SecretAccess, error)
+	secretAccessesMutex       sync.RWMutex
+	secretAccessesArgsForCall []struct {
+		arg1 db.SecretAccessFilter
+	}
+	secretAccessesReturns struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
+	secretAccessesReturnsOnCall map[int]struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretAccessRepository) RecordSecretAccesses(arg1 []atc.SecretAccess) error {
+	var arg1Copy []atc.SecretAccess
+	if arg1 != nil {
+		arg1Copy = make([]atc.SecretAccess, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.recordSecretAccessesMutex.Lock()
+	ret, specificReturn := fake.recordSecretAccessesReturnsOnCall[len(fake.recordSecretAccessesArgsForCall)]
+	fake.recordSecretAccessesArgsForCall = append(fake.recordSecretAccessesArgsForCall, struct {
+		arg1 []atc.SecretAccess
+	}{arg1Copy})
+	fake.recordInvocation("RecordSecretAccesses", []interface{}{arg1Copy})
+	fake.recordSecretAccessesMutex.Unlock()
+	if fake.RecordSecretAccessesStub != nil {
+		return fake.RecordSecretAccessesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.recordSecretAccessesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretAccessRepository) RecordSecretAccessesCallCount() int {
+	fake.recordSecretAccessesMutex.RLock()
+	defer fake.recordSecretAccessesMutex.RUnlock()
+	return len(fake.recordSecretAccessesArgsForCall)
+}
+
+func (fake *FakeSecretAccessRepository) RecordSecretAccessesCalls(stub func([]atc.SecretAccess) error) {
+	fake.recordSecretAccessesMutex.Lock()
+	defer fake.recordSecretAccessesMutex.Unlock()
+	fake.RecordSecretAccessesStub = stub
+}
+
+func (fake *FakeSecretAccessRepository) RecordSecretAccessesArgsForCall(i int) []atc.SecretAccess {
+	fake.recordSecretAccessesMutex.RLock()
+	defer fake.recordSecretAccessesMutex.RUnlock()
+	argsForCall := fake.recordSecretAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessRepository) RecordSecretAccessesReturns(result1 error) {
+	fake.recordSecretAccessesMutex.Lock()
+	defer fake.recordSecretAccessesMutex.Unlock()
+	fake.RecordSecretAccessesStub = nil
+	fake.recordSecretAccessesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessRepository) RecordSecretAccessesReturnsOnCall(i int, result1 error) {
+	fake.recordSecretAccessesMutex.Lock()
+	defer fake.recordSecretAccessesMutex.Unlock()
+	fake.RecordSecretAccessesStub = nil
+	if fake.recordSecretAccessesReturnsOnCall == nil {
+		fake.recordSecretAccessesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordSecretAccessesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessRepository) RemoveSecretAccessesBefore(arg1 time.Time) error {
+	fake.removeSecretAccessesBeforeMutex.Lock()
+	ret, specificReturn := fake.removeSecretAccessesBeforeReturnsOnCall[len(fake.removeSecretAccessesBeforeArgsForCall)]
+	fake.removeSecretAccessesBeforeArgsForCall = append(fake.removeSecretAccessesBeforeArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("RemoveSecretAccessesBefore", []interface{}{arg1})
+	fake.removeSecretAccessesBeforeMutex.Unlock()
+	if fake.RemoveSecretAccessesBeforeStub != nil {
+		return fake.RemoveSecretAccessesBeforeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.removeSecretAccessesBeforeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretAccessRepository) RemoveSecretAccessesBeforeCallCount() int {
+	fake.removeSecretAccessesBeforeMutex.RLock()
+	defer fake.removeSecretAccessesBeforeMutex.RUnlock()
+	return len(fake.removeSecretAccessesBeforeArgsForCall)
+}
+
+func (fake *FakeSecretAccessRepository) RemoveSecretAccessesBeforeCalls(stub func(time.Time) error) {
+	fake.removeSecretAccessesBeforeMutex.Lock()
+	defer fake.removeSecretAccessesBeforeMutex.Unlock()
+	fake.RemoveSecretAccessesBeforeStub = stub
+}
+
+func (fake *FakeSecretAccessRepository) RemoveSecretAccessesBeforeArgsForCall(i int) time.Time {
+	fake.removeSecretAccessesBeforeMutex.RLock()
+	defer fake.removeSecretAccessesBeforeMutex.RUnlock()
+	argsForCall := fake.removeSecretAccessesBeforeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessRepository) RemoveSecretAccessesBeforeReturns(result1 error) {
+	fake.removeSecretAccessesBeforeMutex.Lock()
+	defer fake.removeSecretAccessesBeforeMutex.Unlock()
+	fake.RemoveSecretAccessesBeforeStub = nil
+	fake.removeSecretAccessesBeforeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessRepository) RemoveSecretAccessesBeforeReturnsOnCall(i int, result1 error) {
+	fake.removeSecretAccessesBeforeMutex.Lock()
+	defer fake.removeSecretAccessesBeforeMutex.Unlock()
+	fake.RemoveSecretAccessesBeforeStub = nil
+	if fake.removeSecretAccessesBeforeReturnsOnCall == nil {
+		fake.removeSecretAccessesBeforeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeSecretAccessesBeforeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessRepository) SecretAccesses(arg1 db.SecretAccessFilter) ([]atc.SecretAccess, error) {
+	fake.secretAccessesMutex.Lock()
+	ret, specificReturn := fake.secretAccessesReturnsOnCall[len(fake.secretAccessesArgsForCall)]
+	fake.secretAccessesArgsForCall = append(fake.secretAccessesArgsForCall, struct {
+		arg1 db.SecretAccessFilter
+	}{arg1})
+	fake.recordInvocation("SecretAccesses", []interface{}{arg1})
+	fake.secretAccessesMutex.Unlock()
+	if fake.SecretAccessesStub != nil {
+		return fake.SecretAccessesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.secretAccessesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretAccessRepository) SecretAccessesCallCount() int {
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	return len(fake.secretAccessesArgsForCall)
+}
+
+func (fake *FakeSecretAccessRepository) SecretAccessesCalls(stub func(db.SecretAccessFilter) ([]atc.SecretAccess, error)) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = stub
+}
+
+func (fake *FakeSecretAccessRepository) SecretAccessesArgsForCall(i int) db.SecretAccessFilter {
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	argsForCall := fake.secretAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessRepository) SecretAccessesReturns(result1 []atc.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	fake.secretAccessesReturns = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretAccessRepository) SecretAccessesReturnsOnCall(i int, result1 []atc.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	if fake.secretAccessesReturnsOnCall == nil {
+		fake.secretAccessesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretAccess
+			result2 error
+		})
+	}
+	fake.secretAccessesReturnsOnCall[i] = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretAccessRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordSecretAccessesMutex.RLock()
+	defer fake.recordSecretAccessesMutex.RUnlock()
+	fake.removeSecretAccessesBeforeMutex.RLock()
+	defer fake.removeSecretAccessesBeforeMutex.RUnlock()
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretAccessRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretAccessRepository = new(FakeSecretAccessRepository)
@@ -0,0 +1,258 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretCachePurgeRepository struct {
+	LatestSecretCachePurgeIDStub        func() (int, error)
+	latestSecretCachePurgeIDMutex       sync.RWMutex
+	latestSecretCachePurgeIDArgsForCall []struct {
+	}
+	latestSecretCachePurgeIDReturns struct {
+		result1 int
+		result2 error
+	}
+	latestSecretCachePurgeIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	PurgeSecretCacheStub        func(atc.SecretCachePurge) error
+	purgeSecretCacheMutex       sync.RWMutex
+	purgeSecretCacheArgsForCall []struct {
+		arg1 atc.SecretCachePurge
+	}
+	purgeSecretCacheReturns struct {
+		result1 error
+	}
+	purgeSecretCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SecretCachePurgesSinceStub        func(int) ([]atc.SecretCachePurge, error)
+	secretCachePurgesSinceMutex       sync.RWMutex
+	secretCachePurgesSinceArgsForCall []struct {
+		arg1 int
+	}
+	secretCachePurgesSinceReturns struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}
+	secretCachePurgesSinceReturnsOnCall map[int]struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretCachePurgeRepository) LatestSecretCachePurgeID() (int, error) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	ret, specificReturn := fake.latestSecretCachePurgeIDReturnsOnCall[len(fake.latestSecretCachePurgeIDArgsForCall)]
+	fake.latestSecretCachePurgeIDArgsForCall = append(fake.latestSecretCachePurgeIDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LatestSecretCachePurgeID", []interface{}{})
+	fake.latestSecretCachePurgeIDMutex.Unlock()
+	if fake.LatestSecretCachePurgeIDStub != nil {
+		return fake.LatestSecretCachePurgeIDStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.latestSecretCachePurgeIDReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretCachePurgeRepository) LatestSecretCachePurgeIDCallCount() int {
+	fake.latestSecretCachePurgeIDMutex.RLock()
+	defer fake.latestSecretCachePurgeIDMutex.RUnlock()
+	return len(fake.latestSecretCachePurgeIDArgsForCall)
+}
+
+func (fake *FakeSecretCachePurgeRepository) LatestSecretCachePurgeIDCalls(stub func() (int, error)) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	defer fake.latestSecretCachePurgeIDMutex.Unlock()
+	fake.LatestSecretCachePurgeIDStub = stub
+}
+
+func (fake *FakeSecretCachePurgeRepository) LatestSecretCachePurgeIDReturns(result1 int, result2 error) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	defer fake.latestSecretCachePurgeIDMutex.Unlock()
+	fake.LatestSecretCachePurgeIDStub = nil
+	fake.latestSecretCachePurgeIDReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurgeRepository) LatestSecretCachePurgeIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.latestSecretCachePurgeIDMutex.Lock()
+	defer fake.latestSecretCachePurgeIDMutex.Unlock()
+	fake.LatestSecretCachePurgeIDStub = nil
+	if fake.latestSecretCachePurgeIDReturnsOnCall == nil {
+		fake.latestSecretCachePurgeIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.latestSecretCachePurgeIDReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurgeRepository) PurgeSecretCache(arg1 atc.SecretCachePurge) error {
+	fake.purgeSecretCacheMutex.Lock()
+	ret, specificReturn := fake.purgeSecretCacheReturnsOnCall[len(fake.purgeSecretCacheArgsForCall)]
+	fake.purgeSecretCacheArgsForCall = append(fake.purgeSecretCacheArgsForCall, struct {
+		arg1 atc.SecretCachePurge
+	}{arg1})
+	fake.recordInvocation("PurgeSecretCache", []interface{}{arg1})
+	fake.purgeSecretCacheMutex.Unlock()
+	if fake.PurgeSecretCacheStub != nil {
+		return fake.PurgeSecretCacheStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgeSecretCacheReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretCachePurgeRepository) PurgeSecretCacheCallCount() int {
+	fake.purgeSecretCacheMutex.RLock()
+	defer fake.purgeSecretCacheMutex.RUnlock()
+	return len(fake.purgeSecretCacheArgsForCall)
+}
+
+func (fake *FakeSecretCachePurgeRepository) PurgeSecretCacheCalls(stub func(atc.SecretCachePurge) error) {
+	fake.purgeSecretCacheMutex.Lock()
+	defer fake.purgeSecretCacheMutex.Unlock()
+	fake.PurgeSecretCacheStub = stub
+}
+
+func (fake *FakeSecretCachePurgeRepository) PurgeSecretCacheArgsForCall(i int) atc.SecretCachePurge {
+	fake.purgeSecretCacheMutex.RLock()
+	defer fake.purgeSecretCacheMutex.RUnlock()
+	argsForCall := fake.purgeSecretCacheArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretCachePurgeRepository) PurgeSecretCacheReturns(result1 error) {
+	fake.purgeSecretCacheMutex.Lock()
+	defer fake.purgeSecretCacheMutex.Unlock()
+	fake.PurgeSecretCacheStub = nil
+	fake.purgeSecretCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretCachePurgeRepository) PurgeSecretCacheReturnsOnCall(i int, result1 error) {
+	fake.purgeSecretCacheMutex.Lock()
+	defer fake.purgeSecretCacheMutex.Unlock()
+	fake.PurgeSecretCacheStub = nil
+	if fake.purgeSecretCacheReturnsOnCall == nil {
+		fake.purgeSecretCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgeSecretCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretCachePurgeRepository) SecretCachePurgesSince(arg1 int) ([]atc.SecretCachePurge, error) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	ret, specificReturn := fake.secretCachePurgesSinceReturnsOnCall[len(fake.secretCachePurgesSinceArgsForCall)]
+	fake.secretCachePurgesSinceArgsForCall = append(fake.secretCachePurgesSinceArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("SecretCachePurgesSince", []interface{}{arg1})
+	fake.secretCachePurgesSinceMutex.Unlock()
+	if fake.SecretCachePurgesSinceStub != nil {
+		return fake.SecretCachePurgesSinceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.secretCachePurgesSinceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretCachePurgeRepository) SecretCachePurgesSinceCallCount() int {
+	fake.secretCachePurgesSinceMutex.RLock()
+	defer fake.secretCachePurgesSinceMutex.RUnlock()
+	return len(fake.secretCachePurgesSinceArgsForCall)
+}
+
+func (fake *FakeSecretCachePurgeRepository) SecretCachePurgesSinceCalls(stub func(int) ([]atc.SecretCachePurge, error)) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	defer fake.secretCachePurgesSinceMutex.Unlock()
+	fake.SecretCachePurgesSinceStub = stub
+}
+
+func (fake *FakeSecretCachePurgeRepository) SecretCachePurgesSinceArgsForCall(i int) int {
+	fake.secretCachePurgesSinceMutex.RLock()
+	defer fake.secretCachePurgesSinceMutex.RUnlock()
+	argsForCall := fake.secretCachePurgesSinceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretCachePurgeRepository) SecretCachePurgesSinceReturns(result1 []atc.SecretCachePurge, result2 error) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	defer fake.secretCachePurgesSinceMutex.Unlock()
+	fake.SecretCachePurgesSinceStub = nil
+	fake.secretCachePurgesSinceReturns = struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurgeRepository) SecretCachePurgesSinceReturnsOnCall(i int, result1 []atc.SecretCachePurge, result2 error) {
+	fake.secretCachePurgesSinceMutex.Lock()
+	defer fake.secretCachePurgesSinceMutex.Unlock()
+	fake.SecretCachePurgesSinceStub = nil
+	if fake.secretCachePurgesSinceReturnsOnCall == nil {
+		fake.secretCachePurgesSinceReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretCachePurge
+			result2 error
+		})
+	}
+	fake.secretCachePurgesSinceReturnsOnCall[i] = struct {
+		result1 []atc.SecretCachePurge
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretCachePurgeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.latestSecretCachePurgeIDMutex.RLock()
+	defer fake.latestSecretCachePurgeIDMutex.RUnlock()
+	fake.purgeSecretCacheMutex.RLock()
+	defer fake.purgeSecretCacheMutex.RUnlock()
+	fake.secretCachePurgesSinceMutex.RLock()
+	defer fake.secretCachePurgesSinceMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretCachePurgeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretCachePurgeRepository = new(FakeSecretCachePurgeRepository)
@@ -10,6 +10,16 @@ import (
 )
 
 type FakeTx struct {
+	AfterCommitStub        func(func())
+	afterCommitMutex       sync.RWMutex
+	afterCommitArgsForCall []struct {
+		arg1 func()
+	}
+	BeforeCommitStub        func(func() error)
+	beforeCommitMutex       sync.RWMutex
+	beforeCommitArgsForCall []struct {
+		arg1 func() error
+	}
 	CommitStub        func() error
 	commitMutex       sync.RWMutex
 	commitArgsForCall []struct {
@@ -98,6 +108,68 @@ type FakeTx struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTx) AfterCommit(arg1 func()) {
+	fake.afterCommitMutex.Lock()
+	fake.afterCommitArgsForCall = append(fake.afterCommitArgsForCall, struct {
+		arg1 func()
+	}{arg1})
+	fake.recordInvocation("AfterCommit", []interface{}{arg1})
+	fake.afterCommitMutex.Unlock()
+	if fake.AfterCommitStub != nil {
+		fake.AfterCommitStub(arg1)
+	}
+}
+
+func (fake *FakeTx) AfterCommitCallCount() int {
+	fake.afterCommitMutex.RLock()
+	defer fake.afterCommitMutex.RUnlock()
+	return len(fake.afterCommitArgsForCall)
+}
+
+func (fake *FakeTx) AfterCommitCalls(stub func(func())) {
+	fake.afterCommitMutex.Lock()
+	defer fake.afterCommitMutex.Unlock()
+	fake.AfterCommitStub = stub
+}
+
+func (fake *FakeTx) AfterCommitArgsForCall(i int) func() {
+	fake.afterCommitMutex.RLock()
+	defer fake.afterCommitMutex.RUnlock()
+	argsForCall := fake.afterCommitArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTx) BeforeCommit(arg1 func() error) {
+	fake.beforeCommitMutex.Lock()
+	fake.beforeCommitArgsForCall = append(fake.beforeCommitArgsForCall, struct {
+		arg1 func() error
+	}{arg1})
+	fake.recordInvocation("BeforeCommit", []interface{}{arg1})
+	fake.beforeCommitMutex.Unlock()
+	if fake.BeforeCommitStub != nil {
+		fake.BeforeCommitStub(arg1)
+	}
+}
+
+func (fake *FakeTx) BeforeCommitCallCount() int {
+	fake.beforeCommitMutex.RLock()
+	defer fake.beforeCommitMutex.RUnlock()
+	return len(fake.beforeCommitArgsForCall)
+}
+
+func (fake *FakeTx) BeforeCommitCalls(stub func(func() error)) {
+	fake.beforeCommitMutex.Lock()
+	defer fake.beforeCommitMutex.Unlock()
+	fake.BeforeCommitStub = stub
+}
+
+func (fake *FakeTx) BeforeCommitArgsForCall(i int) func() error {
+	fake.beforeCommitMutex.RLock()
+	defer fake.beforeCommitMutex.RUnlock()
+	argsForCall := fake.beforeCommitArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeTx) Commit() error {
 	fake.commitMutex.Lock()
 	ret, specificReturn := fake.commitReturnsOnCall[len(fake.commitArgsForCall)]
@@ -517,6 +589,10 @@ func (fake *FakeTx) StmtReturnsOnCall(i int, result1 *sql.Stmt) {
 func (fake *FakeTx) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.afterCommitMutex.RLock()
+	defer fake.afterCommitMutex.RUnlock()
+	fake.beforeCommitMutex.RLock()
+	defer fake.beforeCommitMutex.RUnlock()
 	fake.commitMutex.RLock()
 	defer fake.commitMutex.RUnlock()
 	fake.execMutex.RLock()
@@ -0,0 +1,335 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWaitingSteps struct {
+	AddStub        func(db.WaitingStep, time.Duration) (int, error)
+	addMutex       sync.RWMutex
+	addArgsForCall []struct {
+		arg1 db.WaitingStep
+		arg2 time.Duration
+	}
+	addReturns struct {
+		result1 int
+		result2 error
+	}
+	addReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	RefreshStub        func(int, time.Duration) error
+	refreshMutex       sync.RWMutex
+	refreshArgsForCall []struct {
+		arg1 int
+		arg2 time.Duration
+	}
+	refreshReturns struct {
+		result1 error
+	}
+	refreshReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RemoveStub        func(int) error
+	removeMutex       sync.RWMutex
+	removeArgsForCall []struct {
+		arg1 int
+	}
+	removeReturns struct {
+		result1 error
+	}
+	removeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StepsStub        func() ([]db.WaitingStep, error)
+	stepsMutex       sync.RWMutex
+	stepsArgsForCall []struct {
+	}
+	stepsReturns struct {
+		result1 []db.WaitingStep
+		result2 error
+	}
+	stepsReturnsOnCall map[int]struct {
+		result1 []db.WaitingStep
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWaitingSteps) Add(arg1 db.WaitingStep, arg2 time.Duration) (int, error) {
+	fake.addMutex.Lock()
+	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
+	fake.addArgsForCall = append(fake.addArgsForCall, struct {
+		arg1 db.WaitingStep
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("Add", []interface{}{arg1, arg2})
+	fake.addMutex.Unlock()
+	if fake.AddStub != nil {
+		return fake.AddStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.addReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWaitingSteps) AddCallCount() int {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	return len(fake.addArgsForCall)
+}
+
+func (fake *FakeWaitingSteps) AddCalls(stub func(db.WaitingStep, time.Duration) (int, error)) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = stub
+}
+
+func (fake *FakeWaitingSteps) AddArgsForCall(i int) (db.WaitingStep, time.Duration) {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	argsForCall := fake.addArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWaitingSteps) AddReturns(result1 int, result2 error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = nil
+	fake.addReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingSteps) AddReturnsOnCall(i int, result1 int, result2 error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = nil
+	if fake.addReturnsOnCall == nil {
+		fake.addReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.addReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingSteps) Refresh(arg1 int, arg2 time.Duration) error {
+	fake.refreshMutex.Lock()
+	ret, specificReturn := fake.refreshReturnsOnCall[len(fake.refreshArgsForCall)]
+	fake.refreshArgsForCall = append(fake.refreshArgsForCall, struct {
+		arg1 int
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("Refresh", []interface{}{arg1, arg2})
+	fake.refreshMutex.Unlock()
+	if fake.RefreshStub != nil {
+		return fake.RefreshStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.refreshReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWaitingSteps) RefreshCallCount() int {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	return len(fake.refreshArgsForCall)
+}
+
+func (fake *FakeWaitingSteps) RefreshCalls(stub func(int, time.Duration) error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = stub
+}
+
+func (fake *FakeWaitingSteps) RefreshArgsForCall(i int) (int, time.Duration) {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	argsForCall := fake.refreshArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWaitingSteps) RefreshReturns(result1 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	fake.refreshReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingSteps) RefreshReturnsOnCall(i int, result1 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	if fake.refreshReturnsOnCall == nil {
+		fake.refreshReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.refreshReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingSteps) Remove(arg1 int) error {
+	fake.removeMutex.Lock()
+	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
+	fake.removeArgsForCall = append(fake.removeArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Remove", []interface{}{arg1})
+	fake.removeMutex.Unlock()
+	if fake.RemoveStub != nil {
+		return fake.RemoveStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.removeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWaitingSteps) RemoveCallCount() int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return len(fake.removeArgsForCall)
+}
+
+func (fake *FakeWaitingSteps) RemoveCalls(stub func(int) error) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = stub
+}
+
+func (fake *FakeWaitingSteps) RemoveArgsForCall(i int) int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	argsForCall := fake.removeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWaitingSteps) RemoveReturns(result1 error) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = nil
+	fake.removeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingSteps) RemoveReturnsOnCall(i int, result1 error) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = nil
+	if fake.removeReturnsOnCall == nil {
+		fake.removeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingSteps) Steps() ([]db.WaitingStep, error) {
+	fake.stepsMutex.Lock()
+	ret, specificReturn := fake.stepsReturnsOnCall[len(fake.stepsArgsForCall)]
+	fake.stepsArgsForCall = append(fake.stepsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Steps", []interface{}{})
+	fake.stepsMutex.Unlock()
+	if fake.StepsStub != nil {
+		return fake.StepsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.stepsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWaitingSteps) StepsCallCount() int {
+	fake.stepsMutex.RLock()
+	defer fake.stepsMutex.RUnlock()
+	return len(fake.stepsArgsForCall)
+}
+
+func (fake *FakeWaitingSteps) StepsCalls(stub func() ([]db.WaitingStep, error)) {
+	fake.stepsMutex.Lock()
+	defer fake.stepsMutex.Unlock()
+	fake.StepsStub = stub
+}
+
+func (fake *FakeWaitingSteps) StepsReturns(result1 []db.WaitingStep, result2 error) {
+	fake.stepsMutex.Lock()
+	defer fake.stepsMutex.Unlock()
+	fake.StepsStub = nil
+	fake.stepsReturns = struct {
+		result1 []db.WaitingStep
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingSteps) StepsReturnsOnCall(i int, result1 []db.WaitingStep, result2 error) {
+	fake.stepsMutex.Lock()
+	defer fake.stepsMutex.Unlock()
+	fake.StepsStub = nil
+	if fake.stepsReturnsOnCall == nil {
+		fake.stepsReturnsOnCall = make(map[int]struct {
+			result1 []db.WaitingStep
+			result2 error
+		})
+	}
+	fake.stepsReturnsOnCall[i] = struct {
+		result1 []db.WaitingStep
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingSteps) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	fake.stepsMutex.RLock()
+	defer fake.stepsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWaitingSteps) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WaitingSteps = new(FakeWaitingSteps)
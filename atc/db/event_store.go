@@ -0,0 +1,126 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+//go:generate counterfeiter . EventStore
+
+// EventStore stores the events emitted by builds, i.e. their logs. Events are
+// stored in the database unless another store is given to Open.
+type EventStore interface {
+	// Put appends the event to the build's log. It is called within the
+	// transaction which records the event, e.g. along with a change to the
+	// build's status, and the event must only be stored if it commits. Stores
+	// which write events once the transaction has committed do so before its
+	// Commit returns, and notify the build's BuildEventsChannel on conn if
+	// they could only be written later.
+	Put(conn Conn, tx Tx, build Build, event atc.Event) error
+
+	// Get returns at most limit events from the build's log, skipping the
+	// first offset events.
+	Get(conn Conn, build Build, offset uint, limit int) ([]event.Envelope, error)
+
+	// Finalize is called within the transaction which completes the build,
+	// after which no more events will be put. The build's events may be
+	// written just after it commits, so readers stop reading the build's
+	// events once it is completed and its final status has been read.
+	Finalize(tx Tx, build Build) error
+
+	// Delete removes the logs of the given builds.
+	Delete(tx Tx, buildIDs []int) error
+}
+
+// PostgresEventStore stores events in the build_events tables, partitioned by
+// team and pipeline.
+type PostgresEventStore struct{}
+
+func (PostgresEventStore) Put(conn Conn, tx Tx, build Build, event atc.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert(buildEventsTable(build)).
+		Columns("event_id", "build_id", "type", "version", "payload").
+		Values(sq.Expr("nextval('"+buildEventSeq(build.ID())+"')"), build.ID(), string(event.EventType()), string(event.Version()), payload).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func (PostgresEventStore) Get(conn Conn, build Build, offset uint, limit int) ([]event.Envelope, error) {
+	rows, err := conn.Query(`
+		SELECT type, version, payload
+		FROM `+buildEventsTable(build)+`
+		WHERE build_id = $1
+		ORDER BY event_id ASC
+		OFFSET $2
+		LIMIT $3
+	`, build.ID(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var events []event.Envelope
+	for rows.Next() {
+		var t, v, p string
+		err := rows.Scan(&t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		data := json.RawMessage(p)
+
+		events = append(events, event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+	}
+
+	return events, rows.Err()
+}
+
+func (PostgresEventStore) Finalize(tx Tx, build Build) error {
+	return nil
+}
+
+func (PostgresEventStore) Delete(tx Tx, buildIDs []int) error {
+	if len(buildIDs) == 0 {
+		return nil
+	}
+
+	interfaceBuildIDs := make([]interface{}, len(buildIDs))
+	for i, buildID := range buildIDs {
+		interfaceBuildIDs[i] = buildID
+	}
+
+	indexStrings := make([]string, len(buildIDs))
+	for i := range indexStrings {
+		indexStrings[i] = "$" + strconv.Itoa(i+1)
+	}
+
+	_, err := tx.Exec(`
+		DELETE FROM build_events
+		WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
+	`, interfaceBuildIDs...)
+	return err
+}
+
+func buildEventsTable(build Build) string {
+	if build.PipelineID() != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", build.PipelineID())
+	}
+
+	return fmt.Sprintf("team_build_events_%d", build.TeamID())
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -55,9 +56,13 @@ type Job interface {
 
 	SetHasNewInputs(bool) error
 	HasNewInputs() bool
+
+	LastScheduled() time.Time
+	SetLastScheduled(time.Time) error
+	CreateScheduledBuild(scheduledAt time.Time) (Build, bool, error)
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.last_scheduled").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -84,6 +89,7 @@ type job struct {
 	config             atc.JobConfig
 	tags               []string
 	hasNewInputs       bool
+	lastScheduled      time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -123,18 +129,19 @@ func (jobs Jobs) Configs() atc.JobConfigs {
 	return configs
 }
 
-func (j *job) ID() int                 { return j.id }
-func (j *job) Name() string            { return j.name }
-func (j *job) Paused() bool            { return j.paused }
-func (j *job) FirstLoggedBuildID() int { return j.firstLoggedBuildID }
-func (j *job) PipelineID() int         { return j.pipelineID }
-func (j *job) PipelineName() string    { return j.pipelineName }
-func (j *job) TeamID() int             { return j.teamID }
-func (j *job) TeamName() string        { return j.teamName }
-func (j *job) Config() atc.JobConfig   { return j.config }
-func (j *job) Tags() []string          { return j.tags }
-func (j *job) Public() bool            { return j.Config().Public }
-func (j *job) HasNewInputs() bool      { return j.hasNewInputs }
+func (j *job) ID() int                  { return j.id }
+func (j *job) Name() string             { return j.name }
+func (j *job) Paused() bool             { return j.paused }
+func (j *job) FirstLoggedBuildID() int  { return j.firstLoggedBuildID }
+func (j *job) PipelineID() int          { return j.pipelineID }
+func (j *job) PipelineName() string     { return j.pipelineName }
+func (j *job) TeamID() int              { return j.teamID }
+func (j *job) TeamName() string         { return j.teamName }
+func (j *job) Config() atc.JobConfig    { return j.config }
+func (j *job) Tags() []string           { return j.tags }
+func (j *job) LastScheduled() time.Time { return j.lastScheduled }
+func (j *job) Public() bool             { return j.Config().Public }
+func (j *job) HasNewInputs() bool       { return j.hasNewInputs }
 
 func (j *job) Reload() (bool, error) {
 	row := jobsQuery.Where(sq.Eq{"j.id": j.id}).
@@ -524,6 +531,23 @@ func (j *job) CreateBuild() (Build, error) {
 
 	defer Rollback(tx)
 
+	build, err := j.createTriggeredBuild(tx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// createTriggeredBuild creates a build which was asked for, rather than
+// created for new versions of the job's inputs, either by a user or by the
+// job's schedule.
+func (j *job) createTriggeredBuild(tx Tx, scheduled bool) (Build, error) {
 	buildName, err := j.getNewBuildName(tx)
 	if err != nil {
 		return nil, err
@@ -536,7 +560,8 @@ func (j *job) CreateBuild() (Build, error) {
 		"pipeline_id":        j.pipelineID,
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
-		"manually_triggered": true,
+		"manually_triggered": !scheduled,
+		"schedule_triggered": scheduled,
 	})
 	if err != nil {
 		return nil, err
@@ -547,12 +572,81 @@ func (j *job) CreateBuild() (Build, error) {
 		return nil, err
 	}
 
+	return build, nil
+}
+
+// SetLastScheduled records the most recent time the job's schedule was
+// considered, without creating a build.
+func (j *job) SetLastScheduled(scheduledAt time.Time) error {
+	result, err := psql.Update("jobs").
+		Set("last_scheduled", scheduledAt).
+		Where(sq.Eq{"id": j.id}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	j.lastScheduled = scheduledAt
+
+	return nil
+}
+
+// CreateScheduledBuild creates a build for the scheduled time, unless a
+// build has already been scheduled at or after it. It returns false if no
+// build was created.
+func (j *job) CreateScheduledBuild(scheduledAt time.Time) (Build, bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("jobs").
+		Set("last_scheduled", scheduledAt).
+		Where(sq.Eq{"id": j.id}).
+		Where(sq.Or{
+			sq.Eq{"last_scheduled": nil},
+			sq.Lt{"last_scheduled": scheduledAt},
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, false, nil
+	}
+
+	build, err := j.createTriggeredBuild(tx, true)
+	if err != nil {
+		return nil, false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return build, nil
+	j.lastScheduled = scheduledAt
+
+	return build, true, nil
 }
 
 func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
@@ -825,15 +919,18 @@ func (j *job) finishedBuild() (Build, error) {
 
 func scanJob(j *job, row scannable) error {
 	var (
-		configBlob []byte
-		nonce      sql.NullString
+		configBlob    []byte
+		nonce         sql.NullString
+		lastScheduled pq.NullTime
 	)
 
-	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs)
+	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &lastScheduled)
 	if err != nil {
 		return err
 	}
 
+	j.lastScheduled = lastScheduled.Time
+
 	es := j.conn.EncryptionStrategy()
 
 	var noncense *string
@@ -23,7 +23,7 @@ var _ = Describe("Job", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", atc.Config{
+		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 			Jobs: atc.JobConfigs{
 				{
 					Name: "some-job",
@@ -157,7 +157,7 @@ var _ = Describe("Job", func() {
 		BeforeEach(func() {
 			var created bool
 			var err error
-			otherPipeline, created, err = team.SavePipeline("other-pipeline", atc.Config{
+			otherPipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{Name: "some-job"},
 				},
@@ -387,7 +387,7 @@ var _ = Describe("Job", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -788,7 +788,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 
-			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, db.PipelineUnpaused)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline-2"}, config, 1, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource2, found, err = pipeline2.Resource("some-resource")
@@ -968,7 +968,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 
-			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, db.PipelineUnpaused)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline-2"}, config, 1, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource2, found, err = pipeline2.Resource("some-resource")
@@ -1120,7 +1120,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("some-other-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-other-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			build1DB, err = job.CreateBuild()
@@ -1318,6 +1318,86 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateScheduledBuild", func() {
+		var scheduledAt time.Time
+
+		BeforeEach(func() {
+			scheduledAt = time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC)
+		})
+
+		It("creates a pending build triggered by the schedule and records the scheduled time", func() {
+			build, created, err := job.CreateScheduledBuild(scheduledAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.IsScheduleTriggered()).To(BeTrue())
+			Expect(build.IsManuallyTriggered()).To(BeFalse())
+			Expect(job.LastScheduled()).To(BeTemporally("==", scheduledAt))
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.LastScheduled()).To(BeTemporally("==", scheduledAt))
+
+			_, nextBuild, err := job.FinishedAndNextBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextBuild.ID()).To(Equal(build.ID()))
+		})
+
+		Context("when a build has already been scheduled for that time", func() {
+			BeforeEach(func() {
+				_, created, err := job.CreateScheduledBuild(scheduledAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+			})
+
+			It("does not create another build", func() {
+				_, created, err := job.CreateScheduledBuild(scheduledAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeFalse())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+			})
+		})
+
+		Context("when a later time has already been recorded", func() {
+			BeforeEach(func() {
+				err := job.SetLastScheduled(scheduledAt.Add(time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not create a build", func() {
+				_, created, err := job.CreateScheduledBuild(scheduledAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeFalse())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SetLastScheduled", func() {
+		It("starts out unset", func() {
+			Expect(job.LastScheduled().IsZero()).To(BeTrue())
+		})
+
+		It("records the time", func() {
+			scheduledAt := time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC)
+
+			err := job.SetLastScheduled(scheduledAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.LastScheduled()).To(BeTemporally("==", scheduledAt))
+		})
+	})
+
 	Describe("Clear task cache", func() {
 		Context("when task cache exists", func() {
 			var (
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE pipelines DROP COLUMN archived;
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+  CREATE TABLE team_webhooks (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL,
+    name text NOT NULL,
+    type text NOT NULL,
+    secret text NOT NULL,
+    nonce text
+  );
+
+  CREATE UNIQUE INDEX team_webhooks_team_id_name_uniq
+    ON team_webhooks (team_id, name);
+
+  ALTER TABLE ONLY team_webhooks
+    ADD CONSTRAINT team_webhooks_team_id_fkey FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE workers DROP COLUMN namespace;
+  ALTER TABLE workers DROP COLUMN runtime;
+
+COMMIT;
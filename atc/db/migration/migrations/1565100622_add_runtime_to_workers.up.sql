@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE workers ADD COLUMN runtime text NOT NULL DEFAULT 'garden';
+  ALTER TABLE workers ADD COLUMN namespace text;
+
+COMMIT;
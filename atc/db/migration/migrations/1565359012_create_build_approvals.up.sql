@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE build_approvals (
+    build_id integer NOT NULL,
+    plan_id text NOT NULL,
+    approved boolean NOT NULL,
+    approved_by text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (build_id, plan_id)
+  );
+
+  ALTER TABLE ONLY build_approvals
+    ADD CONSTRAINT build_approvals_build_id_fkey FOREIGN KEY (build_id) REFERENCES builds(id) ON DELETE CASCADE;
+COMMIT;
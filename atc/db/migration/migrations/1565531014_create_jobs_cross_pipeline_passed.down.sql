@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE jobs_cross_pipeline_passed;
+COMMIT;
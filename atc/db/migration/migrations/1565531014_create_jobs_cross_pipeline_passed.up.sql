@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE jobs_cross_pipeline_passed (
+    job_id integer NOT NULL,
+    passed_pipeline_name text NOT NULL,
+    passed_job_name text NOT NULL,
+    PRIMARY KEY (job_id, passed_pipeline_name, passed_job_name)
+  );
+
+  ALTER TABLE ONLY jobs_cross_pipeline_passed
+    ADD CONSTRAINT jobs_cross_pipeline_passed_job_id_fkey FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE;
+
+  CREATE INDEX jobs_cross_pipeline_passed_passed_idx ON jobs_cross_pipeline_passed (passed_pipeline_name, passed_job_name);
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE teams
+    DROP COLUMN max_running_builds,
+    DROP COLUMN max_containers,
+    DROP COLUMN max_volumes;
+COMMIT;
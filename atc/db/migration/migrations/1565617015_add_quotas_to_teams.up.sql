@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE teams
+    ADD COLUMN max_running_builds integer NOT NULL DEFAULT 0,
+    ADD COLUMN max_containers integer NOT NULL DEFAULT 0,
+    ADD COLUMN max_volumes integer NOT NULL DEFAULT 0;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+  DROP TABLE build_queue;
+
+  ALTER TABLE jobs
+    DROP COLUMN priority;
+COMMIT;
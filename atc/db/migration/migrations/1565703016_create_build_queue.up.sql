@@ -0,0 +1,9 @@
+BEGIN;
+  ALTER TABLE jobs
+    ADD COLUMN priority integer NOT NULL DEFAULT 0;
+
+  CREATE TABLE build_queue (
+    build_id integer PRIMARY KEY REFERENCES builds (id) ON DELETE CASCADE,
+    queued_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers
+    ADD COLUMN max_containers integer NOT NULL DEFAULT 0;
+COMMIT;
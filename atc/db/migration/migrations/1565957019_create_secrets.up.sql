@@ -0,0 +1,17 @@
+BEGIN;
+  CREATE TABLE secrets (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL,
+    pipeline_name text NOT NULL DEFAULT '',
+    name text NOT NULL,
+    value text NOT NULL,
+    nonce text,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE UNIQUE INDEX secrets_team_id_pipeline_name_name_uniq
+    ON secrets (team_id, pipeline_name, name);
+
+  ALTER TABLE ONLY secrets
+    ADD CONSTRAINT secrets_team_id_fkey FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE;
+COMMIT;
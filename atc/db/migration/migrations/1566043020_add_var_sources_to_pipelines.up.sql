@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE pipelines ADD COLUMN var_sources text, ADD COLUMN nonce text;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+  CREATE TABLE secret_accesses (
+    id bigserial PRIMARY KEY,
+    path text NOT NULL,
+    manager text NOT NULL,
+    team_name text NOT NULL DEFAULT '',
+    pipeline_name text NOT NULL DEFAULT '',
+    build_id integer,
+    user_name text NOT NULL DEFAULT '',
+    accessed_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX secret_accesses_path_idx ON secret_accesses (path);
+
+  CREATE INDEX secret_accesses_build_id_idx ON secret_accesses (build_id);
+
+  CREATE INDEX secret_accesses_accessed_at_idx ON secret_accesses (accessed_at);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE secret_cache_purges;
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+  CREATE TABLE secret_cache_purges (
+    id bigserial PRIMARY KEY,
+    team_name text NOT NULL,
+    pipeline_name text NOT NULL DEFAULT '',
+    path text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+COMMIT;
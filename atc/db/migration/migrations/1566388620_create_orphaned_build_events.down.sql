@@ -0,0 +1,5 @@
+BEGIN;
+  DROP TRIGGER IF EXISTS orphaned_build_events_delete_trigger ON builds;
+  DROP FUNCTION IF EXISTS on_build_delete();
+  DROP TABLE orphaned_build_events;
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE orphaned_build_events (
+    build_id integer PRIMARY KEY
+  );
+
+  CREATE OR REPLACE FUNCTION on_build_delete() RETURNS TRIGGER AS $$
+  BEGIN
+          INSERT INTO orphaned_build_events (build_id) VALUES (OLD.id) ON CONFLICT DO NOTHING;
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE TRIGGER orphaned_build_events_delete_trigger AFTER DELETE ON builds FOR EACH ROW EXECUTE PROCEDURE on_build_delete();
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE waiting_steps;
+COMMIT;
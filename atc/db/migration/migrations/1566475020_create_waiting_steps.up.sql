@@ -0,0 +1,11 @@
+BEGIN;
+  CREATE TABLE waiting_steps (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL DEFAULT 0,
+    tags text[] NOT NULL DEFAULT '{}',
+    platform text NOT NULL DEFAULT '',
+    expires_at timestamp with time zone NOT NULL
+  );
+
+  CREATE INDEX waiting_steps_expires_at_idx ON waiting_steps (expires_at);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN schedule_triggered boolean NOT NULL DEFAULT false;
+COMMIT;
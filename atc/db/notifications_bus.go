@@ -7,6 +7,8 @@ import (
 	"github.com/lib/pq"
 )
 
+//go:generate counterfeiter . NotificationsBus
+
 type NotificationsBus interface {
 	Notify(channel string) error
 	Listen(channel string) (chan bool, error)
@@ -23,6 +23,7 @@ import (
 type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() encryption.Strategy
+	EventStore() EventStore
 
 	Ping() error
 	Driver() driver.Driver
@@ -44,6 +45,16 @@ type Conn interface {
 //go:generate counterfeiter . Tx
 
 type Tx interface {
+	// BeforeCommit registers a callback to run once all of the transaction's
+	// statements have succeeded, just before it is committed. If a callback
+	// returns an error the transaction is rolled back instead.
+	BeforeCommit(func() error)
+
+	// AfterCommit registers a callback to run once the transaction has
+	// committed, before Commit returns. It is not run if the transaction is
+	// rolled back or fails to commit.
+	AfterCommit(func())
+
 	Commit() error
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	Prepare(query string) (*sql.Stmt, error)
@@ -53,7 +64,11 @@ type Tx interface {
 	Stmt(stmt *sql.Stmt) *sql.Stmt
 }
 
-func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *encryption.Key, oldKey *encryption.Key, connectionName string, lockFactory lock.LockFactory) (Conn, error) {
+func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *encryption.Key, oldKey *encryption.Key, eventStore EventStore, connectionName string, lockFactory lock.LockFactory) (Conn, error) {
+	if eventStore == nil {
+		eventStore = PostgresEventStore{}
+	}
+
 	for {
 		var strategy encryption.Strategy
 		if newKey != nil {
@@ -97,6 +112,7 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 
 			bus:        NewNotificationsBus(listener, sqlDb),
 			encryption: strategy,
+			eventStore: eventStore,
 			name:       connectionName,
 		}, nil
 	}
@@ -127,6 +143,10 @@ var encryptedColumns = []encryptedColumn{
 	{"resource_types", "config", "id"},
 	{"builds", "private_plan", "id"},
 	{"cert_cache", "cert", "domain"},
+	{"team_webhooks", "secret", "id"},
+	{"secrets", "value", "id"},
+	{"pipeline_configs", "config", "id"},
+	{"pipelines", "var_sources", "id"},
 }
 
 func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) error {
@@ -339,6 +359,7 @@ type db struct {
 
 	bus        NotificationsBus
 	encryption encryption.Strategy
+	eventStore EventStore
 	name       string
 }
 
@@ -354,6 +375,10 @@ func (db *db) EncryptionStrategy() encryption.Strategy {
 	return db.encryption
 }
 
+func (db *db) EventStore() EventStore {
+	return db.eventStore
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
@@ -381,7 +406,7 @@ func (db *db) Begin() (Tx, error) {
 		return nil, err
 	}
 
-	return &dbTx{tx, GlobalConnectionTracker.Track()}, nil
+	return &dbTx{Tx: tx, session: GlobalConnectionTracker.Track()}, nil
 }
 
 func (db *db) Exec(query string, args ...interface{}) (sql.Result, error) {
@@ -409,6 +434,17 @@ type dbTx struct {
 	*sql.Tx
 
 	session *ConnectionSession
+
+	beforeCommit []func() error
+	afterCommit  []func()
+}
+
+func (tx *dbTx) BeforeCommit(callback func() error) {
+	tx.beforeCommit = append(tx.beforeCommit, callback)
+}
+
+func (tx *dbTx) AfterCommit(callback func()) {
+	tx.afterCommit = append(tx.afterCommit, callback)
 }
 
 // to conform to squirrel.Runner interface
@@ -418,7 +454,25 @@ func (tx *dbTx) QueryRow(query string, args ...interface{}) squirrel.RowScanner
 
 func (tx *dbTx) Commit() error {
 	defer tx.session.Release()
-	return tx.Tx.Commit()
+
+	for _, callback := range tx.beforeCommit {
+		err := callback()
+		if err != nil {
+			_ = tx.Tx.Rollback()
+			return err
+		}
+	}
+
+	err := tx.Tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, callback := range tx.afterCommit {
+		callback()
+	}
+
+	return nil
 }
 
 func (tx *dbTx) Rollback() error {
@@ -34,12 +34,15 @@ type Cause struct {
 type Pipeline interface {
 	ID() int
 	Name() string
+	InstanceVars() atc.InstanceVars
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
+	VarSources() atc.VarSourceConfigs
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	Archived() bool
 
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
@@ -75,12 +78,18 @@ type Pipeline interface {
 	Jobs() (Jobs, error)
 	Dashboard() (Dashboard, error)
 
+	Config() (atc.Config, error)
+	ConfigHistory() ([]PipelineConfigVersion, error)
+	ConfigAtVersion(version ConfigVersion) (PipelineConfigVersion, bool, error)
+
 	Expose() error
 	Hide() error
 
 	Pause() error
 	Unpause() error
 
+	Archive() error
+
 	Destroy() error
 	Rename(string) error
 }
@@ -88,12 +97,15 @@ type Pipeline interface {
 type pipeline struct {
 	id            int
 	name          string
+	instanceVars  atc.InstanceVars
 	teamID        int
 	teamName      string
 	groups        atc.GroupConfigs
+	varSources    atc.VarSourceConfigs
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
+	archived      bool
 
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
@@ -102,7 +114,7 @@ type pipeline struct {
 	lockFactory lock.LockFactory
 }
 
-//ConfigVersion is a sequence identifier used for compare-and-swap
+// ConfigVersion is a sequence identifier used for compare-and-swap
 type ConfigVersion int
 
 type PipelinePausedState string
@@ -110,12 +122,16 @@ type PipelinePausedState string
 var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
+		p.instance_vars,
 		p.groups,
 		p.version,
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		p.archived,
+		p.var_sources,
+		p.nonce
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -149,14 +165,17 @@ func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                      { return p.id }
-func (p *pipeline) Name() string                 { return p.name }
-func (p *pipeline) TeamID() int                  { return p.teamID }
-func (p *pipeline) TeamName() string             { return p.teamName }
-func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
-func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
-func (p *pipeline) Public() bool                 { return p.public }
-func (p *pipeline) Paused() bool                 { return p.paused }
+func (p *pipeline) ID() int                          { return p.id }
+func (p *pipeline) Name() string                     { return p.name }
+func (p *pipeline) InstanceVars() atc.InstanceVars   { return p.instanceVars }
+func (p *pipeline) TeamID() int                      { return p.teamID }
+func (p *pipeline) TeamName() string                 { return p.teamName }
+func (p *pipeline) Groups() atc.GroupConfigs         { return p.groups }
+func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
+func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
+func (p *pipeline) Public() bool                     { return p.public }
+func (p *pipeline) Paused() bool                     { return p.paused }
+func (p *pipeline) Archived() bool                   { return p.archived }
 
 // IMPORTANT: This method is broken with the new resource config versions changes
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
@@ -568,6 +587,31 @@ func (p *pipeline) Jobs() (Jobs, error) {
 	return jobs, err
 }
 
+func (p *pipeline) Config() (atc.Config, error) {
+	jobs, err := p.Jobs()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resources, err := p.Resources()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resourceTypes, err := p.ResourceTypes()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return atc.Config{
+		Groups:        p.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+		VarSources:    p.VarSources(),
+	}, nil
+}
+
 func (p *pipeline) Dashboard() (Dashboard, error) {
 	dashboard := Dashboard{}
 
@@ -641,6 +685,56 @@ func (p *pipeline) Unpause() error {
 	return err
 }
 
+// Archive pauses the pipeline and stops it from being scheduled or checked
+// until it is configured again, while keeping its build history. The
+// pipeline's resources no longer hold on to their resource configs, so that
+// they can be garbage collected.
+func (p *pipeline) Archive() error {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("pipelines").
+		Set("archived", true).
+		Set("paused", true).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resources").
+		Set("resource_config_id", nil).
+		Set("resource_config_scope_id", nil).
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resource_types").
+		Set("resource_config_id", nil).
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (p *pipeline) Hide() error {
 	_, err := psql.Update("pipelines").
 		Set("public", false).
@@ -665,25 +759,92 @@ func (p *pipeline) Expose() error {
 	return err
 }
 
+// Rename also carries the pipeline's secrets over to its new name. They are
+// only removed from the old name once no other instance of the pipeline has
+// it, as secrets are shared by every instance.
 func (p *pipeline) Rename(name string) error {
-	_, err := psql.Update("pipelines").
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("pipelines").
 		Set("name", name).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
-		RunWith(p.conn).
+		RunWith(tx).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	// secrets already set for the new name take precedence
+	_, err = tx.Exec(`
+		INSERT INTO secrets (team_id, pipeline_name, name, value, nonce, updated_at)
+		SELECT team_id, $1, name, value, nonce, updated_at
+		FROM secrets
+		WHERE team_id = $2 AND pipeline_name = $3
+		ON CONFLICT (team_id, pipeline_name, name) DO NOTHING
+	`, name, p.teamID, p.name)
+	if err != nil {
+		return err
+	}
+
+	err = deleteOrphanedSecrets(tx, p.teamID, p.name)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	p.name = name
+
+	return nil
 }
 
+// Destroy also removes the pipeline's secrets once no other instance of the
+// pipeline has its name, so that a new pipeline of that name does not
+// inherit them.
 func (p *pipeline) Destroy() error {
-	_, err := psql.Delete("pipelines").
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("pipelines").
 		Where(sq.Eq{
 			"id": p.id,
 		}).
-		RunWith(p.conn).
+		RunWith(tx).
 		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = deleteOrphanedSecrets(tx, p.teamID, p.name)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func deleteOrphanedSecrets(tx Tx, teamID int, pipelineName string) error {
+	_, err := tx.Exec(`
+		DELETE FROM secrets
+		WHERE team_id = $1 AND pipeline_name = $2
+		AND NOT EXISTS (
+			SELECT 1 FROM pipelines WHERE team_id = $1 AND name = $2
+		)
+	`, teamID, pipelineName)
 
 	return err
 }
@@ -864,12 +1025,102 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceIDs[name] = id
 	}
 
+	// passed constraints can only name pipelines without instance vars, which
+	// is checked when the config is validated
+	rows, err = psql.Select("DISTINCT x.passed_pipeline_name, x.passed_job_name, pj.id").
+		From("jobs_cross_pipeline_passed x").
+		Join("jobs j ON j.id = x.job_id").
+		Join("pipelines pp ON pp.name = x.passed_pipeline_name").
+		Join("jobs pj ON pj.pipeline_id = pp.id AND pj.name = x.passed_job_name").
+		Where(sq.Eq{
+			"j.pipeline_id":    p.id,
+			"pp.team_id":       p.teamID,
+			"pp.instance_vars": nil,
+			"pj.active":        true,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var crossPipelineJobIDs []int
+	for rows.Next() {
+		var pipelineName, jobName string
+		var id int
+		err = rows.Scan(&pipelineName, &jobName, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.JobIDs[pipelineName+"/"+jobName] = id
+		crossPipelineJobIDs = append(crossPipelineJobIDs, id)
+	}
+
+	if len(crossPipelineJobIDs) > 0 {
+		outputs, err := p.crossPipelineBuildOutputs(crossPipelineJobIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		db.BuildOutputs = append(db.BuildOutputs, outputs...)
+	}
+
 	p.versionsDB = db
 	p.cacheIndex = cacheIndex
 
 	return db, nil
 }
 
+// crossPipelineBuildOutputs loads the versions that succeeded through jobs
+// in other pipelines. Versions are only shared between pipelines when their
+// resources have the same resource config scope, so each output is
+// attributed to the resource in this pipeline that shares its scope.
+func (p *pipeline) crossPipelineBuildOutputs(jobIDs []int) ([]algorithm.BuildOutput, error) {
+	outputs := []algorithm.BuildOutput{}
+
+	// a build's inputs are implicitly outputs once it succeeds
+	for _, table := range []string{"build_resource_config_version_outputs", "build_resource_config_version_inputs"} {
+		rows, err := psql.Select("DISTINCT v.id, v.check_order, lr.id, o.build_id, b.job_id").
+			From(table + " o").
+			Join("builds b ON b.id = o.build_id").
+			Join("resources r ON r.id = o.resource_id").
+			Join("resource_config_versions v ON v.version_md5 = o.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+			Join("resources lr ON lr.resource_config_scope_id = v.resource_config_scope_id").
+			Where(sq.Expr("(lr.id, v.version_md5) NOT IN (SELECT resource_id, version_md5 from resource_disabled_versions)")).
+			Where(sq.NotEq{
+				"v.check_order": 0,
+			}).
+			Where(sq.Eq{
+				"b.status":       BuildStatusSucceeded,
+				"b.job_id":       jobIDs,
+				"lr.pipeline_id": p.id,
+			}).
+			RunWith(p.conn).
+			Query()
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var output algorithm.BuildOutput
+			err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+			if err != nil {
+				Close(rows)
+				return nil, err
+			}
+
+			outputs = append(outputs, output)
+		}
+
+		Close(rows)
+	}
+
+	return outputs, nil
+}
+
 func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	if len(buildIDs) == 0 {
 		return nil
@@ -892,10 +1143,7 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 
 	defer Rollback(tx)
 
-	_, err = tx.Exec(`
-   DELETE FROM build_events
-	 WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
-	 `, interfaceBuildIDs...)
+	err = p.conn.EventStore().Delete(tx, buildIDs)
 	if err != nil {
 		return err
 	}
@@ -1040,7 +1288,7 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
-	if err = p.conn.Bus().Notify(buildEventsChannel(build.id)); err != nil {
+	if err = p.conn.Bus().Notify(BuildEventsChannel(build.id)); err != nil {
 		return nil, err
 	}
 
@@ -1093,6 +1341,27 @@ func (p *pipeline) getBuildsFrom(col string) (map[string]Build, error) {
 	return nextBuilds, nil
 }
 
+// bumpCacheIndexForPipelinesPassingJob invalidates the versions of every
+// pipeline with a passed constraint on the given job from another pipeline.
+func bumpCacheIndexForPipelinesPassingJob(tx Tx, jobID int) error {
+	_, err := tx.Exec(`
+		UPDATE pipelines
+		SET cache_index = cache_index + 1
+		WHERE id IN (
+			SELECT j.pipeline_id
+			FROM jobs_cross_pipeline_passed x
+			JOIN jobs j ON j.id = x.job_id
+			JOIN pipelines jp ON jp.id = j.pipeline_id
+			JOIN pipelines pp ON pp.name = x.passed_pipeline_name AND pp.team_id = jp.team_id
+			JOIN jobs pj ON pj.pipeline_id = pp.id AND pj.name = x.passed_job_name
+			WHERE pj.id = $1
+			AND pp.instance_vars IS NULL
+		)
+	`, jobID)
+
+	return err
+}
+
 func bumpCacheIndex(tx Tx, pipelineID int) error {
 	res, err := psql.Update("pipelines").
 		Set("cache_index", sq.Expr("cache_index + 1")).
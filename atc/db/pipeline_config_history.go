@@ -0,0 +1,161 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/encryption"
+)
+
+// PipelineConfigVersion is a config saved to a pipeline, as recorded in the
+// pipeline's config history.
+type PipelineConfigVersion struct {
+	Version   ConfigVersion
+	Author    string
+	CreatedAt time.Time
+
+	// Config is only loaded when looking up a single version.
+	Config atc.Config
+}
+
+func saveConfigHistory(
+	tx Tx,
+	es encryption.Strategy,
+	pipelineID int,
+	version ConfigVersion,
+	author string,
+	config atc.Config,
+) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := es.Encrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("pipeline_configs").
+		SetMap(map[string]interface{}{
+			"pipeline_id": pipelineID,
+			"version":     version,
+			"author":      author,
+			"config":      encryptedPayload,
+			"nonce":       nonce,
+		}).
+		RunWith(tx).
+		Exec()
+
+	return err
+}
+
+// ConfigHistory returns the pipeline's saved configs, newest first, without
+// their configs.
+func (p *pipeline) ConfigHistory() ([]PipelineConfigVersion, error) {
+	rows, err := psql.Select("version", "author", "created_at").
+		From("pipeline_configs").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	history := []PipelineConfigVersion{}
+	for rows.Next() {
+		var configVersion PipelineConfigVersion
+		err = rows.Scan(&configVersion.Version, &configVersion.Author, &configVersion.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, configVersion)
+	}
+
+	return history, nil
+}
+
+// ConfigAtVersion returns the config saved to the pipeline as the given
+// version, if it is still in the pipeline's config history.
+func (p *pipeline) ConfigAtVersion(version ConfigVersion) (PipelineConfigVersion, bool, error) {
+	var (
+		configVersion PipelineConfigVersion
+		configBlob    string
+		nonce         sql.NullString
+	)
+
+	err := psql.Select("version", "author", "created_at", "config", "nonce").
+		From("pipeline_configs").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"version":     version,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&configVersion.Version, &configVersion.Author, &configVersion.CreatedAt, &configBlob, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfigVersion{}, false, nil
+		}
+
+		return PipelineConfigVersion{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := p.conn.EncryptionStrategy().Decrypt(configBlob, noncense)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	err = json.Unmarshal(decryptedConfig, &configVersion.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	return configVersion, true, nil
+}
+
+//go:generate counterfeiter . PipelineConfigLifecycle
+
+type PipelineConfigLifecycle interface {
+	PruneConfigHistory(retain int) error
+}
+
+type pipelineConfigLifecycle struct {
+	conn Conn
+}
+
+func NewPipelineConfigLifecycle(conn Conn) PipelineConfigLifecycle {
+	return &pipelineConfigLifecycle{
+		conn: conn,
+	}
+}
+
+// PruneConfigHistory removes all but the given number of most recent configs
+// from the config history of each pipeline.
+func (lifecycle *pipelineConfigLifecycle) PruneConfigHistory(retain int) error {
+	_, err := lifecycle.conn.Exec(`
+		DELETE FROM pipeline_configs
+		WHERE id IN (
+			SELECT id
+			FROM (
+				SELECT id, row_number() OVER (PARTITION BY pipeline_id ORDER BY version DESC) AS n
+				FROM pipeline_configs
+			) ranked
+			WHERE ranked.n > $1
+		)
+	`, retain)
+
+	return err
+}
@@ -0,0 +1,105 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline config history", func() {
+	var (
+		pipeline db.Pipeline
+		config   atc.Config
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		}
+
+		var err error
+		pipeline, _, err = defaultTeam.SavePipelineAs("some-author", atc.PipelineRef{Name: "history-pipeline"}, config, 0, db.PipelineUnpaused)
+		Expect(err).ToNot(HaveOccurred())
+
+		config.Jobs = append(config.Jobs, atc.JobConfig{Name: "some-other-job"})
+
+		pipeline, _, err = defaultTeam.SavePipelineAs("some-other-author", atc.PipelineRef{Name: "history-pipeline"}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("ConfigHistory", func() {
+		It("returns every saved version, newest first", func() {
+			history, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(2))
+
+			Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+			Expect(history[0].Author).To(Equal("some-other-author"))
+			Expect(history[0].CreatedAt).ToNot(BeZero())
+			Expect(history[1].Version).To(BeNumerically("<", pipeline.ConfigVersion()))
+			Expect(history[1].Author).To(Equal("some-author"))
+		})
+
+		It("does not record an author when saved with SavePipeline", func() {
+			var err error
+			pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "history-pipeline"}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			history, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(3))
+			Expect(history[0].Author).To(BeEmpty())
+		})
+	})
+
+	Describe("ConfigAtVersion", func() {
+		It("returns the config saved as the version", func() {
+			history, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+
+			configVersion, found, err := pipeline.ConfigAtVersion(history[1].Version)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(configVersion.Author).To(Equal("some-author"))
+			Expect(configVersion.Config.Jobs).To(HaveLen(1))
+
+			configVersion, found, err = pipeline.ConfigAtVersion(history[0].Version)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(configVersion.Config).To(Equal(config))
+		})
+
+		It("returns false for an unknown version", func() {
+			_, found, err := pipeline.ConfigAtVersion(pipeline.ConfigVersion() + 100)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("PruneConfigHistory", func() {
+		var otherPipeline db.Pipeline
+
+		BeforeEach(func() {
+			var err error
+			otherPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-history-pipeline"}, config, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = db.NewPipelineConfigLifecycle(dbConn).PruneConfigHistory(1)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps the most recent versions of each pipeline", func() {
+			history, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+
+			history, err = otherPipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,150 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cross-pipeline passed constraints", func() {
+	var (
+		buildPipeline  db.Pipeline
+		deployPipeline db.Pipeline
+
+		unitJob          db.Job
+		deployArtifact   db.Resource
+		artifactScope    db.ResourceConfigScope
+		artifactVersion  db.ResourceConfigVersion
+		artifactSource   atc.Source
+		artifactVersions []atc.Version
+	)
+
+	BeforeEach(func() {
+		atc.EnableGlobalResources = true
+
+		artifactSource = atc.Source{"uri": "some-artifact"}
+		artifactVersions = []atc.Version{{"version": "1"}}
+
+		var err error
+		buildPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "build-pipeline"}, atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "artifact", Type: "some-base-resource-type", Source: artifactSource},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "unit",
+					Plan: atc.PlanSequence{{Get: "artifact"}},
+				},
+			},
+		}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).ToNot(HaveOccurred())
+
+		deployPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "deploy-pipeline"}, atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "artifact", Type: "some-base-resource-type", Source: artifactSource},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "deploy",
+					Plan: atc.PlanSequence{{Get: "artifact", Passed: []string{"build-pipeline/unit", "missing-pipeline/unit"}}},
+				},
+			},
+		}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).ToNot(HaveOccurred())
+
+		var found bool
+		unitJob, found, err = buildPipeline.Job("unit")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		buildArtifact, found, err := buildPipeline.Resource("artifact")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		deployArtifact, found, err = deployPipeline.Resource("artifact")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		artifactScope, err = buildArtifact.SetResourceConfig(artifactSource, atc.VersionedResourceTypes{})
+		Expect(err).ToNot(HaveOccurred())
+
+		deployScope, err := deployArtifact.SetResourceConfig(artifactSource, atc.VersionedResourceTypes{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deployScope.ID()).To(Equal(artifactScope.ID()))
+
+		err = artifactScope.SaveVersions(artifactVersions)
+		Expect(err).ToNot(HaveOccurred())
+
+		artifactVersion, found, err = artifactScope.LatestVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+	})
+
+	AfterEach(func() {
+		atc.EnableGlobalResources = false
+	})
+
+	It("maps the jobs of other pipelines by pipeline and job name", func() {
+		versions, err := deployPipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions.JobIDs).To(HaveKeyWithValue("build-pipeline/unit", unitJob.ID()))
+		Expect(versions.JobIDs).ToNot(HaveKey("missing-pipeline/unit"))
+	})
+
+	It("loads versions which passed jobs in other pipelines as versions of the pipeline's own resources", func() {
+		versions, err := deployPipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions.BuildOutputs).To(BeEmpty())
+
+		build, err := unitJob.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.SaveOutput("some-base-resource-type", artifactSource, atc.VersionedResourceTypes{}, artifactVersions[0], nil, "artifact", "artifact")
+		Expect(err).ToNot(HaveOccurred())
+
+		By("not including builds which have not succeeded")
+		versions, err = deployPipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions.BuildOutputs).To(BeEmpty())
+
+		err = build.Finish(db.BuildStatusSucceeded)
+		Expect(err).ToNot(HaveOccurred())
+
+		versions, err = deployPipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions.BuildOutputs).To(ConsistOf(algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{
+				VersionID:  artifactVersion.ID(),
+				ResourceID: deployArtifact.ID(),
+				CheckOrder: artifactVersion.CheckOrder(),
+			},
+			JobID:   unitJob.ID(),
+			BuildID: build.ID(),
+		}))
+	})
+
+	Context("when the passed constraint is removed", func() {
+		BeforeEach(func() {
+			_, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "deploy-pipeline"}, atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "artifact", Type: "some-base-resource-type", Source: artifactSource},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "deploy",
+						Plan: atc.PlanSequence{{Get: "artifact"}},
+					},
+				},
+			}, deployPipeline.ConfigVersion(), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("no longer loads the other pipeline's jobs", func() {
+			versions, err := deployPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions.JobIDs).ToNot(HaveKey("build-pipeline/unit"))
+		})
+	})
+})
@@ -117,7 +117,7 @@ var _ = Describe("Pipeline", func() {
 			},
 		}
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -207,17 +207,103 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Archive", func() {
+		JustBeforeEach(func() {
+			Expect(pipeline.Archive()).To(Succeed())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the pipeline is unpaused", func() {
+			BeforeEach(func() {
+				Expect(pipeline.Unpause()).To(Succeed())
+			})
+
+			It("archives the pipeline", func() {
+				Expect(pipeline.Archived()).To(BeTrue())
+			})
+
+			It("pauses the pipeline", func() {
+				Expect(pipeline.Paused()).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("Rename", func() {
+		BeforeEach(func() {
+			err := team.SaveSecret(atc.Secret{PipelineName: "fake-pipeline", Name: "some-secret", Value: "some-value"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.SaveSecret(atc.Secret{PipelineName: "fake-pipeline", Name: "other-secret", Value: "old-value"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.SaveSecret(atc.Secret{PipelineName: "oopsies", Name: "other-secret", Value: "new-value"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		JustBeforeEach(func() {
 			Expect(pipeline.Rename("oopsies")).To(Succeed())
 		})
 
 		It("renames the pipeline", func() {
-			pipeline, found, err := team.Pipeline("oopsies")
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: "oopsies"})
 			Expect(pipeline.Name()).To(Equal("oopsies"))
 			Expect(found).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("moves the pipeline's secrets to its new name, keeping any already set", func() {
+			secret, found, err := team.Secret("oopsies", "some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(secret.Value).To(Equal("some-value"))
+
+			secret, found, err = team.Secret("oopsies", "other-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(secret.Value).To(Equal("new-value"))
+
+			_, found, err = team.Secret("fake-pipeline", "some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not give the pipeline's secrets to a new pipeline of the old name", func() {
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := team.Secret("fake-pipeline", "some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			secret, found, err := team.Secret("oopsies", "some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(secret.Value).To(Equal("some-value"))
+		})
+
+		Context("when another instance of the pipeline keeps the old name", func() {
+			BeforeEach(func() {
+				_, _, err := team.SavePipeline(atc.PipelineRef{
+					Name:         "fake-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				}, pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the secrets under the old name too", func() {
+				secret, found, err := team.Secret("fake-pipeline", "some-secret")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(secret.Value).To(Equal("some-value"))
+
+				_, found, err = team.Secret("oopsies", "some-secret")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
 	})
 
 	Describe("Resource Config Versions", func() {
@@ -386,10 +472,10 @@ var _ = Describe("Pipeline", func() {
 			}
 
 			var err error
-			dbPipeline, _, err = team.SavePipeline("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+			dbPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-name"}, pipelineConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherDBPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherDBPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline-name"}, otherPipelineConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, _, err = dbPipeline.Resource(resourceName)
@@ -957,7 +1043,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			pipelineDB, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipelineDB, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -1152,10 +1238,58 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeFalse())
 
-			_, found, err = team.Pipeline(pipeline.Name())
+			_, found, err = team.Pipeline(atc.PipelineRef{Name: pipeline.Name()})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
+
+		Context("when the pipeline has secrets", func() {
+			BeforeEach(func() {
+				err := team.SaveSecret(atc.Secret{PipelineName: "fake-pipeline", Name: "some-secret", Value: "some-value"})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.SaveSecret(atc.Secret{Name: "team-secret", Value: "team-value"})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("removes them, so that a new pipeline of the same name does not inherit them", func() {
+				Expect(pipeline.Destroy()).To(Succeed())
+
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err := team.Secret("fake-pipeline", "some-secret")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("keeps the team's secrets", func() {
+				Expect(pipeline.Destroy()).To(Succeed())
+
+				_, found, err := team.Secret("", "team-secret")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			Context("when another instance of the pipeline has the same name", func() {
+				BeforeEach(func() {
+					_, _, err := team.SavePipeline(atc.PipelineRef{
+						Name:         "fake-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "feature"},
+					}, pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps them for the other instance", func() {
+					Expect(pipeline.Destroy()).To(Succeed())
+
+					secret, found, err := team.Secret("fake-pipeline", "some-secret")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(secret.Value).To(Equal("some-value"))
+				})
+			})
+		})
 	})
 
 	Describe("GetPendingBuilds/GetAllPendingBuilds", func() {
@@ -1198,7 +1332,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline-name"}, otherPipelineConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1657,6 +1791,31 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Config", func() {
+		var config atc.Config
+
+		BeforeEach(func() {
+			var err error
+			config, err = pipeline.Config()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the groups", func() {
+			Expect(config.Groups).To(Equal(pipelineConfig.Groups))
+		})
+
+		It("returns the jobs, resources and resource types", func() {
+			Expect(config.Jobs).To(HaveLen(len(pipelineConfig.Jobs)))
+			Expect(config.Jobs[0].Name).To(Equal("job-name"))
+
+			Expect(config.Resources).To(HaveLen(2))
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{"some": "other-source"}))
+
+			Expect(config.ResourceTypes).To(HaveLen(2))
+			Expect(config.ResourceTypes[0].Name).To(Equal("some-other-resource-type"))
+		})
+	})
+
 	Describe("GetBuildsWithVersionAsInput", func() {
 		var (
 			resourceConfigVersion int
@@ -2159,7 +2318,7 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -2179,7 +2338,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(found).To(BeTrue())
 			}
 
-			otherPipeline, _, err := team.SavePipeline("another-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "another-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			otherJob, found, err := otherPipeline.Job("some-job")
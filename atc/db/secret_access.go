@@ -0,0 +1,124 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// SecretAccessFilter narrows down the secret access audit trail to the
+// lookups of a secret path, or the lookups made for a build.
+type SecretAccessFilter struct {
+	Path    string
+	BuildID int
+	Limit   uint64
+}
+
+//go:generate counterfeiter . SecretAccessRepository
+
+type SecretAccessRepository interface {
+	RecordSecretAccesses([]atc.SecretAccess) error
+	SecretAccesses(SecretAccessFilter) ([]atc.SecretAccess, error)
+	RemoveSecretAccessesBefore(time.Time) error
+}
+
+type secretAccessRepository struct {
+	conn Conn
+}
+
+func NewSecretAccessRepository(conn Conn) SecretAccessRepository {
+	return &secretAccessRepository{
+		conn: conn,
+	}
+}
+
+// RecordSecretAccesses records a batch of secret lookups in a single insert.
+func (repository *secretAccessRepository) RecordSecretAccesses(accesses []atc.SecretAccess) error {
+	if len(accesses) == 0 {
+		return nil
+	}
+
+	query := psql.Insert("secret_accesses").
+		Columns("path", "manager", "team_name", "pipeline_name", "build_id", "user_name", "accessed_at")
+
+	for _, access := range accesses {
+		var buildID interface{}
+		if access.BuildID != 0 {
+			buildID = access.BuildID
+		}
+
+		query = query.Values(
+			access.Path,
+			access.Manager,
+			access.TeamName,
+			access.PipelineName,
+			buildID,
+			access.UserName,
+			time.Unix(access.AccessedAt, 0),
+		)
+	}
+
+	_, err := query.RunWith(repository.conn).Exec()
+	return err
+}
+
+// SecretAccesses returns the secret lookups matching the filter, most recent
+// first.
+func (repository *secretAccessRepository) SecretAccesses(filter SecretAccessFilter) ([]atc.SecretAccess, error) {
+	query := psql.Select("id", "path", "manager", "team_name", "pipeline_name", "build_id", "user_name", "accessed_at").
+		From("secret_accesses").
+		OrderBy("id DESC")
+
+	if filter.Path != "" {
+		query = query.Where(sq.Eq{"path": filter.Path})
+	}
+
+	if filter.BuildID != 0 {
+		query = query.Where(sq.Eq{"build_id": filter.BuildID})
+	}
+
+	if filter.Limit != 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows, err := query.RunWith(repository.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	accesses := []atc.SecretAccess{}
+	for rows.Next() {
+		var (
+			access     atc.SecretAccess
+			buildID    sql.NullInt64
+			accessedAt time.Time
+		)
+
+		err = rows.Scan(&access.ID, &access.Path, &access.Manager, &access.TeamName, &access.PipelineName, &buildID, &access.UserName, &accessedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		access.BuildID = int(buildID.Int64)
+		access.AccessedAt = accessedAt.Unix()
+
+		accesses = append(accesses, access)
+	}
+
+	return accesses, rows.Err()
+}
+
+// RemoveSecretAccessesBefore removes the secret lookups made before the given
+// time from the audit trail.
+func (repository *secretAccessRepository) RemoveSecretAccessesBefore(before time.Time) error {
+	_, err := psql.Delete("secret_accesses").
+		Where(sq.Lt{"accessed_at": before}).
+		RunWith(repository.conn).
+		Exec()
+
+	return err
+}
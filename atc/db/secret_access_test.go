@@ -0,0 +1,98 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessRepository", func() {
+	var (
+		repository db.SecretAccessRepository
+		now        time.Time
+	)
+
+	BeforeEach(func() {
+		repository = db.NewSecretAccessRepository(dbConn)
+		now = time.Now().Truncate(time.Second)
+
+		err := repository.RecordSecretAccesses([]atc.SecretAccess{
+			{
+				Path:         "/concourse/some-team/some-secret",
+				Manager:      "vault",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				BuildID:      42,
+				AccessedAt:   now.Add(-2 * time.Hour).Unix(),
+			},
+			{
+				Path:         "/concourse/some-team/some-secret",
+				Manager:      "vault",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				UserName:     "some-user",
+				AccessedAt:   now.Add(-time.Hour).Unix(),
+			},
+			{
+				Path:         "/concourse/some-team/other-secret",
+				Manager:      "vault",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				BuildID:      42,
+				AccessedAt:   now.Unix(),
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("SecretAccesses", func() {
+		It("returns the lookups of a path, most recent first", func() {
+			accesses, err := repository.SecretAccesses(db.SecretAccessFilter{Path: "/concourse/some-team/some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(2))
+
+			Expect(accesses[0].ID).ToNot(BeZero())
+			accesses[0].ID = 0
+			Expect(accesses[0]).To(Equal(atc.SecretAccess{
+				Path:         "/concourse/some-team/some-secret",
+				Manager:      "vault",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				UserName:     "some-user",
+				AccessedAt:   now.Add(-time.Hour).Unix(),
+			}))
+
+			Expect(accesses[1].BuildID).To(Equal(42))
+		})
+
+		It("returns the lookups made for a build", func() {
+			accesses, err := repository.SecretAccesses(db.SecretAccessFilter{BuildID: 42})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(2))
+			Expect(accesses[0].Path).To(Equal("/concourse/some-team/other-secret"))
+			Expect(accesses[1].Path).To(Equal("/concourse/some-team/some-secret"))
+		})
+
+		It("limits the number of lookups returned", func() {
+			accesses, err := repository.SecretAccesses(db.SecretAccessFilter{Limit: 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(1))
+			Expect(accesses[0].Path).To(Equal("/concourse/some-team/other-secret"))
+		})
+	})
+
+	Describe("RemoveSecretAccessesBefore", func() {
+		It("removes the lookups made before the given time", func() {
+			err := repository.RemoveSecretAccessesBefore(now.Add(-90 * time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+
+			accesses, err := repository.SecretAccesses(db.SecretAccessFilter{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(2))
+			Expect(accesses[1].UserName).To(Equal("some-user"))
+		})
+	})
+})
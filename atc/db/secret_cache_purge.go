@@ -0,0 +1,114 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// secretCachePurgeRetention is how long a purge is kept for, which only
+// needs to be long enough for every ATC to have been notified of it.
+const secretCachePurgeRetention = time.Hour
+
+//go:generate counterfeiter . SecretCachePurgeRepository
+
+type SecretCachePurgeRepository interface {
+	PurgeSecretCache(atc.SecretCachePurge) error
+	LatestSecretCachePurgeID() (int, error)
+	SecretCachePurgesSince(id int) ([]atc.SecretCachePurge, error)
+}
+
+type secretCachePurgeRepository struct {
+	conn Conn
+}
+
+func NewSecretCachePurgeRepository(conn Conn) SecretCachePurgeRepository {
+	return &secretCachePurgeRepository{
+		conn: conn,
+	}
+}
+
+// PurgeSecretCache requests the purge of the secret caches of every ATC,
+// removing the purges which every ATC has had the time to pick up by now.
+func (repository *secretCachePurgeRepository) PurgeSecretCache(purge atc.SecretCachePurge) error {
+	tx, err := repository.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("secret_cache_purges").
+		Where(sq.Expr(fmt.Sprintf("created_at < now() - '%d seconds'::interval", int(secretCachePurgeRetention.Seconds())))).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("secret_cache_purges").
+		SetMap(map[string]interface{}{
+			"team_name":     purge.TeamName,
+			"pipeline_name": purge.PipelineName,
+			"path":          purge.Path,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return repository.conn.Bus().Notify(creds.SecretCachePurgesChannel)
+}
+
+func (repository *secretCachePurgeRepository) LatestSecretCachePurgeID() (int, error) {
+	var id int
+	err := psql.Select("COALESCE(MAX(id), 0)").
+		From("secret_cache_purges").
+		RunWith(repository.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// SecretCachePurgesSince returns the purges requested after the purge with
+// the given id, in the order they were requested.
+func (repository *secretCachePurgeRepository) SecretCachePurgesSince(id int) ([]atc.SecretCachePurge, error) {
+	rows, err := psql.Select("id", "team_name", "pipeline_name", "path").
+		From("secret_cache_purges").
+		Where(sq.Gt{"id": id}).
+		OrderBy("id ASC").
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	purges := []atc.SecretCachePurge{}
+	for rows.Next() {
+		var purge atc.SecretCachePurge
+
+		err = rows.Scan(&purge.ID, &purge.TeamName, &purge.PipelineName, &purge.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		purges = append(purges, purge)
+	}
+
+	return purges, rows.Err()
+}
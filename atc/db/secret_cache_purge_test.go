@@ -0,0 +1,85 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretCachePurgeRepository", func() {
+	var repository db.SecretCachePurgeRepository
+
+	BeforeEach(func() {
+		repository = db.NewSecretCachePurgeRepository(dbConn)
+	})
+
+	It("has no latest purge before any purge is requested", func() {
+		id, err := repository.LatestSecretCachePurgeID()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id).To(BeZero())
+	})
+
+	Describe("PurgeSecretCache", func() {
+		var (
+			notifier chan bool
+			latestID int
+		)
+
+		BeforeEach(func() {
+			var err error
+			notifier, err = dbConn.Bus().Listen(creds.SecretCachePurgesChannel)
+			Expect(err).ToNot(HaveOccurred())
+
+			latestID, err = repository.LatestSecretCachePurgeID()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repository.PurgeSecretCache(atc.SecretCachePurge{TeamName: "some-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repository.PurgeSecretCache(atc.SecretCachePurge{
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Path:         "some-secret",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			err := dbConn.Bus().Unlisten(creds.SecretCachePurgesChannel, notifier)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("notifies every ATC of the purge", func() {
+			Eventually(notifier).Should(Receive(BeTrue()))
+		})
+
+		It("returns the purges requested since, in order", func() {
+			purges, err := repository.SecretCachePurgesSince(latestID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(purges).To(HaveLen(2))
+
+			Expect(purges[0].ID).To(BeNumerically(">", latestID))
+			Expect(purges[0].TeamName).To(Equal("some-team"))
+			Expect(purges[0].PipelineName).To(BeEmpty())
+			Expect(purges[0].Path).To(BeEmpty())
+
+			Expect(purges[1].ID).To(BeNumerically(">", purges[0].ID))
+			Expect(purges[1]).To(Equal(atc.SecretCachePurge{
+				ID:           purges[1].ID,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Path:         "some-secret",
+			}))
+
+			id, err := repository.LatestSecretCachePurgeID()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(id).To(Equal(purges[1].ID))
+
+			purges, err = repository.SecretCachePurgesSince(id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(purges).To(BeEmpty())
+		})
+	})
+})
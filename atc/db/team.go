@@ -25,18 +25,27 @@ type Team interface {
 	Admin() bool
 
 	Auth() atc.TeamAuth
+	Quotas() atc.TeamQuotas
 
 	Delete() error
 	Rename(string) error
 
 	SavePipeline(
-		pipelineName string,
+		pipelineRef atc.PipelineRef,
 		config atc.Config,
 		from ConfigVersion,
 		pausedState PipelinePausedState,
 	) (Pipeline, bool, error)
 
-	Pipeline(pipelineName string) (Pipeline, bool, error)
+	SavePipelineAs(
+		author string,
+		pipelineRef atc.PipelineRef,
+		config atc.Config,
+		from ConfigVersion,
+		pausedState PipelinePausedState,
+	) (Pipeline, bool, error)
+
+	Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
 	VisiblePipelines() ([]Pipeline, error)
@@ -58,13 +67,24 @@ type Team interface {
 	IsContainerWithinTeam(string, bool) (bool, error)
 
 	FindContainerByHandle(string) (Container, bool, error)
-	FindCheckContainers(string, string, creds.Secrets) ([]Container, map[int]time.Time, error)
+	FindCheckContainers(atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]Container, map[int]time.Time, error)
 	FindContainersByMetadata(ContainerMetadata) ([]Container, error)
 	FindCreatedContainerByHandle(string) (CreatedContainer, bool, error)
 	FindWorkerForContainer(handle string) (Worker, bool, error)
 	FindWorkerForVolume(handle string) (Worker, bool, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdateQuotas(quotas atc.TeamQuotas) error
+	Usage() (atc.TeamUsage, error)
+
+	SaveWebhook(webhook atc.Webhook) error
+	Webhook(name string) (atc.Webhook, bool, error)
+	DeleteWebhook(name string) (bool, error)
+
+	Secrets() ([]atc.Secret, error)
+	SaveSecret(secret atc.Secret) error
+	Secret(pipelineName string, name string) (atc.Secret, bool, error)
+	DeleteSecret(pipelineName string, name string) (bool, error)
 }
 
 type team struct {
@@ -75,14 +95,16 @@ type team struct {
 	name  string
 	admin bool
 
-	auth atc.TeamAuth
+	auth   atc.TeamAuth
+	quotas atc.TeamQuotas
 }
 
 func (t *team) ID() int      { return t.id }
 func (t *team) Name() string { return t.name }
 func (t *team) Admin() bool  { return t.admin }
 
-func (t *team) Auth() atc.TeamAuth { return t.auth }
+func (t *team) Auth() atc.TeamAuth     { return t.auth }
+func (t *team) Quotas() atc.TeamQuotas { return t.quotas }
 
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
@@ -338,7 +360,19 @@ func (t *team) FindCreatedContainerByHandle(
 }
 
 func (t *team) SavePipeline(
-	pipelineName string,
+	pipelineRef atc.PipelineRef,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (Pipeline, bool, error) {
+	return t.SavePipelineAs("", pipelineRef, config, from, pausedState)
+}
+
+// SavePipelineAs saves the pipeline's config like SavePipeline, recording it
+// in the pipeline's config history as saved by the author.
+func (t *team) SavePipelineAs(
+	author string,
+	pipelineRef atc.PipelineRef,
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
@@ -348,6 +382,29 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	instanceVarsPayload, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// var sources hold credentials, so unlike the groups they are encrypted
+	var varSourcesPayload interface{}
+	var varSourcesNonce *string
+	if len(config.VarSources) != 0 {
+		payload, err := json.Marshal(config.VarSources)
+		if err != nil {
+			return nil, false, err
+		}
+
+		encryptedPayload, nonce, err := t.conn.EncryptionStrategy().Encrypt(payload)
+		if err != nil {
+			return nil, false, err
+		}
+
+		varSourcesPayload = encryptedPayload
+		varSourcesNonce = nonce
+	}
+
 	jobGroups := make(map[string][]string)
 	for _, group := range config.Groups {
 		for _, job := range group.Jobs {
@@ -365,34 +422,60 @@ func (t *team) SavePipeline(
 
 	defer Rollback(tx)
 
-	err = tx.QueryRow(`
-		SELECT COUNT(1)
-		FROM pipelines
-		WHERE name = $1
-	  AND team_id = $2
-	`, pipelineName, t.id).Scan(&existingConfig)
+	err = psql.Select("COUNT(1)").
+		From("pipelines").
+		Where(sq.Eq{"team_id": t.id}).
+		Where(pipelineRefEq("", pipelineRef)).
+		RunWith(tx).
+		QueryRow().
+		Scan(&existingConfig)
 	if err != nil {
 		return nil, false, err
 	}
 
 	var pipelineID int
+	var version ConfigVersion
 	if existingConfig == 0 {
 		if pausedState == PipelineNoChange {
 			pausedState = PipelinePaused
 		}
 
+		// instances of the same pipeline share their ordering so that they
+		// remain grouped together
+		var ordering interface{} = sq.Expr("currval('pipelines_id_seq')")
+
+		var instanceOrdering int
+		err = psql.Select("ordering").
+			From("pipelines").
+			Where(sq.Eq{
+				"name":    pipelineRef.Name,
+				"team_id": t.id,
+			}).
+			Limit(1).
+			RunWith(tx).
+			QueryRow().
+			Scan(&instanceOrdering)
+		if err == nil {
+			ordering = instanceOrdering
+		} else if err != sql.ErrNoRows {
+			return nil, false, err
+		}
+
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"groups":   groupsPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("currval('pipelines_id_seq')"),
-				"paused":   pausedState.Bool(),
-				"team_id":  t.id,
+				"name":          pipelineRef.Name,
+				"instance_vars": instanceVarsPayload,
+				"groups":        groupsPayload,
+				"var_sources":   varSourcesPayload,
+				"nonce":         varSourcesNonce,
+				"version":       sq.Expr("nextval('config_version_seq')"),
+				"ordering":      ordering,
+				"paused":        pausedState.Bool(),
+				"team_id":       t.id,
 			}).
-			Suffix("RETURNING id").
+			Suffix("RETURNING id, version").
 			RunWith(tx).
-			QueryRow().Scan(&pipelineID)
+			QueryRow().Scan(&pipelineID, &version)
 		if err != nil {
 			return nil, false, err
 		}
@@ -401,19 +484,24 @@ func (t *team) SavePipeline(
 	} else {
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
+			Set("var_sources", varSourcesPayload).
+			Set("nonce", varSourcesNonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Where(sq.Eq{
-				"name":    pipelineName,
 				"version": from,
 				"team_id": t.id,
 			}).
-			Suffix("RETURNING id")
+			Where(pipelineRefEq("", pipelineRef)).
+			Suffix("RETURNING id, version")
+
+		// configuring an archived pipeline brings it back, but always paused
+		update = update.Set("archived", false)
 
 		if pausedState != PipelineNoChange {
-			update = update.Set("paused", pausedState.Bool())
+			update = update.Set("paused", sq.Expr("archived OR ?", *pausedState.Bool()))
 		}
 
-		err = update.RunWith(tx).QueryRow().Scan(&pipelineID)
+		err = update.RunWith(tx).QueryRow().Scan(&pipelineID, &version)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, false, ErrConfigComparisonFailed
@@ -434,6 +522,18 @@ func (t *team) SavePipeline(
 			return nil, false, err
 		}
 
+		_, err = tx.Exec(`
+      DELETE FROM jobs_cross_pipeline_passed
+      WHERE job_id in (
+        SELECT j.id
+        FROM jobs j
+        WHERE j.pipeline_id = $1
+      )
+		`, pipelineID)
+		if err != nil {
+			return nil, false, err
+		}
+
 		_, err = tx.Exec(`
 			UPDATE jobs
 			SET active = false
@@ -503,6 +603,27 @@ func (t *team) SavePipeline(
 				return nil, false, err
 			}
 		}
+
+		for _, input := range job.Inputs() {
+			for _, passed := range input.Passed {
+				passedPipeline, passedJob := atc.PassedJob(passed)
+				if passedPipeline == "" {
+					continue
+				}
+
+				err = t.registerCrossPipelinePassed(tx, job.Name, passedPipeline, passedJob, pipelineID)
+				if err != nil {
+					return nil, false, err
+				}
+			}
+		}
+	}
+
+	// passed constraints may now refer to different jobs in other pipelines,
+	// whose builds are loaded along with the pipeline's versions
+	err = bumpCacheIndex(tx, pipelineID)
+	if err != nil {
+		return nil, false, err
 	}
 
 	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
@@ -510,6 +631,11 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	err = saveConfigHistory(tx, t.conn.EncryptionStrategy(), pipelineID, version, author, config)
+	if err != nil {
+		return nil, false, err
+	}
+
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
 	err = scanPipeline(
@@ -531,16 +657,14 @@ func (t *team) SavePipeline(
 	return pipeline, created, nil
 }
 
-func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
+func (t *team) Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error) {
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
 	err := scanPipeline(
 		pipeline,
 		pipelinesQuery.
-			Where(sq.Eq{
-				"p.team_id": t.id,
-				"p.name":    pipelineName,
-			}).
+			Where(sq.Eq{"p.team_id": t.id}).
+			Where(pipelineRefEq("p.", pipelineRef)).
 			RunWith(t.conn).
 			QueryRow(),
 	)
@@ -559,7 +683,7 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
-		OrderBy("ordering").
+		OrderBy("ordering", "p.name", "p.id").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -580,7 +704,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 			"team_id": t.id,
 			"public":  true,
 		}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.name ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -598,7 +722,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 func (t *team) VisiblePipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
 		Where(sq.Eq{"team_id": t.id}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.name ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -613,7 +737,7 @@ func (t *team) VisiblePipelines() ([]Pipeline, error) {
 	rows, err = pipelinesQuery.
 		Where(sq.NotEq{"team_id": t.id}).
 		Where(sq.Eq{"public": true}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.name ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -736,7 +860,7 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
-	if err = t.conn.Bus().Notify(buildEventsChannel(build.id)); err != nil {
+	if err = t.conn.Bus().Notify(BuildEventsChannel(build.id)); err != nil {
 		return nil, err
 	}
 
@@ -795,7 +919,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, max_running_builds, max_containers, max_volumes
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -805,8 +929,62 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
-func (t *team) FindCheckContainers(pipelineName string, resourceName string, secretManager creds.Secrets) ([]Container, map[int]time.Time, error) {
-	pipeline, found, err := t.Pipeline(pipelineName)
+func (t *team) UpdateQuotas(quotas atc.TeamQuotas) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer Rollback(tx)
+
+	query := `
+		UPDATE teams
+		SET max_running_builds = $1, max_containers = $2, max_volumes = $3
+		WHERE id = $4
+		RETURNING id, name, admin, auth, nonce, max_running_builds, max_containers, max_volumes
+	`
+	err = t.queryTeam(tx, query, quotas.MaxRunningBuilds, quotas.MaxContainers, quotas.MaxVolumes, t.id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Usage loads the team's quotas along with its current number of running
+// builds, containers, and volumes. The quotas are loaded alongside the usage
+// so that it can be called on a team which has only been looked up by ID.
+func (t *team) Usage() (atc.TeamUsage, error) {
+	var usage atc.TeamUsage
+
+	err := psql.Select(
+		"t.max_running_builds",
+		"t.max_containers",
+		"t.max_volumes",
+		"(SELECT COUNT(*) FROM builds b WHERE b.team_id = t.id AND b.status = 'started')",
+		"(SELECT COUNT(*) FROM containers c WHERE c.team_id = t.id AND c.state != 'destroying')",
+		"(SELECT COUNT(*) FROM volumes v WHERE v.team_id = t.id AND v.state != 'destroying')",
+	).
+		From("teams t").
+		Where(sq.Eq{"t.id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(
+			&usage.Quotas.MaxRunningBuilds,
+			&usage.Quotas.MaxContainers,
+			&usage.Quotas.MaxVolumes,
+			&usage.RunningBuilds,
+			&usage.Containers,
+			&usage.Volumes,
+		)
+	if err != nil {
+		return atc.TeamUsage{}, err
+	}
+
+	return usage, nil
+}
+
+func (t *team) FindCheckContainers(pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
+	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -827,7 +1005,7 @@ func (t *team) FindCheckContainers(pipelineName string, resourceName string, sec
 		return nil, nil, err
 	}
 
-	variables := creds.NewVariables(secretManager, t.name, pipeline.Name())
+	variables := creds.NewPipelineVariables(secretManager, varSourcePool, pipeline.VarSources(), t.name, pipeline.Name())
 
 	versionedResourceTypes := pipelineResourceTypes.Deserialize()
 
@@ -1016,9 +1194,9 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
-		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6
+		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6, priority = $7
 		WHERE name = $1 AND pipeline_id = $2
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups))
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups), job.Priority)
 	if err != nil {
 		return err
 	}
@@ -1028,9 +1206,9 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags)
-		VALUES ($1, $2, $3, $4, true, $5, $6)
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups))
+		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags, priority)
+		VALUES ($1, $2, $3, $4, true, $5, $6, $7)
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups), job.Priority)
 
 	return swallowUniqueViolation(err)
 }
@@ -1051,6 +1229,20 @@ func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineI
 	return swallowUniqueViolation(err)
 }
 
+func (t *team) registerCrossPipelinePassed(tx Tx, jobName, passedPipelineName, passedJobName string, pipelineID int) error {
+	_, err := tx.Exec(`
+    INSERT INTO jobs_cross_pipeline_passed (job_id, passed_pipeline_name, passed_job_name) VALUES
+    ((SELECT j.id
+        FROM jobs j
+       WHERE j.name = $1
+         AND j.pipeline_id = $2
+       LIMIT 1), $3, $4);`,
+		jobName, pipelineID, passedPipelineName, passedJobName,
+	)
+
+	return swallowUniqueViolation(err)
+}
+
 func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) error {
 	configPayload, err := json.Marshal(resource)
 	if err != nil {
@@ -1187,12 +1379,36 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 }
 
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var groups, instanceVars, varSources, nonce sql.NullString
+	err := scan.Scan(&p.id, &p.name, &instanceVars, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &varSources, &nonce)
 	if err != nil {
 		return err
 	}
 
+	if varSources.Valid {
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedVarSources, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, noncense)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(decryptedVarSources, &p.varSources)
+		if err != nil {
+			return err
+		}
+	}
+
+	if instanceVars.Valid {
+		err = json.Unmarshal([]byte(instanceVars.String), &p.instanceVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	if groups.Valid {
 		var pipelineGroups atc.GroupConfigs
 		err = json.Unmarshal([]byte(groups.String), &pipelineGroups)
@@ -1206,6 +1422,38 @@ func scanPipeline(p *pipeline, scan scannable) error {
 	return nil
 }
 
+func instanceVarsPayload(instanceVars atc.InstanceVars) (interface{}, error) {
+	if len(instanceVars) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(instanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(payload), nil
+}
+
+// pipelineRefEq matches the pipeline with the ref's name and exactly its
+// instance vars, given the prefix of the pipelines table's columns.
+func pipelineRefEq(prefix string, pipelineRef atc.PipelineRef) sq.Sqlizer {
+	if len(pipelineRef.InstanceVars) == 0 {
+		return sq.And{
+			sq.Eq{prefix + "name": pipelineRef.Name},
+			sq.Expr(prefix + "instance_vars IS NULL"),
+		}
+	}
+
+	// the error is ignored as InstanceVars always marshal
+	payload, _ := json.Marshal(pipelineRef.InstanceVars)
+
+	return sq.And{
+		sq.Eq{prefix + "name": pipelineRef.Name},
+		sq.Expr(prefix+"instance_vars = ?::jsonb", string(payload)),
+	}
+}
+
 func scanPipelines(conn Conn, lockFactory lock.LockFactory, rows *sql.Rows) ([]Pipeline, error) {
 	defer Close(rows)
 
@@ -1261,6 +1509,9 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.quotas.MaxRunningBuilds,
+		&t.quotas.MaxContainers,
+		&t.quotas.MaxVolumes,
 	)
 	if err != nil {
 		return err
@@ -1277,3 +1528,200 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 
 	return nil
 }
+
+func (t *team) SaveWebhook(webhook atc.Webhook) error {
+	encryptedSecret, nonce, err := t.conn.EncryptionStrategy().Encrypt([]byte(webhook.Secret))
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("team_webhooks").
+		Columns("team_id", "name", "type", "secret", "nonce").
+		Values(t.id, webhook.Name, webhook.Type, encryptedSecret, nonce).
+		Suffix(`
+			ON CONFLICT (team_id, name) DO UPDATE SET
+				type = EXCLUDED.type,
+				secret = EXCLUDED.secret,
+				nonce = EXCLUDED.nonce
+		`).
+		RunWith(t.conn).
+		Exec()
+
+	return err
+}
+
+func (t *team) Webhook(name string) (atc.Webhook, bool, error) {
+	var (
+		webhook atc.Webhook
+		secret  string
+		nonce   sql.NullString
+	)
+
+	err := psql.Select("name", "type", "secret", "nonce").
+		From("team_webhooks").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&webhook.Name, &webhook.Type, &secret, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.Webhook{}, false, nil
+		}
+
+		return atc.Webhook{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedSecret, err := t.conn.EncryptionStrategy().Decrypt(secret, noncense)
+	if err != nil {
+		return atc.Webhook{}, false, err
+	}
+
+	webhook.Secret = string(decryptedSecret)
+
+	return webhook, true, nil
+}
+
+func (t *team) DeleteWebhook(name string) (bool, error) {
+	result, err := psql.Delete("team_webhooks").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
+
+func (t *team) Secrets() ([]atc.Secret, error) {
+	rows, err := psql.Select("name", "pipeline_name", "updated_at").
+		From("secrets").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("pipeline_name", "name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	secrets := []atc.Secret{}
+	for rows.Next() {
+		var (
+			secret    atc.Secret
+			updatedAt time.Time
+		)
+
+		err = rows.Scan(&secret.Name, &secret.PipelineName, &updatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		secret.UpdatedAt = updatedAt.Unix()
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+func (t *team) SaveSecret(secret atc.Secret) error {
+	encryptedValue, nonce, err := t.conn.EncryptionStrategy().Encrypt([]byte(secret.Value))
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("secrets").
+		Columns("team_id", "pipeline_name", "name", "value", "nonce").
+		Values(t.id, secret.PipelineName, secret.Name, encryptedValue, nonce).
+		Suffix(`
+			ON CONFLICT (team_id, pipeline_name, name) DO UPDATE SET
+				value = EXCLUDED.value,
+				nonce = EXCLUDED.nonce,
+				updated_at = now()
+		`).
+		RunWith(t.conn).
+		Exec()
+
+	return err
+}
+
+func (t *team) Secret(pipelineName string, name string) (atc.Secret, bool, error) {
+	var (
+		secret    atc.Secret
+		value     string
+		nonce     sql.NullString
+		updatedAt time.Time
+	)
+
+	err := psql.Select("name", "pipeline_name", "value", "nonce", "updated_at").
+		From("secrets").
+		Where(sq.Eq{
+			"team_id":       t.id,
+			"pipeline_name": pipelineName,
+			"name":          name,
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&secret.Name, &secret.PipelineName, &value, &nonce, &updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.Secret{}, false, nil
+		}
+
+		return atc.Secret{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedValue, err := t.conn.EncryptionStrategy().Decrypt(value, noncense)
+	if err != nil {
+		return atc.Secret{}, false, err
+	}
+
+	secret.Value = string(decryptedValue)
+	secret.UpdatedAt = updatedAt.Unix()
+
+	return secret, true, nil
+}
+
+func (t *team) DeleteSecret(pipelineName string, name string) (bool, error) {
+	result, err := psql.Delete("secrets").
+		Where(sq.Eq{
+			"team_id":       t.id,
+			"pipeline_name": pipelineName,
+			"name":          name,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
@@ -50,10 +50,15 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var quotas atc.TeamQuotas
+	if t.Quotas != nil {
+		quotas = *t.Quotas
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, max_running_builds, max_containers, max_volumes").
+		Values(t.Name, auth, admin, quotas.MaxRunningBuilds, quotas.MaxContainers, quotas.MaxVolumes).
+		Suffix("RETURNING id, name, admin, auth, max_running_builds, max_containers, max_volumes").
 		RunWith(tx).
 		QueryRow()
 
@@ -89,7 +94,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, max_running_builds, max_containers, max_volumes").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -108,7 +113,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, max_running_builds, max_containers, max_volumes").
 		From("teams").
 		OrderBy("id ASC").
 		RunWith(factory.conn).
@@ -173,6 +178,9 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&t.quotas.MaxRunningBuilds,
+		&t.quotas.MaxContainers,
+		&t.quotas.MaxVolumes,
 	)
 
 	if providerAuth.Valid {
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// WaitingStep is a build step waiting for a compatible worker with capacity.
+type WaitingStep struct {
+	TeamID   int
+	Tags     []string
+	Platform string
+}
+
+//go:generate counterfeiter . WaitingSteps
+
+// WaitingSteps records the build steps waiting for a compatible worker, so
+// that any ATC can report the demand for workers while they wait. Each step
+// expires unless it is refreshed, so that the steps of an ATC which went away
+// stop counting.
+type WaitingSteps interface {
+	Add(step WaitingStep, ttl time.Duration) (int, error)
+	Refresh(id int, ttl time.Duration) error
+	Remove(id int) error
+
+	// Steps returns the steps which have not expired.
+	Steps() ([]WaitingStep, error)
+}
+
+type waitingSteps struct {
+	conn Conn
+}
+
+func NewWaitingSteps(conn Conn) WaitingSteps {
+	return &waitingSteps{
+		conn: conn,
+	}
+}
+
+func (steps *waitingSteps) Add(step WaitingStep, ttl time.Duration) (int, error) {
+	// steps are only added once in a while, so this is a good time to get rid
+	// of the ones which expired
+	_, err := psql.Delete("waiting_steps").
+		Where(sq.Expr("expires_at < now()")).
+		RunWith(steps.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	tags := step.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var id int
+	err = psql.Insert("waiting_steps").
+		Columns("team_id", "tags", "platform", "expires_at").
+		Values(step.TeamID, pq.Array(tags), step.Platform, expiresAt(ttl)).
+		Suffix("RETURNING id").
+		RunWith(steps.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (steps *waitingSteps) Refresh(id int, ttl time.Duration) error {
+	_, err := psql.Update("waiting_steps").
+		Set("expires_at", expiresAt(ttl)).
+		Where(sq.Eq{"id": id}).
+		RunWith(steps.conn).
+		Exec()
+	return err
+}
+
+func (steps *waitingSteps) Remove(id int) error {
+	_, err := psql.Delete("waiting_steps").
+		Where(sq.Eq{"id": id}).
+		RunWith(steps.conn).
+		Exec()
+	return err
+}
+
+func (steps *waitingSteps) Steps() ([]WaitingStep, error) {
+	rows, err := psql.Select("team_id", "tags", "platform").
+		From("waiting_steps").
+		Where(sq.Expr("expires_at >= now()")).
+		OrderBy("id ASC").
+		RunWith(steps.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	waiting := []WaitingStep{}
+	for rows.Next() {
+		var step WaitingStep
+		err = rows.Scan(&step.TeamID, pq.Array(&step.Tags), &step.Platform)
+		if err != nil {
+			return nil, err
+		}
+
+		waiting = append(waiting, step)
+	}
+
+	return waiting, nil
+}
+
+func expiresAt(ttl time.Duration) sq.Sqlizer {
+	return sq.Expr(fmt.Sprintf("now() + '%d second'::interval", int(ttl.Seconds())))
+}
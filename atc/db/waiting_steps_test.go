@@ -0,0 +1,75 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WaitingSteps", func() {
+	var waitingSteps db.WaitingSteps
+
+	BeforeEach(func() {
+		waitingSteps = db.NewWaitingSteps(dbConn)
+	})
+
+	It("returns the steps until they are removed", func() {
+		id, err := waitingSteps.Add(db.WaitingStep{TeamID: 3, Tags: []string{"a", "b"}, Platform: "linux"}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = waitingSteps.Add(db.WaitingStep{Platform: "windows"}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		steps, err := waitingSteps.Steps()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(steps).To(Equal([]db.WaitingStep{
+			{TeamID: 3, Tags: []string{"a", "b"}, Platform: "linux"},
+			{Tags: []string{}, Platform: "windows"},
+		}))
+
+		Expect(waitingSteps.Remove(id)).To(Succeed())
+
+		steps, err = waitingSteps.Steps()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(steps).To(Equal([]db.WaitingStep{
+			{Tags: []string{}, Platform: "windows"},
+		}))
+	})
+
+	Context("when a step expires", func() {
+		var id int
+
+		BeforeEach(func() {
+			var err error
+			id, err = waitingSteps.Add(db.WaitingStep{Platform: "linux"}, -time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is no longer returned", func() {
+			steps, err := waitingSteps.Steps()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(BeEmpty())
+		})
+
+		It("is returned again once it is refreshed", func() {
+			Expect(waitingSteps.Refresh(id, time.Minute)).To(Succeed())
+
+			steps, err := waitingSteps.Steps()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(HaveLen(1))
+		})
+
+		It("is deleted when another step is added", func() {
+			_, err := waitingSteps.Add(db.WaitingStep{Platform: "linux"}, time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM waiting_steps`).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+	})
+})
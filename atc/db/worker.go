@@ -51,6 +51,8 @@ type Worker interface {
 	NoProxy() string
 	ActiveContainers() int
 	ActiveVolumes() int
+	MaxContainers() int
+	ActiveTasks() int
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
@@ -59,6 +61,8 @@ type Worker interface {
 	StartTime() int64
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	Runtime() string
+	Namespace() string
 
 	Reload() (bool, error)
 
@@ -67,6 +71,9 @@ type Worker interface {
 	Prune() error
 	Delete() error
 
+	IncreaseActiveTasks() error
+	DecreaseActiveTasks() error
+
 	FindContainer(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 }
@@ -84,6 +91,8 @@ type worker struct {
 	noProxy          string
 	activeContainers int
 	activeVolumes    int
+	maxContainers    int
+	activeTasks      int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
@@ -93,6 +102,8 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	runtime          string
+	namespace        string
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -107,12 +118,16 @@ func (worker *worker) HTTPSProxyURL() string                   { return worker.h
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
 func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
+func (worker *worker) MaxContainers() int                      { return worker.maxContainers }
+func (worker *worker) ActiveTasks() int                        { return worker.activeTasks }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) Runtime() string                         { return worker.runtime }
+func (worker *worker) Namespace() string                       { return worker.namespace }
 
 // TODO: normalize time values
 func (worker *worker) StartTime() int64     { return worker.startTime }
@@ -165,6 +180,39 @@ func (worker *worker) Land() error {
 	return nil
 }
 
+// IncreaseActiveTasks counts another task running on the worker.
+func (worker *worker) IncreaseActiveTasks() error {
+	return worker.updateActiveTasks(sq.Expr("active_tasks + 1"))
+}
+
+// DecreaseActiveTasks counts one fewer task running on the worker, never
+// going below zero.
+func (worker *worker) DecreaseActiveTasks() error {
+	return worker.updateActiveTasks(sq.Expr("GREATEST(active_tasks - 1, 0)"))
+}
+
+func (worker *worker) updateActiveTasks(activeTasks sq.Sqlizer) error {
+	var count int
+	err := psql.Update("workers").
+		Set("active_tasks", activeTasks).
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("RETURNING active_tasks").
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkerNotPresent
+		}
+
+		return err
+	}
+
+	worker.activeTasks = count
+
+	return nil
+}
+
 func (worker *worker) Retire() error {
 	result, err := psql.Update("workers").
 		SetMap(map[string]interface{}{
@@ -53,7 +53,11 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.runtime,
+		w.namespace,
+		w.max_containers,
+		w.active_tasks
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -139,6 +143,7 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     sql.NullInt64
 		expiresAt     *time.Time
 		ephemeral     sql.NullBool
+		namespace     sql.NullString
 	)
 
 	err := row.Scan(
@@ -161,6 +166,10 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&worker.runtime,
+		&namespace,
+		&worker.maxContainers,
+		&worker.activeTasks,
 	)
 	if err != nil {
 		return err
@@ -220,6 +229,10 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if namespace.Valid {
+		worker.namespace = namespace.String
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -228,6 +241,19 @@ func scanWorker(worker *worker, row scannable) error {
 	return json.Unmarshal(tags, &worker.tags)
 }
 
+// reconciledActiveTasks caps the worker's count of active tasks at the number
+// of task containers it has for running builds, so that tasks which were never
+// counted down, e.g. because the ATC running them went away, stop counting
+// towards the worker's limit once their builds are over.
+var reconciledActiveTasks = sq.Expr(`LEAST(active_tasks, (
+	SELECT COUNT(*)
+	FROM containers c
+	JOIN builds b ON b.id = c.build_id
+	WHERE c.worker_name = workers.name
+	AND c.meta_type = ?
+	AND b.status = ?
+))`, string(ContainerTypeTask), string(BuildStatusStarted))
+
 func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	// In order to be able to calculate the ttl that we return to the caller
 	// we must compare time.Now() to the worker.expires column
@@ -261,6 +287,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("expires", sq.Expr(expires)).
 		Set("active_containers", atcWorker.ActiveContainers).
 		Set("active_volumes", atcWorker.ActiveVolumes).
+		Set("active_tasks", reconciledActiveTasks).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -397,6 +424,16 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		workerVersion = &atcWorker.Version
 	}
 
+	runtime := atcWorker.Runtime
+	if runtime == "" {
+		runtime = atc.WorkerRuntimeGarden
+	}
+
+	var namespace *string
+	if atcWorker.Namespace != "" {
+		namespace = &atcWorker.Namespace
+	}
+
 	values := []interface{}{
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
@@ -415,6 +452,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		runtime,
+		namespace,
+		atcWorker.MaxContainers,
 	}
 
 	conflictValues := values
@@ -446,6 +486,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"runtime",
+			"namespace",
+			"max_containers",
 		).
 		Values(append([]interface{}{sq.Expr(expires)}, values...)...).
 		Suffix(`
@@ -467,7 +510,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				start_time = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				runtime = ?,
+				namespace = ?,
+				max_containers = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -503,6 +549,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		noProxy:          atcWorker.NoProxy,
 		activeContainers: atcWorker.ActiveContainers,
 		activeVolumes:    atcWorker.ActiveVolumes,
+		maxContainers:    atcWorker.MaxContainers,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
@@ -510,6 +557,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamID:           workerTeamID,
 		startTime:        atcWorker.StartTime,
 		ephemeral:        atcWorker.Ephemeral,
+		runtime:          runtime,
+		namespace:        atcWorker.Namespace,
 		conn:             conn,
 	}
 
@@ -28,6 +28,7 @@ var _ = Describe("WorkerFactory", func() {
 			Ephemeral:        true,
 			ActiveContainers: 140,
 			ActiveVolumes:    550,
+			MaxContainers:    200,
 			ResourceTypes: []atc.WorkerResourceType{
 				{
 					Type:       "some-resource-type",
@@ -202,6 +203,33 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(count).To(Equal(2))
 			})
+
+			It("defaults the runtime to garden", func() {
+				savedWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedWorker.Runtime()).To(Equal(atc.WorkerRuntimeGarden))
+				Expect(savedWorker.Namespace()).To(BeEmpty())
+			})
+
+			Context("when the worker runs on kubernetes", func() {
+				BeforeEach(func() {
+					atcWorker.Runtime = atc.WorkerRuntimeKubernetes
+					atcWorker.Namespace = "some-namespace"
+					atcWorker.GardenAddr = ""
+					atcWorker.BaggageclaimURL = ""
+				})
+
+				It("saves the runtime and namespace", func() {
+					_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+
+					foundWorker, found, err := workerFactory.GetWorker("some-name")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(foundWorker.Runtime()).To(Equal(atc.WorkerRuntimeKubernetes))
+					Expect(foundWorker.Namespace()).To(Equal("some-namespace"))
+				})
+			})
 		})
 	})
 
@@ -227,6 +255,7 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.Ephemeral()).To(Equal(true))
 				Expect(foundWorker.ActiveContainers()).To(Equal(140))
 				Expect(foundWorker.ActiveVolumes()).To(Equal(550))
+				Expect(foundWorker.MaxContainers()).To(Equal(200))
 				Expect(foundWorker.ResourceTypes()).To(Equal([]atc.WorkerResourceType{
 					{
 						Type:       "some-resource-type",
@@ -435,6 +464,45 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
 
+			Context("when the worker has counted more active tasks than it is running", func() {
+				var build db.Build
+
+				JustBeforeEach(func() {
+					worker, found, err := workerFactory.GetWorker(atcWorker.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					build, err = defaultTeam.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					started, err := build.Start(atc.Plan{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(started).To(BeTrue())
+
+					_, err = worker.CreateContainer(
+						db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-task"), defaultTeam.ID()),
+						db.ContainerMetadata{Type: db.ContainerTypeTask},
+					)
+					Expect(err).NotTo(HaveOccurred())
+
+					for i := 0; i < 3; i++ {
+						Expect(worker.IncreaseActiveTasks()).To(Succeed())
+					}
+				})
+
+				It("counts only the task containers of running builds", func() {
+					foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(foundWorker.ActiveTasks()).To(Equal(1))
+
+					Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+					foundWorker, err = workerFactory.HeartbeatWorker(atcWorker, ttl)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(foundWorker.ActiveTasks()).To(Equal(0))
+				})
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(db.WorkerStateLanding)
@@ -570,7 +638,7 @@ var _ = Describe("WorkerFactory", func() {
 					}
 
 					var err error
-					otherPipeline, _, err := defaultTeam.SavePipeline("other-pipeline", atc.Config{
+					otherPipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 						Resources: atc.ResourceConfigs{
 							{
 								Name: "some-resource",
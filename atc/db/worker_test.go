@@ -131,6 +131,52 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("IncreaseActiveTasks/DecreaseActiveTasks", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the tasks running on the worker", func() {
+			Expect(worker.IncreaseActiveTasks()).To(Succeed())
+			Expect(worker.IncreaseActiveTasks()).To(Succeed())
+			Expect(worker.DecreaseActiveTasks()).To(Succeed())
+			Expect(worker.ActiveTasks()).To(Equal(1))
+
+			_, err := worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.ActiveTasks()).To(Equal(1))
+		})
+
+		It("does not go below zero", func() {
+			Expect(worker.DecreaseActiveTasks()).To(Succeed())
+			Expect(worker.ActiveTasks()).To(Equal(0))
+		})
+
+		It("is kept when the worker registers again", func() {
+			Expect(worker.IncreaseActiveTasks()).To(Succeed())
+
+			worker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.ActiveTasks()).To(Equal(1))
+		})
+
+		Context("when the worker is not present", func() {
+			BeforeEach(func() {
+				err := worker.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				Expect(worker.IncreaseActiveTasks()).To(Equal(ErrWorkerNotPresent))
+			})
+		})
+	})
+
 	Describe("Delete", func() {
 		BeforeEach(func() {
 			var err error
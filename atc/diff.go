@@ -1,4 +1,4 @@
-package setpipelinehelpers
+package atc
 
 import (
 	"bytes"
@@ -8,12 +8,126 @@ import (
 	"strings"
 
 	"github.com/aryann/difflib"
-	"github.com/concourse/concourse/atc"
 	"github.com/mgutz/ansi"
 	"github.com/onsi/gomega/gexec"
 	"gopkg.in/yaml.v2"
 )
 
+// Diff writes a human-readable rendering of the differences between the
+// config and newConfig to out, returning whether any differences were found.
+func (c Config) Diff(out io.Writer, newConfig Config) bool {
+	var diffExists bool
+
+	indent := gexec.NewPrefixedWriter("  ", out)
+
+	groupDiffs := groupDiffIndices(GroupIndex(c.Groups), GroupIndex(newConfig.Groups))
+	if len(groupDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "groups:")
+
+		for _, diff := range groupDiffs {
+			diff.Render(indent, "group")
+		}
+	}
+
+	resourceDiffs := diffIndices(ResourceIndex(c.Resources), ResourceIndex(newConfig.Resources))
+	if len(resourceDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "resources:")
+
+		for _, diff := range resourceDiffs {
+			diff.Render(indent, "resource")
+		}
+	}
+
+	resourceTypeDiffs := diffIndices(ResourceTypeIndex(c.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))
+	if len(resourceTypeDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "resource types:")
+
+		for _, diff := range resourceTypeDiffs {
+			diff.Render(indent, "resource type")
+		}
+	}
+
+	jobDiffs := diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs))
+	if len(jobDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "jobs:")
+
+		for _, diff := range jobDiffs {
+			diff.Render(indent, "job")
+		}
+	}
+
+	return diffExists
+}
+
+// ConfigChange is a single difference between two configs, with the
+// changed object rendered as YAML before and after the change.
+type ConfigChange struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Changes returns the differences between the config and newConfig.
+func (c Config) Changes(newConfig Config) []ConfigChange {
+	changes := []ConfigChange{}
+
+	kinds := []struct {
+		kind  string
+		diffs Diffs
+	}{
+		{"group", groupDiffIndices(GroupIndex(c.Groups), GroupIndex(newConfig.Groups))},
+		{"resource", diffIndices(ResourceIndex(c.Resources), ResourceIndex(newConfig.Resources))},
+		{"resource type", diffIndices(ResourceTypeIndex(c.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))},
+		{"job", diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs))},
+	}
+
+	for _, k := range kinds {
+		for _, diff := range k.diffs {
+			change := ConfigChange{Kind: k.kind}
+
+			if diff.Before != nil {
+				change.Name = name(diff.Before)
+
+				payload, _ := yaml.Marshal(diff.Before)
+				change.Before = string(payload)
+			}
+
+			if diff.After != nil {
+				change.Name = name(diff.After)
+
+				payload, _ := yaml.Marshal(diff.After)
+				change.After = string(payload)
+			}
+
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// Render writes a human-readable rendering of the change to out.
+func (change ConfigChange) Render(to io.Writer) {
+	var verb string
+	switch {
+	case change.Before != "" && change.After != "":
+		verb = "has changed"
+	case change.Before != "":
+		verb = "has been removed"
+	default:
+		verb = "has been added"
+	}
+
+	fmt.Fprintf(to, ansi.Color("%s %s %s:", "yellow")+"\n", change.Kind, change.Name, verb)
+
+	renderDiff(gexec.NewPrefixedWriter("  ", to), change.Before, change.After)
+}
+
 type Index interface {
 	FindEquivalent(interface{}) (interface{}, bool)
 	Slice() []interface{}
@@ -54,7 +168,7 @@ func (diff Diff) Render(to io.Writer, label string) {
 	}
 }
 
-type GroupIndex atc.GroupConfigs
+type GroupIndex GroupConfigs
 
 func (index GroupIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -66,10 +180,10 @@ func (index GroupIndex) Slice() []interface{} {
 }
 
 func (index GroupIndex) FindEquivalentWithOrder(obj interface{}) (interface{}, int, bool) {
-	return atc.GroupConfigs(index).Lookup(name(obj))
+	return GroupConfigs(index).Lookup(name(obj))
 }
 
-type JobIndex atc.JobConfigs
+type JobIndex JobConfigs
 
 func (index JobIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -81,10 +195,10 @@ func (index JobIndex) Slice() []interface{} {
 }
 
 func (index JobIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.JobConfigs(index).Lookup(name(obj))
+	return JobConfigs(index).Lookup(name(obj))
 }
 
-type ResourceIndex atc.ResourceConfigs
+type ResourceIndex ResourceConfigs
 
 func (index ResourceIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -96,10 +210,10 @@ func (index ResourceIndex) Slice() []interface{} {
 }
 
 func (index ResourceIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.ResourceConfigs(index).Lookup(name(obj))
+	return ResourceConfigs(index).Lookup(name(obj))
 }
 
-type ResourceTypeIndex atc.ResourceTypes
+type ResourceTypeIndex ResourceTypes
 
 func (index ResourceTypeIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -111,7 +225,7 @@ func (index ResourceTypeIndex) Slice() []interface{} {
 }
 
 func (index ResourceTypeIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.ResourceTypes(index).Lookup(name(obj))
+	return ResourceTypes(index).Lookup(name(obj))
 }
 
 func groupDiffIndices(oldIndex GroupIndex, newIndex GroupIndex) Diffs {
@@ -0,0 +1,60 @@
+package atc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("Config", func() {
+	Describe("Changes", func() {
+		var oldConfig, newConfig atc.Config
+
+		BeforeEach(func() {
+			oldConfig = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "git"},
+					{Name: "removed-resource", Type: "git"},
+				},
+				Jobs: atc.JobConfigs{
+					{Name: "some-job", Public: false},
+				},
+			}
+
+			newConfig = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "git"},
+				},
+				Jobs: atc.JobConfigs{
+					{Name: "some-job", Public: true},
+					{Name: "added-job"},
+				},
+			}
+		})
+
+		It("returns the added, removed and changed objects", func() {
+			changes := oldConfig.Changes(newConfig)
+			Expect(changes).To(HaveLen(3))
+
+			Expect(changes[0].Kind).To(Equal("resource"))
+			Expect(changes[0].Name).To(Equal("removed-resource"))
+			Expect(changes[0].Before).To(ContainSubstring("name: removed-resource"))
+			Expect(changes[0].After).To(BeEmpty())
+
+			Expect(changes[1].Kind).To(Equal("job"))
+			Expect(changes[1].Name).To(Equal("some-job"))
+			Expect(changes[1].Before).ToNot(ContainSubstring("public: true"))
+			Expect(changes[1].After).To(ContainSubstring("public: true"))
+
+			Expect(changes[2].Kind).To(Equal("job"))
+			Expect(changes[2].Name).To(Equal("added-job"))
+			Expect(changes[2].Before).To(BeEmpty())
+			Expect(changes[2].After).To(ContainSubstring("name: added-job"))
+		})
+
+		It("returns no changes for the same config", func() {
+			Expect(oldConfig.Changes(oldConfig)).To(BeEmpty())
+		})
+	})
+})
@@ -1,11 +1,14 @@
 package builder
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"strings"
 
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 )
@@ -15,9 +18,12 @@ const supportedSchema = "exec.v2"
 //go:generate counterfeiter . StepFactory
 
 type StepFactory interface {
-	GetStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) exec.Step
-	PutStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) exec.Step
-	TaskStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.TaskDelegate) exec.Step
+	GetStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.GetDelegate) exec.Step
+	PutStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.PutDelegate) exec.Step
+	TaskStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.TaskDelegate) exec.Step
+	SetPipelineStep(atc.Plan, exec.StepMetadata, exec.BuildStepDelegate) exec.Step
+	LoadVarStep(atc.Plan, exec.StepMetadata, *creds.BuildVariables, exec.BuildStepDelegate) exec.Step
+	ApproveStep(atc.Plan, exec.StepMetadata, exec.ApproveDelegate) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
 }
@@ -25,21 +31,26 @@ type StepFactory interface {
 //go:generate counterfeiter . DelegateFactory
 
 type DelegateFactory interface {
-	GetDelegate(db.Build, atc.PlanID) exec.GetDelegate
-	PutDelegate(db.Build, atc.PlanID) exec.PutDelegate
-	TaskDelegate(db.Build, atc.PlanID) exec.TaskDelegate
-	BuildStepDelegate(db.Build, atc.PlanID) exec.BuildStepDelegate
+	GetDelegate(db.Build, atc.PlanID, *creds.BuildVariables) exec.GetDelegate
+	PutDelegate(db.Build, atc.PlanID, *creds.BuildVariables) exec.PutDelegate
+	TaskDelegate(db.Build, atc.PlanID, *creds.BuildVariables) exec.TaskDelegate
+	ApproveDelegate(db.Build, atc.PlanID, *creds.BuildVariables) exec.ApproveDelegate
+	BuildStepDelegate(db.Build, atc.PlanID, *creds.BuildVariables) exec.BuildStepDelegate
 }
 
 func NewStepBuilder(
 	stepFactory StepFactory,
 	delegateFactory DelegateFactory,
 	externalURL string,
+	varSourcePool creds.VarSourcePool,
+	redactSecrets bool,
 ) *stepBuilder {
 	return &stepBuilder{
 		stepFactory:     stepFactory,
 		delegateFactory: delegateFactory,
 		externalURL:     externalURL,
+		varSourcePool:   varSourcePool,
+		redactSecrets:   redactSecrets,
 	}
 }
 
@@ -47,6 +58,8 @@ type stepBuilder struct {
 	stepFactory     StepFactory
 	delegateFactory DelegateFactory
 	externalURL     string
+	varSourcePool   creds.VarSourcePool
+	redactSecrets   bool
 }
 
 func (builder *stepBuilder) BuildStep(build db.Build) (exec.Step, error) {
@@ -59,185 +72,296 @@ func (builder *stepBuilder) BuildStep(build db.Build) (exec.Step, error) {
 		return exec.IdentityStep{}, errors.New("Schema not supported")
 	}
 
-	return builder.buildStep(build, build.PrivatePlan()), nil
+	buildVars := creds.NewBuildVariables()
+	if builder.redactSecrets {
+		buildVars.TrackSecrets()
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		return exec.IdentityStep{}, err
+	}
+
+	if found && len(pipeline.VarSources()) != 0 {
+		buildVars.UseVarSources(creds.NewVarSources(builder.varSourcePool, pipeline.VarSources(), creds.SecretAccessor{
+			TeamName:     build.TeamName(),
+			PipelineName: pipeline.Name(),
+			BuildID:      build.ID(),
+		}))
+	}
+
+	return builder.buildStep(build, build.PrivatePlan(), buildVars), nil
 }
 
-func (builder *stepBuilder) buildStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	if plan.Aggregate != nil {
-		return builder.buildAggregateStep(build, plan)
+		return builder.buildAggregateStep(build, plan, buildVars)
 	}
 
 	if plan.InParallel != nil {
-		return builder.buildParallelStep(build, plan)
+		return builder.buildParallelStep(build, plan, buildVars)
+	}
+
+	if plan.Across != nil {
+		return builder.buildAcrossStep(build, plan, buildVars)
 	}
 
 	if plan.Do != nil {
-		return builder.buildDoStep(build, plan)
+		return builder.buildDoStep(build, plan, buildVars)
 	}
 
 	if plan.Timeout != nil {
-		return builder.buildTimeoutStep(build, plan)
+		return builder.buildTimeoutStep(build, plan, buildVars)
 	}
 
 	if plan.Try != nil {
-		return builder.buildTryStep(build, plan)
+		return builder.buildTryStep(build, plan, buildVars)
 	}
 
 	if plan.OnAbort != nil {
-		return builder.buildOnAbortStep(build, plan)
+		return builder.buildOnAbortStep(build, plan, buildVars)
 	}
 
 	if plan.OnError != nil {
-		return builder.buildOnErrorStep(build, plan)
+		return builder.buildOnErrorStep(build, plan, buildVars)
 	}
 
 	if plan.OnSuccess != nil {
-		return builder.buildOnSuccessStep(build, plan)
+		return builder.buildOnSuccessStep(build, plan, buildVars)
 	}
 
 	if plan.OnFailure != nil {
-		return builder.buildOnFailureStep(build, plan)
+		return builder.buildOnFailureStep(build, plan, buildVars)
 	}
 
 	if plan.Ensure != nil {
-		return builder.buildEnsureStep(build, plan)
+		return builder.buildEnsureStep(build, plan, buildVars)
 	}
 
 	if plan.Task != nil {
-		return builder.buildTaskStep(build, plan)
+		return builder.buildTaskStep(build, plan, buildVars)
 	}
 
 	if plan.Get != nil {
-		return builder.buildGetStep(build, plan)
+		return builder.buildGetStep(build, plan, buildVars)
+	}
+
+	if plan.SetPipeline != nil {
+		return builder.buildSetPipelineStep(build, plan, buildVars)
+	}
+
+	if plan.LoadVar != nil {
+		return builder.buildLoadVarStep(build, plan, buildVars)
+	}
+
+	if plan.Approve != nil {
+		return builder.buildApproveStep(build, plan, buildVars)
 	}
 
 	if plan.Put != nil {
-		return builder.buildPutStep(build, plan)
+		return builder.buildPutStep(build, plan, buildVars)
 	}
 
 	if plan.Retry != nil {
-		return builder.buildRetryStep(build, plan)
+		return builder.buildRetryStep(build, plan, buildVars)
 	}
 
 	if plan.ArtifactInput != nil {
-		return builder.buildArtifactInputStep(build, plan)
+		return builder.buildArtifactInputStep(build, plan, buildVars)
 	}
 
 	if plan.ArtifactOutput != nil {
-		return builder.buildArtifactOutputStep(build, plan)
+		return builder.buildArtifactOutputStep(build, plan, buildVars)
 	}
 
 	return exec.IdentityStep{}
 }
 
-func (builder *stepBuilder) buildAggregateStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildAggregateStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	agg := exec.AggregateStep{}
 
 	for _, innerPlan := range *plan.Aggregate {
 		innerPlan.Attempts = plan.Attempts
-		step := builder.buildStep(build, innerPlan)
+		step := builder.buildStep(build, innerPlan, buildVars)
 		agg = append(agg, step)
 	}
 
 	return agg
 }
 
-func (builder *stepBuilder) buildParallelStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildParallelStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	var steps []exec.Step
 
 	for _, innerPlan := range plan.InParallel.Steps {
 		innerPlan.Attempts = plan.Attempts
-		step := builder.buildStep(build, innerPlan)
+		step := builder.buildStep(build, innerPlan, buildVars)
 		steps = append(steps, step)
 	}
 
 	return exec.InParallel(steps, plan.InParallel.Limit, plan.InParallel.FailFast)
 }
 
-func (builder *stepBuilder) buildDoStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildAcrossStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
+	if plan.Across.Step == nil {
+		return builder.buildAcrossVar(build, plan, 0, plan.Across.Steps, buildVars)
+	}
+
+	// the vars' values are only known once the build runs, so the step is
+	// constructed for each combination then, with its own plan IDs so that
+	// the combinations' events can be told apart. the combinations are saved
+	// in the build's plan before they run, so that they can be shown and, e.g.
+	// in the case of approve steps, decided like any other step.
+	return exec.Across(plan.Across.Vars, plan.Across.FailFast, buildVars, func(combinations [][]interface{}) ([]exec.Step, error) {
+		scopedPlans := make([]atc.VarScopedPlan, len(combinations))
+		for index, values := range combinations {
+			innerPlan, err := plan.Across.Step.WithIDSuffix("/" + strconv.Itoa(index))
+			if err != nil {
+				return nil, err
+			}
+
+			scopedPlans[index] = atc.VarScopedPlan{
+				Step:   innerPlan,
+				Values: values,
+			}
+		}
+
+		err := build.ExpandAcross(plan.ID, scopedPlans)
+		if err != nil {
+			return nil, err
+		}
+
+		steps := make([]exec.Step, len(scopedPlans))
+		for index, scopedPlan := range scopedPlans {
+			scope := buildVars.NewLocalScope()
+			for i, acrossVar := range plan.Across.Vars {
+				scope.AddLocalVar(acrossVar.Var, scopedPlan.Values[i], false)
+			}
+
+			innerPlan := scopedPlan.Step
+			innerPlan.Attempts = plan.Attempts
+			steps[index] = builder.buildStep(build, innerPlan, scope)
+		}
+
+		return steps, nil
+	})
+}
+
+// buildAcrossVar runs the given combinations in parallel for each value of the
+// var at the given index, nesting the remaining vars beneath it so that each
+// var's max_in_flight is respected.
+func (builder *stepBuilder) buildAcrossVar(build db.Build, plan atc.Plan, index int, steps []atc.VarScopedPlan, buildVars *creds.BuildVariables) exec.Step {
+	if index == len(plan.Across.Vars) {
+		scope := buildVars.NewLocalScope()
+		for i, acrossVar := range plan.Across.Vars {
+			scope.AddLocalVar(acrossVar.Var, steps[0].Values[i], false)
+		}
+
+		innerPlan := steps[0].Step
+		innerPlan.Attempts = plan.Attempts
+		return builder.buildStep(build, innerPlan, scope)
+	}
+
+	acrossVar := plan.Across.Vars[index]
+	if len(acrossVar.Values) == 0 {
+		return exec.IdentityStep{}
+	}
+
+	// combinations are ordered with the last var varying fastest, so each of
+	// this var's values covers a contiguous chunk of them
+	chunk := len(steps) / len(acrossVar.Values)
+
+	var substeps []exec.Step
+	for i := range acrossVar.Values {
+		substeps = append(substeps, builder.buildAcrossVar(build, plan, index+1, steps[i*chunk:(i+1)*chunk], buildVars))
+	}
+
+	return exec.InParallel(substeps, acrossVar.MaxInFlight, plan.Across.FailFast)
+}
+
+func (builder *stepBuilder) buildDoStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	var step exec.Step = exec.IdentityStep{}
 
 	for i := len(*plan.Do) - 1; i >= 0; i-- {
 		innerPlan := (*plan.Do)[i]
 		innerPlan.Attempts = plan.Attempts
-		previous := builder.buildStep(build, innerPlan)
+		previous := builder.buildStep(build, innerPlan, buildVars)
 		step = exec.OnSuccess(previous, step)
 	}
 
 	return step
 }
 
-func (builder *stepBuilder) buildTimeoutStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildTimeoutStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	innerPlan := plan.Timeout.Step
 	innerPlan.Attempts = plan.Attempts
-	step := builder.buildStep(build, innerPlan)
+	step := builder.buildStep(build, innerPlan, buildVars)
 	return exec.Timeout(step, plan.Timeout.Duration)
 }
 
-func (builder *stepBuilder) buildTryStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildTryStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
-	step := builder.buildStep(build, innerPlan)
+	step := builder.buildStep(build, innerPlan, buildVars)
 	return exec.Try(step)
 }
 
-func (builder *stepBuilder) buildOnAbortStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildOnAbortStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	plan.OnAbort.Step.Attempts = plan.Attempts
-	step := builder.buildStep(build, plan.OnAbort.Step)
+	step := builder.buildStep(build, plan.OnAbort.Step, buildVars)
 	plan.OnAbort.Next.Attempts = plan.Attempts
-	next := builder.buildStep(build, plan.OnAbort.Next)
+	next := builder.buildStep(build, plan.OnAbort.Next, buildVars)
 	return exec.OnAbort(step, next)
 }
 
-func (builder *stepBuilder) buildOnErrorStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildOnErrorStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	plan.OnError.Step.Attempts = plan.Attempts
-	step := builder.buildStep(build, plan.OnError.Step)
+	step := builder.buildStep(build, plan.OnError.Step, buildVars)
 	plan.OnError.Next.Attempts = plan.Attempts
-	next := builder.buildStep(build, plan.OnError.Next)
+	next := builder.buildStep(build, plan.OnError.Next, buildVars)
 	return exec.OnError(step, next)
 }
 
-func (builder *stepBuilder) buildOnSuccessStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildOnSuccessStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	plan.OnSuccess.Step.Attempts = plan.Attempts
-	step := builder.buildStep(build, plan.OnSuccess.Step)
+	step := builder.buildStep(build, plan.OnSuccess.Step, buildVars)
 	plan.OnSuccess.Next.Attempts = plan.Attempts
-	next := builder.buildStep(build, plan.OnSuccess.Next)
+	next := builder.buildStep(build, plan.OnSuccess.Next, buildVars)
 	return exec.OnSuccess(step, next)
 }
 
-func (builder *stepBuilder) buildOnFailureStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildOnFailureStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	plan.OnFailure.Step.Attempts = plan.Attempts
-	step := builder.buildStep(build, plan.OnFailure.Step)
+	step := builder.buildStep(build, plan.OnFailure.Step, buildVars)
 	plan.OnFailure.Next.Attempts = plan.Attempts
-	next := builder.buildStep(build, plan.OnFailure.Next)
+	next := builder.buildStep(build, plan.OnFailure.Next, buildVars)
 	return exec.OnFailure(step, next)
 }
 
-func (builder *stepBuilder) buildEnsureStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildEnsureStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	plan.Ensure.Step.Attempts = plan.Attempts
-	step := builder.buildStep(build, plan.Ensure.Step)
+	step := builder.buildStep(build, plan.Ensure.Step, buildVars)
 	plan.Ensure.Next.Attempts = plan.Attempts
-	next := builder.buildStep(build, plan.Ensure.Next)
+	next := builder.buildStep(build, plan.Ensure.Next, buildVars)
 	return exec.Ensure(step, next)
 }
 
-func (builder *stepBuilder) buildRetryStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildRetryStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 	steps := []exec.Step{}
 
 	for index, innerPlan := range *plan.Retry {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
-		step := builder.buildStep(build, innerPlan)
+		step := builder.buildStep(build, innerPlan, buildVars)
 		steps = append(steps, step)
 	}
 
 	return exec.Retry(steps...)
 }
 
-func (builder *stepBuilder) buildGetStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildGetStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	containerMetadata := builder.containerMetadata(
 		build,
@@ -251,15 +375,18 @@ func (builder *stepBuilder) buildGetStep(build db.Build, plan atc.Plan) exec.Ste
 		builder.externalURL,
 	)
 
-	return builder.stepFactory.GetStep(
+	delegate := builder.delegateFactory.GetDelegate(build, plan.ID, buildVars)
+
+	return flushOutput(builder.stepFactory.GetStep(
 		plan,
 		stepMetadata,
 		containerMetadata,
-		builder.delegateFactory.GetDelegate(build, plan.ID),
-	)
+		buildVars,
+		delegate,
+	), delegate)
 }
 
-func (builder *stepBuilder) buildPutStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildPutStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	containerMetadata := builder.containerMetadata(
 		build,
@@ -273,15 +400,18 @@ func (builder *stepBuilder) buildPutStep(build db.Build, plan atc.Plan) exec.Ste
 		builder.externalURL,
 	)
 
-	return builder.stepFactory.PutStep(
+	delegate := builder.delegateFactory.PutDelegate(build, plan.ID, buildVars)
+
+	return flushOutput(builder.stepFactory.PutStep(
 		plan,
 		stepMetadata,
 		containerMetadata,
-		builder.delegateFactory.PutDelegate(build, plan.ID),
-	)
+		buildVars,
+		delegate,
+	), delegate)
 }
 
-func (builder *stepBuilder) buildTaskStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildTaskStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	containerMetadata := builder.containerMetadata(
 		build,
@@ -295,29 +425,79 @@ func (builder *stepBuilder) buildTaskStep(build db.Build, plan atc.Plan) exec.St
 		builder.externalURL,
 	)
 
-	return builder.stepFactory.TaskStep(
+	delegate := builder.delegateFactory.TaskDelegate(build, plan.ID, buildVars)
+
+	return flushOutput(builder.stepFactory.TaskStep(
 		plan,
 		stepMetadata,
 		containerMetadata,
-		builder.delegateFactory.TaskDelegate(build, plan.ID),
+		buildVars,
+		delegate,
+	), delegate)
+}
+
+func (builder *stepBuilder) buildSetPipelineStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
+
+	stepMetadata := builder.stepMetadata(
+		build,
+		builder.externalURL,
 	)
+
+	delegate := builder.delegateFactory.BuildStepDelegate(build, plan.ID, buildVars)
+
+	return flushOutput(builder.stepFactory.SetPipelineStep(
+		plan,
+		stepMetadata,
+		delegate,
+	), delegate)
 }
 
-func (builder *stepBuilder) buildArtifactInputStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildLoadVarStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
+
+	stepMetadata := builder.stepMetadata(
+		build,
+		builder.externalURL,
+	)
+
+	delegate := builder.delegateFactory.BuildStepDelegate(build, plan.ID, buildVars)
+
+	return flushOutput(builder.stepFactory.LoadVarStep(
+		plan,
+		stepMetadata,
+		buildVars,
+		delegate,
+	), delegate)
+}
+
+func (builder *stepBuilder) buildApproveStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
+
+	stepMetadata := builder.stepMetadata(
+		build,
+		builder.externalURL,
+	)
+
+	return builder.stepFactory.ApproveStep(
+		plan,
+		stepMetadata,
+		builder.delegateFactory.ApproveDelegate(build, plan.ID, buildVars),
+	)
+}
+
+func (builder *stepBuilder) buildArtifactInputStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	return builder.stepFactory.ArtifactInputStep(
 		plan,
 		build,
-		builder.delegateFactory.BuildStepDelegate(build, plan.ID),
+		builder.delegateFactory.BuildStepDelegate(build, plan.ID, buildVars),
 	)
 }
 
-func (builder *stepBuilder) buildArtifactOutputStep(build db.Build, plan atc.Plan) exec.Step {
+func (builder *stepBuilder) buildArtifactOutputStep(build db.Build, plan atc.Plan, buildVars *creds.BuildVariables) exec.Step {
 
 	return builder.stepFactory.ArtifactOutputStep(
 		plan,
 		build,
-		builder.delegateFactory.BuildStepDelegate(build, plan.ID),
+		builder.delegateFactory.BuildStepDelegate(build, plan.ID, buildVars),
 	)
 }
 
@@ -364,3 +544,25 @@ func (builder *stepBuilder) stepMetadata(
 		ExternalURL:  externalURL,
 	}
 }
+
+// flushOutput wraps a step which writes output through its delegate, so that
+// any output held back to be redacted is saved when the step exits, including
+// when it errors, is aborted or times out.
+func flushOutput(step exec.Step, delegate exec.BuildStepDelegate) exec.Step {
+	return flushOutputStep{
+		Step:     step,
+		delegate: delegate,
+	}
+}
+
+type flushOutputStep struct {
+	exec.Step
+
+	delegate exec.BuildStepDelegate
+}
+
+func (step flushOutputStep) Run(ctx context.Context, state exec.RunState) error {
+	defer step.delegate.Flush(lagerctx.FromContext(ctx))
+
+	return step.Step.Run(ctx, state)
+}
@@ -1,12 +1,19 @@
 package builder_test
 
 import (
+	"context"
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/engine/builder/builderfakes"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -25,6 +32,8 @@ var _ = Describe("Builder", func() {
 
 			fakeStepFactory     *builderfakes.FakeStepFactory
 			fakeDelegateFactory *builderfakes.FakeDelegateFactory
+			fakeVarSourcePool   *credsfakes.FakeVarSourcePool
+			redactSecrets       bool
 
 			planFactory atc.PlanFactory
 			stepBuilder StepBuilder
@@ -33,14 +42,20 @@ var _ = Describe("Builder", func() {
 		BeforeEach(func() {
 			fakeStepFactory = new(builderfakes.FakeStepFactory)
 			fakeDelegateFactory = new(builderfakes.FakeDelegateFactory)
+			fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+			redactSecrets = true
 
+			planFactory = atc.NewPlanFactory(123)
+		})
+
+		JustBeforeEach(func() {
 			stepBuilder = builder.NewStepBuilder(
 				fakeStepFactory,
 				fakeDelegateFactory,
 				"http://example.com",
+				fakeVarSourcePool,
+				redactSecrets,
 			)
-
-			planFactory = atc.NewPlanFactory(123)
 		})
 
 		Context("with no build", func() {
@@ -59,6 +74,7 @@ var _ = Describe("Builder", func() {
 
 				expectedPlan     atc.Plan
 				expectedMetadata exec.StepMetadata
+				builtStep        exec.Step
 			)
 
 			BeforeEach(func() {
@@ -88,7 +104,7 @@ var _ = Describe("Builder", func() {
 			JustBeforeEach(func() {
 				fakeBuild.PrivatePlanReturns(expectedPlan)
 
-				_, err = stepBuilder.BuildStep(fakeBuild)
+				builtStep, err = stepBuilder.BuildStep(fakeBuild)
 			})
 
 			Context("when the build has the wrong schema", func() {
@@ -110,6 +126,331 @@ var _ = Describe("Builder", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 
+				Context("with a step across vars", func() {
+					var taskPlans []atc.Plan
+
+					BeforeEach(func() {
+						taskPlans = nil
+
+						var steps []atc.VarScopedPlan
+						for _, goVersion := range []string{"1.12", "1.13"} {
+							for _, db := range []string{"postgres", "mysql"} {
+								taskPlan := planFactory.NewPlan(atc.TaskPlan{
+									Name:       "some-task",
+									ConfigPath: "some-artifact/task.yml",
+								})
+
+								taskPlans = append(taskPlans, taskPlan)
+								steps = append(steps, atc.VarScopedPlan{
+									Step:   taskPlan,
+									Values: []interface{}{goVersion, db},
+								})
+							}
+						}
+
+						expectedPlan = planFactory.NewPlan(atc.AcrossPlan{
+							Vars: []atc.AcrossVar{
+								{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 1},
+								{Var: "db", Values: []interface{}{"postgres", "mysql"}},
+							},
+							Steps:    steps,
+							FailFast: true,
+						})
+					})
+
+					It("constructs a step for each combination", func() {
+						Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(4))
+
+						for i, taskPlan := range taskPlans {
+							plan, stepMetadata, _, _, _ := fakeStepFactory.TaskStepArgsForCall(i)
+							Expect(plan).To(Equal(taskPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadata))
+						}
+					})
+
+					It("scopes the vars' values to each combination", func() {
+						expectedValues := [][]string{
+							{"1.12", "postgres"},
+							{"1.12", "mysql"},
+							{"1.13", "postgres"},
+							{"1.13", "mysql"},
+						}
+
+						for i, values := range expectedValues {
+							_, _, _, buildVars, _ := fakeStepFactory.TaskStepArgsForCall(i)
+
+							params, err := creds.NewParams(buildVars.Scope(nil), atc.Params{
+								"go": "((.:go))",
+								"db": "((.:db))",
+							}).Evaluate()
+							Expect(err).ToNot(HaveOccurred())
+							Expect(params).To(Equal(atc.Params{"go": values[0], "db": values[1]}))
+
+							_, _, delegateBuildVars := fakeDelegateFactory.TaskDelegateArgsForCall(i)
+							Expect(delegateBuildVars).To(BeIdenticalTo(buildVars))
+						}
+					})
+				})
+
+				Context("with a step across vars whose values are only known when the build runs", func() {
+					var taskPlan atc.Plan
+
+					BeforeEach(func() {
+						taskPlan = planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-artifact/task.yml",
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.AcrossPlan{
+							Vars: []atc.AcrossVar{
+								{Var: "go", Values: []interface{}{"1.12", "1.13"}},
+							},
+							Step: &taskPlan,
+						})
+
+						fakeStepFactory.TaskStepReturns(new(execfakes.FakeStep))
+						fakeDelegateFactory.TaskDelegateReturns(new(execfakes.FakeTaskDelegate))
+					})
+
+					It("does not construct any steps until it runs", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeStepFactory.TaskStepCallCount()).To(BeZero())
+					})
+
+					Context("when it runs", func() {
+						JustBeforeEach(func() {
+							Expect(builtStep.Run(context.Background(), new(execfakes.FakeRunState))).To(Succeed())
+						})
+
+						It("constructs a step for each combination, with its own plan IDs", func() {
+							Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(2))
+
+							for i, id := range []atc.PlanID{taskPlan.ID + "/0", taskPlan.ID + "/1"} {
+								plan, stepMetadata, _, _, _ := fakeStepFactory.TaskStepArgsForCall(i)
+								Expect(plan.ID).To(Equal(id))
+								Expect(plan.Task).To(Equal(taskPlan.Task))
+								Expect(stepMetadata).To(Equal(expectedMetadata))
+							}
+						})
+
+						It("saves the combinations in the build's plan", func() {
+							Expect(fakeBuild.ExpandAcrossCallCount()).To(Equal(1))
+
+							planID, steps := fakeBuild.ExpandAcrossArgsForCall(0)
+							Expect(planID).To(Equal(expectedPlan.ID))
+							Expect(steps).To(HaveLen(2))
+							Expect(steps[0].Step.ID).To(Equal(taskPlan.ID + "/0"))
+							Expect(steps[0].Values).To(Equal([]interface{}{"1.12"}))
+							Expect(steps[1].Step.ID).To(Equal(taskPlan.ID + "/1"))
+							Expect(steps[1].Values).To(Equal([]interface{}{"1.13"}))
+						})
+
+						It("scopes the var's value to each combination", func() {
+							for i, value := range []string{"1.12", "1.13"} {
+								_, _, _, buildVars, _ := fakeStepFactory.TaskStepArgsForCall(i)
+
+								val, found, err := buildVars.Get(template.VariableDefinition{Name: ".:go"})
+								Expect(err).ToNot(HaveOccurred())
+								Expect(found).To(BeTrue())
+								Expect(val).To(Equal(value))
+							}
+						})
+					})
+				})
+
+				Context("with an across step whose combinations cannot be saved", func() {
+					BeforeEach(func() {
+						taskPlan := planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+
+						expectedPlan = planFactory.NewPlan(atc.AcrossPlan{
+							Vars: []atc.AcrossVar{{Var: "go", Values: []interface{}{"1.12"}}},
+							Step: &taskPlan,
+						})
+
+						fakeBuild.ExpandAcrossReturns(errors.New("nope"))
+					})
+
+					It("errors without running them", func() {
+						Expect(builtStep.Run(context.Background(), new(execfakes.FakeRunState))).To(MatchError("nope"))
+						Expect(fakeStepFactory.TaskStepCallCount()).To(BeZero())
+					})
+				})
+
+				Context("with a load_var followed by a put", func() {
+					var (
+						loadVarPlan atc.Plan
+						putPlan     atc.Plan
+					)
+
+					BeforeEach(func() {
+						loadVarPlan = planFactory.NewPlan(atc.LoadVarPlan{
+							Name: "some-var",
+							File: "some-artifact/version",
+						})
+
+						putPlan = planFactory.NewPlan(atc.PutPlan{
+							Name:     "some-put",
+							Resource: "some-output-resource",
+							Type:     "put",
+							Source:   atc.Source{"some": "source"},
+							Params:   atc.Params{"version": "((.:some-var))"},
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.DoPlan{loadVarPlan, putPlan})
+					})
+
+					It("constructs the load_var step correctly", func() {
+						Expect(fakeStepFactory.LoadVarStepCallCount()).To(Equal(1))
+						plan, stepMetadata, buildVars, _ := fakeStepFactory.LoadVarStepArgsForCall(0)
+						Expect(plan).To(Equal(loadVarPlan))
+						Expect(stepMetadata).To(Equal(expectedMetadata))
+						Expect(buildVars).ToNot(BeNil())
+					})
+
+					It("shares the build's variables between the steps and their delegates", func() {
+						_, _, loadVarBuildVars, _ := fakeStepFactory.LoadVarStepArgsForCall(0)
+						_, _, _, putBuildVars, _ := fakeStepFactory.PutStepArgsForCall(0)
+						Expect(putBuildVars).To(BeIdenticalTo(loadVarBuildVars))
+
+						_, _, putDelegateBuildVars := fakeDelegateFactory.PutDelegateArgsForCall(0)
+						Expect(putDelegateBuildVars).To(BeIdenticalTo(loadVarBuildVars))
+					})
+				})
+
+				Context("when the build's pipeline has var sources", func() {
+					var varSources atc.VarSourceConfigs
+
+					BeforeEach(func() {
+						varSources = atc.VarSourceConfigs{
+							{Name: "some-vault", Type: "vault", Config: map[string]interface{}{"url": "https://vault.example.com"}},
+						}
+
+						fakePipeline := new(dbfakes.FakePipeline)
+						fakePipeline.NameReturns("some-pipeline")
+						fakePipeline.VarSourcesReturns(varSources)
+						fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+						fakeSecrets := new(credsfakes.FakeSecrets)
+						fakeSecrets.GetReturns("some-value", nil, true, nil)
+						fakeVarSourcePool.FindOrCreateReturns(fakeSecrets, nil)
+
+						expectedPlan = planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-artifact/task.yml",
+						})
+					})
+
+					It("resolves vars from the var sources in the build's steps", func() {
+						_, _, _, buildVars, _ := fakeStepFactory.TaskStepArgsForCall(0)
+
+						value, found, err := buildVars.Scope(template.StaticVariables{}).Get(template.VariableDefinition{Name: "some-vault:foo"})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(value).To(Equal("some-value"))
+
+						Expect(fakeVarSourcePool.FindOrCreateArgsForCall(0)).To(Equal(varSources[0]))
+					})
+				})
+
+				Context("when a step resolves secrets", func() {
+					var secrets template.StaticVariables
+
+					BeforeEach(func() {
+						secrets = template.StaticVariables{"some-secret": "some-value"}
+
+						expectedPlan = planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-artifact/task.yml",
+						})
+					})
+
+					It("redacts them from the build's output", func() {
+						_, _, _, buildVars, _ := fakeStepFactory.TaskStepArgsForCall(0)
+
+						_, found, err := buildVars.Scope(secrets).Get(template.VariableDefinition{Name: "some-secret"})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+
+						Expect(buildVars.RedactedValues()).To(ConsistOf("some-value"))
+					})
+
+					Context("when redacting secrets is disabled", func() {
+						BeforeEach(func() {
+							redactSecrets = false
+						})
+
+						It("does not redact them", func() {
+							_, _, _, buildVars, _ := fakeStepFactory.TaskStepArgsForCall(0)
+
+							_, found, err := buildVars.Scope(secrets).Get(template.VariableDefinition{Name: "some-secret"})
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+
+							Expect(buildVars.RedactedValues()).To(BeEmpty())
+						})
+					})
+				})
+
+				Context("when a step which writes output exits", func() {
+					var (
+						fakeTaskStep     *execfakes.FakeStep
+						fakeTaskDelegate *execfakes.FakeTaskDelegate
+					)
+
+					BeforeEach(func() {
+						fakeTaskStep = new(execfakes.FakeStep)
+						fakeTaskStep.RunStub = func(context.Context, exec.RunState) error {
+							Expect(fakeTaskDelegate.FlushCallCount()).To(BeZero())
+							return context.Canceled
+						}
+						fakeStepFactory.TaskStepReturns(fakeTaskStep)
+
+						fakeTaskDelegate = new(execfakes.FakeTaskDelegate)
+						fakeDelegateFactory.TaskDelegateReturns(fakeTaskDelegate)
+
+						expectedPlan = planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-artifact/task.yml",
+						})
+					})
+
+					It("flushes the step's output, even if it was aborted", func() {
+						runErr := builtStep.Run(context.Background(), new(execfakes.FakeRunState))
+						Expect(runErr).To(Equal(context.Canceled))
+
+						Expect(fakeTaskStep.RunCallCount()).To(Equal(1))
+						Expect(fakeTaskDelegate.FlushCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("with an approve step in a timeout", func() {
+					var approvePlan atc.Plan
+
+					BeforeEach(func() {
+						approvePlan = planFactory.NewPlan(atc.ApprovePlan{
+							Name: "deploy-prod",
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.TimeoutPlan{
+							Duration: "1h",
+							Step:     approvePlan,
+						})
+					})
+
+					It("constructs the approve step correctly", func() {
+						Expect(fakeStepFactory.ApproveStepCallCount()).To(Equal(1))
+						plan, stepMetadata, _ := fakeStepFactory.ApproveStepArgsForCall(0)
+						Expect(plan).To(Equal(approvePlan))
+						Expect(stepMetadata).To(Equal(expectedMetadata))
+					})
+
+					It("creates the approve delegate for the step", func() {
+						Expect(fakeDelegateFactory.ApproveDelegateCallCount()).To(Equal(1))
+						_, planID, _ := fakeDelegateFactory.ApproveDelegateArgsForCall(0)
+						Expect(planID).To(Equal(approvePlan.ID))
+					})
+				})
+
 				Context("with a putget in an aggregate", func() {
 					var (
 						putPlan               atc.Plan
@@ -149,7 +490,7 @@ var _ = Describe("Builder", func() {
 
 					Context("constructing outputs", func() {
 						It("constructs the put correctly", func() {
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.PutStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.PutStepArgsForCall(0)
 							Expect(plan).To(Equal(putPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -163,7 +504,7 @@ var _ = Describe("Builder", func() {
 								BuildName:    "42",
 							}))
 
-							plan, stepMetadata, containerMetadata, _ = fakeStepFactory.PutStepArgsForCall(1)
+							plan, stepMetadata, containerMetadata, _, _ = fakeStepFactory.PutStepArgsForCall(1)
 							Expect(plan).To(Equal(otherPutPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -223,7 +564,7 @@ var _ = Describe("Builder", func() {
 
 					Context("constructing outputs", func() {
 						It("constructs the put correctly", func() {
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.PutStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.PutStepArgsForCall(0)
 							Expect(plan).To(Equal(putPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -237,7 +578,7 @@ var _ = Describe("Builder", func() {
 								BuildName:    "42",
 							}))
 
-							plan, stepMetadata, containerMetadata, _ = fakeStepFactory.PutStepArgsForCall(1)
+							plan, stepMetadata, containerMetadata, _, _ = fakeStepFactory.PutStepArgsForCall(1)
 							Expect(plan).To(Equal(otherPutPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -313,7 +654,7 @@ var _ = Describe("Builder", func() {
 					})
 
 					It("constructs the first get correctly", func() {
-						plan, stepMetadata, containerMetadata, _ := fakeStepFactory.GetStepArgsForCall(0)
+						plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
 						expectedPlan := getPlan
 						expectedPlan.Attempts = []int{1}
 						Expect(plan).To(Equal(expectedPlan))
@@ -332,7 +673,7 @@ var _ = Describe("Builder", func() {
 					})
 
 					It("constructs the second get correctly", func() {
-						plan, stepMetadata, containerMetadata, _ := fakeStepFactory.GetStepArgsForCall(1)
+						plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(1)
 						expectedPlan := getPlan
 						expectedPlan.Attempts = []int{3}
 						Expect(plan).To(Equal(expectedPlan))
@@ -355,7 +696,7 @@ var _ = Describe("Builder", func() {
 					})
 
 					It("constructs nested steps correctly", func() {
-						plan, stepMetadata, containerMetadata, _ := fakeStepFactory.TaskStepArgsForCall(0)
+						plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
 						expectedPlan := taskPlan
 						expectedPlan.Attempts = []int{2, 1}
 						Expect(plan).To(Equal(expectedPlan))
@@ -372,7 +713,7 @@ var _ = Describe("Builder", func() {
 							Attempt:      "2.1",
 						}))
 
-						plan, stepMetadata, containerMetadata, _ = fakeStepFactory.TaskStepArgsForCall(1)
+						plan, stepMetadata, containerMetadata, _, _ = fakeStepFactory.TaskStepArgsForCall(1)
 						expectedPlan = taskPlan
 						expectedPlan.Attempts = []int{2, 2}
 						Expect(plan).To(Equal(expectedPlan))
@@ -442,15 +783,15 @@ var _ = Describe("Builder", func() {
 					It("constructs nested steps correctly", func() {
 						Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(6))
 
-						_, _, containerMetadata, _ := fakeStepFactory.TaskStepArgsForCall(0)
+						_, _, containerMetadata, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
 						Expect(containerMetadata.Attempt).To(Equal("1"))
-						_, _, containerMetadata, _ = fakeStepFactory.TaskStepArgsForCall(1)
+						_, _, containerMetadata, _, _ = fakeStepFactory.TaskStepArgsForCall(1)
 						Expect(containerMetadata.Attempt).To(Equal("1"))
-						_, _, containerMetadata, _ = fakeStepFactory.TaskStepArgsForCall(2)
+						_, _, containerMetadata, _, _ = fakeStepFactory.TaskStepArgsForCall(2)
 						Expect(containerMetadata.Attempt).To(Equal("1"))
-						_, _, containerMetadata, _ = fakeStepFactory.TaskStepArgsForCall(3)
+						_, _, containerMetadata, _, _ = fakeStepFactory.TaskStepArgsForCall(3)
 						Expect(containerMetadata.Attempt).To(Equal("1"))
-						_, _, containerMetadata, _ = fakeStepFactory.TaskStepArgsForCall(4)
+						_, _, containerMetadata, _, _ = fakeStepFactory.TaskStepArgsForCall(4)
 						Expect(containerMetadata.Attempt).To(Equal("1"))
 					})
 				})
@@ -471,7 +812,7 @@ var _ = Describe("Builder", func() {
 						})
 
 						It("constructs inputs correctly", func() {
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.GetStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
 							Expect(plan).To(Equal(expectedPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -498,7 +839,7 @@ var _ = Describe("Builder", func() {
 						})
 
 						It("constructs tasks correctly", func() {
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.TaskStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
 							Expect(plan).To(Equal(expectedPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -547,7 +888,7 @@ var _ = Describe("Builder", func() {
 						})
 
 						It("constructs the put correctly", func() {
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.PutStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.PutStepArgsForCall(0)
 							Expect(plan).To(Equal(putPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -563,7 +904,7 @@ var _ = Describe("Builder", func() {
 						})
 
 						It("constructs the dependent get correctly", func() {
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.GetStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
 							Expect(plan).To(Equal(dependentGetPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -628,7 +969,7 @@ var _ = Describe("Builder", func() {
 
 						It("constructs the step correctly", func() {
 							Expect(fakeStepFactory.GetStepCallCount()).To(Equal(1))
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.GetStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
 							Expect(plan).To(Equal(inputPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -645,7 +986,7 @@ var _ = Describe("Builder", func() {
 
 						It("constructs the completion hook correctly", func() {
 							Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(4))
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.TaskStepArgsForCall(2)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.TaskStepArgsForCall(2)
 							Expect(plan).To(Equal(completionTaskPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -662,7 +1003,7 @@ var _ = Describe("Builder", func() {
 
 						It("constructs the failure hook correctly", func() {
 							Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(4))
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.TaskStepArgsForCall(0)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
 							Expect(plan).To(Equal(failureTaskPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -679,7 +1020,7 @@ var _ = Describe("Builder", func() {
 
 						It("constructs the success hook correctly", func() {
 							Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(4))
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.TaskStepArgsForCall(1)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.TaskStepArgsForCall(1)
 							Expect(plan).To(Equal(successTaskPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -696,7 +1037,7 @@ var _ = Describe("Builder", func() {
 
 						It("constructs the next step correctly", func() {
 							Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(4))
-							plan, stepMetadata, containerMetadata, _ := fakeStepFactory.TaskStepArgsForCall(3)
+							plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.TaskStepArgsForCall(3)
 							Expect(plan).To(Equal(nextTaskPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadata))
 							Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -728,7 +1069,7 @@ var _ = Describe("Builder", func() {
 
 					It("constructs the step correctly", func() {
 						Expect(fakeStepFactory.GetStepCallCount()).To(Equal(1))
-						plan, stepMetadata, containerMetadata, _ := fakeStepFactory.GetStepArgsForCall(0)
+						plan, stepMetadata, containerMetadata, _, _ := fakeStepFactory.GetStepArgsForCall(0)
 						Expect(plan).To(Equal(inputPlan))
 						Expect(stepMetadata).To(Equal(expectedMetadata))
 						Expect(containerMetadata).To(Equal(db.ContainerMetadata{
@@ -5,17 +5,32 @@ import (
 	"sync"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/exec"
 )
 
 type FakeDelegateFactory struct {
-	BuildStepDelegateStub        func(db.Build, atc.PlanID) exec.BuildStepDelegate
+	ApproveDelegateStub        func(db.Build, atc.PlanID, *creds.BuildVariables) exec.ApproveDelegate
+	approveDelegateMutex       sync.RWMutex
+	approveDelegateArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 *creds.BuildVariables
+	}
+	approveDelegateReturns struct {
+		result1 exec.ApproveDelegate
+	}
+	approveDelegateReturnsOnCall map[int]struct {
+		result1 exec.ApproveDelegate
+	}
+	BuildStepDelegateStub        func(db.Build, atc.PlanID, *creds.BuildVariables) exec.BuildStepDelegate
 	buildStepDelegateMutex       sync.RWMutex
 	buildStepDelegateArgsForCall []struct {
 		arg1 db.Build
 		arg2 atc.PlanID
+		arg3 *creds.BuildVariables
 	}
 	buildStepDelegateReturns struct {
 		result1 exec.BuildStepDelegate
@@ -23,11 +38,12 @@ type FakeDelegateFactory struct {
 	buildStepDelegateReturnsOnCall map[int]struct {
 		result1 exec.BuildStepDelegate
 	}
-	GetDelegateStub        func(db.Build, atc.PlanID) exec.GetDelegate
+	GetDelegateStub        func(db.Build, atc.PlanID, *creds.BuildVariables) exec.GetDelegate
 	getDelegateMutex       sync.RWMutex
 	getDelegateArgsForCall []struct {
 		arg1 db.Build
 		arg2 atc.PlanID
+		arg3 *creds.BuildVariables
 	}
 	getDelegateReturns struct {
 		result1 exec.GetDelegate
@@ -35,11 +51,12 @@ type FakeDelegateFactory struct {
 	getDelegateReturnsOnCall map[int]struct {
 		result1 exec.GetDelegate
 	}
-	PutDelegateStub        func(db.Build, atc.PlanID) exec.PutDelegate
+	PutDelegateStub        func(db.Build, atc.PlanID, *creds.BuildVariables) exec.PutDelegate
 	putDelegateMutex       sync.RWMutex
 	putDelegateArgsForCall []struct {
 		arg1 db.Build
 		arg2 atc.PlanID
+		arg3 *creds.BuildVariables
 	}
 	putDelegateReturns struct {
 		result1 exec.PutDelegate
@@ -47,11 +64,12 @@ type FakeDelegateFactory struct {
 	putDelegateReturnsOnCall map[int]struct {
 		result1 exec.PutDelegate
 	}
-	TaskDelegateStub        func(db.Build, atc.PlanID) exec.TaskDelegate
+	TaskDelegateStub        func(db.Build, atc.PlanID, *creds.BuildVariables) exec.TaskDelegate
 	taskDelegateMutex       sync.RWMutex
 	taskDelegateArgsForCall []struct {
 		arg1 db.Build
 		arg2 atc.PlanID
+		arg3 *creds.BuildVariables
 	}
 	taskDelegateReturns struct {
 		result1 exec.TaskDelegate
@@ -63,17 +81,80 @@ type FakeDelegateFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeDelegateFactory) BuildStepDelegate(arg1 db.Build, arg2 atc.PlanID) exec.BuildStepDelegate {
+func (fake *FakeDelegateFactory) ApproveDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 *creds.BuildVariables) exec.ApproveDelegate {
+	fake.approveDelegateMutex.Lock()
+	ret, specificReturn := fake.approveDelegateReturnsOnCall[len(fake.approveDelegateArgsForCall)]
+	fake.approveDelegateArgsForCall = append(fake.approveDelegateArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 *creds.BuildVariables
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ApproveDelegate", []interface{}{arg1, arg2, arg3})
+	fake.approveDelegateMutex.Unlock()
+	if fake.ApproveDelegateStub != nil {
+		return fake.ApproveDelegateStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approveDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeDelegateFactory) ApproveDelegateCallCount() int {
+	fake.approveDelegateMutex.RLock()
+	defer fake.approveDelegateMutex.RUnlock()
+	return len(fake.approveDelegateArgsForCall)
+}
+
+func (fake *FakeDelegateFactory) ApproveDelegateCalls(stub func(db.Build, atc.PlanID, *creds.BuildVariables) exec.ApproveDelegate) {
+	fake.approveDelegateMutex.Lock()
+	defer fake.approveDelegateMutex.Unlock()
+	fake.ApproveDelegateStub = stub
+}
+
+func (fake *FakeDelegateFactory) ApproveDelegateArgsForCall(i int) (db.Build, atc.PlanID, *creds.BuildVariables) {
+	fake.approveDelegateMutex.RLock()
+	defer fake.approveDelegateMutex.RUnlock()
+	argsForCall := fake.approveDelegateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDelegateFactory) ApproveDelegateReturns(result1 exec.ApproveDelegate) {
+	fake.approveDelegateMutex.Lock()
+	defer fake.approveDelegateMutex.Unlock()
+	fake.ApproveDelegateStub = nil
+	fake.approveDelegateReturns = struct {
+		result1 exec.ApproveDelegate
+	}{result1}
+}
+
+func (fake *FakeDelegateFactory) ApproveDelegateReturnsOnCall(i int, result1 exec.ApproveDelegate) {
+	fake.approveDelegateMutex.Lock()
+	defer fake.approveDelegateMutex.Unlock()
+	fake.ApproveDelegateStub = nil
+	if fake.approveDelegateReturnsOnCall == nil {
+		fake.approveDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.ApproveDelegate
+		})
+	}
+	fake.approveDelegateReturnsOnCall[i] = struct {
+		result1 exec.ApproveDelegate
+	}{result1}
+}
+
+func (fake *FakeDelegateFactory) BuildStepDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 *creds.BuildVariables) exec.BuildStepDelegate {
 	fake.buildStepDelegateMutex.Lock()
 	ret, specificReturn := fake.buildStepDelegateReturnsOnCall[len(fake.buildStepDelegateArgsForCall)]
 	fake.buildStepDelegateArgsForCall = append(fake.buildStepDelegateArgsForCall, struct {
 		arg1 db.Build
 		arg2 atc.PlanID
-	}{arg1, arg2})
-	fake.recordInvocation("BuildStepDelegate", []interface{}{arg1, arg2})
+		arg3 *creds.BuildVariables
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("BuildStepDelegate", []interface{}{arg1, arg2, arg3})
 	fake.buildStepDelegateMutex.Unlock()
 	if fake.BuildStepDelegateStub != nil {
-		return fake.BuildStepDelegateStub(arg1, arg2)
+		return fake.BuildStepDelegateStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -88,17 +169,17 @@ func (fake *FakeDelegateFactory) BuildStepDelegateCallCount() int {
 	return len(fake.buildStepDelegateArgsForCall)
 }
 
-func (fake *FakeDelegateFactory) BuildStepDelegateCalls(stub func(db.Build, atc.PlanID) exec.BuildStepDelegate) {
+func (fake *FakeDelegateFactory) BuildStepDelegateCalls(stub func(db.Build, atc.PlanID, *creds.BuildVariables) exec.BuildStepDelegate) {
 	fake.buildStepDelegateMutex.Lock()
 	defer fake.buildStepDelegateMutex.Unlock()
 	fake.BuildStepDelegateStub = stub
 }
 
-func (fake *FakeDelegateFactory) BuildStepDelegateArgsForCall(i int) (db.Build, atc.PlanID) {
+func (fake *FakeDelegateFactory) BuildStepDelegateArgsForCall(i int) (db.Build, atc.PlanID, *creds.BuildVariables) {
 	fake.buildStepDelegateMutex.RLock()
 	defer fake.buildStepDelegateMutex.RUnlock()
 	argsForCall := fake.buildStepDelegateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeDelegateFactory) BuildStepDelegateReturns(result1 exec.BuildStepDelegate) {
@@ -124,17 +205,18 @@ func (fake *FakeDelegateFactory) BuildStepDelegateReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
-func (fake *FakeDelegateFactory) GetDelegate(arg1 db.Build, arg2 atc.PlanID) exec.GetDelegate {
+func (fake *FakeDelegateFactory) GetDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 *creds.BuildVariables) exec.GetDelegate {
 	fake.getDelegateMutex.Lock()
 	ret, specificReturn := fake.getDelegateReturnsOnCall[len(fake.getDelegateArgsForCall)]
 	fake.getDelegateArgsForCall = append(fake.getDelegateArgsForCall, struct {
 		arg1 db.Build
 		arg2 atc.PlanID
-	}{arg1, arg2})
-	fake.recordInvocation("GetDelegate", []interface{}{arg1, arg2})
+		arg3 *creds.BuildVariables
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetDelegate", []interface{}{arg1, arg2, arg3})
 	fake.getDelegateMutex.Unlock()
 	if fake.GetDelegateStub != nil {
-		return fake.GetDelegateStub(arg1, arg2)
+		return fake.GetDelegateStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -149,17 +231,17 @@ func (fake *FakeDelegateFactory) GetDelegateCallCount() int {
 	return len(fake.getDelegateArgsForCall)
 }
 
-func (fake *FakeDelegateFactory) GetDelegateCalls(stub func(db.Build, atc.PlanID) exec.GetDelegate) {
+func (fake *FakeDelegateFactory) GetDelegateCalls(stub func(db.Build, atc.PlanID, *creds.BuildVariables) exec.GetDelegate) {
 	fake.getDelegateMutex.Lock()
 	defer fake.getDelegateMutex.Unlock()
 	fake.GetDelegateStub = stub
 }
 
-func (fake *FakeDelegateFactory) GetDelegateArgsForCall(i int) (db.Build, atc.PlanID) {
+func (fake *FakeDelegateFactory) GetDelegateArgsForCall(i int) (db.Build, atc.PlanID, *creds.BuildVariables) {
 	fake.getDelegateMutex.RLock()
 	defer fake.getDelegateMutex.RUnlock()
 	argsForCall := fake.getDelegateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeDelegateFactory) GetDelegateReturns(result1 exec.GetDelegate) {
@@ -185,17 +267,18 @@ func (fake *FakeDelegateFactory) GetDelegateReturnsOnCall(i int, result1 exec.Ge
 	}{result1}
 }
 
-func (fake *FakeDelegateFactory) PutDelegate(arg1 db.Build, arg2 atc.PlanID) exec.PutDelegate {
+func (fake *FakeDelegateFactory) PutDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 *creds.BuildVariables) exec.PutDelegate {
 	fake.putDelegateMutex.Lock()
 	ret, specificReturn := fake.putDelegateReturnsOnCall[len(fake.putDelegateArgsForCall)]
 	fake.putDelegateArgsForCall = append(fake.putDelegateArgsForCall, struct {
 		arg1 db.Build
 		arg2 atc.PlanID
-	}{arg1, arg2})
-	fake.recordInvocation("PutDelegate", []interface{}{arg1, arg2})
+		arg3 *creds.BuildVariables
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("PutDelegate", []interface{}{arg1, arg2, arg3})
 	fake.putDelegateMutex.Unlock()
 	if fake.PutDelegateStub != nil {
-		return fake.PutDelegateStub(arg1, arg2)
+		return fake.PutDelegateStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -210,17 +293,17 @@ func (fake *FakeDelegateFactory) PutDelegateCallCount() int {
 	return len(fake.putDelegateArgsForCall)
 }
 
-func (fake *FakeDelegateFactory) PutDelegateCalls(stub func(db.Build, atc.PlanID) exec.PutDelegate) {
+func (fake *FakeDelegateFactory) PutDelegateCalls(stub func(db.Build, atc.PlanID, *creds.BuildVariables) exec.PutDelegate) {
 	fake.putDelegateMutex.Lock()
 	defer fake.putDelegateMutex.Unlock()
 	fake.PutDelegateStub = stub
 }
 
-func (fake *FakeDelegateFactory) PutDelegateArgsForCall(i int) (db.Build, atc.PlanID) {
+func (fake *FakeDelegateFactory) PutDelegateArgsForCall(i int) (db.Build, atc.PlanID, *creds.BuildVariables) {
 	fake.putDelegateMutex.RLock()
 	defer fake.putDelegateMutex.RUnlock()
 	argsForCall := fake.putDelegateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeDelegateFactory) PutDelegateReturns(result1 exec.PutDelegate) {
@@ -246,17 +329,18 @@ func (fake *FakeDelegateFactory) PutDelegateReturnsOnCall(i int, result1 exec.Pu
 	}{result1}
 }
 
-func (fake *FakeDelegateFactory) TaskDelegate(arg1 db.Build, arg2 atc.PlanID) exec.TaskDelegate {
+func (fake *FakeDelegateFactory) TaskDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 *creds.BuildVariables) exec.TaskDelegate {
 	fake.taskDelegateMutex.Lock()
 	ret, specificReturn := fake.taskDelegateReturnsOnCall[len(fake.taskDelegateArgsForCall)]
 	fake.taskDelegateArgsForCall = append(fake.taskDelegateArgsForCall, struct {
 		arg1 db.Build
 		arg2 atc.PlanID
-	}{arg1, arg2})
-	fake.recordInvocation("TaskDelegate", []interface{}{arg1, arg2})
+		arg3 *creds.BuildVariables
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("TaskDelegate", []interface{}{arg1, arg2, arg3})
 	fake.taskDelegateMutex.Unlock()
 	if fake.TaskDelegateStub != nil {
-		return fake.TaskDelegateStub(arg1, arg2)
+		return fake.TaskDelegateStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -271,17 +355,17 @@ func (fake *FakeDelegateFactory) TaskDelegateCallCount() int {
 	return len(fake.taskDelegateArgsForCall)
 }
 
-func (fake *FakeDelegateFactory) TaskDelegateCalls(stub func(db.Build, atc.PlanID) exec.TaskDelegate) {
+func (fake *FakeDelegateFactory) TaskDelegateCalls(stub func(db.Build, atc.PlanID, *creds.BuildVariables) exec.TaskDelegate) {
 	fake.taskDelegateMutex.Lock()
 	defer fake.taskDelegateMutex.Unlock()
 	fake.TaskDelegateStub = stub
 }
 
-func (fake *FakeDelegateFactory) TaskDelegateArgsForCall(i int) (db.Build, atc.PlanID) {
+func (fake *FakeDelegateFactory) TaskDelegateArgsForCall(i int) (db.Build, atc.PlanID, *creds.BuildVariables) {
 	fake.taskDelegateMutex.RLock()
 	defer fake.taskDelegateMutex.RUnlock()
 	argsForCall := fake.taskDelegateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeDelegateFactory) TaskDelegateReturns(result1 exec.TaskDelegate) {
@@ -310,6 +394,8 @@ func (fake *FakeDelegateFactory) TaskDelegateReturnsOnCall(i int, result1 exec.T
 func (fake *FakeDelegateFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approveDelegateMutex.RLock()
+	defer fake.approveDelegateMutex.RUnlock()
 	fake.buildStepDelegateMutex.RLock()
 	defer fake.buildStepDelegateMutex.RUnlock()
 	fake.getDelegateMutex.RLock()
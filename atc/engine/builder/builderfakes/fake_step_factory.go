@@ -5,12 +5,26 @@ import (
 	"sync"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/exec"
 )
 
 type FakeStepFactory struct {
+	ApproveStepStub        func(atc.Plan, exec.StepMetadata, exec.ApproveDelegate) exec.Step
+	approveStepMutex       sync.RWMutex
+	approveStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 exec.ApproveDelegate
+	}
+	approveStepReturns struct {
+		result1 exec.Step
+	}
+	approveStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	ArtifactInputStepStub        func(atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
 	artifactInputStepMutex       sync.RWMutex
 	artifactInputStepArgsForCall []struct {
@@ -37,13 +51,14 @@ type FakeStepFactory struct {
 	artifactOutputStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
-	GetStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) exec.Step
+	GetStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.GetDelegate) exec.Step
 	getStepMutex       sync.RWMutex
 	getStepArgsForCall []struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
 		arg3 db.ContainerMetadata
-		arg4 exec.GetDelegate
+		arg4 *creds.BuildVariables
+		arg5 exec.GetDelegate
 	}
 	getStepReturns struct {
 		result1 exec.Step
@@ -51,13 +66,28 @@ type FakeStepFactory struct {
 	getStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
-	PutStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) exec.Step
+	LoadVarStepStub        func(atc.Plan, exec.StepMetadata, *creds.BuildVariables, exec.BuildStepDelegate) exec.Step
+	loadVarStepMutex       sync.RWMutex
+	loadVarStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 *creds.BuildVariables
+		arg4 exec.BuildStepDelegate
+	}
+	loadVarStepReturns struct {
+		result1 exec.Step
+	}
+	loadVarStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	PutStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.PutDelegate) exec.Step
 	putStepMutex       sync.RWMutex
 	putStepArgsForCall []struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
 		arg3 db.ContainerMetadata
-		arg4 exec.PutDelegate
+		arg4 *creds.BuildVariables
+		arg5 exec.PutDelegate
 	}
 	putStepReturns struct {
 		result1 exec.Step
@@ -65,13 +95,27 @@ type FakeStepFactory struct {
 	putStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
-	TaskStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.TaskDelegate) exec.Step
+	SetPipelineStepStub        func(atc.Plan, exec.StepMetadata, exec.BuildStepDelegate) exec.Step
+	setPipelineStepMutex       sync.RWMutex
+	setPipelineStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 exec.BuildStepDelegate
+	}
+	setPipelineStepReturns struct {
+		result1 exec.Step
+	}
+	setPipelineStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	TaskStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.TaskDelegate) exec.Step
 	taskStepMutex       sync.RWMutex
 	taskStepArgsForCall []struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
 		arg3 db.ContainerMetadata
-		arg4 exec.TaskDelegate
+		arg4 *creds.BuildVariables
+		arg5 exec.TaskDelegate
 	}
 	taskStepReturns struct {
 		result1 exec.Step
@@ -83,6 +127,68 @@ type FakeStepFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStepFactory) ApproveStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 exec.ApproveDelegate) exec.Step {
+	fake.approveStepMutex.Lock()
+	ret, specificReturn := fake.approveStepReturnsOnCall[len(fake.approveStepArgsForCall)]
+	fake.approveStepArgsForCall = append(fake.approveStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 exec.ApproveDelegate
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ApproveStep", []interface{}{arg1, arg2, arg3})
+	fake.approveStepMutex.Unlock()
+	if fake.ApproveStepStub != nil {
+		return fake.ApproveStepStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approveStepReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStepFactory) ApproveStepCallCount() int {
+	fake.approveStepMutex.RLock()
+	defer fake.approveStepMutex.RUnlock()
+	return len(fake.approveStepArgsForCall)
+}
+
+func (fake *FakeStepFactory) ApproveStepCalls(stub func(atc.Plan, exec.StepMetadata, exec.ApproveDelegate) exec.Step) {
+	fake.approveStepMutex.Lock()
+	defer fake.approveStepMutex.Unlock()
+	fake.ApproveStepStub = stub
+}
+
+func (fake *FakeStepFactory) ApproveStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, exec.ApproveDelegate) {
+	fake.approveStepMutex.RLock()
+	defer fake.approveStepMutex.RUnlock()
+	argsForCall := fake.approveStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStepFactory) ApproveStepReturns(result1 exec.Step) {
+	fake.approveStepMutex.Lock()
+	defer fake.approveStepMutex.Unlock()
+	fake.ApproveStepStub = nil
+	fake.approveStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeStepFactory) ApproveStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.approveStepMutex.Lock()
+	defer fake.approveStepMutex.Unlock()
+	fake.ApproveStepStub = nil
+	if fake.approveStepReturnsOnCall == nil {
+		fake.approveStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.approveStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeStepFactory) ArtifactInputStep(arg1 atc.Plan, arg2 db.Build, arg3 exec.BuildStepDelegate) exec.Step {
 	fake.artifactInputStepMutex.Lock()
 	ret, specificReturn := fake.artifactInputStepReturnsOnCall[len(fake.artifactInputStepArgsForCall)]
@@ -207,19 +313,20 @@ func (fake *FakeStepFactory) ArtifactOutputStepReturnsOnCall(i int, result1 exec
 	}{result1}
 }
 
-func (fake *FakeStepFactory) GetStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 exec.GetDelegate) exec.Step {
+func (fake *FakeStepFactory) GetStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 *creds.BuildVariables, arg5 exec.GetDelegate) exec.Step {
 	fake.getStepMutex.Lock()
 	ret, specificReturn := fake.getStepReturnsOnCall[len(fake.getStepArgsForCall)]
 	fake.getStepArgsForCall = append(fake.getStepArgsForCall, struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
 		arg3 db.ContainerMetadata
-		arg4 exec.GetDelegate
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("GetStep", []interface{}{arg1, arg2, arg3, arg4})
+		arg4 *creds.BuildVariables
+		arg5 exec.GetDelegate
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("GetStep", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.getStepMutex.Unlock()
 	if fake.GetStepStub != nil {
-		return fake.GetStepStub(arg1, arg2, arg3, arg4)
+		return fake.GetStepStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -234,17 +341,17 @@ func (fake *FakeStepFactory) GetStepCallCount() int {
 	return len(fake.getStepArgsForCall)
 }
 
-func (fake *FakeStepFactory) GetStepCalls(stub func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) exec.Step) {
+func (fake *FakeStepFactory) GetStepCalls(stub func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.GetDelegate) exec.Step) {
 	fake.getStepMutex.Lock()
 	defer fake.getStepMutex.Unlock()
 	fake.GetStepStub = stub
 }
 
-func (fake *FakeStepFactory) GetStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) {
+func (fake *FakeStepFactory) GetStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.GetDelegate) {
 	fake.getStepMutex.RLock()
 	defer fake.getStepMutex.RUnlock()
 	argsForCall := fake.getStepArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeStepFactory) GetStepReturns(result1 exec.Step) {
@@ -270,19 +377,83 @@ func (fake *FakeStepFactory) GetStepReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
-func (fake *FakeStepFactory) PutStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 exec.PutDelegate) exec.Step {
+func (fake *FakeStepFactory) LoadVarStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 *creds.BuildVariables, arg4 exec.BuildStepDelegate) exec.Step {
+	fake.loadVarStepMutex.Lock()
+	ret, specificReturn := fake.loadVarStepReturnsOnCall[len(fake.loadVarStepArgsForCall)]
+	fake.loadVarStepArgsForCall = append(fake.loadVarStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 *creds.BuildVariables
+		arg4 exec.BuildStepDelegate
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("LoadVarStep", []interface{}{arg1, arg2, arg3, arg4})
+	fake.loadVarStepMutex.Unlock()
+	if fake.LoadVarStepStub != nil {
+		return fake.LoadVarStepStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.loadVarStepReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStepFactory) LoadVarStepCallCount() int {
+	fake.loadVarStepMutex.RLock()
+	defer fake.loadVarStepMutex.RUnlock()
+	return len(fake.loadVarStepArgsForCall)
+}
+
+func (fake *FakeStepFactory) LoadVarStepCalls(stub func(atc.Plan, exec.StepMetadata, *creds.BuildVariables, exec.BuildStepDelegate) exec.Step) {
+	fake.loadVarStepMutex.Lock()
+	defer fake.loadVarStepMutex.Unlock()
+	fake.LoadVarStepStub = stub
+}
+
+func (fake *FakeStepFactory) LoadVarStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, *creds.BuildVariables, exec.BuildStepDelegate) {
+	fake.loadVarStepMutex.RLock()
+	defer fake.loadVarStepMutex.RUnlock()
+	argsForCall := fake.loadVarStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStepFactory) LoadVarStepReturns(result1 exec.Step) {
+	fake.loadVarStepMutex.Lock()
+	defer fake.loadVarStepMutex.Unlock()
+	fake.LoadVarStepStub = nil
+	fake.loadVarStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeStepFactory) LoadVarStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.loadVarStepMutex.Lock()
+	defer fake.loadVarStepMutex.Unlock()
+	fake.LoadVarStepStub = nil
+	if fake.loadVarStepReturnsOnCall == nil {
+		fake.loadVarStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.loadVarStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeStepFactory) PutStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 *creds.BuildVariables, arg5 exec.PutDelegate) exec.Step {
 	fake.putStepMutex.Lock()
 	ret, specificReturn := fake.putStepReturnsOnCall[len(fake.putStepArgsForCall)]
 	fake.putStepArgsForCall = append(fake.putStepArgsForCall, struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
 		arg3 db.ContainerMetadata
-		arg4 exec.PutDelegate
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("PutStep", []interface{}{arg1, arg2, arg3, arg4})
+		arg4 *creds.BuildVariables
+		arg5 exec.PutDelegate
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("PutStep", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.putStepMutex.Unlock()
 	if fake.PutStepStub != nil {
-		return fake.PutStepStub(arg1, arg2, arg3, arg4)
+		return fake.PutStepStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -297,17 +468,17 @@ func (fake *FakeStepFactory) PutStepCallCount() int {
 	return len(fake.putStepArgsForCall)
 }
 
-func (fake *FakeStepFactory) PutStepCalls(stub func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) exec.Step) {
+func (fake *FakeStepFactory) PutStepCalls(stub func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.PutDelegate) exec.Step) {
 	fake.putStepMutex.Lock()
 	defer fake.putStepMutex.Unlock()
 	fake.PutStepStub = stub
 }
 
-func (fake *FakeStepFactory) PutStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) {
+func (fake *FakeStepFactory) PutStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.PutDelegate) {
 	fake.putStepMutex.RLock()
 	defer fake.putStepMutex.RUnlock()
 	argsForCall := fake.putStepArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeStepFactory) PutStepReturns(result1 exec.Step) {
@@ -333,19 +504,82 @@ func (fake *FakeStepFactory) PutStepReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
-func (fake *FakeStepFactory) TaskStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 exec.TaskDelegate) exec.Step {
+func (fake *FakeStepFactory) SetPipelineStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 exec.BuildStepDelegate) exec.Step {
+	fake.setPipelineStepMutex.Lock()
+	ret, specificReturn := fake.setPipelineStepReturnsOnCall[len(fake.setPipelineStepArgsForCall)]
+	fake.setPipelineStepArgsForCall = append(fake.setPipelineStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 exec.BuildStepDelegate
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetPipelineStep", []interface{}{arg1, arg2, arg3})
+	fake.setPipelineStepMutex.Unlock()
+	if fake.SetPipelineStepStub != nil {
+		return fake.SetPipelineStepStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setPipelineStepReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStepFactory) SetPipelineStepCallCount() int {
+	fake.setPipelineStepMutex.RLock()
+	defer fake.setPipelineStepMutex.RUnlock()
+	return len(fake.setPipelineStepArgsForCall)
+}
+
+func (fake *FakeStepFactory) SetPipelineStepCalls(stub func(atc.Plan, exec.StepMetadata, exec.BuildStepDelegate) exec.Step) {
+	fake.setPipelineStepMutex.Lock()
+	defer fake.setPipelineStepMutex.Unlock()
+	fake.SetPipelineStepStub = stub
+}
+
+func (fake *FakeStepFactory) SetPipelineStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, exec.BuildStepDelegate) {
+	fake.setPipelineStepMutex.RLock()
+	defer fake.setPipelineStepMutex.RUnlock()
+	argsForCall := fake.setPipelineStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStepFactory) SetPipelineStepReturns(result1 exec.Step) {
+	fake.setPipelineStepMutex.Lock()
+	defer fake.setPipelineStepMutex.Unlock()
+	fake.SetPipelineStepStub = nil
+	fake.setPipelineStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeStepFactory) SetPipelineStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.setPipelineStepMutex.Lock()
+	defer fake.setPipelineStepMutex.Unlock()
+	fake.SetPipelineStepStub = nil
+	if fake.setPipelineStepReturnsOnCall == nil {
+		fake.setPipelineStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.setPipelineStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeStepFactory) TaskStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 *creds.BuildVariables, arg5 exec.TaskDelegate) exec.Step {
 	fake.taskStepMutex.Lock()
 	ret, specificReturn := fake.taskStepReturnsOnCall[len(fake.taskStepArgsForCall)]
 	fake.taskStepArgsForCall = append(fake.taskStepArgsForCall, struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
 		arg3 db.ContainerMetadata
-		arg4 exec.TaskDelegate
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("TaskStep", []interface{}{arg1, arg2, arg3, arg4})
+		arg4 *creds.BuildVariables
+		arg5 exec.TaskDelegate
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("TaskStep", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.taskStepMutex.Unlock()
 	if fake.TaskStepStub != nil {
-		return fake.TaskStepStub(arg1, arg2, arg3, arg4)
+		return fake.TaskStepStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -360,17 +594,17 @@ func (fake *FakeStepFactory) TaskStepCallCount() int {
 	return len(fake.taskStepArgsForCall)
 }
 
-func (fake *FakeStepFactory) TaskStepCalls(stub func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.TaskDelegate) exec.Step) {
+func (fake *FakeStepFactory) TaskStepCalls(stub func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.TaskDelegate) exec.Step) {
 	fake.taskStepMutex.Lock()
 	defer fake.taskStepMutex.Unlock()
 	fake.TaskStepStub = stub
 }
 
-func (fake *FakeStepFactory) TaskStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.TaskDelegate) {
+func (fake *FakeStepFactory) TaskStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.ContainerMetadata, *creds.BuildVariables, exec.TaskDelegate) {
 	fake.taskStepMutex.RLock()
 	defer fake.taskStepMutex.RUnlock()
 	argsForCall := fake.taskStepArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeStepFactory) TaskStepReturns(result1 exec.Step) {
@@ -399,14 +633,20 @@ func (fake *FakeStepFactory) TaskStepReturnsOnCall(i int, result1 exec.Step) {
 func (fake *FakeStepFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approveStepMutex.RLock()
+	defer fake.approveStepMutex.RUnlock()
 	fake.artifactInputStepMutex.RLock()
 	defer fake.artifactInputStepMutex.RUnlock()
 	fake.artifactOutputStepMutex.RLock()
 	defer fake.artifactOutputStepMutex.RUnlock()
 	fake.getStepMutex.RLock()
 	defer fake.getStepMutex.RUnlock()
+	fake.loadVarStepMutex.RLock()
+	defer fake.loadVarStepMutex.RUnlock()
 	fake.putStepMutex.RLock()
 	defer fake.putStepMutex.RUnlock()
+	fake.setPipelineStepMutex.RLock()
+	defer fake.setPipelineStepMutex.RUnlock()
 	fake.taskStepMutex.RLock()
 	defer fake.taskStepMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
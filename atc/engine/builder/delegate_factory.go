@@ -2,12 +2,16 @@ package builder
 
 import (
 	"io"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
@@ -19,25 +23,29 @@ func NewDelegateFactory() *delegateFactory {
 
 type delegateFactory struct{}
 
-func (delegate *delegateFactory) GetDelegate(build db.Build, planID atc.PlanID) exec.GetDelegate {
-	return NewGetDelegate(build, planID, clock.NewClock())
+func (delegate *delegateFactory) GetDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables) exec.GetDelegate {
+	return NewGetDelegate(build, planID, buildVars, clock.NewClock())
 }
 
-func (delegate *delegateFactory) PutDelegate(build db.Build, planID atc.PlanID) exec.PutDelegate {
-	return NewPutDelegate(build, planID, clock.NewClock())
+func (delegate *delegateFactory) PutDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables) exec.PutDelegate {
+	return NewPutDelegate(build, planID, buildVars, clock.NewClock())
 }
 
-func (delegate *delegateFactory) TaskDelegate(build db.Build, planID atc.PlanID) exec.TaskDelegate {
-	return NewTaskDelegate(build, planID, clock.NewClock())
+func (delegate *delegateFactory) TaskDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables) exec.TaskDelegate {
+	return NewTaskDelegate(build, planID, buildVars, clock.NewClock())
 }
 
-func (delegate *delegateFactory) BuildStepDelegate(build db.Build, planID atc.PlanID) exec.BuildStepDelegate {
-	return NewBuildStepDelegate(build, planID, clock.NewClock())
+func (delegate *delegateFactory) ApproveDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables) exec.ApproveDelegate {
+	return NewApproveDelegate(build, planID, buildVars, clock.NewClock())
 }
 
-func NewGetDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.GetDelegate {
+func (delegate *delegateFactory) BuildStepDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables) exec.BuildStepDelegate {
+	return NewBuildStepDelegate(build, planID, buildVars, clock.NewClock())
+}
+
+func NewGetDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables, clock clock.Clock) exec.GetDelegate {
 	return &getDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		buildStepDelegate: NewBuildStepDelegate(build, planID, buildVars, clock),
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
@@ -46,7 +54,7 @@ func NewGetDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.G
 }
 
 type getDelegate struct {
-	exec.BuildStepDelegate
+	*buildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
@@ -80,6 +88,8 @@ func (d *getDelegate) Starting(logger lager.Logger) {
 }
 
 func (d *getDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus, info exec.VersionInfo) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishGet{
 		Origin:          d.eventOrigin,
 		Time:            d.clock.Now().Unix(),
@@ -133,9 +143,9 @@ func (d *getDelegate) UpdateVersion(log lager.Logger, plan atc.GetPlan, info exe
 	}
 }
 
-func NewPutDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.PutDelegate {
+func NewPutDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables, clock clock.Clock) exec.PutDelegate {
 	return &putDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		buildStepDelegate: NewBuildStepDelegate(build, planID, buildVars, clock),
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
@@ -144,7 +154,7 @@ func NewPutDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.P
 }
 
 type putDelegate struct {
-	exec.BuildStepDelegate
+	*buildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
@@ -178,6 +188,8 @@ func (d *putDelegate) Starting(logger lager.Logger) {
 }
 
 func (d *putDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus, info exec.VersionInfo) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishPut{
 		Origin:          d.eventOrigin,
 		Time:            d.clock.Now().Unix(),
@@ -216,9 +228,9 @@ func (d *putDelegate) SaveOutput(log lager.Logger, plan atc.PutPlan, source atc.
 	}
 }
 
-func NewTaskDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.TaskDelegate {
+func NewTaskDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables, clock clock.Clock) exec.TaskDelegate {
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		buildStepDelegate: NewBuildStepDelegate(build, planID, buildVars, clock),
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
@@ -226,7 +238,7 @@ func NewTaskDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.
 }
 
 type taskDelegate struct {
-	exec.BuildStepDelegate
+	*buildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
@@ -261,6 +273,8 @@ func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig)
 }
 
 func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(exitStatus),
 		Time:       time.Now().Unix(),
@@ -274,22 +288,103 @@ func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus)
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
+func NewApproveDelegate(build db.Build, planID atc.PlanID, buildVars *creds.BuildVariables, clock clock.Clock) exec.ApproveDelegate {
+	return &approveDelegate{
+		buildStepDelegate: NewBuildStepDelegate(build, planID, buildVars, clock),
+
+		planID:      planID,
+		eventOrigin: event.Origin{ID: event.OriginID(planID)},
+		build:       build,
+		clock:       clock,
+	}
+}
+
+type approveDelegate struct {
+	*buildStepDelegate
+
+	planID      atc.PlanID
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func (d *approveDelegate) Waiting(logger lager.Logger) {
+	err := d.build.SaveEvent(event.WaitForApproval{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-wait-for-approval-event", err)
+		return
+	}
+
+	logger.Info("waiting")
+}
+
+func (d *approveDelegate) Finished(logger lager.Logger, approval db.BuildApproval) {
+	err := d.build.SaveEvent(event.FinishApproval{
+		Origin:     d.eventOrigin,
+		Time:       d.clock.Now().Unix(),
+		Approved:   approval.Approved,
+		ApprovedBy: approval.ApprovedBy,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-approval-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{"approved": approval.Approved, "approved-by": approval.ApprovedBy})
+}
+
+func (d *approveDelegate) Approval() (db.BuildApproval, bool, error) {
+	return d.build.Approval(d.planID)
+}
+
+func (d *approveDelegate) ApprovalNotifier() (db.Notifier, error) {
+	return d.build.ApprovalNotifier(d.planID)
+}
+
 func NewBuildStepDelegate(
 	build db.Build,
 	planID atc.PlanID,
+	buildVars *creds.BuildVariables,
 	clock clock.Clock,
 ) *buildStepDelegate {
 	return &buildStepDelegate{
-		build:  build,
-		planID: planID,
-		clock:  clock,
+		build:     build,
+		planID:    planID,
+		buildVars: buildVars,
+		clock:     clock,
+
+		stdout: newDBEventWriter(
+			build,
+			event.Origin{
+				Source: event.OriginSourceStdout,
+				ID:     event.OriginID(planID),
+			},
+			buildVars,
+			clock,
+		),
+		stderr: newDBEventWriter(
+			build,
+			event.Origin{
+				Source: event.OriginSourceStderr,
+				ID:     event.OriginID(planID),
+			},
+			buildVars,
+			clock,
+		),
 	}
 }
 
 type buildStepDelegate struct {
-	build  db.Build
-	planID atc.PlanID
-	clock  clock.Clock
+	build     db.Build
+	planID    atc.PlanID
+	buildVars *creds.BuildVariables
+	clock     clock.Clock
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
 }
 
 func (delegate *buildStepDelegate) ImageVersionDetermined(resourceCache db.UsedResourceCache) error {
@@ -297,28 +392,16 @@ func (delegate *buildStepDelegate) ImageVersionDetermined(resourceCache db.UsedR
 }
 
 func (delegate *buildStepDelegate) Stdout() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStdout,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
+	return delegate.stdout
 }
 
 func (delegate *buildStepDelegate) Stderr() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStderr,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
+	return delegate.stderr
 }
 
 func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string) {
+	delegate.Flush(logger)
+
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
 		Origin: event.Origin{
@@ -331,22 +414,60 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
-func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
+func (delegate *buildStepDelegate) WaitingForWorker(logger lager.Logger) {
+	err := delegate.build.SaveEvent(event.WaitingForWorker{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-worker-event", err)
+	}
+}
+
+// Flush saves any output held back by the step's writers, once the step is
+// not going to write anything more.
+func (delegate *buildStepDelegate) Flush(logger lager.Logger) {
+	for _, writer := range []*dbEventWriter{delegate.stdout, delegate.stderr} {
+		err := writer.Flush()
+		if err != nil {
+			logger.Error("failed-to-save-log-event", err)
+		}
+	}
+}
+
+const redactedValue = "((redacted))"
+
+func newDBEventWriter(build db.Build, origin event.Origin, buildVars *creds.BuildVariables, clock clock.Clock) *dbEventWriter {
 	return &dbEventWriter{
-		build:  build,
-		origin: origin,
-		clock:  clock,
+		build:     build,
+		origin:    origin,
+		buildVars: buildVars,
+		clock:     clock,
 	}
 }
 
+// dbEventWriter saves each write as a log event, with the values of the
+// build's redacted vars and secrets replaced.
+//
+// Output which could be the start of a redacted value is held back until the
+// next write shows whether it is, so that a value split across writes is
+// still redacted. Flush saves whatever is held back at the end of the output.
 type dbEventWriter struct {
-	build    db.Build
-	origin   event.Origin
-	clock    clock.Clock
+	build     db.Build
+	origin    event.Origin
+	buildVars *creds.BuildVariables
+	clock     clock.Clock
+
+	lock     sync.Mutex
 	dangling []byte
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
 	text := append(writer.dangling, data...)
 
 	checkEncoding, _ := utf8.DecodeLastRune(text)
@@ -355,16 +476,99 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 		return len(data), nil
 	}
 
+	payload, held := writer.redact(string(text))
+
+	writer.dangling = []byte(held)
+
+	if payload == "" {
+		return len(data), nil
+	}
+
+	err := writer.saveLog(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// Flush saves the output held back by Write.
+func (writer *dbEventWriter) Flush() error {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if len(writer.dangling) == 0 {
+		return nil
+	}
+
+	payload := replaceValues(string(writer.dangling), writer.redactedValues())
 	writer.dangling = nil
 
-	err := writer.build.SaveEvent(event.Log{
+	return writer.saveLog(payload)
+}
+
+func (writer *dbEventWriter) saveLog(payload string) error {
+	return writer.build.SaveEvent(event.Log{
 		Time:    writer.clock.Now().Unix(),
-		Payload: string(text),
+		Payload: payload,
 		Origin:  writer.origin,
 	})
-	if err != nil {
-		return 0, err
+}
+
+func (writer *dbEventWriter) redactedValues() []string {
+	if writer.buildVars == nil {
+		return nil
 	}
 
-	return len(data), nil
+	values := writer.buildVars.RedactedValues()
+
+	// replace longer values first, so that a value containing another one is
+	// not left partially visible
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	return values
+}
+
+// redact splits text into the part which can be saved now, with the redacted
+// values replaced, and the part which has to be held back because it is the
+// start of a redacted value.
+func (writer *dbEventWriter) redact(text string) (string, string) {
+	values := writer.redactedValues()
+
+	held := len(text)
+	for moved := true; moved; {
+		moved = false
+
+		for _, value := range values {
+			start := held - len(value) + 1
+			if start < 0 {
+				start = 0
+			}
+
+			for i := start; i < held; i++ {
+				rest := text[i:]
+
+				// either the text ends with the start of the value, or the value
+				// overlaps the text being held back, in which case both parts of it
+				// have to be held back to be replaced
+				if strings.HasPrefix(value, rest) || strings.HasPrefix(rest, value) {
+					held = i
+					moved = true
+					break
+				}
+			}
+		}
+	}
+
+	return replaceValues(text[:held], values), text[held:]
+}
+
+func replaceValues(text string, values []string) string {
+	for _, value := range values {
+		text = strings.Replace(text, value, redactedValue, -1)
+	}
+
+	return text
 }
@@ -9,12 +9,14 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -26,6 +28,7 @@ var _ = Describe("DelegateFactory", func() {
 		fakePipeline *dbfakes.FakePipeline
 		fakeResource *dbfakes.FakeResource
 		fakeClock    *fakeclock.FakeClock
+		buildVars    *creds.BuildVariables
 	)
 
 	BeforeEach(func() {
@@ -35,6 +38,7 @@ var _ = Describe("DelegateFactory", func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakeResource = new(dbfakes.FakeResource)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		buildVars = creds.NewBuildVariables()
 	})
 
 	Describe("GetDelegate", func() {
@@ -50,7 +54,7 @@ var _ = Describe("DelegateFactory", func() {
 				Metadata: []atc.MetadataField{{Name: "baz", Value: "shmaz"}},
 			}
 
-			delegate = builder.NewGetDelegate(fakeBuild, "some-plan-id", fakeClock)
+			delegate = builder.NewGetDelegate(fakeBuild, "some-plan-id", buildVars, fakeClock)
 		})
 
 		Describe("Finished", func() {
@@ -160,7 +164,7 @@ var _ = Describe("DelegateFactory", func() {
 				Metadata: []atc.MetadataField{{Name: "baz", Value: "shmaz"}},
 			}
 
-			delegate = builder.NewPutDelegate(fakeBuild, "some-plan-id", fakeClock)
+			delegate = builder.NewPutDelegate(fakeBuild, "some-plan-id", buildVars, fakeClock)
 		})
 
 		Describe("Finished", func() {
@@ -219,7 +223,7 @@ var _ = Describe("DelegateFactory", func() {
 		)
 
 		BeforeEach(func() {
-			delegate = builder.NewTaskDelegate(fakeBuild, "some-plan-id", fakeClock)
+			delegate = builder.NewTaskDelegate(fakeBuild, "some-plan-id", buildVars, fakeClock)
 		})
 
 		Describe("Initializing", func() {
@@ -259,13 +263,67 @@ var _ = Describe("DelegateFactory", func() {
 		})
 	})
 
+	Describe("ApproveDelegate", func() {
+		var delegate exec.ApproveDelegate
+
+		BeforeEach(func() {
+			delegate = builder.NewApproveDelegate(fakeBuild, "some-plan-id", buildVars, fakeClock)
+		})
+
+		Describe("Waiting", func() {
+			JustBeforeEach(func() {
+				delegate.Waiting(logger)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.WaitForApproval{
+					Origin: event.Origin{ID: "some-plan-id"},
+					Time:   123456789,
+				}))
+			})
+		})
+
+		Describe("Finished", func() {
+			JustBeforeEach(func() {
+				delegate.Finished(logger, db.BuildApproval{Approved: true, ApprovedBy: "some-user"})
+			})
+
+			It("saves an event recording who approved", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.FinishApproval{
+					Origin:     event.Origin{ID: "some-plan-id"},
+					Time:       123456789,
+					Approved:   true,
+					ApprovedBy: "some-user",
+				}))
+			})
+		})
+
+		Describe("Approval", func() {
+			BeforeEach(func() {
+				fakeBuild.ApprovalReturns(db.BuildApproval{Approved: true}, true, nil)
+			})
+
+			It("looks up the step's approval", func() {
+				approval, found, err := delegate.Approval()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(approval.Approved).To(BeTrue())
+
+				Expect(fakeBuild.ApprovalCallCount()).To(Equal(1))
+				Expect(fakeBuild.ApprovalArgsForCall(0)).To(Equal(atc.PlanID("some-plan-id")))
+			})
+		})
+	})
+
 	Describe("BuildStepDelegate", func() {
 		var (
 			delegate exec.BuildStepDelegate
 		)
 
 		BeforeEach(func() {
-			delegate = builder.NewBuildStepDelegate(fakeBuild, "some-plan-id", fakeClock)
+			delegate = builder.NewBuildStepDelegate(fakeBuild, "some-plan-id", buildVars, fakeClock)
 		})
 
 		Describe("ImageVersionDetermined", func() {
@@ -324,6 +382,69 @@ var _ = Describe("DelegateFactory", func() {
 					})
 				})
 
+				Context("when the build has sensitive vars", func() {
+					BeforeEach(func() {
+						fakeBuild.SaveEventReturns(nil)
+						buildVars.AddLocalVar("some-secret", "ell", true)
+						buildVars.AddLocalVar("some-var", "hel", false)
+					})
+
+					It("redacts their values from the log event", func() {
+						Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+						Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+							Time:    123456789,
+							Payload: "h((redacted))o",
+							Origin: event.Origin{
+								Source: event.OriginSourceStdout,
+								ID:     "some-plan-id",
+							},
+						}))
+					})
+				})
+
+				Context("when the build has resolved secrets", func() {
+					BeforeEach(func() {
+						fakeBuild.SaveEventReturns(nil)
+						buildVars.TrackSecrets()
+
+						secrets := template.StaticVariables{"some-secret": "ell", "other-secret": "hello world"}
+						for _, name := range []string{"some-secret", "other-secret"} {
+							_, found, err := buildVars.Scope(secrets).Get(template.VariableDefinition{Name: name})
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+						}
+					})
+
+					It("holds back output which could be the start of a value", func() {
+						Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+
+						_, err := writer.Write([]byte(" there!"))
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+						Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("h((redacted))o there!"))
+					})
+
+					It("redacts a value split across writes", func() {
+						_, err := writer.Write([]byte(" world!"))
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+						Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("((redacted))!"))
+					})
+
+					It("saves the held back output once the step errors", func() {
+						_, err := writer.Write([]byte(" wor"))
+						Expect(err).NotTo(HaveOccurred())
+
+						delegate.Errored(logger, "fake error message")
+
+						Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+						Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("h((redacted))o wor"))
+						Expect(fakeBuild.SaveEventArgsForCall(1)).To(BeAssignableToTypeOf(event.Error{}))
+					})
+				})
+
 				Context("when saving the event succeeds", func() {
 					disaster := errors.New("nope")
 
@@ -429,5 +550,34 @@ var _ = Describe("DelegateFactory", func() {
 				})
 			})
 		})
+
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				delegate.WaitingForWorker(logger)
+			})
+
+			It("saves a waiting-for-worker event with the current time", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.WaitingForWorker{
+					Time: 123456789,
+					Origin: event.Origin{
+						ID: "some-plan-id",
+					},
+				}))
+			})
+
+			Context("when saving the event fails", func() {
+				BeforeEach(func() {
+					fakeBuild.SaveEventReturns(errors.New("nope"))
+				})
+
+				It("logs an error", func() {
+					logs := logger.Logs()
+					Expect(len(logs)).To(Equal(1))
+					Expect(logs[0].Message).To(Equal("test.failed-to-save-waiting-for-worker-event"))
+					Expect(logs[0].Data).To(Equal(lager.Data{"error": "nope"}))
+				})
+			})
+		})
 	})
 })
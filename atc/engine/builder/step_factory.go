@@ -20,6 +20,7 @@ type stepFactory struct {
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
 	secretManager         creds.Secrets
+	teamFactory           db.TeamFactory
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	resourceFactory       resource.ResourceFactory
@@ -32,6 +33,7 @@ func NewStepFactory(
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	secretManager creds.Secrets,
+	teamFactory db.TeamFactory,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
@@ -43,6 +45,7 @@ func NewStepFactory(
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
 		secretManager:         secretManager,
+		teamFactory:           teamFactory,
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		resourceFactory:       resourceFactory,
@@ -53,6 +56,7 @@ func (factory *stepFactory) GetStep(
 	plan atc.Plan,
 	stepMetadata exec.StepMetadata,
 	containerMetadata db.ContainerMetadata,
+	buildVars *creds.BuildVariables,
 	delegate exec.GetDelegate,
 ) exec.Step {
 	containerMetadata.WorkingDirectory = resource.ResourcesDir("get")
@@ -62,7 +66,8 @@ func (factory *stepFactory) GetStep(
 		*plan.Get,
 		stepMetadata,
 		containerMetadata,
-		factory.secretManager,
+		factory.secretsFor(stepMetadata),
+		buildVars,
 		factory.resourceFetcher,
 		factory.resourceCacheFactory,
 		factory.strategy,
@@ -77,6 +82,7 @@ func (factory *stepFactory) PutStep(
 	plan atc.Plan,
 	stepMetadata exec.StepMetadata,
 	containerMetadata db.ContainerMetadata,
+	buildVars *creds.BuildVariables,
 	delegate exec.PutDelegate,
 ) exec.Step {
 	containerMetadata.WorkingDirectory = resource.ResourcesDir("put")
@@ -86,7 +92,8 @@ func (factory *stepFactory) PutStep(
 		*plan.Put,
 		stepMetadata,
 		containerMetadata,
-		factory.secretManager,
+		factory.secretsFor(stepMetadata),
+		buildVars,
 		factory.resourceFactory,
 		factory.resourceConfigFactory,
 		factory.strategy,
@@ -101,6 +108,7 @@ func (factory *stepFactory) TaskStep(
 	plan atc.Plan,
 	stepMetadata exec.StepMetadata,
 	containerMetadata db.ContainerMetadata,
+	buildVars *creds.BuildVariables,
 	delegate exec.TaskDelegate,
 ) exec.Step {
 	sum := sha1.Sum([]byte(plan.Task.Name))
@@ -112,7 +120,8 @@ func (factory *stepFactory) TaskStep(
 		factory.defaultLimits,
 		stepMetadata,
 		containerMetadata,
-		factory.secretManager,
+		factory.secretsFor(stepMetadata),
+		buildVars,
 		factory.strategy,
 		factory.pool,
 		delegate,
@@ -121,6 +130,54 @@ func (factory *stepFactory) TaskStep(
 	return exec.LogError(taskStep, delegate)
 }
 
+func (factory *stepFactory) SetPipelineStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegate exec.BuildStepDelegate,
+) exec.Step {
+	spStep := exec.NewSetPipelineStep(
+		plan.ID,
+		*plan.SetPipeline,
+		stepMetadata,
+		factory.teamFactory,
+		delegate,
+	)
+
+	return exec.LogError(spStep, delegate)
+}
+
+func (factory *stepFactory) LoadVarStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	buildVars *creds.BuildVariables,
+	delegate exec.BuildStepDelegate,
+) exec.Step {
+	loadVarStep := exec.NewLoadVarStep(
+		plan.ID,
+		*plan.LoadVar,
+		stepMetadata,
+		buildVars,
+		delegate,
+	)
+
+	return exec.LogError(loadVarStep, delegate)
+}
+
+func (factory *stepFactory) ApproveStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegate exec.ApproveDelegate,
+) exec.Step {
+	approveStep := exec.NewApproveStep(
+		plan.ID,
+		*plan.Approve,
+		stepMetadata,
+		delegate,
+	)
+
+	return exec.LogError(approveStep, delegate)
+}
+
 func (factory *stepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
@@ -136,3 +193,11 @@ func (factory *stepFactory) ArtifactOutputStep(
 ) exec.Step {
 	return exec.NewArtifactOutputStep(plan, build, factory.client, delegate)
 }
+
+// secretsFor attributes the secret lookups of a step to its build in the
+// secret access audit trail.
+func (factory *stepFactory) secretsFor(stepMetadata exec.StepMetadata) creds.Secrets {
+	return creds.SecretsFor(factory.secretManager, creds.SecretAccessor{
+		BuildID: stepMetadata.BuildID,
+	})
+}
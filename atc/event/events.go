@@ -164,3 +164,29 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.1" }
+
+type WaitForApproval struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (WaitForApproval) EventType() atc.EventType  { return EventTypeWaitForApproval }
+func (WaitForApproval) Version() atc.EventVersion { return "1.0" }
+
+type FinishApproval struct {
+	Origin     Origin `json:"origin"`
+	Time       int64  `json:"time"`
+	Approved   bool   `json:"approved"`
+	ApprovedBy string `json:"approved_by,omitempty"`
+}
+
+func (FinishApproval) EventType() atc.EventType  { return EventTypeFinishApproval }
+func (FinishApproval) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForWorker struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
+func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
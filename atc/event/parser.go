@@ -43,6 +43,9 @@ func init() {
 	RegisterEvent(InitializePut{})
 	RegisterEvent(StartPut{})
 	RegisterEvent(FinishPut{})
+	RegisterEvent(WaitForApproval{})
+	RegisterEvent(FinishApproval{})
+	RegisterEvent(WaitingForWorker{})
 	RegisterEvent(Status{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
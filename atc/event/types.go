@@ -36,6 +36,15 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// approve step waiting for a decision
+	EventTypeWaitForApproval atc.EventType = "wait-for-approval"
+
+	// approve step decided
+	EventTypeFinishApproval atc.EventType = "finish-approval"
+
+	// step waiting for a compatible worker with capacity
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
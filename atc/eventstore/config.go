@@ -0,0 +1,77 @@
+package eventstore
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	StorePostgres = "postgres"
+	StoreDir      = "dir"
+	StoreS3       = "s3"
+)
+
+type Config struct {
+	Store string `long:"store" default:"postgres" choice:"postgres" choice:"dir" choice:"s3" description:"Where to store build events, i.e. build logs."`
+
+	RetryInterval time.Duration `long:"retry-interval" default:"1s" description:"How long to wait before retrying to write build events which could not be written when using the 'dir' or 's3' store."`
+
+	Dir string `long:"dir" description:"Directory in which to store build events when using the 'dir' store."`
+
+	S3Bucket          string `long:"s3-bucket"            description:"Bucket in which to store build events when using the 's3' store."`
+	S3Prefix          string `long:"s3-prefix"            description:"Prefix for the keys of objects storing build events."`
+	S3Region          string `long:"s3-region"            description:"AWS region of the bucket."`
+	S3Endpoint        string `long:"s3-endpoint"          description:"Endpoint of an S3-compatible object store, e.g. MinIO. Defaults to AWS S3."`
+	S3ForcePathStyle  bool   `long:"s3-force-path-style"  description:"Use path-style bucket addressing, as required by most S3-compatible object stores."`
+	S3AccessKeyID     string `long:"s3-access-key"        description:"Access key ID for the object store."`
+	S3SecretAccessKey string `long:"s3-secret-key"        description:"Secret access key for the object store."`
+	S3SessionToken    string `long:"s3-session-token"     description:"Session token for the object store."`
+}
+
+// NewStore returns the configured event store, or nil if events are to be
+// stored in the database.
+func (config Config) NewStore(logger lager.Logger) (db.EventStore, error) {
+	switch config.Store {
+	case StoreDir:
+		if config.Dir == "" {
+			return nil, errors.New("must specify --build-events-dir to use the 'dir' build event store")
+		}
+
+		return NewStore(logger, NewDirBlobs(config.Dir), config.RetryInterval), nil
+
+	case StoreS3:
+		if config.S3Bucket == "" {
+			return nil, errors.New("must specify --build-events-s3-bucket to use the 's3' build event store")
+		}
+
+		awsConfig := &aws.Config{
+			Region:           aws.String(config.S3Region),
+			S3ForcePathStyle: aws.Bool(config.S3ForcePathStyle),
+		}
+
+		if config.S3Endpoint != "" {
+			awsConfig.Endpoint = aws.String(config.S3Endpoint)
+		}
+
+		if config.S3AccessKeyID != "" {
+			awsConfig.Credentials = credentials.NewStaticCredentials(config.S3AccessKeyID, config.S3SecretAccessKey, config.S3SessionToken)
+		}
+
+		session, err := session.NewSession(awsConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewStore(logger, NewS3Blobs(s3.New(session), config.S3Bucket, config.S3Prefix), config.RetryInterval), nil
+
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,79 @@
+package eventstore
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type dirBlobs struct {
+	dir string
+}
+
+// NewDirBlobs stores blobs as files in a local directory.
+func NewDirBlobs(dir string) Blobs {
+	return &dirBlobs{
+		dir: dir,
+	}
+}
+
+func (blobs *dirBlobs) Append(key string, data []byte) error {
+	path := blobs.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func (blobs *dirBlobs) Read(key string, offset int64) (io.ReadCloser, bool, error) {
+	file, err := os.Open(blobs.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		_ = file.Close()
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+// Seal does nothing, as each blob is already a single file.
+func (blobs *dirBlobs) Seal(key string) error {
+	return nil
+}
+
+func (blobs *dirBlobs) Delete(keys []string) error {
+	for _, key := range keys {
+		err := os.Remove(blobs.path(key))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (blobs *dirBlobs) path(key string) string {
+	return filepath.Join(blobs.dir, filepath.FromSlash(key))
+}
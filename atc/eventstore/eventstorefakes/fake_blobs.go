@@ -0,0 +1,354 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package eventstorefakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/concourse/atc/eventstore"
+)
+
+type FakeBlobs struct {
+	AppendStub        func(string, []byte) error
+	appendMutex       sync.RWMutex
+	appendArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	appendReturns struct {
+		result1 error
+	}
+	appendReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteStub        func([]string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 []string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReadStub        func(string, int64) (io.ReadCloser, bool, error)
+	readMutex       sync.RWMutex
+	readArgsForCall []struct {
+		arg1 string
+		arg2 int64
+	}
+	readReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	readReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	SealStub        func(string) error
+	sealMutex       sync.RWMutex
+	sealArgsForCall []struct {
+		arg1 string
+	}
+	sealReturns struct {
+		result1 error
+	}
+	sealReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBlobs) Append(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.appendMutex.Lock()
+	ret, specificReturn := fake.appendReturnsOnCall[len(fake.appendArgsForCall)]
+	fake.appendArgsForCall = append(fake.appendArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Append", []interface{}{arg1, arg2Copy})
+	fake.appendMutex.Unlock()
+	if fake.AppendStub != nil {
+		return fake.AppendStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.appendReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlobs) AppendCallCount() int {
+	fake.appendMutex.RLock()
+	defer fake.appendMutex.RUnlock()
+	return len(fake.appendArgsForCall)
+}
+
+func (fake *FakeBlobs) AppendCalls(stub func(string, []byte) error) {
+	fake.appendMutex.Lock()
+	defer fake.appendMutex.Unlock()
+	fake.AppendStub = stub
+}
+
+func (fake *FakeBlobs) AppendArgsForCall(i int) (string, []byte) {
+	fake.appendMutex.RLock()
+	defer fake.appendMutex.RUnlock()
+	argsForCall := fake.appendArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlobs) AppendReturns(result1 error) {
+	fake.appendMutex.Lock()
+	defer fake.appendMutex.Unlock()
+	fake.AppendStub = nil
+	fake.appendReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlobs) AppendReturnsOnCall(i int, result1 error) {
+	fake.appendMutex.Lock()
+	defer fake.appendMutex.Unlock()
+	fake.AppendStub = nil
+	if fake.appendReturnsOnCall == nil {
+		fake.appendReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appendReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlobs) Delete(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("Delete", []interface{}{arg1Copy})
+	fake.deleteMutex.Unlock()
+	if fake.DeleteStub != nil {
+		return fake.DeleteStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlobs) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeBlobs) DeleteCalls(stub func([]string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *FakeBlobs) DeleteArgsForCall(i int) []string {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBlobs) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlobs) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlobs) Read(arg1 string, arg2 int64) (io.ReadCloser, bool, error) {
+	fake.readMutex.Lock()
+	ret, specificReturn := fake.readReturnsOnCall[len(fake.readArgsForCall)]
+	fake.readArgsForCall = append(fake.readArgsForCall, struct {
+		arg1 string
+		arg2 int64
+	}{arg1, arg2})
+	fake.recordInvocation("Read", []interface{}{arg1, arg2})
+	fake.readMutex.Unlock()
+	if fake.ReadStub != nil {
+		return fake.ReadStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.readReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBlobs) ReadCallCount() int {
+	fake.readMutex.RLock()
+	defer fake.readMutex.RUnlock()
+	return len(fake.readArgsForCall)
+}
+
+func (fake *FakeBlobs) ReadCalls(stub func(string, int64) (io.ReadCloser, bool, error)) {
+	fake.readMutex.Lock()
+	defer fake.readMutex.Unlock()
+	fake.ReadStub = stub
+}
+
+func (fake *FakeBlobs) ReadArgsForCall(i int) (string, int64) {
+	fake.readMutex.RLock()
+	defer fake.readMutex.RUnlock()
+	argsForCall := fake.readArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlobs) ReadReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.readMutex.Lock()
+	defer fake.readMutex.Unlock()
+	fake.ReadStub = nil
+	fake.readReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBlobs) ReadReturnsOnCall(i int, result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.readMutex.Lock()
+	defer fake.readMutex.Unlock()
+	fake.ReadStub = nil
+	if fake.readReturnsOnCall == nil {
+		fake.readReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 bool
+			result3 error
+		})
+	}
+	fake.readReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBlobs) Seal(arg1 string) error {
+	fake.sealMutex.Lock()
+	ret, specificReturn := fake.sealReturnsOnCall[len(fake.sealArgsForCall)]
+	fake.sealArgsForCall = append(fake.sealArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Seal", []interface{}{arg1})
+	fake.sealMutex.Unlock()
+	if fake.SealStub != nil {
+		return fake.SealStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sealReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlobs) SealCallCount() int {
+	fake.sealMutex.RLock()
+	defer fake.sealMutex.RUnlock()
+	return len(fake.sealArgsForCall)
+}
+
+func (fake *FakeBlobs) SealCalls(stub func(string) error) {
+	fake.sealMutex.Lock()
+	defer fake.sealMutex.Unlock()
+	fake.SealStub = stub
+}
+
+func (fake *FakeBlobs) SealArgsForCall(i int) string {
+	fake.sealMutex.RLock()
+	defer fake.sealMutex.RUnlock()
+	argsForCall := fake.sealArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBlobs) SealReturns(result1 error) {
+	fake.sealMutex.Lock()
+	defer fake.sealMutex.Unlock()
+	fake.SealStub = nil
+	fake.sealReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlobs) SealReturnsOnCall(i int, result1 error) {
+	fake.sealMutex.Lock()
+	defer fake.sealMutex.Unlock()
+	fake.SealStub = nil
+	if fake.sealReturnsOnCall == nil {
+		fake.sealReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sealReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlobs) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.appendMutex.RLock()
+	defer fake.appendMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.readMutex.RLock()
+	defer fake.readMutex.RUnlock()
+	fake.sealMutex.RLock()
+	defer fake.sealMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBlobs) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ eventstore.Blobs = new(FakeBlobs)
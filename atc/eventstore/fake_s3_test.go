@@ -0,0 +1,202 @@
+package eventstore_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// fakeListPageSize is small so that listings span several pages.
+const fakeListPageSize = 2
+
+// fakeS3 is an in-memory stand-in for an S3-compatible object store.
+type fakeS3 struct {
+	s3iface.S3API
+
+	lock     sync.Mutex
+	objects  map[string][]byte
+	metadata map[string]map[string]*string
+
+	heads         int
+	listings      []s3.ListObjectsV2Input
+	failDeletions bool
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects:  map[string][]byte{},
+		metadata: map[string]map[string]*string{},
+	}
+}
+
+func (f *fakeS3) headCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.heads
+}
+
+func (f *fakeS3) listInputs() []s3.ListObjectsV2Input {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]s3.ListObjectsV2Input{}, f.listings...)
+}
+
+func (f *fakeS3) failDeleting(fail bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.failDeletions = fail
+}
+
+func (f *fakeS3) keys() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var keys []string
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (f *fakeS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	content, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	key := aws.StringValue(input.Bucket) + "/" + aws.StringValue(input.Key)
+
+	// like S3, metadata keys are returned canonicalized
+	metadata := map[string]*string{}
+	for name, value := range input.Metadata {
+		metadata[http.CanonicalHeaderKey(name)] = value
+	}
+
+	f.objects[key] = content
+	f.metadata[key] = metadata
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	content, found := f.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)]
+	if !found {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "not found", nil)
+	}
+
+	if input.Range != nil {
+		var from, to int
+		_, err := fmt.Sscanf(aws.StringValue(input.Range), "bytes=%d-%d", &from, &to)
+		if err != nil {
+			return nil, err
+		}
+
+		if to >= len(content) {
+			to = len(content) - 1
+		}
+
+		content = content[from : to+1]
+	}
+
+	return &s3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader(content)),
+	}, nil
+}
+
+func (f *fakeS3) HeadObject(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.heads++
+
+	key := aws.StringValue(input.Bucket) + "/" + aws.StringValue(input.Key)
+
+	content, found := f.objects[key]
+	if !found {
+		return nil, awserr.New("NotFound", "not found", nil)
+	}
+
+	return &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(int64(len(content))),
+		Metadata:      f.metadata[key],
+	}, nil
+}
+
+func (f *fakeS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.listings = append(f.listings, *input)
+
+	bucket := aws.StringValue(input.Bucket) + "/"
+
+	var keys []string
+	for key := range f.objects {
+		objectKey := strings.TrimPrefix(key, bucket)
+		if strings.HasPrefix(key, bucket) && strings.HasPrefix(objectKey, aws.StringValue(input.Prefix)) && objectKey > aws.StringValue(input.StartAfter) {
+			keys = append(keys, objectKey)
+		}
+	}
+	sort.Strings(keys)
+
+	for len(keys) > 0 {
+		count := fakeListPageSize
+		if count > len(keys) {
+			count = len(keys)
+		}
+
+		page := &s3.ListObjectsV2Output{}
+		for _, key := range keys[:count] {
+			page.Contents = append(page.Contents, &s3.Object{
+				Key:  aws.String(key),
+				Size: aws.Int64(int64(len(f.objects[bucket+key]))),
+			})
+		}
+
+		keys = keys[count:]
+
+		if !fn(page, len(keys) == 0) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeS3) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.failDeletions {
+		return nil, errors.New("failed to delete")
+	}
+
+	for _, object := range input.Delete.Objects {
+		key := aws.StringValue(input.Bucket) + "/" + aws.StringValue(object.Key)
+		delete(f.objects, key)
+		delete(f.metadata, key)
+	}
+
+	return &s3.DeleteObjectsOutput{}, nil
+}
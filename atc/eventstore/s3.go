@@ -0,0 +1,420 @@
+package eventstore
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// S3 limits the number of objects deleted per request.
+const maxDeleteObjects = 1000
+
+// Like cursors, listings are all forgotten once there are this many.
+const maxListings = 1000
+
+// sealedThroughMetadata is the metadata of a sealed blob's object naming the
+// last part concatenated into it, as the parts are only deleted afterwards.
+const sealedThroughMetadata = "Sealed-Through"
+
+type s3Blobs struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+
+	partSeq uint64
+
+	listingsL sync.Mutex
+	listings  map[string]listing
+}
+
+// segment is an object making up part of a blob.
+type segment struct {
+	key  string
+	size int64
+}
+
+// listing is what is known of the segments making up a blob. As parts are
+// never modified, only the parts after the last one known need to be listed
+// to bring it up to date.
+type listing struct {
+	segments []segment
+	after    string
+}
+
+// NewS3Blobs stores blobs as objects in an S3-compatible bucket.
+//
+// As objects cannot be appended to, each append is stored as a separate part
+// object until the blob is sealed, at which point the parts are concatenated
+// into a single object. Each blob's parts are kept under their own prefix,
+// named so that they are listed in the order they were appended.
+func NewS3Blobs(client s3iface.S3API, bucket string, prefix string) Blobs {
+	return &s3Blobs{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+
+		listings: map[string]listing{},
+	}
+}
+
+func (blobs *s3Blobs) Append(key string, data []byte) error {
+	seq := atomic.AddUint64(&blobs.partSeq, 1)
+
+	part := fmt.Sprintf("%s%020d-%010d", blobs.partsPrefix(key), time.Now().UnixNano(), seq)
+
+	return blobs.put(part, data, nil)
+}
+
+func (blobs *s3Blobs) Read(key string, offset int64) (io.ReadCloser, bool, error) {
+	segments, err := blobs.segments(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(segments) == 0 {
+		return nil, false, nil
+	}
+
+	buf := new(bytes.Buffer)
+
+	start := int64(0)
+	for _, segment := range segments {
+		end := start + segment.size
+
+		if segment.size > 0 && end > offset {
+			from := int64(0)
+			if offset > start {
+				from = offset - start
+			}
+
+			content, found, err := blobs.get(segment.key, from, segment.size)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !found {
+				// the parts have been concatenated into the object
+				blobs.forget(key)
+				return blobs.Read(key, offset)
+			}
+
+			buf.Write(content)
+		}
+
+		start = end
+	}
+
+	return ioutil.NopCloser(buf), true, nil
+}
+
+func (blobs *s3Blobs) Seal(key string) error {
+	segments, err := blobs.segments(key)
+	if err != nil {
+		return err
+	}
+
+	var parts []string
+	for _, segment := range segments {
+		if segment.key != blobs.objectKey(key) {
+			parts = append(parts, segment.key)
+		}
+	}
+
+	if len(parts) == 0 {
+		if len(segments) == 0 {
+			return nil
+		}
+
+		// sealed before, but the parts may have failed to be deleted
+		return blobs.deleteSealedParts(key)
+	}
+
+	buf := new(bytes.Buffer)
+	for _, segment := range segments {
+		if segment.size == 0 {
+			continue
+		}
+
+		content, found, err := blobs.get(segment.key, 0, segment.size)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			// sealed concurrently, e.g. by another ATC
+			blobs.forget(key)
+			return blobs.Seal(key)
+		}
+
+		buf.Write(content)
+	}
+
+	// readers skip the parts which were concatenated until they are deleted
+	lastPart := parts[len(parts)-1]
+	err = blobs.put(blobs.objectKey(key), buf.Bytes(), map[string]*string{
+		sealedThroughMetadata: aws.String(strings.TrimPrefix(lastPart, blobs.partsPrefix(key))),
+	})
+	if err != nil {
+		return err
+	}
+
+	blobs.forget(key)
+
+	// only the parts which were concatenated are deleted, in case more were
+	// appended in the meantime
+	return blobs.deleteObjects(parts)
+}
+
+// deleteSealedParts deletes the parts which were concatenated into the blob's
+// object.
+func (blobs *s3Blobs) deleteSealedParts(key string) error {
+	sealed, err := blobs.sealed(key)
+	if err != nil {
+		return err
+	}
+
+	if sealed.after == "" {
+		return nil
+	}
+
+	parts, err := blobs.list(blobs.partsPrefix(key), "")
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	for _, part := range parts {
+		if part.key <= sealed.after {
+			keys = append(keys, part.key)
+		}
+	}
+
+	return blobs.deleteObjects(keys)
+}
+
+func (blobs *s3Blobs) Delete(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var objects []string
+	for _, key := range keys {
+		objects = append(objects, blobs.objectKey(key))
+
+		parts, err := blobs.list(blobs.partsPrefix(key), "")
+		if err != nil {
+			return err
+		}
+
+		for _, part := range parts {
+			objects = append(objects, part.key)
+		}
+
+		blobs.forget(key)
+	}
+
+	return blobs.deleteObjects(objects)
+}
+
+func (blobs *s3Blobs) objectKey(key string) string {
+	return blobs.prefix + key
+}
+
+func (blobs *s3Blobs) partsPrefix(key string) string {
+	return blobs.prefix + "parts/" + key + "/"
+}
+
+// segments returns the object storing the blob, if any, followed by the
+// parts appended since it was last sealed.
+func (blobs *s3Blobs) segments(key string) ([]segment, error) {
+	blobs.listingsL.Lock()
+	known, found := blobs.listings[key]
+	blobs.listingsL.Unlock()
+
+	if !found {
+		var err error
+		known, err = blobs.sealed(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	parts, err := blobs.list(blobs.partsPrefix(key), known.after)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]segment, 0, len(known.segments)+len(parts))
+	segments = append(segments, known.segments...)
+	segments = append(segments, parts...)
+
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	current := listing{segments: segments, after: known.after}
+	if len(parts) > 0 {
+		current.after = parts[len(parts)-1].key
+	}
+
+	blobs.listingsL.Lock()
+	if len(blobs.listings) >= maxListings {
+		blobs.listings = map[string]listing{}
+	}
+	blobs.listings[key] = current
+	blobs.listingsL.Unlock()
+
+	return segments, nil
+}
+
+// sealed returns the listing of the object storing the blob, if any, so that
+// the parts which were concatenated into it are skipped.
+func (blobs *s3Blobs) sealed(key string) (listing, error) {
+	output, err := blobs.client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(blobs.bucket),
+		Key:    aws.String(blobs.objectKey(key)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return listing{}, nil
+		}
+
+		return listing{}, err
+	}
+
+	sealed := listing{
+		segments: []segment{{
+			key:  blobs.objectKey(key),
+			size: aws.Int64Value(output.ContentLength),
+		}},
+	}
+
+	for name, value := range output.Metadata {
+		if strings.EqualFold(name, sealedThroughMetadata) {
+			sealed.after = blobs.partsPrefix(key) + aws.StringValue(value)
+		}
+	}
+
+	return sealed, nil
+}
+
+func (blobs *s3Blobs) forget(key string) {
+	blobs.listingsL.Lock()
+	delete(blobs.listings, key)
+	blobs.listingsL.Unlock()
+}
+
+func (blobs *s3Blobs) put(objectKey string, data []byte, metadata map[string]*string) error {
+	_, err := blobs.client.PutObject(&s3.PutObjectInput{
+		Bucket:   aws.String(blobs.bucket),
+		Key:      aws.String(objectKey),
+		Body:     bytes.NewReader(data),
+		Metadata: metadata,
+	})
+	return err
+}
+
+// get returns the object's content from the given byte offset up to its
+// expected size, ignoring anything appended by a concurrent Seal.
+func (blobs *s3Blobs) get(objectKey string, from int64, size int64) ([]byte, bool, error) {
+	output, err := blobs.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(blobs.bucket),
+		Key:    aws.String(objectKey),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", from, size-1)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	defer output.Body.Close()
+
+	content, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return content, true, nil
+}
+
+// list returns the objects under the prefix whose keys sort after the given
+// key, if any, a page at a time.
+func (blobs *s3Blobs) list(prefix string, after string) ([]segment, error) {
+	var objects []segment
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(blobs.bucket),
+		Prefix: aws.String(prefix),
+	}
+
+	if after != "" {
+		input.StartAfter = aws.String(after)
+	}
+
+	err := blobs.client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			objects = append(objects, segment{
+				key:  aws.StringValue(object.Key),
+				size: aws.Int64Value(object.Size),
+			})
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].key < objects[j].key
+	})
+
+	return objects, nil
+}
+
+func (blobs *s3Blobs) deleteObjects(keys []string) error {
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > maxDeleteObjects {
+			batch = batch[:maxDeleteObjects]
+		}
+
+		keys = keys[len(batch):]
+
+		var objects []*s3.ObjectIdentifier
+		for _, key := range batch {
+			objects = append(objects, &s3.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		_, err := blobs.client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(blobs.bucket),
+			Delete: &s3.Delete{Objects: objects},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HeadObject reports a missing object as NotFound, as its response has no
+// body to carry the usual NoSuchKey code.
+func isNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && (awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound")
+}
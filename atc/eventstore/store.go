@@ -0,0 +1,481 @@
+// Package eventstore implements db.EventStore on top of blob storage, i.e. a
+// local directory or an S3-compatible object store, so that build logs do not
+// have to be kept in the database.
+//
+// Each build's events are kept in a single blob of gzip-compressed JSON
+// lines. Events are written once the transaction recording them commits,
+// before its Commit returns, each write being appended to the blob as its own
+// gzip member. As a member can be decompressed on its own, reads pick up from
+// the member at which the previous read of the build left off rather than
+// from the start.
+package eventstore
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// Cursors are only an optimisation, so rather than tracking which builds are
+// still being read they are all forgotten once there are this many.
+const maxCursors = 1000
+
+//go:generate counterfeiter . Blobs
+
+// Blobs stores append-only blobs by key.
+type Blobs interface {
+	// Append appends data to the blob, creating it if it does not exist.
+	Append(key string, data []byte) error
+
+	// Read returns the contents of the blob starting at the given byte
+	// offset, or false if it does not exist.
+	Read(key string, offset int64) (io.ReadCloser, bool, error)
+
+	// Seal is called once nothing more will be appended to the blob, so
+	// that it may be compacted. Its contents must not change.
+	Seal(key string) error
+
+	// Delete removes the blobs. Deleting a blob which does not exist is not
+	// an error.
+	Delete(keys []string) error
+}
+
+type store struct {
+	logger        lager.Logger
+	blobs         Blobs
+	retryInterval time.Duration
+
+	pendingL sync.Mutex
+	pending  map[int]*pendingEvents
+
+	cursorsL sync.Mutex
+	cursors  map[int]cursor
+}
+
+// pendingEvents are the events of a build which have been committed but not
+// yet written to its blob, either because they are being written or because
+// writing them failed and is to be retried.
+type pendingEvents struct {
+	// held while writing, so that the build's events are appended in order
+	flushL sync.Mutex
+
+	conn      db.Conn
+	lines     []byte
+	finalized bool
+	scheduled bool
+}
+
+// cursor is the position of a gzip member in a build's blob, along with the
+// number of events before it.
+type cursor struct {
+	events uint
+	offset int64
+}
+
+// NewStore returns an event store which writes each build's events as soon
+// as they are committed, retrying every retryInterval if that fails.
+func NewStore(logger lager.Logger, blobs Blobs, retryInterval time.Duration) db.EventStore {
+	return &store{
+		logger:        logger,
+		blobs:         blobs,
+		retryInterval: retryInterval,
+
+		pending: map[int]*pendingEvents{},
+		cursors: map[int]cursor{},
+	}
+}
+
+func (s *store) Put(conn db.Conn, tx db.Tx, build db.Build, ev atc.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	data := json.RawMessage(payload)
+
+	line, err := json.Marshal(event.Envelope{
+		Data:    &data,
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+	})
+	if err != nil {
+		return err
+	}
+
+	buildID := build.ID()
+
+	tx.AfterCommit(func() {
+		s.buffer(conn, buildID, append(line, '\n'))
+
+		err := s.flush(buildID)
+		if err != nil {
+			s.logger.Error("failed-to-write-build-events", err, lager.Data{"build": buildID})
+		}
+	})
+
+	return nil
+}
+
+func (s *store) Get(conn db.Conn, build db.Build, offset uint, limit int) ([]event.Envelope, error) {
+	buildID := build.ID()
+
+	start := s.cursor(buildID, offset)
+
+	events, err := s.read(buildID, start, offset, limit)
+	if err != nil && start.offset != 0 && isCorrupt(err) {
+		// the blob may have been written differently than when the cursor
+		// was taken, e.g. by another ATC with a skewed clock
+		s.forgetCursors(buildID)
+
+		events, err = s.read(buildID, cursor{}, offset, limit)
+	}
+
+	return events, err
+}
+
+func (s *store) Finalize(tx db.Tx, build db.Build) error {
+	buildID := build.ID()
+
+	tx.AfterCommit(func() {
+		s.pendingL.Lock()
+		s.pendingFor(buildID).finalized = true
+		s.pendingL.Unlock()
+
+		err := s.flush(buildID)
+		if err != nil {
+			s.logger.Error("failed-to-finalize-build-events", err, lager.Data{"build": buildID})
+		}
+	})
+
+	return nil
+}
+
+func (s *store) Delete(tx db.Tx, buildIDs []int) error {
+	s.pendingL.Lock()
+	for _, buildID := range buildIDs {
+		delete(s.pending, buildID)
+	}
+	s.pendingL.Unlock()
+
+	s.forgetCursors(buildIDs...)
+
+	keys := make([]string, len(buildIDs))
+	for i, buildID := range buildIDs {
+		keys[i] = buildKey(buildID)
+	}
+
+	return s.blobs.Delete(keys)
+}
+
+func (s *store) buffer(conn db.Conn, buildID int, line []byte) {
+	s.pendingL.Lock()
+	defer s.pendingL.Unlock()
+
+	pending := s.pendingFor(buildID)
+	pending.conn = conn
+	pending.lines = append(pending.lines, line...)
+}
+
+// pendingFor must be called with pendingL held.
+func (s *store) pendingFor(buildID int) *pendingEvents {
+	pending, found := s.pending[buildID]
+	if !found {
+		pending = &pendingEvents{}
+		s.pending[buildID] = pending
+	}
+
+	return pending
+}
+
+// schedule retries writing the build's pending events. It must be called
+// with pendingL held.
+func (s *store) schedule(buildID int, pending *pendingEvents) {
+	if pending.scheduled {
+		return
+	}
+
+	pending.scheduled = true
+
+	time.AfterFunc(s.retryInterval, func() {
+		err := s.flush(buildID)
+		if err != nil {
+			s.logger.Error("failed-to-write-build-events", err, lager.Data{"build": buildID})
+		}
+	})
+}
+
+// flush writes the build's pending events, if any, and seals its blob if it
+// has been finalized.
+func (s *store) flush(buildID int) error {
+	for {
+		s.pendingL.Lock()
+		pending, found := s.pending[buildID]
+		s.pendingL.Unlock()
+
+		if !found {
+			return nil
+		}
+
+		pending.flushL.Lock()
+		current, err := s.write(buildID, pending)
+		pending.flushL.Unlock()
+
+		if current || err != nil {
+			return err
+		}
+	}
+}
+
+// write returns false if the pending events are no longer the build's, i.e.
+// they were written and removed while waiting for flushL.
+func (s *store) write(buildID int, pending *pendingEvents) (bool, error) {
+	s.pendingL.Lock()
+
+	if s.pending[buildID] != pending {
+		s.pendingL.Unlock()
+		return false, nil
+	}
+
+	conn := pending.conn
+	lines := pending.lines
+	finalized := pending.finalized
+
+	pending.lines = nil
+	pending.scheduled = false
+
+	s.pendingL.Unlock()
+
+	if len(lines) > 0 {
+		compressed, err := compress(lines)
+		if err == nil {
+			err = s.blobs.Append(buildKey(buildID), compressed)
+		}
+
+		if err != nil {
+			s.pendingL.Lock()
+			pending.lines = append(lines, pending.lines...)
+			s.schedule(buildID, pending)
+			s.pendingL.Unlock()
+
+			return true, err
+		}
+
+		err = conn.Bus().Notify(db.BuildEventsChannel(buildID))
+		if err != nil {
+			s.logger.Error("failed-to-notify-build-events", err, lager.Data{"build": buildID})
+		}
+	}
+
+	if finalized {
+		err := s.blobs.Seal(buildKey(buildID))
+		if err != nil {
+			s.pendingL.Lock()
+			s.schedule(buildID, pending)
+			s.pendingL.Unlock()
+
+			return true, err
+		}
+	}
+
+	s.pendingL.Lock()
+	if len(pending.lines) == 0 && !pending.scheduled {
+		delete(s.pending, buildID)
+	}
+	s.pendingL.Unlock()
+
+	return true, nil
+}
+
+func (s *store) read(buildID int, start cursor, offset uint, limit int) ([]event.Envelope, error) {
+	blob, found, err := s.blobs.Read(buildKey(buildID), start.offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	defer blob.Close()
+
+	reader := &countingReader{
+		reader: bufio.NewReader(blob),
+		count:  start.offset,
+	}
+
+	var events []event.Envelope
+
+	index := start.events
+	next := start
+	for len(events) < limit {
+		memberStart := cursor{events: index, offset: reader.count}
+
+		content, complete, err := readMember(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if !complete {
+			// the last member may be partially appended; it will be read
+			// next time
+			break
+		}
+
+		next = memberStart
+
+		for _, line := range bytes.SplitAfter(content, []byte{'\n'}) {
+			if len(line) == 0 {
+				continue
+			}
+
+			if index >= offset && len(events) < limit {
+				var ev event.Envelope
+				err = json.Unmarshal(line, &ev)
+				if err != nil {
+					return nil, err
+				}
+
+				events = append(events, ev)
+			}
+
+			index++
+		}
+
+		if index <= offset+uint(len(events)) {
+			// every event in the member has been read, so the next read
+			// can start after it
+			next = cursor{events: index, offset: reader.count}
+		}
+	}
+
+	s.saveCursor(buildID, next)
+
+	return events, nil
+}
+
+func (s *store) cursor(buildID int, offset uint) cursor {
+	s.cursorsL.Lock()
+	defer s.cursorsL.Unlock()
+
+	saved, found := s.cursors[buildID]
+	if !found || saved.events > offset {
+		return cursor{}
+	}
+
+	return saved
+}
+
+func (s *store) saveCursor(buildID int, next cursor) {
+	s.cursorsL.Lock()
+	defer s.cursorsL.Unlock()
+
+	if next.offset <= s.cursors[buildID].offset {
+		return
+	}
+
+	if len(s.cursors) >= maxCursors {
+		s.cursors = map[int]cursor{}
+	}
+
+	s.cursors[buildID] = next
+}
+
+func (s *store) forgetCursors(buildIDs ...int) {
+	s.cursorsL.Lock()
+	defer s.cursorsL.Unlock()
+
+	for _, buildID := range buildIDs {
+		delete(s.cursors, buildID)
+	}
+}
+
+// readMember reads the next gzip member, returning false if there are no
+// more complete members.
+func readMember(reader *countingReader) ([]byte, bool, error) {
+	member, err := gzip.NewReader(reader)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	member.Multistream(false)
+
+	content, err := ioutil.ReadAll(member)
+	if err == io.ErrUnexpectedEOF {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return content, true, nil
+}
+
+func isCorrupt(err error) bool {
+	switch err.(type) {
+	case flate.CorruptInputError, *json.SyntaxError:
+		return true
+	}
+
+	return err == gzip.ErrHeader || err == gzip.ErrChecksum
+}
+
+// countingReader counts the bytes read through it. As it is an
+// io.ByteReader, gzip reads exactly up to the end of each member through it.
+type countingReader struct {
+	reader *bufio.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+func (r *countingReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err == nil {
+		r.count++
+	}
+
+	return b, err
+}
+
+func buildKey(buildID int) string {
+	return fmt.Sprintf("builds/%d.json.gz", buildID)
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	writer := gzip.NewWriter(buf)
+
+	_, err := writer.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
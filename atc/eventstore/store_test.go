@@ -0,0 +1,434 @@
+package eventstore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore"
+	"github.com/concourse/concourse/atc/eventstore/eventstorefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		blobs         eventstore.Blobs
+		retryInterval time.Duration
+		store         db.EventStore
+
+		fakeConn *dbfakes.FakeConn
+		fakeBus  *dbfakes.FakeNotificationsBus
+
+		build      *dbfakes.FakeBuild
+		otherBuild *dbfakes.FakeBuild
+	)
+
+	BeforeEach(func() {
+		retryInterval = 10 * time.Millisecond
+
+		fakeBus = new(dbfakes.FakeNotificationsBus)
+		fakeConn = new(dbfakes.FakeConn)
+		fakeConn.BusReturns(fakeBus)
+
+		build = new(dbfakes.FakeBuild)
+		build.IDReturns(1)
+
+		otherBuild = new(dbfakes.FakeBuild)
+		otherBuild.IDReturns(2)
+	})
+
+	JustBeforeEach(func() {
+		store = eventstore.NewStore(lagertest.NewTestLogger("event-store"), blobs, retryInterval)
+	})
+
+	envelope := func(ev atc.Event) event.Envelope {
+		payload, err := json.Marshal(ev)
+		Expect(err).ToNot(HaveOccurred())
+
+		data := json.RawMessage(payload)
+
+		return event.Envelope{
+			Data:    &data,
+			Event:   ev.EventType(),
+			Version: ev.Version(),
+		}
+	}
+
+	commit := func(tx *dbfakes.FakeTx) error {
+		for i := 0; i < tx.BeforeCommitCallCount(); i++ {
+			err := tx.BeforeCommitArgsForCall(i)()
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := 0; i < tx.AfterCommitCallCount(); i++ {
+			tx.AfterCommitArgsForCall(i)()
+		}
+
+		return nil
+	}
+
+	putEvents := func(build db.Build, events ...atc.Event) {
+		for _, ev := range events {
+			tx := new(dbfakes.FakeTx)
+			Expect(store.Put(fakeConn, tx, build, ev)).To(Succeed())
+			Expect(commit(tx)).To(Succeed())
+		}
+	}
+
+	finalize := func(build db.Build) error {
+		tx := new(dbfakes.FakeTx)
+		Expect(store.Finalize(tx, build)).To(Succeed())
+		return commit(tx)
+	}
+
+	itStoresEvents := func() {
+		It("returns no events for a build with none", func() {
+			events, err := store.Get(nil, build, 0, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("returns the events that were put, in order, as soon as they are committed", func() {
+			putEvents(build,
+				event.Status{Status: atc.StatusStarted, Time: 1},
+				event.Log{Payload: "hello"},
+				event.Log{Payload: "world"},
+			)
+
+			Expect(store.Get(nil, build, 0, 10)).To(Equal([]event.Envelope{
+				envelope(event.Status{Status: atc.StatusStarted, Time: 1}),
+				envelope(event.Log{Payload: "hello"}),
+				envelope(event.Log{Payload: "world"}),
+			}))
+		})
+
+		It("notifies the build's event channel once they are written", func() {
+			putEvents(build, event.Log{Payload: "hello"})
+
+			Expect(fakeBus.NotifyCallCount()).To(Equal(1))
+			Expect(fakeBus.NotifyArgsForCall(0)).To(Equal("build_events_1"))
+		})
+
+		It("does not store the events of transactions which do not commit", func() {
+			tx := new(dbfakes.FakeTx)
+			Expect(store.Put(fakeConn, tx, build, event.Log{Payload: "rolled back"})).To(Succeed())
+			Expect(tx.BeforeCommitCallCount()).To(BeZero())
+
+			putEvents(build, event.Log{Payload: "committed"})
+
+			Expect(finalize(build)).To(Succeed())
+
+			Expect(store.Get(nil, build, 0, 10)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "committed"}),
+			}))
+		})
+
+		It("respects the offset and limit", func() {
+			putEvents(build,
+				event.Log{Payload: "a"},
+				event.Log{Payload: "b"},
+				event.Log{Payload: "c"},
+				event.Log{Payload: "d"},
+			)
+
+			Expect(store.Get(nil, build, 1, 2)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "b"}),
+				envelope(event.Log{Payload: "c"}),
+			}))
+
+			Expect(store.Get(nil, build, 3, 2)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "d"}),
+			}))
+
+			Expect(store.Get(nil, build, 0, 1)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "a"}),
+			}))
+
+			Expect(store.Get(nil, build, 4, 2)).To(BeEmpty())
+		})
+
+		It("keeps each build's events separate", func() {
+			putEvents(build, event.Log{Payload: "mine"})
+			putEvents(otherBuild, event.Log{Payload: "theirs"})
+
+			Expect(store.Get(nil, otherBuild, 0, 10)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "theirs"}),
+			}))
+		})
+
+		Context("when the build is finalized", func() {
+			It("writes the events put within the same transaction once it commits", func() {
+				putEvents(build, event.Log{Payload: "hello"})
+
+				tx := new(dbfakes.FakeTx)
+				Expect(store.Put(fakeConn, tx, build, event.Status{Status: atc.StatusSucceeded, Time: 2})).To(Succeed())
+				Expect(store.Finalize(tx, build)).To(Succeed())
+				Expect(tx.BeforeCommitCallCount()).To(BeZero())
+
+				Expect(store.Get(nil, build, 0, 10)).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "hello"}),
+				}))
+
+				Expect(commit(tx)).To(Succeed())
+
+				Expect(store.Get(nil, build, 0, 10)).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "hello"}),
+					envelope(event.Status{Status: atc.StatusSucceeded, Time: 2}),
+				}))
+			})
+
+			It("succeeds for a build with no events", func() {
+				Expect(finalize(build)).To(Succeed())
+			})
+		})
+
+		Describe("Delete", func() {
+			It("removes the events of the given builds", func() {
+				putEvents(build, event.Log{Payload: "mine"})
+				putEvents(otherBuild, event.Log{Payload: "theirs"})
+
+				Expect(finalize(build)).To(Succeed())
+
+				Expect(store.Delete(nil, []int{1, 3})).To(Succeed())
+
+				events, err := store.Get(nil, build, 0, 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(events).To(BeEmpty())
+
+				events, err = store.Get(nil, otherBuild, 0, 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(events).To(HaveLen(1))
+			})
+		})
+	}
+
+	Context("with a local directory", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "event-store")
+			Expect(err).ToNot(HaveOccurred())
+
+			blobs = eventstore.NewDirBlobs(dir)
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		itStoresEvents()
+
+		Context("when reading the build's events again", func() {
+			var fakeBlobs *eventstorefakes.FakeBlobs
+
+			BeforeEach(func() {
+				dirBlobs := blobs
+
+				fakeBlobs = new(eventstorefakes.FakeBlobs)
+				fakeBlobs.AppendStub = dirBlobs.Append
+				fakeBlobs.ReadStub = dirBlobs.Read
+				fakeBlobs.SealStub = dirBlobs.Seal
+
+				blobs = fakeBlobs
+			})
+
+			It("starts reading from where the previous read left off", func() {
+				putEvents(build, event.Log{Payload: "a"}, event.Log{Payload: "b"})
+				Expect(store.Get(nil, build, 0, 10)).To(HaveLen(2))
+
+				putEvents(build, event.Log{Payload: "c"})
+				Expect(store.Get(nil, build, 2, 10)).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "c"}),
+				}))
+
+				_, offset := fakeBlobs.ReadArgsForCall(fakeBlobs.ReadCallCount() - 1)
+				Expect(offset).ToNot(BeZero())
+			})
+
+			It("starts from the beginning to read earlier events", func() {
+				putEvents(build, event.Log{Payload: "a"}, event.Log{Payload: "b"})
+				Expect(store.Get(nil, build, 0, 10)).To(HaveLen(2))
+
+				Expect(store.Get(nil, build, 0, 1)).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "a"}),
+				}))
+
+				_, offset := fakeBlobs.ReadArgsForCall(fakeBlobs.ReadCallCount() - 1)
+				Expect(offset).To(BeZero())
+			})
+		})
+
+		It("ignores an event which is still being appended", func() {
+			putEvents(build, event.Log{Payload: "hello"})
+			Expect(finalize(build)).To(Succeed())
+
+			file, err := os.OpenFile(dir+"/builds/1.json.gz", os.O_WRONLY|os.O_APPEND, 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = file.Write([]byte{0x1f, 0x8b, 0x08})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+
+			Expect(store.Get(nil, build, 0, 10)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "hello"}),
+			}))
+		})
+	})
+
+	Context("with an S3-compatible bucket", func() {
+		var s3 *fakeS3
+
+		BeforeEach(func() {
+			s3 = newFakeS3()
+			blobs = eventstore.NewS3Blobs(s3, "some-bucket", "some-prefix/")
+		})
+
+		itStoresEvents()
+
+		It("writes the events of each transaction as a part", func() {
+			putEvents(build, event.Log{Payload: "a"}, event.Log{Payload: "b"}, event.Log{Payload: "c"})
+			Expect(s3.keys()).To(HaveLen(3))
+		})
+
+		It("concatenates the parts once the build is finalized", func() {
+			putEvents(build, event.Log{Payload: "a"})
+			Expect(s3.keys()).To(HaveLen(1))
+
+			putEvents(build, event.Log{Payload: "b"})
+			Expect(s3.keys()).To(HaveLen(2))
+
+			Expect(finalize(build)).To(Succeed())
+			Expect(s3.keys()).To(Equal([]string{"some-bucket/some-prefix/builds/1.json.gz"}))
+
+			putEvents(build, event.Log{Payload: "late"})
+			Expect(store.Get(nil, build, 0, 10)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "a"}),
+				envelope(event.Log{Payload: "b"}),
+				envelope(event.Log{Payload: "late"}),
+			}))
+		})
+
+		Context("when the concatenated parts fail to be deleted", func() {
+			BeforeEach(func() {
+				retryInterval = 10 * time.Millisecond
+			})
+
+			It("does not return their events twice, and deletes them when retrying", func() {
+				putEvents(build, event.Log{Payload: "a"}, event.Log{Payload: "b"})
+
+				s3.failDeleting(true)
+				Expect(finalize(build)).To(Succeed())
+				Expect(s3.keys()).To(HaveLen(3))
+
+				otherStore := eventstore.NewStore(lagertest.NewTestLogger("other-event-store"), eventstore.NewS3Blobs(s3, "some-bucket", "some-prefix/"), retryInterval)
+				Expect(otherStore.Get(nil, build, 0, 10)).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "a"}),
+					envelope(event.Log{Payload: "b"}),
+				}))
+
+				s3.failDeleting(false)
+				Eventually(s3.keys).Should(Equal([]string{"some-bucket/some-prefix/builds/1.json.gz"}))
+			})
+		})
+
+		It("only lists the parts appended since the build's events were last read", func() {
+			putEvents(build, event.Log{Payload: "a"}, event.Log{Payload: "b"}, event.Log{Payload: "c"})
+			Expect(store.Get(nil, build, 0, 10)).To(HaveLen(3))
+
+			putEvents(build, event.Log{Payload: "d"})
+			Expect(store.Get(nil, build, 3, 10)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "d"}),
+			}))
+
+			Expect(s3.headCount()).To(Equal(1))
+
+			listings := s3.listInputs()
+			Expect(listings).To(HaveLen(2))
+			Expect(aws.StringValue(listings[0].Prefix)).To(Equal("some-prefix/parts/builds/1.json.gz/"))
+			Expect(listings[0].StartAfter).To(BeNil())
+			Expect(aws.StringValue(listings[1].StartAfter)).To(HavePrefix("some-prefix/parts/builds/1.json.gz/"))
+		})
+
+		It("deletes the parts of builds which were never finalized", func() {
+			putEvents(build, event.Log{Payload: "mine"})
+			putEvents(otherBuild, event.Log{Payload: "theirs"})
+			Expect(s3.keys()).To(HaveLen(2))
+
+			Expect(store.Delete(nil, []int{1})).To(Succeed())
+			Expect(s3.keys()).To(ConsistOf(HavePrefix("some-bucket/some-prefix/parts/builds/2.json.gz/")))
+
+			for _, listing := range s3.listInputs() {
+				Expect(aws.StringValue(listing.Prefix)).To(Equal("some-prefix/parts/builds/1.json.gz/"))
+			}
+		})
+	})
+
+	Context("when writing the events fails", func() {
+		var (
+			fakeBlobs *eventstorefakes.FakeBlobs
+			failing   int32
+		)
+
+		BeforeEach(func() {
+			atomic.StoreInt32(&failing, 1)
+
+			fakeBlobs = new(eventstorefakes.FakeBlobs)
+			fakeBlobs.AppendStub = func(string, []byte) error {
+				if atomic.LoadInt32(&failing) == 1 {
+					return errors.New("nope")
+				}
+
+				return nil
+			}
+			fakeBlobs.ReadReturns(nil, false, nil)
+
+			blobs = fakeBlobs
+		})
+
+		It("seals the blob once the events have been written", func() {
+			putEvents(build, event.Log{Payload: "hello"})
+
+			Expect(finalize(build)).To(Succeed())
+			Expect(fakeBlobs.SealCallCount()).To(BeZero())
+
+			atomic.StoreInt32(&failing, 0)
+
+			Eventually(fakeBlobs.SealCallCount).Should(Equal(1))
+			Expect(fakeBus.NotifyCallCount()).To(Equal(1))
+		})
+
+		It("discards the events of builds which are deleted", func() {
+			putEvents(build, event.Log{Payload: "hello"})
+
+			Expect(store.Delete(nil, []int{1})).To(Succeed())
+
+			atomic.StoreInt32(&failing, 0)
+
+			Consistently(fakeBus.NotifyCallCount).Should(BeZero())
+		})
+
+		It("keeps retrying", func() {
+			putEvents(build, event.Log{Payload: "hello"})
+
+			Eventually(fakeBlobs.AppendCallCount).Should(BeNumerically(">", 2))
+
+			atomic.StoreInt32(&failing, 0)
+
+			Eventually(fakeBus.NotifyCallCount).Should(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,158 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"gopkg.in/yaml.v2"
+)
+
+// AcrossStepFactory constructs the steps to run for each combination of
+// values, given in order with the last var varying fastest, e.g. recording
+// them in the build's plan first.
+type AcrossStepFactory func(combinations [][]interface{}) ([]Step, error)
+
+// AcrossStep runs a step across vars whose values may only be known once the
+// build is running, e.g. because they are loaded from a file in one of the
+// build's artifacts.
+type AcrossStep struct {
+	vars      []atc.AcrossVar
+	failFast  bool
+	buildVars *creds.BuildVariables
+	factory   AcrossStepFactory
+
+	step Step
+}
+
+// Across constructs an AcrossStep.
+func Across(
+	vars []atc.AcrossVar,
+	failFast bool,
+	buildVars *creds.BuildVariables,
+	factory AcrossStepFactory,
+) *AcrossStep {
+	return &AcrossStep{
+		vars:      vars,
+		failFast:  failFast,
+		buildVars: buildVars,
+		factory:   factory,
+	}
+}
+
+// Run resolves the values of every var and then runs a step for each
+// combination of them, with the last var varying fastest. As with a static
+// across step, each var's values are run in parallel up to its max_in_flight.
+func (step *AcrossStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).Session("across-step")
+
+	values := make([][]interface{}, len(step.vars))
+	for i, acrossVar := range step.vars {
+		var err error
+		values[i], err = step.values(logger, state, acrossVar)
+		if err != nil {
+			return err
+		}
+	}
+
+	steps, err := step.factory(combinations(values, nil))
+	if err != nil {
+		return err
+	}
+
+	var index int
+	substep := step.nest(values, 0, steps, &index)
+
+	step.step = substep
+
+	return substep.Run(ctx, state)
+}
+
+// Succeeded is true if a step was run for every combination and they all
+// succeeded.
+func (step *AcrossStep) Succeeded() bool {
+	return step.step != nil && step.step.Succeeded()
+}
+
+func (step *AcrossStep) values(logger lager.Logger, state RunState, acrossVar atc.AcrossVar) ([]interface{}, error) {
+	switch {
+	case acrossVar.ValuesFile != "":
+		content, err := readFileFromArtifact(logger, state.Artifacts(), acrossVar.ValuesFile)
+		if err != nil {
+			return nil, err
+		}
+
+		// yaml is a superset of json, so this parses both
+		var values []interface{}
+		err = yaml.Unmarshal(content, &values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s as a list of values for var '%s': %s", acrossVar.ValuesFile, acrossVar.Var, err)
+		}
+
+		return values, nil
+
+	case acrossVar.ValuesVar != "":
+		value, found, err := step.buildVars.Get(template.VariableDefinition{Name: acrossVar.ValuesVar})
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, fmt.Errorf("undefined var '%s' for the values of var '%s'", acrossVar.ValuesVar, acrossVar.Var)
+		}
+
+		values, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("var '%s' is not a list of values for var '%s'", acrossVar.ValuesVar, acrossVar.Var)
+		}
+
+		return values, nil
+	}
+
+	return acrossVar.Values, nil
+}
+
+// combinations returns every combination of the values of the vars following
+// the given prefix, with the last var varying fastest.
+func combinations(values [][]interface{}, prefix []interface{}) [][]interface{} {
+	varIndex := len(prefix)
+	if varIndex == len(values) {
+		return [][]interface{}{prefix}
+	}
+
+	var all [][]interface{}
+	for _, value := range values[varIndex] {
+		combination := make([]interface{}, varIndex, varIndex+1)
+		copy(combination, prefix)
+
+		all = append(all, combinations(values, append(combination, value))...)
+	}
+
+	return all
+}
+
+// nest runs the steps for each combination in parallel for each value of the
+// var at varIndex, up to its max_in_flight, taking the steps in order from
+// index.
+func (step *AcrossStep) nest(values [][]interface{}, varIndex int, steps []Step, index *int) Step {
+	if varIndex == len(step.vars) {
+		substep := steps[*index]
+		*index++
+		return substep
+	}
+
+	if len(values[varIndex]) == 0 {
+		return IdentityStep{}
+	}
+
+	var substeps []Step
+	for range values[varIndex] {
+		substeps = append(substeps, step.nest(values, varIndex+1, steps, index))
+	}
+
+	return InParallel(substeps, step.vars[varIndex].MaxInFlight, step.failFast)
+}
@@ -0,0 +1,231 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AcrossStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeSource *workerfakes.FakeArtifactSource
+		buildVars  *creds.BuildVariables
+		state      exec.RunState
+
+		files map[string]string
+
+		vars      []atc.AcrossVar
+		succeeded bool
+
+		lock         sync.Mutex
+		indexes      []int
+		combinations [][]interface{}
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		files = map[string]string{
+			"dbs.json":   `["postgres","mysql"]`,
+			"dbs.yml":    "- postgres\n- mysql\n",
+			"not-a-list": "db: postgres\n",
+		}
+
+		fakeSource = new(workerfakes.FakeArtifactSource)
+		fakeSource.StreamFileStub = func(_ lager.Logger, path string) (io.ReadCloser, error) {
+			content, found := files[path]
+			if !found {
+				return nil, baggageclaim.ErrFileNotFound
+			}
+
+			return ioutil.NopCloser(strings.NewReader(content)), nil
+		}
+
+		state = exec.NewRunState()
+		state.Artifacts().RegisterSource("some-artifact", fakeSource)
+
+		buildVars = creds.NewBuildVariables()
+
+		vars = []atc.AcrossVar{
+			{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 1},
+			{Var: "db", ValuesFile: "some-artifact/dbs.json"},
+		}
+
+		succeeded = true
+
+		indexes = nil
+		combinations = nil
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.Across(vars, false, buildVars, func(values [][]interface{}) ([]exec.Step, error) {
+			combinations = values
+
+			steps := make([]exec.Step, len(values))
+			for i := range values {
+				index := i
+
+				fakeStep := new(execfakes.FakeStep)
+				fakeStep.SucceededReturns(succeeded)
+				fakeStep.RunStub = func(context.Context, exec.RunState) error {
+					lock.Lock()
+					indexes = append(indexes, index)
+					lock.Unlock()
+					return nil
+				}
+
+				steps[i] = fakeStep
+			}
+
+			return steps, nil
+		})
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("constructs a step for every combination, with the last var varying fastest, and runs them all", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(indexes).To(ConsistOf(0, 1, 2, 3))
+		Expect(combinations).To(Equal([][]interface{}{
+			{"1.12", "postgres"},
+			{"1.12", "mysql"},
+			{"1.13", "postgres"},
+			{"1.13", "mysql"},
+		}))
+	})
+
+	It("succeeds", func() {
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	Context("when a combination fails", func() {
+		BeforeEach(func() {
+			succeeded = false
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the values are in a yaml file", func() {
+		BeforeEach(func() {
+			vars[1].ValuesFile = "some-artifact/dbs.yml"
+		})
+
+		It("parses them", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(combinations).To(HaveLen(4))
+			Expect(combinations[1]).To(Equal([]interface{}{"1.12", "mysql"}))
+		})
+	})
+
+	Context("when the file is not a list", func() {
+		BeforeEach(func() {
+			vars[1].ValuesFile = "some-artifact/not-a-list"
+		})
+
+		It("errors without running any steps", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-artifact/not-a-list as a list of values for var 'db'")))
+			Expect(combinations).To(BeEmpty())
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		BeforeEach(func() {
+			vars[1].ValuesFile = "some-artifact/missing"
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(Equal(exec.FileNotFoundError{Path: "some-artifact/missing"}))
+		})
+	})
+
+	Context("when the values are in a var", func() {
+		BeforeEach(func() {
+			vars[1] = atc.AcrossVar{Var: "db", ValuesVar: "dbs"}
+			buildVars.AddLocalVar("dbs", []interface{}{"sqlite"}, false)
+		})
+
+		It("uses them", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(combinations).To(Equal([][]interface{}{
+				{"1.12", "sqlite"},
+				{"1.13", "sqlite"},
+			}))
+		})
+	})
+
+	Context("when the var is not a list", func() {
+		BeforeEach(func() {
+			vars[1] = atc.AcrossVar{Var: "db", ValuesVar: "dbs"}
+			buildVars.AddLocalVar("dbs", "sqlite", false)
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(MatchError("var 'dbs' is not a list of values for var 'db'"))
+		})
+	})
+
+	Context("when the var is not defined", func() {
+		BeforeEach(func() {
+			vars[1] = atc.AcrossVar{Var: "db", ValuesVar: "dbs"}
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(MatchError("undefined var 'dbs' for the values of var 'db'"))
+		})
+	})
+
+	Context("when a var has no values", func() {
+		BeforeEach(func() {
+			files["dbs.json"] = "[]"
+		})
+
+		It("runs nothing and succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(combinations).To(BeEmpty())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when constructing a step fails", func() {
+		var disaster = errors.New("nope")
+
+		JustBeforeEach(func() {
+			step = exec.Across(vars, false, buildVars, func([][]interface{}) ([]exec.Step, error) {
+				return nil, disaster
+			})
+
+			stepErr = step.Run(ctx, state)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+})
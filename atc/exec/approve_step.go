@@ -0,0 +1,94 @@
+package exec
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . ApproveDelegate
+
+type ApproveDelegate interface {
+	BuildStepDelegate
+
+	Waiting(lager.Logger)
+	Finished(lager.Logger, db.BuildApproval)
+
+	Approval() (db.BuildApproval, bool, error)
+	ApprovalNotifier() (db.Notifier, error)
+}
+
+// ApproveStep pauses the build until a user approves or rejects it.
+//
+// Decisions are recorded against the build and the step's plan ID. Each of a
+// step's attempts has its own plan ID, and a re-run is a new build, so both
+// ask for a decision again. A step only finds an earlier decision when it
+// runs again as part of the same build, e.g. when the build is resumed after
+// the ATC restarts, in which case the decision is not asked for twice.
+type ApproveStep struct {
+	planID    atc.PlanID
+	plan      atc.ApprovePlan
+	metadata  StepMetadata
+	delegate  ApproveDelegate
+	succeeded bool
+}
+
+func NewApproveStep(
+	planID atc.PlanID,
+	plan atc.ApprovePlan,
+	metadata StepMetadata,
+	delegate ApproveDelegate,
+) Step {
+	return &ApproveStep{
+		planID:   planID,
+		plan:     plan,
+		metadata: metadata,
+		delegate: delegate,
+	}
+}
+
+// Run waits for the step to be decided. If the build is aborted or the step
+// times out first, the context's error is returned.
+func (step *ApproveStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approve-step", lager.Data{
+		"name":   step.plan.Name,
+		"job-id": step.metadata.JobID,
+	})
+
+	notifier, err := step.delegate.ApprovalNotifier()
+	if err != nil {
+		return err
+	}
+
+	defer notifier.Close()
+
+	step.delegate.Waiting(logger)
+
+	for {
+		approval, found, err := step.delegate.Approval()
+		if err != nil {
+			return err
+		}
+
+		if found {
+			step.delegate.Finished(logger, approval)
+			step.succeeded = approval.Approved
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notifier.Notify():
+		}
+	}
+}
+
+// Succeeded returns true if the step was approved.
+func (step *ApproveStep) Succeeded() bool {
+	return step.succeeded
+}
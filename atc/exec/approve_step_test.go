@@ -0,0 +1,190 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApproveStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate *execfakes.FakeApproveDelegate
+		fakeNotifier *dbfakes.FakeNotifier
+		notify       chan struct{}
+		decisions    chan db.BuildApproval
+
+		step    exec.Step
+		stepErr error
+		done    chan struct{}
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+
+		fakeDelegate = new(execfakes.FakeApproveDelegate)
+		fakeDelegate.ApprovalNotifierReturns(fakeNotifier, nil)
+
+		decisions = make(chan db.BuildApproval, 1)
+		fakeDelegate.ApprovalStub = func() (db.BuildApproval, bool, error) {
+			select {
+			case approval := <-decisions:
+				return approval, true, nil
+			default:
+				return db.BuildApproval{}, false, nil
+			}
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewApproveStep(
+			"some-plan-id",
+			atc.ApprovePlan{Name: "deploy-prod"},
+			exec.StepMetadata{TeamName: "some-team", JobID: 1},
+			fakeDelegate,
+		)
+
+		done = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			stepErr = step.Run(ctx, exec.NewRunState())
+		}()
+	})
+
+	It("waits for a decision", func() {
+		Eventually(fakeDelegate.WaitingCallCount).Should(Equal(1))
+		Consistently(done).ShouldNot(BeClosed())
+	})
+
+	Context("when the step is approved", func() {
+		var approval db.BuildApproval
+
+		JustBeforeEach(func() {
+			Eventually(fakeDelegate.WaitingCallCount).Should(Equal(1))
+
+			approval = db.BuildApproval{Approved: true, ApprovedBy: "some-user"}
+			decisions <- approval
+			notify <- struct{}{}
+
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		It("finishes with who approved it", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, finishedApproval := fakeDelegate.FinishedArgsForCall(0)
+			Expect(finishedApproval).To(Equal(approval))
+		})
+
+		It("closes the notifier", func() {
+			Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the step is rejected", func() {
+		BeforeEach(func() {
+			decisions <- db.BuildApproval{Approved: false, ApprovedBy: "some-user"}
+		})
+
+		JustBeforeEach(func() {
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("finishes with who rejected it", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the step was decided before it ran, e.g. before the build was resumed", func() {
+		BeforeEach(func() {
+			decisions <- db.BuildApproval{Approved: true, ApprovedBy: "some-user"}
+		})
+
+		It("keeps the decision rather than asking for another", func() {
+			Eventually(done).Should(BeClosed())
+
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+			Expect(fakeDelegate.ApprovalCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the context is canceled", func() {
+		JustBeforeEach(func() {
+			Eventually(fakeDelegate.WaitingCallCount).Should(Equal(1))
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("returns the context's error", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("does not finish", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when looking up the approval fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.ApprovalStub = nil
+			fakeDelegate.ApprovalReturns(db.BuildApproval{}, false, disaster)
+		})
+
+		JustBeforeEach(func() {
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when creating the notifier fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.ApprovalNotifierReturns(nil, disaster)
+		})
+
+		JustBeforeEach(func() {
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+})
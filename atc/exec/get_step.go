@@ -52,6 +52,7 @@ type GetStep struct {
 	metadata             StepMetadata
 	containerMetadata    db.ContainerMetadata
 	secrets              creds.Secrets
+	buildVars            *creds.BuildVariables
 	resourceFetcher      resource.Fetcher
 	resourceCacheFactory db.ResourceCacheFactory
 	strategy             worker.ContainerPlacementStrategy
@@ -66,6 +67,7 @@ func NewGetStep(
 	metadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	secrets creds.Secrets,
+	buildVars *creds.BuildVariables,
 	resourceFetcher resource.Fetcher,
 	resourceCacheFactory db.ResourceCacheFactory,
 	strategy worker.ContainerPlacementStrategy,
@@ -78,6 +80,7 @@ func NewGetStep(
 		metadata:             metadata,
 		containerMetadata:    containerMetadata,
 		secrets:              secrets,
+		buildVars:            buildVars,
 		resourceFetcher:      resourceFetcher,
 		resourceCacheFactory: resourceCacheFactory,
 		strategy:             strategy,
@@ -118,7 +121,7 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 
 	step.delegate.Initializing(logger)
 
-	variables := creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName)
+	variables := step.buildVars.Scope(creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName))
 
 	source, err := creds.NewSource(variables, step.plan.Source).Evaluate()
 	if err != nil {
@@ -181,6 +184,7 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 	chosenWorker, err := step.workerPool.FindOrChooseWorkerForContainer(
 		ctx,
 		logger,
+		step.delegate,
 		resourceInstance.ContainerOwner(),
 		containerSpec,
 		workerSpec,
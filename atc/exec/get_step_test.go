@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/DataDog/zstd"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -39,6 +40,7 @@ var _ = Describe("GetStep", func() {
 		fakeResourceFetcher      *resourcefakes.FakeFetcher
 		fakeResourceCacheFactory *dbfakes.FakeResourceCacheFactory
 		fakeSecretManager        *credsfakes.FakeSecrets
+		buildVars                *creds.BuildVariables
 		fakeDelegate             *execfakes.FakeGetDelegate
 		getPlan                  *atc.GetPlan
 
@@ -81,6 +83,7 @@ var _ = Describe("GetStep", func() {
 		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 
 		fakeSecretManager = new(credsfakes.FakeSecrets)
+		buildVars = creds.NewBuildVariables()
 		fakeSecretManager.GetReturns("super-secret-source", nil, true, nil)
 
 		artifactRepository = artifact.NewRepository()
@@ -141,6 +144,7 @@ var _ = Describe("GetStep", func() {
 			stepMetadata,
 			containerMetadata,
 			fakeSecretManager,
+			buildVars,
 			fakeResourceFetcher,
 			fakeResourceCacheFactory,
 			fakeStrategy,
@@ -153,7 +157,8 @@ var _ = Describe("GetStep", func() {
 
 	It("finds or chooses a worker", func() {
 		Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(1))
-		_, _, actualOwner, actualContainerSpec, actualWorkerSpec, strategy := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+		_, _, waitingDelegate, actualOwner, actualContainerSpec, actualWorkerSpec, strategy := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+		Expect(waitingDelegate).To(Equal(fakeDelegate))
 		Expect(actualOwner).To(Equal(db.NewBuildStepContainerOwner(stepMetadata.BuildID, atc.PlanID(planID), stepMetadata.TeamID)))
 		Expect(actualContainerSpec).To(Equal(worker.ContainerSpec{
 			ImageSpec: worker.ImageSpec{
@@ -0,0 +1,144 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	LoadVarFormatRaw  = "raw"
+	LoadVarFormatTrim = "trim"
+	LoadVarFormatJSON = "json"
+	LoadVarFormatYAML = "yaml"
+)
+
+// UnknownLoadVarFormatError is returned when a load_var step specifies a
+// format that is not supported.
+type UnknownLoadVarFormatError struct {
+	Format string
+}
+
+func (err UnknownLoadVarFormatError) Error() string {
+	return fmt.Sprintf("unknown load_var format: '%s'", err.Format)
+}
+
+// LoadVarStep loads a value from a file in the artifact.Repository and
+// registers it as a build-local variable, which subsequent steps can
+// reference as ((.:name)).
+type LoadVarStep struct {
+	planID    atc.PlanID
+	plan      atc.LoadVarPlan
+	metadata  StepMetadata
+	buildVars *creds.BuildVariables
+	delegate  BuildStepDelegate
+	succeeded bool
+}
+
+func NewLoadVarStep(
+	planID atc.PlanID,
+	plan atc.LoadVarPlan,
+	metadata StepMetadata,
+	buildVars *creds.BuildVariables,
+	delegate BuildStepDelegate,
+) Step {
+	return &LoadVarStep{
+		planID:    planID,
+		plan:      plan,
+		metadata:  metadata,
+		buildVars: buildVars,
+		delegate:  delegate,
+	}
+}
+
+// Run reads the file out of the artifact.Repository and parses it according
+// to the plan's format. If no format is specified, it is determined by the
+// file's extension, falling back to trimmed text.
+//
+// Values loaded by a sensitive step are redacted from the build's output.
+func (step *LoadVarStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("load-var-step", lager.Data{
+		"var-name": step.plan.Name,
+		"job-id":   step.metadata.JobID,
+	})
+
+	content, err := readFileFromArtifact(logger, state.Artifacts(), step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	value, err := step.parse(content)
+	if err != nil {
+		return err
+	}
+
+	step.buildVars.AddLocalVar(step.plan.Name, value, step.plan.Sensitive)
+
+	fmt.Fprintf(step.delegate.Stdout(), "loaded var %s from file %s\n", step.plan.Name, step.plan.File)
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Succeeded returns true if the var was loaded.
+func (step *LoadVarStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *LoadVarStep) parse(content []byte) (interface{}, error) {
+	format := step.plan.Format
+	if format == "" {
+		switch filepath.Ext(step.plan.File) {
+		case ".json":
+			format = LoadVarFormatJSON
+		case ".yml", ".yaml":
+			format = LoadVarFormatYAML
+		default:
+			format = LoadVarFormatTrim
+		}
+	}
+
+	switch format {
+	case LoadVarFormatRaw:
+		return string(content), nil
+
+	case LoadVarFormatTrim:
+		return strings.TrimSpace(string(content)), nil
+
+	case LoadVarFormatJSON:
+		if !json.Valid(content) {
+			return nil, fmt.Errorf("failed to parse %s as json", step.plan.File)
+		}
+
+		// parse with yaml so that nested fields can be accessed the same way
+		// as in yaml files, e.g. ((.:name.field))
+		var value interface{}
+		err := yaml.Unmarshal(content, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s as json: %s", step.plan.File, err)
+		}
+
+		return value, nil
+
+	case LoadVarFormatYAML:
+		var value interface{}
+		err := yaml.Unmarshal(content, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s as yaml: %s", step.plan.File, err)
+		}
+
+		return value, nil
+	}
+
+	return nil, UnknownLoadVarFormatError{format}
+}
@@ -0,0 +1,204 @@
+package exec_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("LoadVarStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeSource   *workerfakes.FakeArtifactSource
+		fakeDelegate *execfakes.FakeBuildStepDelegate
+		buildVars    *creds.BuildVariables
+
+		files map[string]string
+
+		stdout *gbytes.Buffer
+
+		state exec.RunState
+
+		plan atc.LoadVarPlan
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		files = map[string]string{
+			"version":     "1.2.3\n",
+			"config.json": `{"tag":"some-tag","count":3}`,
+			"config.yml":  "tag: some-tag\ncount: 3\n",
+			"invalid":     "{",
+		}
+
+		fakeSource = new(workerfakes.FakeArtifactSource)
+		fakeSource.StreamFileStub = func(_ lager.Logger, path string) (io.ReadCloser, error) {
+			content, found := files[path]
+			if !found {
+				return nil, baggageclaim.ErrFileNotFound
+			}
+
+			return ioutil.NopCloser(strings.NewReader(content)), nil
+		}
+
+		state = exec.NewRunState()
+		state.Artifacts().RegisterSource("some-artifact", fakeSource)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+
+		buildVars = creds.NewBuildVariables()
+
+		plan = atc.LoadVarPlan{
+			Name: "some-var",
+			File: "some-artifact/version",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewLoadVarStep(
+			"some-plan-id",
+			plan,
+			exec.StepMetadata{TeamName: "some-team", JobID: 1},
+			buildVars,
+			fakeDelegate,
+		)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	lookup := func(name string) interface{} {
+		val, found, err := buildVars.Get(template.VariableDefinition{Name: name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return val
+	}
+
+	Context("when no format is specified", func() {
+		It("loads the trimmed file contents", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(lookup(".:some-var")).To(Equal("1.2.3"))
+		})
+
+		It("succeeds", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		It("does not print the value", func() {
+			Expect(stdout).To(gbytes.Say("loaded var some-var from file some-artifact/version"))
+			Expect(stdout.Contents()).ToNot(ContainSubstring("1.2.3"))
+		})
+
+		Context("when the file has a .json extension", func() {
+			BeforeEach(func() {
+				plan.File = "some-artifact/config.json"
+			})
+
+			It("parses it as json", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(lookup("some-var")).To(Equal(map[interface{}]interface{}{
+					"tag":   "some-tag",
+					"count": 3,
+				}))
+			})
+		})
+
+		Context("when the file has a .yml extension", func() {
+			BeforeEach(func() {
+				plan.File = "some-artifact/config.yml"
+			})
+
+			It("parses it as yaml", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(lookup("some-var")).To(Equal(map[interface{}]interface{}{
+					"tag":   "some-tag",
+					"count": 3,
+				}))
+			})
+		})
+	})
+
+	Context("when the format is raw", func() {
+		BeforeEach(func() {
+			plan.Format = "raw"
+		})
+
+		It("loads the file contents as-is", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(lookup("some-var")).To(Equal("1.2.3\n"))
+		})
+	})
+
+	Context("when the format is json and the file is invalid", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/invalid"
+			plan.Format = "json"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(MatchError("failed to parse some-artifact/invalid as json"))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the format is unknown", func() {
+		BeforeEach(func() {
+			plan.Format = "toml"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.UnknownLoadVarFormatError{Format: "toml"}))
+		})
+	})
+
+	Context("when the step is sensitive", func() {
+		BeforeEach(func() {
+			plan.Sensitive = true
+		})
+
+		It("marks the value for redaction", func() {
+			Expect(buildVars.RedactedValues()).To(ConsistOf("1.2.3"))
+		})
+	})
+
+	Context("when the step is not sensitive", func() {
+		It("does not mark the value for redaction", func() {
+			Expect(buildVars.RedactedValues()).To(BeEmpty())
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/missing"
+		})
+
+		It("returns a FileNotFoundError", func() {
+			Expect(stepErr).To(Equal(exec.FileNotFoundError{Path: "some-artifact/missing"}))
+		})
+	})
+})
@@ -30,6 +30,7 @@ type PutStep struct {
 	metadata              StepMetadata
 	containerMetadata     db.ContainerMetadata
 	secrets               creds.Secrets
+	buildVars             *creds.BuildVariables
 	resourceFactory       resource.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
 	strategy              worker.ContainerPlacementStrategy
@@ -44,6 +45,7 @@ func NewPutStep(
 	metadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	secrets creds.Secrets,
+	buildVars *creds.BuildVariables,
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	strategy worker.ContainerPlacementStrategy,
@@ -56,6 +58,7 @@ func NewPutStep(
 		metadata:              metadata,
 		containerMetadata:     containerMetadata,
 		secrets:               secrets,
+		buildVars:             buildVars,
 		resourceFactory:       resourceFactory,
 		resourceConfigFactory: resourceConfigFactory,
 		pool:                  pool,
@@ -81,7 +84,7 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 
 	step.delegate.Initializing(logger)
 
-	variables := creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName)
+	variables := step.buildVars.Scope(creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName))
 
 	source, err := creds.NewSource(variables, step.plan.Source).Evaluate()
 	if err != nil {
@@ -142,6 +145,7 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 	chosenWorker, err := step.pool.FindOrChooseWorkerForContainer(
 		ctx,
 		logger,
+		step.delegate,
 		owner,
 		containerSpec,
 		workerSpec,
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -31,6 +32,7 @@ var _ = Describe("PutStep", func() {
 		fakeResourceFactory       *resourcefakes.FakeResourceFactory
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeSecretManager         *credsfakes.FakeSecrets
+		buildVars                 *creds.BuildVariables
 		fakeDelegate              *execfakes.FakePutDelegate
 		putPlan                   *atc.PutPlan
 
@@ -75,6 +77,7 @@ var _ = Describe("PutStep", func() {
 		fakeResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 
 		fakeSecretManager = new(credsfakes.FakeSecrets)
+		buildVars = creds.NewBuildVariables()
 		fakeSecretManager.GetReturnsOnCall(0, "super-secret-source", nil, true, nil)
 		fakeSecretManager.GetReturnsOnCall(1, "source", nil, true, nil)
 
@@ -137,6 +140,7 @@ var _ = Describe("PutStep", func() {
 			stepMetadata,
 			containerMetadata,
 			fakeSecretManager,
+			buildVars,
 			fakeResourceFactory,
 			fakeResourceConfigFactory,
 			fakeStrategy,
@@ -192,7 +196,8 @@ var _ = Describe("PutStep", func() {
 
 			It("finds/chooses a worker and creates a container with the correct type, session, and sources with no inputs specified (meaning it takes all artifacts)", func() {
 				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(1))
-				_, _, actualOwner, actualContainerSpec, actualWorkerSpec, strategy := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+				_, _, waitingDelegate, actualOwner, actualContainerSpec, actualWorkerSpec, strategy := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+				Expect(waitingDelegate).To(Equal(fakeDelegate))
 				Expect(actualOwner).To(Equal(db.NewBuildStepContainerOwner(42, atc.PlanID(planID), 123)))
 				Expect(actualContainerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 					ResourceType: "some-resource-type",
@@ -269,6 +274,18 @@ var _ = Describe("PutStep", func() {
 				Expect(putParams).To(Equal(atc.Params{"some-param": "some-value"}))
 			})
 
+			Context("when the params reference build-local vars", func() {
+				BeforeEach(func() {
+					buildVars.AddLocalVar("some-var", map[interface{}]interface{}{"tag": "some-tag"}, false)
+					putPlan.Params = atc.Params{"some-param": "((.:some-var.tag))"}
+				})
+
+				It("interpolates them", func() {
+					_, _, _, putParams := fakeResource.PutArgsForCall(0)
+					Expect(putParams).To(Equal(atc.Params{"some-param": "some-tag"}))
+				})
+			})
+
 			It("puts the resource with the io config forwarded", func() {
 				Expect(fakeResource.PutCallCount()).To(Equal(1))
 
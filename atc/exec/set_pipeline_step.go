@@ -0,0 +1,228 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/template"
+	"gopkg.in/yaml.v2"
+)
+
+// SetPipelineStep loads a pipeline config from a file in the
+// artifact.Repository and saves it to the build's team, printing the diff
+// between the existing config and the new one to the build's output.
+type SetPipelineStep struct {
+	planID      atc.PlanID
+	plan        atc.SetPipelinePlan
+	metadata    StepMetadata
+	teamFactory db.TeamFactory
+	delegate    BuildStepDelegate
+	succeeded   bool
+}
+
+func NewSetPipelineStep(
+	planID atc.PlanID,
+	plan atc.SetPipelinePlan,
+	metadata StepMetadata,
+	teamFactory db.TeamFactory,
+	delegate BuildStepDelegate,
+) Step {
+	return &SetPipelineStep{
+		planID:      planID,
+		plan:        plan,
+		metadata:    metadata,
+		teamFactory: teamFactory,
+		delegate:    delegate,
+	}
+}
+
+// Run reads the pipeline config and var files out of the artifact.Repository,
+// interpolates the config with the configured vars, and validates it.
+//
+// If the config is invalid, the errors are written to stderr and the step
+// fails. Otherwise the diff against the current config is written to stdout
+// and, if there are any changes, the config is saved.
+//
+// Pipelines created by this step start out unpaused; the paused state of an
+// existing pipeline is left as-is.
+func (step *SetPipelineStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("set-pipeline-step", lager.Data{
+		"pipeline-name": step.plan.Name,
+		"job-id":        step.metadata.JobID,
+	})
+
+	stdout := step.delegate.Stdout()
+	stderr := step.delegate.Stderr()
+
+	configStructure, err := step.fetchConfig(logger, state.Artifacts())
+	if err != nil {
+		return err
+	}
+
+	team, found, err := step.teamFactory.FindTeam(step.metadata.TeamName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("team not found: %s", step.metadata.TeamName)
+	}
+
+	config, warnings, errorMessages, err := configvalidate.Load(team, configStructure)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintln(stderr, "[WARNING]", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintln(stderr, "invalid pipeline config:")
+		for _, message := range errorMessages {
+			fmt.Fprintln(stderr, message)
+		}
+
+		return nil
+	}
+
+	fromConfig := atc.Config{}
+	fromVersion := db.ConfigVersion(0)
+	pausedState := db.PipelineUnpaused
+
+	pipelineRef := atc.PipelineRef{Name: step.plan.Name}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		fromConfig, err = pipeline.Config()
+		if err != nil {
+			return err
+		}
+
+		fromVersion = pipeline.ConfigVersion()
+		pausedState = db.PipelineNoChange
+	}
+
+	if !fromConfig.Diff(stdout, config) {
+		fmt.Fprintln(stdout, "no changes to apply")
+		step.succeeded = true
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "setting pipeline: %s\n", step.plan.Name)
+
+	_, _, err = team.SavePipelineAs(step.author(), pipelineRef, config, fromVersion, pausedState)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("saved")
+
+	fmt.Fprintln(stdout, "done")
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Succeeded returns true if the pipeline config was valid and saved (or did
+// not need to be).
+func (step *SetPipelineStep) Succeeded() bool {
+	return step.succeeded
+}
+
+// author identifies the build which saved the pipeline in its config
+// history, e.g. "some-team/some-pipeline/some-job #42".
+func (step *SetPipelineStep) author() string {
+	if step.metadata.JobName == "" {
+		return fmt.Sprintf("%s/build #%d", step.metadata.TeamName, step.metadata.BuildID)
+	}
+
+	return fmt.Sprintf("%s/%s/%s #%s", step.metadata.TeamName, step.metadata.PipelineName, step.metadata.JobName, step.metadata.BuildName)
+}
+
+func (step *SetPipelineStep) fetchConfig(logger lager.Logger, repo *artifact.Repository) (interface{}, error) {
+	configPayload, err := readFileFromArtifact(logger, repo, step.plan.File)
+	if err != nil {
+		return nil, err
+	}
+
+	// explicitly specified vars take precedence over var files, and values in
+	// var files specified later take precedence over earlier ones
+	params := []boshtemplate.Variables{boshtemplate.StaticVariables(step.plan.Vars)}
+
+	for i := len(step.plan.VarFiles) - 1; i >= 0; i-- {
+		path := step.plan.VarFiles[i]
+
+		payload, err := readFileFromArtifact(logger, repo, path)
+		if err != nil {
+			return nil, err
+		}
+
+		var staticVars boshtemplate.StaticVariables
+		err = yaml.Unmarshal(payload, &staticVars)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal var file %s: %s", path, err)
+		}
+
+		params = append(params, staticVars)
+	}
+
+	evaluatedConfig, err := template.NewTemplateResolver(configPayload, params).Resolve(false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var configStructure interface{}
+	err = yaml.Unmarshal(evaluatedConfig, &configStructure)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pipeline config %s: %s", step.plan.File, err)
+	}
+
+	return configStructure, nil
+}
+
+// readFileFromArtifact reads the file at the given path out of the
+// artifact.Repository. The path must be in the format SOURCE_NAME/FILE/PATH.
+func readFileFromArtifact(logger lager.Logger, repo *artifact.Repository, path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := artifact.Name(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, UnknownArtifactSourceError{sourceName, path}
+	}
+
+	stream, err := source.StreamFile(logger, filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, FileNotFoundError{Path: path}
+		}
+
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
@@ -0,0 +1,340 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakePipeline    *dbfakes.FakePipeline
+		fakeSource      *workerfakes.FakeArtifactSource
+		fakeDelegate    *execfakes.FakeBuildStepDelegate
+
+		files map[string]string
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		state exec.RunState
+
+		plan atc.SetPipelinePlan
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+
+		files = map[string]string{
+			"pipeline.yml": `
+resources:
+- name: some-resource
+  type: git
+  source: {uri: ((uri))}
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`,
+		}
+
+		fakeSource = new(workerfakes.FakeArtifactSource)
+		fakeSource.StreamFileStub = func(_ lager.Logger, path string) (io.ReadCloser, error) {
+			content, found := files[path]
+			if !found {
+				return nil, baggageclaim.ErrFileNotFound
+			}
+
+			return ioutil.NopCloser(strings.NewReader(content)), nil
+		}
+
+		state = exec.NewRunState()
+		state.Artifacts().RegisterSource("some-artifact", fakeSource)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+
+		plan = atc.SetPipelinePlan{
+			Name: "some-pipeline",
+			File: "some-artifact/pipeline.yml",
+			Vars: atc.Params{"uri": "some-uri"},
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewSetPipelineStep(
+			"some-plan-id",
+			plan,
+			exec.StepMetadata{
+				TeamName:     "some-team",
+				PipelineName: "other-pipeline",
+				JobID:        1,
+				JobName:      "some-job",
+				BuildID:      42,
+				BuildName:    "7",
+			},
+			fakeTeamFactory,
+			fakeDelegate,
+		)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(nil, false, nil)
+		})
+
+		It("looks up the build's team", func() {
+			Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+		})
+
+		It("saves the interpolated config unpaused, authored by the build", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+			author, pipelineRef, config, version, pausedState := fakeTeam.SavePipelineAsArgsForCall(0)
+			Expect(author).To(Equal("some-team/other-pipeline/some-job #7"))
+			Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{"uri": "some-uri"}))
+			Expect(version).To(Equal(db.ConfigVersion(0)))
+			Expect(pausedState).To(Equal(db.PipelineUnpaused))
+		})
+
+		It("prints the diff", func() {
+			Expect(stdout).To(gbytes.Say("resource some-resource has been added"))
+			Expect(stdout).To(gbytes.Say("job some-job has been added"))
+			Expect(stdout).To(gbytes.Say("setting pipeline: some-pipeline"))
+		})
+
+		It("succeeds", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		Context("when saving fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTeam.SavePipelineAsReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the pipeline exists", func() {
+		BeforeEach(func() {
+			fakePipeline.ConfigVersionReturns(42)
+			fakeTeam.PipelineReturns(fakePipeline, true, nil)
+		})
+
+		Context("when the config has not changed", func() {
+			BeforeEach(func() {
+				fakePipeline.ConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+					},
+					Jobs: atc.JobConfigs{
+						{Name: "some-job", Plan: atc.PlanSequence{{Get: "some-resource"}}},
+					},
+				}, nil)
+			})
+
+			It("does not save the pipeline", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+			})
+
+			It("says so", func() {
+				Expect(stdout).To(gbytes.Say("no changes to apply"))
+			})
+
+			It("succeeds", func() {
+				Expect(step.Succeeded()).To(BeTrue())
+			})
+		})
+
+		Context("when the config has changed", func() {
+			BeforeEach(func() {
+				fakePipeline.ConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-other-uri"}},
+					},
+				}, nil)
+			})
+
+			It("prints the diff", func() {
+				Expect(stdout).To(gbytes.Say("resource some-resource has changed"))
+			})
+
+			It("saves the config from the current version without changing the paused state", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+				_, _, _, version, pausedState := fakeTeam.SavePipelineAsArgsForCall(0)
+				Expect(version).To(Equal(db.ConfigVersion(42)))
+				Expect(pausedState).To(Equal(db.PipelineNoChange))
+			})
+		})
+	})
+
+	Context("when var files are specified", func() {
+		BeforeEach(func() {
+			files["vars-1.yml"] = "uri: uri-from-file-1\nother: other-from-file-1"
+			files["vars-2.yml"] = "uri: uri-from-file-2"
+
+			plan.Vars = nil
+			plan.VarFiles = []string{"some-artifact/vars-1.yml", "some-artifact/vars-2.yml"}
+
+			fakeTeam.PipelineReturns(nil, false, nil)
+		})
+
+		It("gives precedence to later files", func() {
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+			_, _, config, _, _ := fakeTeam.SavePipelineAsArgsForCall(0)
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{"uri": "uri-from-file-2"}))
+		})
+
+		Context("when vars are also specified", func() {
+			BeforeEach(func() {
+				plan.Vars = atc.Params{"uri": "some-uri"}
+			})
+
+			It("gives precedence to the vars", func() {
+				_, _, config, _, _ := fakeTeam.SavePipelineAsArgsForCall(0)
+				Expect(config.Resources[0].Source).To(Equal(atc.Source{"uri": "some-uri"}))
+			})
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = `
+jobs:
+- name: some-job
+  plan:
+  - get: some-missing-resource
+`
+		})
+
+		It("prints the errors and fails without saving", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stderr).To(gbytes.Say("invalid pipeline config"))
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the config has unknown keys", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = `
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+  bogus: true
+`
+		})
+
+		It("rejects it like set-pipeline does", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stderr).To(gbytes.Say("invalid pipeline config"))
+			Expect(stderr).To(gbytes.Say("resources\\[0\\].bogus"))
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when a passed constraint references a missing pipeline", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = `
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    passed: [other-pipeline/other-job]
+`
+
+			fakeTeam.PipelineReturns(nil, false, nil)
+		})
+
+		It("warns about it and saves the pipeline", func() {
+			Expect(stderr).To(gbytes.Say(`\[WARNING\] jobs.some-job.get.some-resource.passed references a pipeline that does not exist \('other-pipeline'\)`))
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the config file does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/missing.yml"
+		})
+
+		It("returns a FileNotFoundError", func() {
+			Expect(stepErr).To(Equal(exec.FileNotFoundError{Path: "some-artifact/missing.yml"}))
+		})
+	})
+
+	Context("when the config file's artifact does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "bogus-artifact/pipeline.yml"
+		})
+
+		It("returns an UnknownArtifactSourceError", func() {
+			Expect(stepErr).To(Equal(exec.UnknownArtifactSourceError{
+				SourceName: "bogus-artifact",
+				ConfigPath: "bogus-artifact/pipeline.yml",
+			}))
+		})
+	})
+
+	Context("when the team cannot be found", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(HaveOccurred())
+		})
+	})
+})
@@ -40,6 +40,14 @@ type BuildStepDelegate interface {
 	Stderr() io.Writer
 
 	Errored(lager.Logger, string)
+
+	// WaitingForWorker is called when the step has to wait for a compatible
+	// worker with capacity before it can run.
+	WaitingForWorker(lager.Logger)
+
+	// Flush saves any output written to Stdout and Stderr which is still held
+	// back. It is called once the step has exited, however it exited.
+	Flush(lager.Logger)
 }
 
 //go:generate counterfeiter . RunState
@@ -72,6 +72,7 @@ type TaskStep struct {
 	metadata          StepMetadata
 	containerMetadata db.ContainerMetadata
 	secrets           creds.Secrets
+	buildVars         *creds.BuildVariables
 	strategy          worker.ContainerPlacementStrategy
 	workerPool        worker.Pool
 	delegate          TaskDelegate
@@ -85,6 +86,7 @@ func NewTaskStep(
 	metadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	secrets creds.Secrets,
+	buildVars *creds.BuildVariables,
 	strategy worker.ContainerPlacementStrategy,
 	workerPool worker.Pool,
 	delegate TaskDelegate,
@@ -96,6 +98,7 @@ func NewTaskStep(
 		metadata:          metadata,
 		containerMetadata: containerMetadata,
 		secrets:           secrets,
+		buildVars:         buildVars,
 		strategy:          strategy,
 		workerPool:        workerPool,
 		delegate:          delegate,
@@ -124,7 +127,7 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		"job-id":    step.metadata.JobID,
 	})
 
-	variables := creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName)
+	variables := step.buildVars.Scope(creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName))
 
 	resourceTypes, err := creds.NewVersionedResourceTypes(variables, step.plan.VersionedResourceTypes).Evaluate()
 	if err != nil {
@@ -193,6 +196,7 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 	chosenWorker, err := step.workerPool.FindOrChooseWorkerForContainer(
 		ctx,
 		logger,
+		step.delegate,
 		owner,
 		containerSpec,
 		workerSpec,
@@ -202,6 +206,19 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	err = chosenWorker.IncreaseActiveTasks()
+	if err != nil {
+		logger.Error("failed-to-increase-active-tasks", err)
+		return err
+	}
+
+	defer func() {
+		err := chosenWorker.DecreaseActiveTasks()
+		if err != nil {
+			logger.Error("failed-to-decrease-active-tasks", err)
+		}
+	}()
+
 	container, err := chosenWorker.FindOrCreateContainer(
 		ctx,
 		logger,
@@ -408,6 +425,7 @@ func (step *TaskStep) containerSpec(logger lager.Logger, repository *artifact.Re
 		ImageSpec: imageSpec,
 		Limits:    worker.ContainerLimits(config.Limits),
 		User:      config.Run.User,
+		Type:      metadata.Type,
 		Dir:       metadata.WorkingDirectory,
 		Env:       step.envForParams(config.Params),
 
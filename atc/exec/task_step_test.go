@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/DataDog/zstd"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
@@ -40,6 +41,7 @@ var _ = Describe("TaskStep", func() {
 		fakeStrategy *workerfakes.FakeContainerPlacementStrategy
 
 		fakeSecretManager *credsfakes.FakeSecrets
+		buildVars         *creds.BuildVariables
 		fakeDelegate      *execfakes.FakeTaskDelegate
 		taskPlan          *atc.TaskPlan
 
@@ -78,6 +80,7 @@ var _ = Describe("TaskStep", func() {
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
 		fakeSecretManager = new(credsfakes.FakeSecrets)
+		buildVars = creds.NewBuildVariables()
 		fakeSecretManager.GetReturns("super-secret-source", nil, true, nil)
 
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
@@ -133,6 +136,7 @@ var _ = Describe("TaskStep", func() {
 			stepMetadata,
 			containerMetadata,
 			fakeSecretManager,
+			buildVars,
 			fakeStrategy,
 			fakePool,
 			fakeDelegate,
@@ -180,7 +184,8 @@ var _ = Describe("TaskStep", func() {
 
 			It("finds or chooses a worker", func() {
 				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(1))
-				_, _, owner, containerSpec, workerSpec, strategy := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+				_, _, waitingDelegate, owner, containerSpec, workerSpec, strategy := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+				Expect(waitingDelegate).To(Equal(fakeDelegate))
 				Expect(owner).To(Equal(db.NewBuildStepContainerOwner(stepMetadata.BuildID, planID, stepMetadata.TeamID)))
 				cpu := uint64(1024)
 				memory := uint64(1024)
@@ -205,6 +210,7 @@ var _ = Describe("TaskStep", func() {
 					Env:     []string{"SECURE=secret-task-param"},
 					Inputs:  []worker.InputSource{},
 					Outputs: worker.OutputPaths{},
+					Type:    db.ContainerTypeTask,
 				}))
 
 				Expect(workerSpec).To(Equal(worker.WorkerSpec{
@@ -217,6 +223,31 @@ var _ = Describe("TaskStep", func() {
 				Expect(strategy).To(Equal(fakeStrategy))
 			})
 
+			It("counts the task as active on the worker while it runs", func() {
+				Expect(fakeWorker.IncreaseActiveTasksCallCount()).To(Equal(1))
+				Expect(fakeWorker.DecreaseActiveTasksCallCount()).To(Equal(1))
+			})
+
+			Context("when increasing the active tasks fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeWorker.IncreaseActiveTasksReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(stepErr).To(Equal(disaster))
+				})
+
+				It("does not create a container", func() {
+					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(BeZero())
+				})
+
+				It("does not decrease the active tasks", func() {
+					Expect(fakeWorker.DecreaseActiveTasksCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the task's container is either found or created", func() {
 				var (
 					fakeContainer *workerfakes.FakeContainer
@@ -277,6 +308,7 @@ var _ = Describe("TaskStep", func() {
 						Env:     []string{"SECURE=secret-task-param"},
 						Inputs:  []worker.InputSource{},
 						Outputs: worker.OutputPaths{},
+						Type:    db.ContainerTypeTask,
 					}))
 					Expect(actualResourceTypes).To(Equal(interpolatedResourceTypes))
 				})
@@ -319,6 +351,7 @@ var _ = Describe("TaskStep", func() {
 							Env:     []string{"SOME=params"},
 							Inputs:  []worker.InputSource{},
 							Outputs: worker.OutputPaths{},
+							Type:    db.ContainerTypeTask,
 						}))
 
 						Expect(actualResourceTypes).To(Equal(interpolatedResourceTypes))
@@ -1203,7 +1236,7 @@ var _ = Describe("TaskStep", func() {
 							})
 
 							It("chooses a worker and creates the container with the image artifact source", func() {
-								_, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+								_, _, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 								Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 									ImageArtifactSource: imageArtifactSource,
 								}))
@@ -1237,7 +1270,7 @@ var _ = Describe("TaskStep", func() {
 										})
 
 										It("still chooses a worker and creates the container with the volume and a metadata stream", func() {
-											_, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+											_, _, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 											Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 												ImageArtifactSource: imageArtifactSource,
 											}))
@@ -1265,7 +1298,7 @@ var _ = Describe("TaskStep", func() {
 										})
 
 										It("still chooses a worker and creates the container with the volume and a metadata stream", func() {
-											_, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+											_, _, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 											Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 												ImageArtifactSource: imageArtifactSource,
 											}))
@@ -1294,7 +1327,7 @@ var _ = Describe("TaskStep", func() {
 										})
 
 										It("still chooses a worker and creates the container with the volume and a metadata stream", func() {
-											_, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+											_, _, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 											Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 												ImageArtifactSource: imageArtifactSource,
 											}))
@@ -1336,7 +1369,7 @@ var _ = Describe("TaskStep", func() {
 						})
 
 						It("creates the specs with the image resource", func() {
-							_, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+							_, _, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 							Expect(containerSpec.ImageSpec.ImageResource).To(Equal(&worker.ImageResource{
 								Type:    "docker",
 								Source:  atc.Source{"some": "super-secret-source"},
@@ -1368,7 +1401,7 @@ var _ = Describe("TaskStep", func() {
 						})
 
 						It("creates the specs with the image resource", func() {
-							_, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+							_, _, _, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 							Expect(containerSpec.ImageSpec.ImageURL).To(Equal("some-image"))
 
 							Expect(workerSpec).To(Equal(worker.WorkerSpec{
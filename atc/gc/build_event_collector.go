@@ -0,0 +1,29 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type buildEventCollector struct {
+	buildEventLifecycle db.BuildEventLifecycle
+	batchSize           int
+}
+
+func NewBuildEventCollector(buildEventLifecycle db.BuildEventLifecycle, batchSize int) *buildEventCollector {
+	return &buildEventCollector{
+		buildEventLifecycle: buildEventLifecycle,
+		batchSize:           batchSize,
+	}
+}
+
+func (b *buildEventCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-event-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	return b.buildEventLifecycle.RemoveOrphanedBuildEvents(b.batchSize)
+}
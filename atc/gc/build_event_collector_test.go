@@ -0,0 +1,31 @@
+package gc_test
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildEventCollector", func() {
+	var collector gc.Collector
+	var fakeBuildEventLifecycle *dbfakes.FakeBuildEventLifecycle
+
+	BeforeEach(func() {
+		fakeBuildEventLifecycle = new(dbfakes.FakeBuildEventLifecycle)
+
+		collector = gc.NewBuildEventCollector(fakeBuildEventLifecycle, 500)
+	})
+
+	Describe("Run", func() {
+		It("tells the build event lifecycle to remove the events of deleted builds", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuildEventLifecycle.RemoveOrphanedBuildEventsCallCount()).To(Equal(1))
+			Expect(fakeBuildEventLifecycle.RemoveOrphanedBuildEventsArgsForCall(0)).To(Equal(500))
+		})
+	})
+})
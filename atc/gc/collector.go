@@ -22,6 +22,11 @@ type aggregateCollector struct {
 	containerCollector                  Collector
 	resourceConfigCheckSessionCollector Collector
 	artifactCollector                   Collector
+	pipelineConfigCollector             Collector
+	teamUsageCollector                  Collector
+	workerDemandCollector               Collector
+	secretAccessCollector               Collector
+	buildEventCollector                 Collector
 }
 
 func NewCollector(
@@ -34,6 +39,11 @@ func NewCollector(
 	volumes Collector,
 	containers Collector,
 	resourceConfigCheckSessionCollector Collector,
+	pipelineConfigCollector Collector,
+	teamUsageCollector Collector,
+	workerDemandCollector Collector,
+	secretAccessCollector Collector,
+	buildEventCollector Collector,
 ) Collector {
 	return &aggregateCollector{
 		buildCollector:                      buildCollector,
@@ -45,6 +55,11 @@ func NewCollector(
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
 		resourceConfigCheckSessionCollector: resourceConfigCheckSessionCollector,
+		pipelineConfigCollector:             pipelineConfigCollector,
+		teamUsageCollector:                  teamUsageCollector,
+		workerDemandCollector:               workerDemandCollector,
+		secretAccessCollector:               secretAccessCollector,
+		buildEventCollector:                 buildEventCollector,
 	}
 }
 
@@ -83,6 +98,11 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("resource-config-check-session-collector", err)
 	}
 
+	err = c.pipelineConfigCollector.Run(ctx)
+	if err != nil {
+		logger.Error("pipeline-config-collector", err)
+	}
+
 	err = c.artifactCollector.Run(ctx)
 	if err != nil {
 		logger.Error("artifact-collector", err)
@@ -98,5 +118,25 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("volume-collector", err)
 	}
 
+	err = c.teamUsageCollector.Run(ctx)
+	if err != nil {
+		logger.Error("team-usage-collector", err)
+	}
+
+	err = c.workerDemandCollector.Run(ctx)
+	if err != nil {
+		logger.Error("worker-demand-collector", err)
+	}
+
+	err = c.secretAccessCollector.Run(ctx)
+	if err != nil {
+		logger.Error("secret-access-collector", err)
+	}
+
+	err = c.buildEventCollector.Run(ctx)
+	if err != nil {
+		logger.Error("build-event-collector", err)
+	}
+
 	return nil
 }
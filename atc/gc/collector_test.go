@@ -23,6 +23,11 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
 		fakeResourceConfigCheckSessionCollector *gcfakes.FakeCollector
+		fakePipelineConfigCollector             *gcfakes.FakeCollector
+		fakeTeamUsageCollector                  *gcfakes.FakeCollector
+		fakeWorkerDemandCollector               *gcfakes.FakeCollector
+		fakeSecretAccessCollector               *gcfakes.FakeCollector
+		fakeBuildEventCollector                 *gcfakes.FakeCollector
 
 		err      error
 		disaster error
@@ -38,6 +43,11 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCheckSessionCollector = new(gcfakes.FakeCollector)
+		fakePipelineConfigCollector = new(gcfakes.FakeCollector)
+		fakeTeamUsageCollector = new(gcfakes.FakeCollector)
+		fakeWorkerDemandCollector = new(gcfakes.FakeCollector)
+		fakeSecretAccessCollector = new(gcfakes.FakeCollector)
+		fakeBuildEventCollector = new(gcfakes.FakeCollector)
 
 		subject = NewCollector(
 			fakeBuildCollector,
@@ -49,6 +59,11 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeVolumeCollector,
 			fakeContainerCollector,
 			fakeResourceConfigCheckSessionCollector,
+			fakePipelineConfigCollector,
+			fakeTeamUsageCollector,
+			fakeWorkerDemandCollector,
+			fakeSecretAccessCollector,
+			fakeBuildEventCollector,
 		)
 
 		disaster = errors.New("disaster")
@@ -81,6 +96,27 @@ var _ = Describe("Aggregate Collector", func() {
 				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+				Expect(fakePipelineConfigCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeTeamUsageCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeWorkerDemandCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeSecretAccessCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeBuildEventCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the pipeline config collector errors", func() {
+			BeforeEach(func() {
+				fakePipelineConfigCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeArtifactCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 			})
 		})
 
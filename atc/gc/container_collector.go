@@ -20,17 +20,23 @@ type containerCollector struct {
 	containerRepository         db.ContainerRepository
 	jobRunner                   WorkerJobRunner
 	missingContainerGracePeriod time.Duration
+	workerFactory               db.WorkerFactory
+	runtimes                    worker.RuntimeFactories
 }
 
 func NewContainerCollector(
 	containerRepository db.ContainerRepository,
 	jobRunner WorkerJobRunner,
 	missingContainerGracePeriod time.Duration,
+	workerFactory db.WorkerFactory,
+	runtimes worker.RuntimeFactories,
 ) Collector {
 	return &containerCollector{
 		containerRepository:         containerRepository,
 		jobRunner:                   jobRunner,
 		missingContainerGracePeriod: missingContainerGracePeriod,
+		workerFactory:               workerFactory,
+		runtimes:                    runtimes,
 	}
 }
 
@@ -67,6 +73,12 @@ func (c *containerCollector) Run(ctx context.Context) error {
 		logger.Error("failed-to-clean-up-failed-containers", err)
 	}
 
+	err = c.destroyRuntimeContainers(logger.Session("runtime-containers"))
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		logger.Error("failed-to-destroy-runtime-containers", err)
+	}
+
 	_, err = c.containerRepository.RemoveMissingContainers(c.missingContainerGracePeriod)
 	if err != nil {
 		errs = multierror.Append(errs, err)
@@ -76,6 +88,87 @@ func (c *containerCollector) Run(ctx context.Context) error {
 	return errs
 }
 
+// destroyRuntimeContainers does the work of a worker's beacon for workers
+// whose runtime has none, such as Kubernetes workers: it destroys their
+// destroying containers and reports the containers which remain.
+func (c *containerCollector) destroyRuntimeContainers(logger lager.Logger) error {
+	if len(c.runtimes) == 0 {
+		return nil
+	}
+
+	workers, err := c.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	var errs error
+	for _, w := range workers {
+		runtime, found := c.runtimes[w.Runtime()]
+		if !found || w.State() == db.WorkerStateStalled {
+			continue
+		}
+
+		wLog := logger.Session("worker", lager.Data{
+			"worker":  w.Name(),
+			"runtime": w.Runtime(),
+		})
+
+		err := c.destroyWorkerContainers(wLog, w.Name(), runtime.NewGardenClient(wLog, w))
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (c *containerCollector) destroyWorkerContainers(logger lager.Logger, workerName string, gardenClient garden.Client) error {
+	handles, err := c.containerRepository.FindDestroyingContainers(workerName)
+	if err != nil {
+		logger.Error("failed-to-find-destroying-containers", err)
+		return err
+	}
+
+	for _, handle := range handles {
+		err := gardenClient.Destroy(handle)
+		if err != nil {
+			if _, ok := err.(garden.ContainerNotFoundError); ok {
+				continue
+			}
+
+			logger.Error("failed-to-destroy-container", err, lager.Data{"container": handle})
+		}
+	}
+
+	containers, err := gardenClient.Containers(nil)
+	if err != nil {
+		logger.Error("failed-to-list-containers", err)
+		return err
+	}
+
+	currentHandles := []string{}
+	for _, container := range containers {
+		currentHandles = append(currentHandles, container.Handle())
+	}
+
+	err = c.containerRepository.UpdateContainersMissingSince(workerName, currentHandles)
+	if err != nil {
+		logger.Error("failed-to-update-containers-missing-since", err)
+		return err
+	}
+
+	deleted, err := c.containerRepository.RemoveDestroyingContainers(workerName, currentHandles)
+	if err != nil {
+		logger.Error("failed-to-remove-destroying-containers", err)
+		return err
+	}
+
+	metric.ContainersDeleted.IncDelta(deleted)
+
+	return nil
+}
+
 func (c *containerCollector) cleanupFailedContainers(logger lager.Logger) error {
 	failedContainersLen, err := c.containerRepository.DestroyFailedContainers()
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/gc/gcfakes"
@@ -23,6 +24,8 @@ import (
 var _ = Describe("ContainerCollector", func() {
 	var (
 		fakeContainerRepository *dbfakes.FakeContainerRepository
+		fakeWorkerFactory       *dbfakes.FakeWorkerFactory
+		fakeRuntimeFactory      *workerfakes.FakeRuntimeFactory
 		fakeWorkerProvider      *workerfakes.FakeWorkerProvider
 		fakeJobRunner           *gcfakes.FakeWorkerJobRunner
 
@@ -46,6 +49,9 @@ var _ = Describe("ContainerCollector", func() {
 
 		fakeWorkerProvider = new(workerfakes.FakeWorkerProvider)
 
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeRuntimeFactory = new(workerfakes.FakeRuntimeFactory)
+
 		logger = lagertest.NewTestLogger("test")
 
 		fakeJobRunner = new(gcfakes.FakeWorkerJobRunner)
@@ -59,12 +65,16 @@ var _ = Describe("ContainerCollector", func() {
 			fakeContainerRepository,
 			fakeJobRunner,
 			missingContainerGracePeriod,
+			fakeWorkerFactory,
+			worker.RuntimeFactories{atc.WorkerRuntimeKubernetes: fakeRuntimeFactory},
 		)
 
 		fakeCollector = gc.NewContainerCollector(
 			fakeContainerRepository,
 			fakeJobRunner,
 			missingContainerGracePeriod,
+			fakeWorkerFactory,
+			worker.RuntimeFactories{atc.WorkerRuntimeKubernetes: fakeRuntimeFactory},
 		)
 	})
 
@@ -274,5 +284,90 @@ var _ = Describe("ContainerCollector", func() {
 				})
 			})
 		})
+
+		Describe("Runtime Containers", func() {
+			var (
+				kubernetesWorker   *dbfakes.FakeWorker
+				fakeRuntimeGarden  *gardenfakes.FakeClient
+				remainingContainer *gardenfakes.FakeContainer
+			)
+
+			BeforeEach(func() {
+				kubernetesWorker = new(dbfakes.FakeWorker)
+				kubernetesWorker.NameReturns("kubernetes-worker")
+				kubernetesWorker.RuntimeReturns(atc.WorkerRuntimeKubernetes)
+				kubernetesWorker.StateReturns(db.WorkerStateRunning)
+
+				gardenWorker := new(dbfakes.FakeWorker)
+				gardenWorker.NameReturns("garden-worker")
+				gardenWorker.RuntimeReturns(atc.WorkerRuntimeGarden)
+				gardenWorker.StateReturns(db.WorkerStateRunning)
+
+				fakeWorkerFactory.WorkersReturns([]db.Worker{gardenWorker, kubernetesWorker}, nil)
+
+				remainingContainer = new(gardenfakes.FakeContainer)
+				remainingContainer.HandleReturns("remaining-handle")
+
+				fakeRuntimeGarden = new(gardenfakes.FakeClient)
+				fakeRuntimeGarden.ContainersReturns([]garden.Container{remainingContainer}, nil)
+				fakeRuntimeFactory.NewGardenClientReturns(fakeRuntimeGarden)
+
+				fakeContainerRepository.FindDestroyingContainersReturns([]string{"destroying-handle", "gone-handle"}, nil)
+				fakeRuntimeGarden.DestroyStub = func(handle string) error {
+					if handle == "gone-handle" {
+						return garden.ContainerNotFoundError{Handle: handle}
+					}
+
+					return nil
+				}
+			})
+
+			It("destroys the destroying containers of workers without a beacon", func() {
+				Expect(fakeRuntimeFactory.NewGardenClientCallCount()).To(Equal(1))
+				_, savedWorker := fakeRuntimeFactory.NewGardenClientArgsForCall(0)
+				Expect(savedWorker).To(Equal(kubernetesWorker))
+
+				Expect(fakeContainerRepository.FindDestroyingContainersCallCount()).To(Equal(1))
+				Expect(fakeContainerRepository.FindDestroyingContainersArgsForCall(0)).To(Equal("kubernetes-worker"))
+
+				Expect(fakeRuntimeGarden.DestroyCallCount()).To(Equal(2))
+				Expect(fakeRuntimeGarden.DestroyArgsForCall(0)).To(Equal("destroying-handle"))
+				Expect(fakeRuntimeGarden.DestroyArgsForCall(1)).To(Equal("gone-handle"))
+			})
+
+			It("reports the containers remaining on the worker", func() {
+				Expect(fakeContainerRepository.UpdateContainersMissingSinceCallCount()).To(Equal(1))
+				workerName, handles := fakeContainerRepository.UpdateContainersMissingSinceArgsForCall(0)
+				Expect(workerName).To(Equal("kubernetes-worker"))
+				Expect(handles).To(Equal([]string{"remaining-handle"}))
+
+				Expect(fakeContainerRepository.RemoveDestroyingContainersCallCount()).To(Equal(1))
+				workerName, handles = fakeContainerRepository.RemoveDestroyingContainersArgsForCall(0)
+				Expect(workerName).To(Equal("kubernetes-worker"))
+				Expect(handles).To(Equal([]string{"remaining-handle"}))
+			})
+
+			Context("when the worker is stalled", func() {
+				BeforeEach(func() {
+					kubernetesWorker.StateReturns(db.WorkerStateStalled)
+				})
+
+				It("leaves its containers alone", func() {
+					Expect(fakeRuntimeFactory.NewGardenClientCallCount()).To(BeZero())
+					Expect(fakeContainerRepository.FindDestroyingContainersCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when listing the containers fails", func() {
+				BeforeEach(func() {
+					fakeRuntimeGarden.ContainersReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns an error without removing any containers", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(fakeContainerRepository.RemoveDestroyingContainersCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
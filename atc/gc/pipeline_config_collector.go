@@ -0,0 +1,44 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type pipelineConfigCollector struct {
+	pipelineConfigLifecycle db.PipelineConfigLifecycle
+	retain                  int
+}
+
+// NewPipelineConfigCollector prunes each pipeline's config history down to
+// the given number of versions. A retain of 0 keeps every version.
+func NewPipelineConfigCollector(
+	pipelineConfigLifecycle db.PipelineConfigLifecycle,
+	retain int,
+) Collector {
+	return &pipelineConfigCollector{
+		pipelineConfigLifecycle: pipelineConfigLifecycle,
+		retain:                  retain,
+	}
+}
+
+func (pcc *pipelineConfigCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("pipeline-config-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if pcc.retain == 0 {
+		return nil
+	}
+
+	err := pcc.pipelineConfigLifecycle.PruneConfigHistory(pcc.retain)
+	if err != nil {
+		logger.Error("failed-to-prune-config-history", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineConfigCollector", func() {
+	var collector gc.Collector
+	var fakePipelineConfigLifecycle *dbfakes.FakePipelineConfigLifecycle
+	var retain int
+
+	BeforeEach(func() {
+		fakePipelineConfigLifecycle = new(dbfakes.FakePipelineConfigLifecycle)
+		retain = 20
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewPipelineConfigCollector(fakePipelineConfigLifecycle, retain)
+	})
+
+	Describe("Run", func() {
+		It("tells the pipeline config lifecycle to prune config history", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePipelineConfigLifecycle.PruneConfigHistoryCallCount()).To(Equal(1))
+			Expect(fakePipelineConfigLifecycle.PruneConfigHistoryArgsForCall(0)).To(Equal(20))
+		})
+
+		Context("when pruning fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakePipelineConfigLifecycle.PruneConfigHistoryReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(Equal(disaster))
+			})
+		})
+
+		Context("when configured to retain every version", func() {
+			BeforeEach(func() {
+				retain = 0
+			})
+
+			It("does not prune", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineConfigLifecycle.PruneConfigHistoryCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
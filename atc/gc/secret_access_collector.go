@@ -0,0 +1,50 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type secretAccessCollector struct {
+	secretAccessRepository db.SecretAccessRepository
+	retention              time.Duration
+	clock                  clock.Clock
+}
+
+// NewSecretAccessCollector removes secret lookups older than the given
+// retention from the secret access audit trail. A retention of 0 keeps every
+// lookup.
+func NewSecretAccessCollector(
+	secretAccessRepository db.SecretAccessRepository,
+	retention time.Duration,
+	clock clock.Clock,
+) Collector {
+	return &secretAccessCollector{
+		secretAccessRepository: secretAccessRepository,
+		retention:              retention,
+		clock:                  clock,
+	}
+}
+
+func (sac *secretAccessCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("secret-access-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if sac.retention == 0 {
+		return nil
+	}
+
+	err := sac.secretAccessRepository.RemoveSecretAccessesBefore(sac.clock.Now().Add(-sac.retention))
+	if err != nil {
+		logger.Error("failed-to-remove-secret-accesses", err)
+		return err
+	}
+
+	return nil
+}
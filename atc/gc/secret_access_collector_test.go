@@ -0,0 +1,66 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessCollector", func() {
+	var collector gc.Collector
+	var fakeSecretAccessRepository *dbfakes.FakeSecretAccessRepository
+	var fakeClock *fakeclock.FakeClock
+	var retention time.Duration
+
+	BeforeEach(func() {
+		fakeSecretAccessRepository = new(dbfakes.FakeSecretAccessRepository)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		retention = 24 * time.Hour
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewSecretAccessCollector(fakeSecretAccessRepository, retention, fakeClock)
+	})
+
+	Describe("Run", func() {
+		It("removes the secret accesses older than the retention", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSecretAccessRepository.RemoveSecretAccessesBeforeCallCount()).To(Equal(1))
+			Expect(fakeSecretAccessRepository.RemoveSecretAccessesBeforeArgsForCall(0)).To(Equal(time.Unix(123456789, 0).Add(-24 * time.Hour)))
+		})
+
+		Context("when removing fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeSecretAccessRepository.RemoveSecretAccessesBeforeReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(Equal(disaster))
+			})
+		})
+
+		Context("when configured to keep every secret access", func() {
+			BeforeEach(func() {
+				retention = 0
+			})
+
+			It("does not remove anything", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSecretAccessRepository.RemoveSecretAccessesBeforeCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type teamUsageCollector struct {
+	teamFactory db.TeamFactory
+}
+
+// NewTeamUsageCollector returns a Collector which emits each team's usage of
+// the resources limited by its quotas. It collects nothing.
+func NewTeamUsageCollector(teamFactory db.TeamFactory) Collector {
+	return &teamUsageCollector{
+		teamFactory: teamFactory,
+	}
+}
+
+func (tc *teamUsageCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("team-usage-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	teams, err := tc.teamFactory.GetTeams()
+	if err != nil {
+		logger.Error("failed-to-get-teams-for-metrics", err)
+		return nil
+	}
+
+	for _, team := range teams {
+		usage, err := team.Usage()
+		if err != nil {
+			logger.Error("failed-to-get-team-usage-for-metrics", err, lager.Data{"team": team.Name()})
+			continue
+		}
+
+		metric.TeamUsage{
+			TeamName: team.Name(),
+			Usage:    usage,
+		}.Emit(logger)
+	}
+
+	return nil
+}
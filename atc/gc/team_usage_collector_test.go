@@ -0,0 +1,65 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamUsageCollector", func() {
+	var collector gc.Collector
+	var fakeTeamFactory *dbfakes.FakeTeamFactory
+	var fakeTeam1, fakeTeam2 *dbfakes.FakeTeam
+
+	BeforeEach(func() {
+		fakeTeam1 = new(dbfakes.FakeTeam)
+		fakeTeam1.NameReturns("team-1")
+		fakeTeam2 = new(dbfakes.FakeTeam)
+		fakeTeam2.NameReturns("team-2")
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetTeamsReturns([]db.Team{fakeTeam1, fakeTeam2}, nil)
+
+		collector = gc.NewTeamUsageCollector(fakeTeamFactory)
+	})
+
+	Describe("Run", func() {
+		It("gets the usage of every team", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTeam1.UsageCallCount()).To(Equal(1))
+			Expect(fakeTeam2.UsageCallCount()).To(Equal(1))
+		})
+
+		Context("when getting the usage of a team fails", func() {
+			BeforeEach(func() {
+				fakeTeam1.UsageReturns(atc.TeamUsage{}, errors.New("nope"))
+			})
+
+			It("carries on with the other teams", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTeam2.UsageCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when getting the teams fails", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.GetTeamsReturns(nil, errors.New("nope"))
+			})
+
+			It("does not return the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
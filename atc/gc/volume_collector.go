@@ -6,23 +6,31 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
 	multierror "github.com/hashicorp/go-multierror"
 )
 
 type volumeCollector struct {
 	volumeRepository         db.VolumeRepository
 	missingVolumeGracePeriod time.Duration
+	workerFactory            db.WorkerFactory
+	runtimes                 worker.RuntimeFactories
 }
 
 func NewVolumeCollector(
 	volumeRepository db.VolumeRepository,
 	missingVolumeGracePeriod time.Duration,
+	workerFactory db.WorkerFactory,
+	runtimes worker.RuntimeFactories,
 ) Collector {
 	return &volumeCollector{
 		volumeRepository:         volumeRepository,
 		missingVolumeGracePeriod: missingVolumeGracePeriod,
+		workerFactory:            workerFactory,
+		runtimes:                 runtimes,
 	}
 }
 
@@ -46,6 +54,12 @@ func (vc *volumeCollector) Run(ctx context.Context) error {
 		logger.Error("failed-to-transition-created-volumes-to-destroying", err)
 	}
 
+	err = vc.destroyRuntimeVolumes(logger.Session("runtime-volumes"))
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		logger.Error("failed-to-destroy-runtime-volumes", err)
+	}
+
 	_, err = vc.volumeRepository.RemoveMissingVolumes(vc.missingVolumeGracePeriod)
 	if err != nil {
 		errs = multierror.Append(errs, err)
@@ -55,6 +69,83 @@ func (vc *volumeCollector) Run(ctx context.Context) error {
 	return errs
 }
 
+// destroyRuntimeVolumes does the work of a worker's beacon for workers whose
+// runtime has none, such as Kubernetes workers: it destroys their destroying
+// volumes and reports the volumes which remain.
+func (vc *volumeCollector) destroyRuntimeVolumes(logger lager.Logger) error {
+	if len(vc.runtimes) == 0 {
+		return nil
+	}
+
+	workers, err := vc.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	var errs error
+	for _, w := range workers {
+		runtime, found := vc.runtimes[w.Runtime()]
+		if !found || w.State() == db.WorkerStateStalled {
+			continue
+		}
+
+		wLog := logger.Session("worker", lager.Data{
+			"worker":  w.Name(),
+			"runtime": w.Runtime(),
+		})
+
+		err := vc.destroyWorkerVolumes(wLog, w.Name(), runtime.NewBaggageclaimClient(wLog, w))
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (vc *volumeCollector) destroyWorkerVolumes(logger lager.Logger, workerName string, baggageclaimClient baggageclaim.Client) error {
+	handles, err := vc.volumeRepository.GetDestroyingVolumes(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-destroying-volumes", err)
+		return err
+	}
+
+	if len(handles) > 0 {
+		err = baggageclaimClient.DestroyVolumes(logger, handles)
+		if err != nil {
+			logger.Error("failed-to-destroy-volumes", err)
+		}
+	}
+
+	volumes, err := baggageclaimClient.ListVolumes(logger, nil)
+	if err != nil {
+		logger.Error("failed-to-list-volumes", err)
+		return err
+	}
+
+	currentHandles := volumes.Handles()
+	if currentHandles == nil {
+		currentHandles = []string{}
+	}
+
+	err = vc.volumeRepository.UpdateVolumesMissingSince(workerName, currentHandles)
+	if err != nil {
+		logger.Error("failed-to-update-volumes-missing-since", err)
+		return err
+	}
+
+	deleted, err := vc.volumeRepository.RemoveDestroyingVolumes(workerName, currentHandles)
+	if err != nil {
+		logger.Error("failed-to-remove-destroying-volumes", err)
+		return err
+	}
+
+	metric.VolumesDeleted.IncDelta(deleted)
+
+	return nil
+}
+
 func (vc *volumeCollector) cleanupFailedVolumes(logger lager.Logger) error {
 	failedVolumesLen, err := vc.volumeRepository.DestroyFailedVolumes()
 	if err != nil {
@@ -4,10 +4,14 @@ import (
 	"context"
 	"time"
 
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/gc"
+	w "github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -38,6 +42,8 @@ var _ = Describe("VolumeCollector", func() {
 		volumeCollector = gc.NewVolumeCollector(
 			volumeRepository,
 			missingVolumeGracePeriod,
+			workerFactory,
+			nil,
 		)
 	})
 
@@ -73,6 +79,8 @@ var _ = Describe("VolumeCollector", func() {
 				volumeCollector = gc.NewVolumeCollector(
 					fakeVolumeRepository,
 					missingVolumeGracePeriod,
+					workerFactory,
+					nil,
 				)
 
 				err = volumeCollector.Run(context.TODO())
@@ -85,6 +93,78 @@ var _ = Describe("VolumeCollector", func() {
 			})
 		})
 
+		Context("when a worker runs on a runtime without a beacon", func() {
+			var (
+				fakeVolumeRepository   *dbfakes.FakeVolumeRepository
+				fakeWorkerFactory      *dbfakes.FakeWorkerFactory
+				fakeRuntimeFactory     *workerfakes.FakeRuntimeFactory
+				fakeBaggageclaimClient *baggageclaimfakes.FakeClient
+				kubernetesWorker       *dbfakes.FakeWorker
+			)
+
+			BeforeEach(func() {
+				fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
+				fakeVolumeRepository.GetDestroyingVolumesReturns([]string{"destroying-handle"}, nil)
+
+				kubernetesWorker = new(dbfakes.FakeWorker)
+				kubernetesWorker.NameReturns("kubernetes-worker")
+				kubernetesWorker.RuntimeReturns(atc.WorkerRuntimeKubernetes)
+				kubernetesWorker.StateReturns(db.WorkerStateRunning)
+
+				gardenWorker := new(dbfakes.FakeWorker)
+				gardenWorker.NameReturns("garden-worker")
+				gardenWorker.RuntimeReturns(atc.WorkerRuntimeGarden)
+				gardenWorker.StateReturns(db.WorkerStateRunning)
+
+				fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+				fakeWorkerFactory.WorkersReturns([]db.Worker{gardenWorker, kubernetesWorker}, nil)
+
+				remainingVolume := new(baggageclaimfakes.FakeVolume)
+				remainingVolume.HandleReturns("remaining-handle")
+
+				fakeBaggageclaimClient = new(baggageclaimfakes.FakeClient)
+				fakeBaggageclaimClient.ListVolumesReturns(baggageclaim.Volumes{remainingVolume}, nil)
+
+				fakeRuntimeFactory = new(workerfakes.FakeRuntimeFactory)
+				fakeRuntimeFactory.NewBaggageclaimClientReturns(fakeBaggageclaimClient)
+
+				volumeCollector = gc.NewVolumeCollector(
+					fakeVolumeRepository,
+					missingVolumeGracePeriod,
+					fakeWorkerFactory,
+					w.RuntimeFactories{atc.WorkerRuntimeKubernetes: fakeRuntimeFactory},
+				)
+
+				err := volumeCollector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("destroys the destroying volumes on the worker", func() {
+				Expect(fakeRuntimeFactory.NewBaggageclaimClientCallCount()).To(Equal(1))
+				_, savedWorker := fakeRuntimeFactory.NewBaggageclaimClientArgsForCall(0)
+				Expect(savedWorker).To(Equal(kubernetesWorker))
+
+				Expect(fakeVolumeRepository.GetDestroyingVolumesCallCount()).To(Equal(1))
+				Expect(fakeVolumeRepository.GetDestroyingVolumesArgsForCall(0)).To(Equal("kubernetes-worker"))
+
+				Expect(fakeBaggageclaimClient.DestroyVolumesCallCount()).To(Equal(1))
+				_, handles := fakeBaggageclaimClient.DestroyVolumesArgsForCall(0)
+				Expect(handles).To(Equal([]string{"destroying-handle"}))
+			})
+
+			It("reports the volumes remaining on the worker", func() {
+				Expect(fakeVolumeRepository.UpdateVolumesMissingSinceCallCount()).To(Equal(1))
+				workerName, handles := fakeVolumeRepository.UpdateVolumesMissingSinceArgsForCall(0)
+				Expect(workerName).To(Equal("kubernetes-worker"))
+				Expect(handles).To(Equal([]string{"remaining-handle"}))
+
+				Expect(fakeVolumeRepository.RemoveDestroyingVolumesCallCount()).To(Equal(1))
+				workerName, handles = fakeVolumeRepository.RemoveDestroyingVolumesArgsForCall(0)
+				Expect(workerName).To(Equal("kubernetes-worker"))
+				Expect(handles).To(Equal([]string{"remaining-handle"}))
+			})
+		})
+
 		Context("when there are failed volumes", func() {
 			JustBeforeEach(func() {
 				creatingVolume1, err := volumeRepository.CreateContainerVolume(team.ID(), worker.Name(), creatingContainer1, "some-path-1")
@@ -0,0 +1,42 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type workerDemandCollector struct {
+	workerDemand worker.WorkerDemand
+}
+
+// NewWorkerDemandCollector returns a Collector which emits the demand for
+// each kind of worker. It collects nothing.
+func NewWorkerDemandCollector(workerDemand worker.WorkerDemand) Collector {
+	return &workerDemandCollector{
+		workerDemand: workerDemand,
+	}
+}
+
+func (wc *workerDemandCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("worker-demand-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	demand, err := wc.workerDemand.Report(logger)
+	if err != nil {
+		logger.Error("failed-to-report-worker-demand-for-metrics", err)
+		return nil
+	}
+
+	for _, group := range demand.Groups {
+		metric.WorkerDemand{
+			Group: group,
+		}.Emit(logger)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerDemandCollector", func() {
+	var collector gc.Collector
+	var fakeWorkerDemand *workerfakes.FakeWorkerDemand
+
+	BeforeEach(func() {
+		fakeWorkerDemand = new(workerfakes.FakeWorkerDemand)
+		fakeWorkerDemand.ReportReturns(atc.WorkerDemand{
+			Groups: []atc.WorkerDemandGroup{
+				{Team: "some-team", RunningSteps: 3, RecommendedWorkers: 1},
+			},
+		}, nil)
+
+		collector = gc.NewWorkerDemandCollector(fakeWorkerDemand)
+	})
+
+	Describe("Run", func() {
+		It("reports the demand for workers", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerDemand.ReportCallCount()).To(Equal(1))
+		})
+
+		Context("when reporting the demand fails", func() {
+			BeforeEach(func() {
+				fakeWorkerDemand.ReportReturns(atc.WorkerDemand{}, errors.New("nope"))
+			})
+
+			It("does not return the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
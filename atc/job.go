@@ -1,5 +1,7 @@
 package atc
 
+import "strings"
+
 type Job struct {
 	ID int `json:"id"`
 
@@ -30,6 +32,19 @@ type JobInput struct {
 	Tags     Tags           `json:"tags,omitempty"`
 }
 
+// PassedJob splits an entry of a `passed` constraint into the pipeline and
+// job it refers to. Entries of the form `pipeline/job` refer to a job in
+// another pipeline belonging to the same team, which must not have instance
+// vars; for any other entry the returned pipeline name is empty.
+func PassedJob(passed string) (string, string) {
+	i := strings.Index(passed, "/")
+	if i == -1 {
+		return "", passed
+	}
+
+	return passed[:i], passed[i+1:]
+}
+
 type JobOutput struct {
 	Name     string `json:"name"`
 	Resource string `json:"resource"`
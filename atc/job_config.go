@@ -1,5 +1,11 @@
 package atc
 
+import (
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
 type JobConfig struct {
 	Name    string `yaml:"name" json:"name" mapstructure:"name"`
 	OldName string `yaml:"old_name,omitempty" json:"old_name,omitempty" mapstructure:"old_name"`
@@ -11,9 +17,12 @@ type JobConfig struct {
 	SerialGroups         []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
+	Priority             int      `yaml:"priority,omitempty" json:"priority,omitempty" mapstructure:"priority"`
 
 	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
 
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
@@ -28,6 +37,44 @@ type BuildLogRetention struct {
 	Days   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
 }
 
+const (
+	// ScheduleCatchUpSkip drops any scheduled runs that were missed while
+	// the pipeline was not being scheduled, e.g. during downtime.
+	ScheduleCatchUpSkip = "skip"
+
+	// ScheduleCatchUpOnce creates a single build to make up for any number
+	// of missed scheduled runs.
+	ScheduleCatchUpOnce = "once"
+)
+
+// ScheduleConfig triggers a job at the times matching a cron expression,
+// evaluated in the given timezone.
+type ScheduleConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty" mapstructure:"timezone"`
+	CatchUp  string `yaml:"catch_up,omitempty" json:"catch_up,omitempty" mapstructure:"catch_up"`
+}
+
+func (config ScheduleConfig) Expression() (*cronexpr.Expression, error) {
+	return cronexpr.Parse(config.Cron)
+}
+
+func (config ScheduleConfig) Location() (*time.Location, error) {
+	if config.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(config.Timezone)
+}
+
+func (config ScheduleConfig) CatchUpPolicy() string {
+	if config.CatchUp == "" {
+		return ScheduleCatchUpSkip
+	}
+
+	return config.CatchUp
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{Abort: config.Abort, Error: config.Error, Failure: config.Failure, Ensure: config.Ensure, Success: config.Success}
 }
@@ -41,6 +41,17 @@ type PrometheusEmitter struct {
 	schedulingFullDuration    *prometheus.CounterVec
 	schedulingLoadingDuration *prometheus.CounterVec
 
+	secretCacheHits       prometheus.Counter
+	secretCacheMisses     prometheus.Counter
+	secretAccessesDropped prometheus.Counter
+
+	stepsWaitingForWorker prometheus.Gauge
+	workersDemand         *prometheus.GaugeVec
+	workersRecommended    *prometheus.GaugeVec
+
+	teamQuota *prometheus.GaugeVec
+	teamUsage *prometheus.GaugeVec
+
 	workerContainers  *prometheus.GaugeVec
 	workerVolumes     *prometheus.GaugeVec
 	workersRegistered *prometheus.GaugeVec
@@ -193,6 +204,61 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(workersRegistered)
 
+	stepsWaitingForWorker := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "steps",
+			Name:      "waiting_for_worker",
+			Help:      "Number of build steps waiting for a compatible worker with capacity",
+		},
+	)
+	prometheus.MustRegister(stepsWaitingForWorker)
+
+	workersDemand := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "demand",
+			Help:      "Number of running steps, ready pending builds and waiting steps per team, tags and platform",
+		},
+		[]string{"team", "tags", "platform", "demand"},
+	)
+	prometheus.MustRegister(workersDemand)
+
+	workersRecommended := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "recommended",
+			Help:      "Recommended number of workers per team, tags and platform",
+		},
+		[]string{"team", "tags", "platform"},
+	)
+	prometheus.MustRegister(workersRecommended)
+
+	// team metrics
+	teamUsage := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "teams",
+			Name:      "usage",
+			Help:      "Number of running builds, containers, and volumes per team",
+		},
+		[]string{"team", "resource"},
+	)
+	prometheus.MustRegister(teamUsage)
+
+	teamQuota := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "teams",
+			Name:      "quota",
+			Help:      "Maximum number of running builds, containers, and volumes per team (0 for unlimited)",
+		},
+		[]string{"team", "resource"},
+	)
+	prometheus.MustRegister(teamQuota)
+
 	// http metrics
 	httpRequestsDuration := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -247,6 +313,30 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	})
 	prometheus.MustRegister(dbQueriesTotal)
 
+	secretCacheHits := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "secrets",
+		Name:      "cache_hits_total",
+		Help:      "Total number of secret lookups answered from the secret cache",
+	})
+	prometheus.MustRegister(secretCacheHits)
+
+	secretCacheMisses := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "secrets",
+		Name:      "cache_misses_total",
+		Help:      "Total number of secret lookups not found in the secret cache, and made in the credential manager",
+	})
+	prometheus.MustRegister(secretCacheMisses)
+
+	secretAccessesDropped := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "secrets",
+		Name:      "accesses_dropped_total",
+		Help:      "Total number of secret lookups which could not be recorded in the secret access audit trail",
+	})
+	prometheus.MustRegister(secretAccessesDropped)
+
 	dbConnections := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "concourse",
@@ -302,6 +392,17 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		schedulingFullDuration:    schedulingFullDuration,
 		schedulingLoadingDuration: schedulingLoadingDuration,
 
+		secretCacheHits:       secretCacheHits,
+		secretCacheMisses:     secretCacheMisses,
+		secretAccessesDropped: secretAccessesDropped,
+
+		stepsWaitingForWorker: stepsWaitingForWorker,
+		workersDemand:         workersDemand,
+		workersRecommended:    workersRecommended,
+
+		teamQuota: teamQuota,
+		teamUsage: teamUsage,
+
 		workerContainers:  workerContainers,
 		workersRegistered: workersRegistered,
 		workerLastSeen:    map[string]time.Time{},
@@ -336,6 +437,16 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workerVolumesMetric(logger, event)
 	case "worker state":
 		emitter.workersRegisteredMetric(logger, event)
+	case "steps waiting for worker":
+		emitter.stepsWaitingForWorkerMetric(logger, event)
+	case "worker demand":
+		emitter.workerDemandMetric(logger, event, emitter.workersDemand, "team", "tags", "platform", "demand")
+	case "recommended workers":
+		emitter.workerDemandMetric(logger, event, emitter.workersRecommended, "team", "tags", "platform")
+	case "team usage":
+		emitter.teamMetric(logger, event, emitter.teamUsage)
+	case "team quota":
+		emitter.teamMetric(logger, event, emitter.teamQuota)
 	case "http response time":
 		emitter.httpResponseTimeMetrics(logger, event)
 	case "scheduling: full duration (ms)":
@@ -350,6 +461,12 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "secret cache hits":
+		emitter.secretsMetrics(logger, event)
+	case "secret cache misses":
+		emitter.secretsMetrics(logger, event)
+	case "secret accesses dropped":
+		emitter.secretsMetrics(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -474,6 +591,58 @@ func (emitter *PrometheusEmitter) workersRegisteredMetric(logger lager.Logger, e
 	emitter.workersRegistered.WithLabelValues(state).Set(float64(count))
 }
 
+func (emitter *PrometheusEmitter) stepsWaitingForWorkerMetric(logger lager.Logger, event metric.Event) {
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("steps-waiting-for-worker-value-type-mismatch", fmt.Errorf("expected event.Value to be a int"))
+		return
+	}
+
+	emitter.stepsWaitingForWorker.Set(float64(value))
+}
+
+func (emitter *PrometheusEmitter) workerDemandMetric(logger lager.Logger, event metric.Event, gauge *prometheus.GaugeVec, labels ...string) {
+	values := []string{}
+	for _, label := range labels {
+		value, exists := event.Attributes[label]
+		if !exists {
+			logger.Error("failed-to-find-"+label+"-in-event", fmt.Errorf("expected %s to exist in event.Attributes", label))
+			return
+		}
+
+		values = append(values, value)
+	}
+
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("worker-demand-event-value-type-mismatch", fmt.Errorf("expected event.Value to be an int"))
+		return
+	}
+
+	gauge.WithLabelValues(values...).Set(float64(value))
+}
+
+func (emitter *PrometheusEmitter) teamMetric(logger lager.Logger, event metric.Event, gauge *prometheus.GaugeVec) {
+	team, exists := event.Attributes["team"]
+	if !exists {
+		logger.Error("failed-to-find-team-in-event", fmt.Errorf("expected team to exist in event.Attributes"))
+		return
+	}
+	resource, exists := event.Attributes["resource"]
+	if !exists {
+		logger.Error("failed-to-find-resource-in-event", fmt.Errorf("expected resource to exist in event.Attributes"))
+		return
+	}
+
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("team-event-value-type-mismatch", fmt.Errorf("expected event.Value to be an int"))
+		return
+	}
+
+	gauge.WithLabelValues(team, resource).Set(float64(value))
+}
+
 func (emitter *PrometheusEmitter) workerVolumesMetric(logger lager.Logger, event metric.Event) {
 	worker, exists := event.Attributes["worker"]
 	if !exists {
@@ -570,6 +739,24 @@ func (emitter *PrometheusEmitter) databaseMetrics(logger lager.Logger, event met
 
 }
 
+func (emitter *PrometheusEmitter) secretsMetrics(logger lager.Logger, event metric.Event) {
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("secrets-value-type-mismatch", fmt.Errorf("expected event.Value to be a int"))
+		return
+	}
+
+	switch event.Name {
+	case "secret cache hits":
+		emitter.secretCacheHits.Add(float64(value))
+	case "secret cache misses":
+		emitter.secretCacheMisses.Add(float64(value))
+	case "secret accesses dropped":
+		emitter.secretAccessesDropped.Add(float64(value))
+	default:
+	}
+}
+
 func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metric.Event) {
 	pipeline, exists := event.Attributes["pipeline"]
 	if !exists {
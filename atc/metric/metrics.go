@@ -2,11 +2,13 @@ package metric
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -22,6 +24,13 @@ var FailedVolumes = Meter(0)
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
 
+var SecretCacheHits = Meter(0)
+var SecretCacheMisses = Meter(0)
+
+var SecretAccessesDropped = Meter(0)
+
+var StepsWaitingForWorker = &Gauge{}
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
@@ -549,3 +558,104 @@ func (event WorkersState) Emit(logger lager.Logger) {
 		)
 	}
 }
+
+type TeamUsage struct {
+	TeamName string
+	Usage    atc.TeamUsage
+}
+
+func (event TeamUsage) Emit(logger lager.Logger) {
+	resources := []struct {
+		name    string
+		used    int
+		limit   int
+		reached bool
+	}{
+		{"running_builds", event.Usage.RunningBuilds, event.Usage.Quotas.MaxRunningBuilds, event.Usage.RunningBuildsQuotaReached()},
+		{"containers", event.Usage.Containers, event.Usage.Quotas.MaxContainers, event.Usage.ContainersQuotaReached()},
+		{"volumes", event.Usage.Volumes, event.Usage.Quotas.MaxVolumes, event.Usage.VolumesQuotaReached()},
+	}
+
+	for _, resource := range resources {
+		state := EventStateOK
+		if resource.reached {
+			state = EventStateWarning
+		}
+
+		attributes := map[string]string{
+			"team":     event.TeamName,
+			"resource": resource.name,
+		}
+
+		emit(
+			logger.Session("team-usage"),
+			Event{
+				Name:       "team usage",
+				Value:      resource.used,
+				State:      state,
+				Attributes: attributes,
+			},
+		)
+
+		emit(
+			logger.Session("team-quota"),
+			Event{
+				Name:       "team quota",
+				Value:      resource.limit,
+				State:      EventStateOK,
+				Attributes: attributes,
+			},
+		)
+	}
+}
+
+type WorkerDemand struct {
+	Group atc.WorkerDemandGroup
+}
+
+func (event WorkerDemand) Emit(logger lager.Logger) {
+	demands := []struct {
+		name  string
+		value int
+	}{
+		{"running_steps", event.Group.RunningSteps},
+		{"pending_builds", event.Group.PendingBuilds},
+		{"waiting_steps", event.Group.WaitingSteps},
+	}
+
+	for _, demand := range demands {
+		emit(
+			logger.Session("worker-demand"),
+			Event{
+				Name:  "worker demand",
+				Value: demand.value,
+				State: EventStateOK,
+				Attributes: map[string]string{
+					"team":     event.Group.Team,
+					"tags":     strings.Join(event.Group.Tags, ","),
+					"platform": event.Group.Platform,
+					"demand":   demand.name,
+				},
+			},
+		)
+	}
+
+	state := EventStateOK
+	if event.Group.RecommendedWorkers > event.Group.Workers {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("recommended-workers"),
+		Event{
+			Name:  "recommended workers",
+			Value: event.Group.RecommendedWorkers,
+			State: state,
+			Attributes: map[string]string{
+				"team":     event.Group.Team,
+				"tags":     strings.Join(event.Group.Tags, ","),
+				"platform": event.Group.Platform,
+			},
+		},
+	)
+}
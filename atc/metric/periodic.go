@@ -107,6 +107,48 @@ func tick(logger lager.Logger) {
 		},
 	)
 
+	emit(
+		logger.Session("secret-cache-hits"),
+		Event{
+			Name:  "secret cache hits",
+			Value: SecretCacheHits.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("secret-cache-misses"),
+		Event{
+			Name:  "secret cache misses",
+			Value: SecretCacheMisses.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	secretAccessesDropped := SecretAccessesDropped.Delta()
+	secretAccessesDroppedState := EventStateOK
+	if secretAccessesDropped > 0 {
+		secretAccessesDroppedState = EventStateCritical
+	}
+
+	emit(
+		logger.Session("secret-accesses-dropped"),
+		Event{
+			Name:  "secret accesses dropped",
+			Value: secretAccessesDropped,
+			State: secretAccessesDroppedState,
+		},
+	)
+
+	emit(
+		logger.Session("steps-waiting-for-worker"),
+		Event{
+			Name:  "steps waiting for worker",
+			Value: StepsWaitingForWorker.Max(),
+			State: EventStateOK,
+		},
+	)
+
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
@@ -80,4 +80,47 @@ var _ = Describe("Periodic emission of metrics", func() {
 			),
 		)
 	})
+
+	It("emits secret cache hits and misses", func() {
+		metric.SecretCacheHits.IncDelta(3)
+		metric.SecretCacheMisses.Inc()
+
+		Eventually(emitter.Invocations).Should(HaveKeyWithValue("Emit",
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("secret cache hits"),
+						"Value": Equal(3),
+					}),
+				),
+			),
+		))
+
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("secret cache misses"),
+						"Value": Equal(1),
+					}),
+				),
+			),
+		)
+	})
+
+	It("emits dropped secret accesses as critical", func() {
+		metric.SecretAccessesDropped.IncDelta(2)
+
+		Eventually(emitter.Invocations).Should(HaveKeyWithValue("Emit",
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("secret accesses dropped"),
+						"Value": Equal(2),
+						"State": Equal(metric.EventStateCritical),
+					}),
+				),
+			),
+		))
+	})
 })
@@ -1,14 +1,96 @@
 package atc
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
 type Pipeline struct {
-	ID       int          `json:"id"`
-	Name     string       `json:"name"`
-	Paused   bool         `json:"paused"`
-	Public   bool         `json:"public"`
-	Groups   GroupConfigs `json:"groups,omitempty"`
-	TeamName string       `json:"team_name"`
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	Paused       bool         `json:"paused"`
+	Public       bool         `json:"public"`
+	Archived     bool         `json:"archived"`
+	Groups       GroupConfigs `json:"groups,omitempty"`
+	TeamName     string       `json:"team_name"`
+}
+
+func (p Pipeline) Ref() PipelineRef {
+	return PipelineRef{
+		Name:         p.Name,
+		InstanceVars: p.InstanceVars,
+	}
 }
 
 type RenameRequest struct {
 	NewName string `json:"name"`
 }
+
+// InstanceVars distinguish multiple instances of a pipeline with the same
+// name.
+type InstanceVars map[string]interface{}
+
+// InstanceVarsQueryParam is the query parameter used to identify a pipeline
+// instance in pipeline-scoped API routes. Its value is the JSON-encoded
+// instance vars.
+const InstanceVarsQueryParam = "instance_vars"
+
+// PipelineRef identifies a pipeline by its name and instance vars. A ref with
+// no instance vars refers to the pipeline which has none.
+type PipelineRef struct {
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+}
+
+func (ref PipelineRef) String() string {
+	if len(ref.InstanceVars) == 0 {
+		return ref.Name
+	}
+
+	var names []string
+	for name := range ref.InstanceVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, name := range names {
+		payload, _ := json.Marshal(ref.InstanceVars[name])
+		pairs = append(pairs, name+":"+string(payload))
+	}
+
+	return fmt.Sprintf("%s/%s", ref.Name, strings.Join(pairs, ","))
+}
+
+// QueryParams returns the query parameters which identify the instance when
+// passed along with the pipeline name in an API route.
+func (ref PipelineRef) QueryParams() url.Values {
+	if len(ref.InstanceVars) == 0 {
+		return nil
+	}
+
+	payload, _ := json.Marshal(ref.InstanceVars)
+
+	return url.Values{InstanceVarsQueryParam: []string{string(payload)}}
+}
+
+// InstanceVarsFromQueryParams parses the instance vars given in the query
+// parameters of a pipeline-scoped API request, if any.
+func InstanceVarsFromQueryParams(params url.Values) (InstanceVars, error) {
+	payload := params.Get(InstanceVarsQueryParam)
+	if payload == "" {
+		return nil, nil
+	}
+
+	var instanceVars InstanceVars
+	err := json.Unmarshal([]byte(payload), &instanceVars)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", InstanceVarsQueryParam, err)
+	}
+
+	return instanceVars, nil
+}
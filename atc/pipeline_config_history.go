@@ -0,0 +1,18 @@
+package atc
+
+const PipelineConfigDiffQueryFrom = "from"
+
+type PipelineConfigVersion struct {
+	Version   int    `json:"version"`
+	Author    string `json:"author,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+
+	// Config is only included when fetching a single version.
+	Config *Config `json:"config,omitempty"`
+}
+
+type PipelineConfigDiff struct {
+	From    int            `json:"from"`
+	To      int            `json:"to"`
+	Changes []ConfigChange `json:"changes"`
+}
@@ -0,0 +1,61 @@
+package atc_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("PipelineRef", func() {
+	Describe("String", func() {
+		It("returns the name if there are no instance vars", func() {
+			ref := atc.PipelineRef{Name: "some-pipeline"}
+			Expect(ref.String()).To(Equal("some-pipeline"))
+		})
+
+		It("includes the instance vars sorted by name", func() {
+			ref := atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"version": 2,
+					"branch":  "feature",
+				},
+			}
+			Expect(ref.String()).To(Equal(`some-pipeline/branch:"feature",version:2`))
+		})
+	})
+
+	Describe("QueryParams", func() {
+		It("returns nothing if there are no instance vars", func() {
+			ref := atc.PipelineRef{Name: "some-pipeline"}
+			Expect(ref.QueryParams()).To(BeEmpty())
+		})
+
+		It("round-trips the instance vars", func() {
+			ref := atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "feature"},
+			}
+
+			instanceVars, err := atc.InstanceVarsFromQueryParams(ref.QueryParams())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instanceVars).To(Equal(ref.InstanceVars))
+		})
+	})
+
+	Describe("InstanceVarsFromQueryParams", func() {
+		It("returns nil if the param is not given", func() {
+			instanceVars, err := atc.InstanceVarsFromQueryParams(url.Values{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instanceVars).To(BeNil())
+		})
+
+		It("errors if the param is not a JSON object", func() {
+			_, err := atc.InstanceVarsFromQueryParams(url.Values{"instance_vars": {"bogus"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -27,6 +27,8 @@ type radarSchedulerFactory struct {
 	pool                         worker.Pool
 	resourceFactory              resource.ResourceFactory
 	resourceConfigFactory        db.ResourceConfigFactory
+	teamFactory                  db.TeamFactory
+	buildQueue                   scheduler.BuildQueue
 	resourceTypeCheckingInterval time.Duration
 	resourceCheckingInterval     time.Duration
 	strategy                     worker.ContainerPlacementStrategy
@@ -36,6 +38,8 @@ func NewRadarSchedulerFactory(
 	pool worker.Pool,
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	teamFactory db.TeamFactory,
+	buildQueue scheduler.BuildQueue,
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
 	strategy worker.ContainerPlacementStrategy,
@@ -44,6 +48,8 @@ func NewRadarSchedulerFactory(
 		pool:                         pool,
 		resourceFactory:              resourceFactory,
 		resourceConfigFactory:        resourceConfigFactory,
+		teamFactory:                  teamFactory,
+		buildQueue:                   buildQueue,
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
 		resourceCheckingInterval:     resourceCheckingInterval,
 		strategy:                     strategy,
@@ -77,11 +83,14 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline) scheduler
 		BuildStarter: scheduler.NewBuildStarter(
 			pipeline,
 			maxinflight.NewUpdater(pipeline),
+			rsf.teamFactory,
+			rsf.buildQueue,
 			factory.NewBuildFactory(
 				pipeline.ID(),
 				atc.NewPlanFactory(time.Now().Unix()),
 			),
 			inputMapper,
 		),
+		Clock: clock.NewClock(),
 	}
 }
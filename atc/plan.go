@@ -1,23 +1,29 @@
 package atc
 
+import "encoding/json"
+
 type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
-	Aggregate  *AggregatePlan  `json:"aggregate,omitempty"`
-	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
-	Do         *DoPlan         `json:"do,omitempty"`
-	Get        *GetPlan        `json:"get,omitempty"`
-	Put        *PutPlan        `json:"put,omitempty"`
-	Task       *TaskPlan       `json:"task,omitempty"`
-	OnAbort    *OnAbortPlan    `json:"on_abort,omitempty"`
-	OnError    *OnErrorPlan    `json:"on_error,omitempty"`
-	Ensure     *EnsurePlan     `json:"ensure,omitempty"`
-	OnSuccess  *OnSuccessPlan  `json:"on_success,omitempty"`
-	OnFailure  *OnFailurePlan  `json:"on_failure,omitempty"`
-	Try        *TryPlan        `json:"try,omitempty"`
-	Timeout    *TimeoutPlan    `json:"timeout,omitempty"`
-	Retry      *RetryPlan      `json:"retry,omitempty"`
+	Aggregate   *AggregatePlan   `json:"aggregate,omitempty"`
+	InParallel  *InParallelPlan  `json:"in_parallel,omitempty"`
+	Across      *AcrossPlan      `json:"across,omitempty"`
+	Do          *DoPlan          `json:"do,omitempty"`
+	Get         *GetPlan         `json:"get,omitempty"`
+	Put         *PutPlan         `json:"put,omitempty"`
+	Task        *TaskPlan        `json:"task,omitempty"`
+	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
+	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Approve     *ApprovePlan     `json:"approve,omitempty"`
+	OnAbort     *OnAbortPlan     `json:"on_abort,omitempty"`
+	OnError     *OnErrorPlan     `json:"on_error,omitempty"`
+	Ensure      *EnsurePlan      `json:"ensure,omitempty"`
+	OnSuccess   *OnSuccessPlan   `json:"on_success,omitempty"`
+	OnFailure   *OnFailurePlan   `json:"on_failure,omitempty"`
+	Try         *TryPlan         `json:"try,omitempty"`
+	Timeout     *TimeoutPlan     `json:"timeout,omitempty"`
+	Retry       *RetryPlan       `json:"retry,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -29,6 +35,123 @@ type Plan struct {
 
 type PlanID string
 
+// Each calls f with the plan and then every plan nested within it, depth
+// first.
+func (plan Plan) Each(f func(Plan)) {
+	f(plan)
+
+	for _, child := range plan.children() {
+		child.Each(f)
+	}
+}
+
+// WithIDSuffix returns a copy of the plan in which the ID of it and of every
+// plan nested within it has the given suffix, so that the same plan can be run
+// more than once in a build, e.g. for each combination of an across step.
+func (plan Plan) WithIDSuffix(suffix string) (Plan, error) {
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	var scoped Plan
+	err = json.Unmarshal(payload, &scoped)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	scoped.suffixIDs(suffix)
+
+	return scoped, nil
+}
+
+// ExpandAcross returns a copy of the plan in which the across step with the
+// given ID runs the given steps, i.e. the steps for each combination of vars
+// whose values were only known once the build was running.
+func (plan Plan) ExpandAcross(id PlanID, steps []VarScopedPlan) (Plan, bool, error) {
+	expanded, err := plan.WithIDSuffix("")
+	if err != nil {
+		return Plan{}, false, err
+	}
+
+	found := expanded.expandAcross(id, steps)
+
+	return expanded, found, nil
+}
+
+func (plan *Plan) expandAcross(id PlanID, steps []VarScopedPlan) bool {
+	if plan.ID == id && plan.Across != nil {
+		plan.Across.Steps = steps
+		return true
+	}
+
+	for _, child := range plan.children() {
+		if child.expandAcross(id, steps) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (plan *Plan) suffixIDs(suffix string) {
+	plan.ID += PlanID(suffix)
+
+	if plan.Get != nil && plan.Get.VersionFrom != nil {
+		versionFrom := *plan.Get.VersionFrom + PlanID(suffix)
+		plan.Get.VersionFrom = &versionFrom
+	}
+
+	for _, child := range plan.children() {
+		child.suffixIDs(suffix)
+	}
+}
+
+func (plan *Plan) children() []*Plan {
+	var children []*Plan
+
+	each := func(plans []Plan) {
+		for i := range plans {
+			children = append(children, &plans[i])
+		}
+	}
+
+	switch {
+	case plan.Aggregate != nil:
+		each(*plan.Aggregate)
+	case plan.InParallel != nil:
+		each(plan.InParallel.Steps)
+	case plan.Across != nil:
+		for i := range plan.Across.Steps {
+			children = append(children, &plan.Across.Steps[i].Step)
+		}
+
+		if plan.Across.Step != nil {
+			children = append(children, plan.Across.Step)
+		}
+	case plan.Do != nil:
+		each(*plan.Do)
+	case plan.Retry != nil:
+		each(*plan.Retry)
+	case plan.OnAbort != nil:
+		children = []*Plan{&plan.OnAbort.Step, &plan.OnAbort.Next}
+	case plan.OnError != nil:
+		children = []*Plan{&plan.OnError.Step, &plan.OnError.Next}
+	case plan.Ensure != nil:
+		children = []*Plan{&plan.Ensure.Step, &plan.Ensure.Next}
+	case plan.OnSuccess != nil:
+		children = []*Plan{&plan.OnSuccess.Step, &plan.OnSuccess.Next}
+	case plan.OnFailure != nil:
+		children = []*Plan{&plan.OnFailure.Step, &plan.OnFailure.Next}
+	case plan.Try != nil:
+		children = []*Plan{&plan.Try.Step}
+	case plan.Timeout != nil:
+		children = []*Plan{&plan.Timeout.Step}
+	}
+
+	return children
+}
+
 type ArtifactInputPlan struct {
 	ArtifactID int    `json:"artifact_id"`
 	Name       string `json:"name"`
@@ -80,6 +203,32 @@ type InParallelPlan struct {
 	FailFast bool   `json:"fail_fast,omitempty"`
 }
 
+// AcrossPlan runs a step once for each combination of its vars' values.
+// Steps holds one plan per combination, in order, each alongside the value of
+// every var in Vars.
+//
+// If any of the vars' values are only known once the build runs, Steps is
+// empty and Step is instead the plan which is run for every combination.
+type AcrossPlan struct {
+	Vars     []AcrossVar     `json:"vars"`
+	Steps    []VarScopedPlan `json:"steps"`
+	Step     *Plan           `json:"step,omitempty"`
+	FailFast bool            `json:"fail_fast,omitempty"`
+}
+
+type AcrossVar struct {
+	Var         string        `json:"name"`
+	Values      []interface{} `json:"values"`
+	ValuesFile  string        `json:"values_file,omitempty"`
+	ValuesVar   string        `json:"values_var,omitempty"`
+	MaxInFlight int           `json:"max_in_flight,omitempty"`
+}
+
+type VarScopedPlan struct {
+	Step   Plan          `json:"step"`
+	Values []interface{} `json:"values"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -125,6 +274,24 @@ type TaskPlan struct {
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
+type SetPipelinePlan struct {
+	Name     string   `json:"name"`
+	File     string   `json:"file"`
+	Vars     Params   `json:"vars,omitempty"`
+	VarFiles []string `json:"var_files,omitempty"`
+}
+
+type LoadVarPlan struct {
+	Name      string `json:"name"`
+	File      string `json:"file"`
+	Format    string `json:"format,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+}
+
+type ApprovePlan struct {
+	Name string `json:"name"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
@@ -29,6 +29,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Aggregate = &t
 	case InParallelPlan:
 		plan.InParallel = &t
+	case AcrossPlan:
+		plan.Across = &t
 	case DoPlan:
 		plan.Do = &t
 	case GetPlan:
@@ -37,6 +39,12 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Put = &t
 	case TaskPlan:
 		plan.Task = &t
+	case SetPipelinePlan:
+		plan.SetPipeline = &t
+	case LoadVarPlan:
+		plan.LoadVar = &t
+	case ApprovePlan:
+		plan.Approve = &t
 	case OnAbortPlan:
 		plan.OnAbort = &t
 	case OnErrorPlan:
@@ -0,0 +1,125 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan", func() {
+	Describe("Each", func() {
+		It("visits every nested plan depth first", func() {
+			plan := atc.Plan{
+				ID: "0",
+				Do: &atc.DoPlan{
+					{
+						ID: "1",
+						Timeout: &atc.TimeoutPlan{
+							Duration: "1h",
+							Step: atc.Plan{
+								ID:      "2",
+								Approve: &atc.ApprovePlan{Name: "deploy-prod"},
+							},
+						},
+					},
+					{
+						ID: "3",
+						OnSuccess: &atc.OnSuccessPlan{
+							Step: atc.Plan{ID: "4", Task: &atc.TaskPlan{Name: "deploy"}},
+							Next: atc.Plan{
+								ID: "5",
+								InParallel: &atc.InParallelPlan{
+									Steps: []atc.Plan{
+										{ID: "6", Task: &atc.TaskPlan{Name: "smoke-test"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			var ids []atc.PlanID
+			plan.Each(func(p atc.Plan) {
+				ids = append(ids, p.ID)
+			})
+
+			Expect(ids).To(Equal([]atc.PlanID{"0", "1", "2", "3", "4", "5", "6"}))
+		})
+	})
+
+	Describe("WithIDSuffix", func() {
+		var plan atc.Plan
+
+		BeforeEach(func() {
+			versionFrom := atc.PlanID("2")
+
+			plan = atc.Plan{
+				ID: "0",
+				Do: &atc.DoPlan{
+					{ID: "1", Put: &atc.PutPlan{Name: "some-output"}},
+					{ID: "2", Get: &atc.GetPlan{Name: "some-output", VersionFrom: &versionFrom}},
+				},
+			}
+		})
+
+		It("suffixes the IDs of the plan and every nested plan", func() {
+			scoped, err := plan.WithIDSuffix("/1")
+			Expect(err).ToNot(HaveOccurred())
+
+			var ids []atc.PlanID
+			scoped.Each(func(p atc.Plan) {
+				ids = append(ids, p.ID)
+			})
+
+			Expect(ids).To(Equal([]atc.PlanID{"0/1", "1/1", "2/1"}))
+			Expect(*(*scoped.Do)[1].Get.VersionFrom).To(Equal(atc.PlanID("2/1")))
+		})
+
+		It("does not change the original plan", func() {
+			_, err := plan.WithIDSuffix("/1")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect((*plan.Do)[0].ID).To(Equal(atc.PlanID("1")))
+			Expect(*(*plan.Do)[1].Get.VersionFrom).To(Equal(atc.PlanID("2")))
+		})
+	})
+
+	Describe("ExpandAcross", func() {
+		var plan atc.Plan
+
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "0",
+				Do: &atc.DoPlan{
+					{
+						ID: "1",
+						Across: &atc.AcrossPlan{
+							Vars: []atc.AcrossVar{{Var: "v", ValuesVar: "vs"}},
+							Step: &atc.Plan{ID: "2", Approve: &atc.ApprovePlan{Name: "some-approval"}},
+						},
+					},
+				},
+			}
+		})
+
+		It("sets the steps of the across step", func() {
+			steps := []atc.VarScopedPlan{
+				{Step: atc.Plan{ID: "2/0", Approve: &atc.ApprovePlan{Name: "some-approval"}}, Values: []interface{}{"a"}},
+			}
+
+			expanded, found, err := plan.ExpandAcross("1", steps)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect((*expanded.Do)[0].Across.Steps).To(Equal(steps))
+
+			Expect((*plan.Do)[0].Across.Steps).To(BeEmpty())
+		})
+
+		It("does not find steps which are not across steps", func() {
+			_, found, err := plan.ExpandAcross("0", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -154,6 +154,7 @@ func (runner *Runner) OpenConn() db.Conn {
 		runner.DataSourceName(),
 		nil,
 		nil,
+		nil,
 		"postgresrunner",
 		nil,
 	)
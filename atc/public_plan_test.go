@@ -340,6 +340,31 @@ var _ = Describe("Plan", func() {
 							},
 						},
 					},
+					atc.Plan{
+						ID: "38",
+						Across: &atc.AcrossPlan{
+							Vars: []atc.AcrossVar{
+								{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 1},
+							},
+							FailFast: true,
+							Steps: []atc.VarScopedPlan{
+								{
+									Step: atc.Plan{
+										ID:   "39",
+										Task: &atc.TaskPlan{Name: "name", Vars: atc.Params{"some": "secret"}},
+									},
+									Values: []interface{}{"1.12"},
+								},
+								{
+									Step: atc.Plan{
+										ID:   "40",
+										Task: &atc.TaskPlan{Name: "name", Vars: atc.Params{"some": "secret"}},
+									},
+									Values: []interface{}{"1.13"},
+								},
+							},
+						},
+					},
 				},
 			}
 
@@ -591,6 +616,35 @@ var _ = Describe("Plan", func() {
 				"limit": 1,
 				"fail_fast": true
 			}
+		},
+		{
+			"id": "38",
+			"across": {
+				"vars": ["go"],
+				"steps": [
+					{
+						"step": {
+							"id": "39",
+							"task": {
+								"name": "name",
+								"privileged": false
+							}
+						},
+						"values": ["1.12"]
+					},
+					{
+						"step": {
+							"id": "40",
+							"task": {
+								"name": "name",
+								"privileged": false
+							}
+						},
+						"values": ["1.13"]
+					}
+				],
+				"fail_fast": true
+			}
 		}
   ]
 }
@@ -324,6 +324,7 @@ func (scanner *resourceScanner) check(
 	chosenWorker, err := scanner.pool.FindOrChooseWorkerForContainer(
 		context.Background(),
 		logger,
+		nil,
 		owner,
 		containerSpec,
 		workerSpec,
@@ -259,6 +259,7 @@ func (scanner *resourceTypeScanner) check(
 	chosenWorker, err := scanner.pool.FindOrChooseWorkerForContainer(
 		context.Background(),
 		logger,
+		nil,
 		owner,
 		containerSpec,
 		workerSpec,
@@ -27,6 +27,7 @@ type scannerFactory struct {
 	resourceCheckingInterval     time.Duration
 	externalURL                  string
 	secretManager                creds.Secrets
+	varSourcePool                creds.VarSourcePool
 	strategy                     worker.ContainerPlacementStrategy
 }
 
@@ -43,6 +44,7 @@ func NewScannerFactory(
 	resourceCheckingInterval time.Duration,
 	externalURL string,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	strategy worker.ContainerPlacementStrategy,
 ) ScannerFactory {
 	return &scannerFactory{
@@ -53,12 +55,13 @@ func NewScannerFactory(
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
 		externalURL:                  externalURL,
 		secretManager:                secretManager,
+		varSourcePool:                varSourcePool,
 		strategy:                     strategy,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
-	variables := creds.NewVariables(f.secretManager, dbPipeline.TeamName(), dbPipeline.Name())
+	variables := creds.NewPipelineVariables(f.secretManager, f.varSourcePool, dbPipeline.VarSources(), dbPipeline.TeamName(), dbPipeline.Name())
 
 	return NewResourceScanner(
 		clock.NewClock(),
@@ -74,7 +77,7 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 }
 
 func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner {
-	variables := creds.NewVariables(f.secretManager, dbPipeline.TeamName(), dbPipeline.Name())
+	variables := creds.NewPipelineVariables(f.secretManager, f.varSourcePool, dbPipeline.VarSources(), dbPipeline.TeamName(), dbPipeline.Name())
 
 	return NewResourceTypeScanner(
 		clock.NewClock(),
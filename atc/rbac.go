@@ -0,0 +1,64 @@
+package atc
+
+const (
+	OwnerRole            = "owner"
+	MemberRole           = "member"
+	PipelineOperatorRole = "pipeline-operator"
+	ViewerRole           = "viewer"
+)
+
+// Roles lists the team roles from most to least privileged. Each role is
+// granted every action permitted to the roles after it.
+var Roles = []string{
+	OwnerRole,
+	MemberRole,
+	PipelineOperatorRole,
+	ViewerRole,
+}
+
+// IsValidRole returns true if the role is one of Roles.
+func IsValidRole(role string) bool {
+	return roleRank(role) != -1
+}
+
+// RoleGrants returns true if a user with the given role may perform an action
+// which requires requiredRole.
+func RoleGrants(role string, requiredRole string) bool {
+	rank := roleRank(role)
+	requiredRank := roleRank(requiredRole)
+
+	return rank != -1 && requiredRank != -1 && rank <= requiredRank
+}
+
+func roleRank(role string) int {
+	for i, r := range Roles {
+		if r == role {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// RBACInfo describes the role-based access control policy in effect, i.e.
+// the roles known to the ATC and the minimum role required for each action.
+type RBACInfo struct {
+	Roles   []string          `json:"roles"`
+	Actions map[string]string `json:"actions"`
+}
+
+// PermittedActions returns the actions which a user with the given roles may
+// perform.
+func (info RBACInfo) PermittedActions(roles []string) []string {
+	var actions []string
+	for action, requiredRole := range info.Actions {
+		for _, role := range roles {
+			if RoleGrants(role, requiredRole) {
+				actions = append(actions, action)
+				break
+			}
+		}
+	}
+
+	return actions
+}
@@ -0,0 +1,41 @@
+package atc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("RBAC", func() {
+	DescribeTable("RoleGrants",
+		func(role, requiredRole string, granted bool) {
+			Expect(atc.RoleGrants(role, requiredRole)).To(Equal(granted))
+		},
+		Entry("owner :: owner", "owner", "owner", true),
+		Entry("owner :: viewer", "owner", "viewer", true),
+		Entry("member :: owner", "member", "owner", false),
+		Entry("member :: pipeline-operator", "member", "pipeline-operator", true),
+		Entry("viewer :: pipeline-operator", "viewer", "pipeline-operator", false),
+		Entry("viewer :: viewer", "viewer", "viewer", true),
+		Entry("unknown role", "some-role", "viewer", false),
+		Entry("unknown required role", "owner", "some-role", false),
+	)
+
+	Describe("RBACInfo.PermittedActions", func() {
+		It("returns the actions permitted by any of the roles", func() {
+			info := atc.RBACInfo{
+				Roles: atc.Roles,
+				Actions: map[string]string{
+					"SetTeam":    "owner",
+					"SaveConfig": "member",
+					"GetConfig":  "viewer",
+				},
+			}
+
+			Expect(info.PermittedActions([]string{"viewer", "member"})).To(ConsistOf("SaveConfig", "GetConfig"))
+			Expect(info.PermittedActions([]string{"some-role"})).To(BeEmpty())
+		})
+	})
+})
@@ -6,6 +6,10 @@ const (
 	SaveConfig = "SaveConfig"
 	GetConfig  = "GetConfig"
 
+	ListPipelineConfigHistory = "ListPipelineConfigHistory"
+	GetPipelineConfigVersion  = "GetPipelineConfigVersion"
+	DiffPipelineConfigVersion = "DiffPipelineConfigVersion"
+
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
 	CreateBuild         = "CreateBuild"
@@ -13,20 +17,22 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	ApproveBuild        = "ApproveBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	ListAllJobs    = "ListAllJobs"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	GetJob             = "GetJob"
+	CreateJobBuild     = "CreateJobBuild"
+	ListAllJobs        = "ListAllJobs"
+	ListJobs           = "ListJobs"
+	ListJobBuilds      = "ListJobBuilds"
+	ListJobInputs      = "ListJobInputs"
+	GetJobBuild        = "GetJobBuild"
+	SearchJobBuildLogs = "SearchJobBuildLogs"
+	PauseJob           = "PauseJob"
+	UnpauseJob         = "UnpauseJob"
+	GetVersionsDB      = "GetVersionsDB"
+	JobBadge           = "JobBadge"
+	MainJobBadge       = "MainJobBadge"
 
 	ClearTaskCache = "ClearTaskCache"
 
@@ -58,6 +64,7 @@ const (
 	OrderPipelines      = "OrderPipelines"
 	PausePipeline       = "PausePipeline"
 	UnpausePipeline     = "UnpausePipeline"
+	ArchivePipeline     = "ArchivePipeline"
 	ExposePipeline      = "ExposePipeline"
 	HidePipeline        = "HidePipeline"
 	RenamePipeline      = "RenamePipeline"
@@ -72,6 +79,9 @@ const (
 	HeartbeatWorker = "HeartbeatWorker"
 	ListWorkers     = "ListWorkers"
 	DeleteWorker    = "DeleteWorker"
+	GetWorkerDemand = "GetWorkerDemand"
+
+	ListBuildQueue = "ListBuildQueue"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -79,6 +89,7 @@ const (
 	DownloadCLI  = "DownloadCLI"
 	GetInfo      = "Info"
 	GetInfoCreds = "InfoCreds"
+	GetInfoRBAC  = "InfoRBAC"
 
 	ListContainers           = "ListContainers"
 	GetContainer             = "GetContainer"
@@ -96,10 +107,23 @@ const (
 	RenameTeam     = "RenameTeam"
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
+	GetTeamUsage   = "GetTeamUsage"
 
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
+
+	SetWebhook     = "SetWebhook"
+	DestroyWebhook = "DestroyWebhook"
+	ReceiveWebhook = "ReceiveWebhook"
+
+	ListSecrets           = "ListSecrets"
+	SetSecret             = "SetSecret"
+	DestroySecret         = "DestroySecret"
+	SetPipelineSecret     = "SetPipelineSecret"
+	DestroyPipelineSecret = "DestroyPipelineSecret"
+	ListSecretAccesses    = "ListSecretAccesses"
+	PurgeSecretCache      = "PurgeSecretCache"
 )
 
 const (
@@ -110,6 +134,9 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/history", Method: "GET", Name: ListPipelineConfigHistory},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/history/:config_version", Method: "GET", Name: GetPipelineConfigVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/history/:config_version/diff", Method: "GET", Name: DiffPipelineConfigVersion},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -119,6 +146,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/approvals/:plan_id", Method: "PUT", Name: ApproveBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
 
@@ -128,6 +156,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/search", Method: "GET", Name: SearchJobBuildLogs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
@@ -143,6 +172,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/archive", Method: "PUT", Name: ArchivePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/expose", Method: "PUT", Name: ExposePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
@@ -173,6 +203,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/cc.xml", Method: "GET", Name: GetCC},
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
+	{Path: "/api/v1/workers/demand", Method: "GET", Name: GetWorkerDemand},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
 	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
 	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
@@ -180,12 +211,15 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
 
+	{Path: "/api/v1/queue", Method: "GET", Name: ListBuildQueue},
+
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
 
 	{Path: "/api/v1/cli", Method: "GET", Name: DownloadCLI},
 	{Path: "/api/v1/info", Method: "GET", Name: GetInfo},
 	{Path: "/api/v1/info/creds", Method: "GET", Name: GetInfoCreds},
+	{Path: "/api/v1/info/rbac", Method: "GET", Name: GetInfoRBAC},
 
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
@@ -203,7 +237,20 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/usage", Method: "GET", Name: GetTeamUsage},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
+
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "PUT", Name: SetWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "DELETE", Name: DestroyWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "POST", Name: ReceiveWebhook},
+
+	{Path: "/api/v1/teams/:team_name/secrets", Method: "GET", Name: ListSecrets},
+	{Path: "/api/v1/teams/:team_name/secrets/:secret_name", Method: "PUT", Name: SetSecret},
+	{Path: "/api/v1/teams/:team_name/secrets/:secret_name", Method: "DELETE", Name: DestroySecret},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/secrets/:secret_name", Method: "PUT", Name: SetPipelineSecret},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/secrets/:secret_name", Method: "DELETE", Name: DestroyPipelineSecret},
+	{Path: "/api/v1/secret-accesses", Method: "GET", Name: ListSecretAccesses},
+	{Path: "/api/v1/teams/:team_name/secret-cache", Method: "DELETE", Name: PurgeSecretCache},
 })
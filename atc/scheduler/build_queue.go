@@ -0,0 +1,251 @@
+package scheduler
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . BuildQueue
+
+// BuildQueue decides which pending builds may start while the workers are at
+// capacity. Builds that cannot start yet wait in a queue shared by every
+// pipeline, ordered by job priority and then by fair share between teams.
+type BuildQueue interface {
+	// Admit returns true if the build may be scheduled now. If not, the build
+	// is left in the queue until its turn comes up.
+	Admit(logger lager.Logger, build db.Build) (bool, error)
+
+	// Remove takes the build out of the queue once it has been scheduled or
+	// is no longer pending.
+	Remove(logger lager.Logger, build db.Build) error
+
+	Status(logger lager.Logger) (atc.BuildQueue, error)
+}
+
+// NewBuildQueue returns a BuildQueue which considers a worker to be at
+// capacity once it has containersPerWorker active containers. If
+// containersPerWorker is 0 workers are never at capacity and every build is
+// admitted straight away.
+func NewBuildQueue(
+	queue db.BuildQueue,
+	workerFactory db.WorkerFactory,
+	containersPerWorker int,
+) BuildQueue {
+	return &buildQueue{
+		queue:               queue,
+		workerFactory:       workerFactory,
+		containersPerWorker: containersPerWorker,
+	}
+}
+
+type buildQueue struct {
+	queue               db.BuildQueue
+	workerFactory       db.WorkerFactory
+	containersPerWorker int
+}
+
+func (q *buildQueue) Admit(logger lager.Logger, build db.Build) (bool, error) {
+	if q.containersPerWorker <= 0 {
+		return true, nil
+	}
+
+	logger = logger.Session("admit")
+
+	_, withCapacity, err := q.workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return false, err
+	}
+
+	err = q.queue.Enqueue(build.ID())
+	if err != nil {
+		logger.Error("failed-to-enqueue-build", err)
+		return false, err
+	}
+
+	entries, err := q.orderedEntries()
+	if err != nil {
+		logger.Error("failed-to-get-queue", err)
+		return false, err
+	}
+
+	for position, entry := range entries {
+		if position >= withCapacity {
+			break
+		}
+
+		if entry.BuildID == build.ID() {
+			return true, nil
+		}
+	}
+
+	logger.Debug("waiting-for-worker-capacity", lager.Data{
+		"workers-with-capacity": withCapacity,
+		"queued-builds":         len(entries),
+	})
+
+	return false, nil
+}
+
+func (q *buildQueue) Remove(logger lager.Logger, build db.Build) error {
+	if q.containersPerWorker <= 0 {
+		return nil
+	}
+
+	err := q.queue.Dequeue(build.ID())
+	if err != nil {
+		logger.Error("failed-to-dequeue-build", err)
+		return err
+	}
+
+	return nil
+}
+
+func (q *buildQueue) Status(logger lager.Logger) (atc.BuildQueue, error) {
+	status := atc.BuildQueue{
+		Entries: []atc.BuildQueueEntry{},
+	}
+
+	if q.containersPerWorker <= 0 {
+		return status, nil
+	}
+
+	running, withCapacity, err := q.workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return atc.BuildQueue{}, err
+	}
+
+	entries, err := q.orderedEntries()
+	if err != nil {
+		logger.Error("failed-to-get-queue", err)
+		return atc.BuildQueue{}, err
+	}
+
+	status.Workers = running
+	status.WorkersWithCapacity = withCapacity
+
+	for position, entry := range entries {
+		status.Entries = append(status.Entries, atc.BuildQueueEntry{
+			Position:     position + 1,
+			BuildID:      entry.BuildID,
+			BuildName:    entry.BuildName,
+			TeamName:     entry.TeamName,
+			PipelineName: entry.PipelineName,
+			JobName:      entry.JobName,
+			Priority:     entry.Priority,
+			QueuedAt:     entry.QueuedAt.Unix(),
+		})
+	}
+
+	return status, nil
+}
+
+func (q *buildQueue) workers() (int, int, error) {
+	workers, err := q.workerFactory.Workers()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var running, withCapacity int
+	for _, worker := range workers {
+		if worker.State() != db.WorkerStateRunning {
+			continue
+		}
+
+		running++
+
+		if worker.ActiveContainers() < q.containersPerWorker {
+			withCapacity++
+		}
+	}
+
+	return running, withCapacity, nil
+}
+
+func (q *buildQueue) orderedEntries() ([]db.BuildQueueEntry, error) {
+	entries, err := q.queue.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	runningByTeam, err := q.queue.RunningBuildsByTeam()
+	if err != nil {
+		return nil, err
+	}
+
+	return orderQueue(entries, runningByTeam), nil
+}
+
+// orderQueue returns the entries in the order they should be started. Builds
+// of higher priority jobs always go first. Between builds of equal priority,
+// the team with the fewest builds running or already ahead in the queue goes
+// next, so that one busy team cannot starve the others. Each team's own
+// builds keep the order they were created in.
+func orderQueue(entries []db.BuildQueueEntry, runningByTeam map[int]int) []db.BuildQueueEntry {
+	byTeam := map[int][]db.BuildQueueEntry{}
+	teamIDs := []int{}
+	for _, entry := range entries {
+		if _, found := byTeam[entry.TeamID]; !found {
+			teamIDs = append(teamIDs, entry.TeamID)
+		}
+
+		byTeam[entry.TeamID] = append(byTeam[entry.TeamID], entry)
+	}
+
+	load := map[int]int{}
+	for teamID, count := range runningByTeam {
+		load[teamID] = count
+	}
+
+	ordered := make([]db.BuildQueueEntry, 0, len(entries))
+	for len(ordered) < len(entries) {
+		next := -1
+		for _, teamID := range teamIDs {
+			if len(byTeam[teamID]) == 0 {
+				continue
+			}
+
+			if next == -1 || queuedBefore(byTeam[teamID], load[teamID], byTeam[next], load[next]) {
+				next = teamID
+			}
+		}
+
+		head, nextPosition := headOfTeam(byTeam[next])
+		ordered = append(ordered, head)
+		byTeam[next] = append(byTeam[next][:nextPosition], byTeam[next][nextPosition+1:]...)
+		load[next]++
+	}
+
+	return ordered
+}
+
+// headOfTeam returns the team's highest priority entry, picking the oldest
+// build between entries of equal priority.
+func headOfTeam(entries []db.BuildQueueEntry) (db.BuildQueueEntry, int) {
+	position := 0
+	for i, entry := range entries {
+		head := entries[position]
+		if entry.Priority > head.Priority || (entry.Priority == head.Priority && entry.BuildID < head.BuildID) {
+			position = i
+		}
+	}
+
+	return entries[position], position
+}
+
+func queuedBefore(entries []db.BuildQueueEntry, load int, otherEntries []db.BuildQueueEntry, otherLoad int) bool {
+	head, _ := headOfTeam(entries)
+	otherHead, _ := headOfTeam(otherEntries)
+
+	if head.Priority != otherHead.Priority {
+		return head.Priority > otherHead.Priority
+	}
+
+	if load != otherLoad {
+		return load < otherLoad
+	}
+
+	return head.BuildID < otherHead.BuildID
+}
@@ -0,0 +1,294 @@
+package scheduler_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/scheduler"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildQueue", func() {
+	var (
+		fakeQueue           *dbfakes.FakeBuildQueue
+		fakeWorkerFactory   *dbfakes.FakeWorkerFactory
+		containersPerWorker int
+
+		queuedAt time.Time
+		logger   *lagertest.TestLogger
+		disaster error
+
+		buildQueue scheduler.BuildQueue
+	)
+
+	worker := func(state db.WorkerState, activeContainers int) db.Worker {
+		worker := new(dbfakes.FakeWorker)
+		worker.StateReturns(state)
+		worker.ActiveContainersReturns(activeContainers)
+		return worker
+	}
+
+	entry := func(buildID int, teamID int, priority int) db.BuildQueueEntry {
+		return db.BuildQueueEntry{
+			BuildID:      buildID,
+			BuildName:    "1",
+			JobName:      "some-job",
+			PipelineName: "some-pipeline",
+			TeamID:       teamID,
+			TeamName:     "some-team",
+			Priority:     priority,
+			QueuedAt:     queuedAt,
+		}
+	}
+
+	build := func(id int) db.Build {
+		build := new(dbfakes.FakeBuild)
+		build.IDReturns(id)
+		return build
+	}
+
+	buildIDs := func(status atc.BuildQueue) []int {
+		ids := []int{}
+		for _, entry := range status.Entries {
+			ids = append(ids, entry.BuildID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		fakeQueue = new(dbfakes.FakeBuildQueue)
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		containersPerWorker = 10
+
+		queuedAt = time.Unix(1565703016, 0)
+		logger = lagertest.NewTestLogger("test")
+		disaster = errors.New("nope")
+
+		fakeWorkerFactory.WorkersReturns([]db.Worker{
+			worker(db.WorkerStateRunning, 3),
+			worker(db.WorkerStateRunning, 10),
+			worker(db.WorkerStateRunning, 9),
+			worker(db.WorkerStateStalled, 0),
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		buildQueue = scheduler.NewBuildQueue(fakeQueue, fakeWorkerFactory, containersPerWorker)
+	})
+
+	Describe("Admit", func() {
+		var (
+			admitted bool
+			admitErr error
+		)
+
+		BeforeEach(func() {
+			fakeQueue.EntriesReturns([]db.BuildQueueEntry{
+				entry(1, 1, 0),
+				entry(2, 1, 0),
+				entry(3, 2, 0),
+			}, nil)
+			fakeQueue.RunningBuildsByTeamReturns(map[int]int{1: 5}, nil)
+		})
+
+		Context("when the build is within the number of workers with capacity", func() {
+			JustBeforeEach(func() {
+				admitted, admitErr = buildQueue.Admit(logger, build(1))
+			})
+
+			It("queues the build", func() {
+				Expect(fakeQueue.EnqueueCallCount()).To(Equal(1))
+				Expect(fakeQueue.EnqueueArgsForCall(0)).To(Equal(1))
+			})
+
+			It("admits the build", func() {
+				Expect(admitErr).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+		})
+
+		Context("when other teams' builds are ahead of it", func() {
+			JustBeforeEach(func() {
+				admitted, admitErr = buildQueue.Admit(logger, build(2))
+			})
+
+			It("leaves the build waiting in the queue", func() {
+				Expect(admitErr).NotTo(HaveOccurred())
+				Expect(admitted).To(BeFalse())
+			})
+		})
+
+		Context("when capacity is not limited", func() {
+			BeforeEach(func() {
+				containersPerWorker = 0
+			})
+
+			JustBeforeEach(func() {
+				admitted, admitErr = buildQueue.Admit(logger, build(2))
+			})
+
+			It("admits the build without queueing it", func() {
+				Expect(admitErr).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+				Expect(fakeQueue.EnqueueCallCount()).To(BeZero())
+				Expect(fakeWorkerFactory.WorkersCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when getting the workers fails", func() {
+			BeforeEach(func() {
+				fakeWorkerFactory.WorkersReturns(nil, disaster)
+			})
+
+			JustBeforeEach(func() {
+				admitted, admitErr = buildQueue.Admit(logger, build(1))
+			})
+
+			It("returns the error", func() {
+				Expect(admitErr).To(Equal(disaster))
+				Expect(admitted).To(BeFalse())
+			})
+		})
+
+		Context("when queueing the build fails", func() {
+			BeforeEach(func() {
+				fakeQueue.EnqueueReturns(disaster)
+			})
+
+			JustBeforeEach(func() {
+				admitted, admitErr = buildQueue.Admit(logger, build(1))
+			})
+
+			It("returns the error", func() {
+				Expect(admitErr).To(Equal(disaster))
+				Expect(admitted).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Remove", func() {
+		var removeErr error
+
+		JustBeforeEach(func() {
+			removeErr = buildQueue.Remove(logger, build(42))
+		})
+
+		It("dequeues the build", func() {
+			Expect(removeErr).NotTo(HaveOccurred())
+			Expect(fakeQueue.DequeueCallCount()).To(Equal(1))
+			Expect(fakeQueue.DequeueArgsForCall(0)).To(Equal(42))
+		})
+
+		Context("when dequeueing fails", func() {
+			BeforeEach(func() {
+				fakeQueue.DequeueReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(removeErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when capacity is not limited", func() {
+			BeforeEach(func() {
+				containersPerWorker = 0
+			})
+
+			It("does not touch the queue", func() {
+				Expect(removeErr).NotTo(HaveOccurred())
+				Expect(fakeQueue.DequeueCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("Status", func() {
+		var (
+			status    atc.BuildQueue
+			statusErr error
+		)
+
+		JustBeforeEach(func() {
+			status, statusErr = buildQueue.Status(logger)
+		})
+
+		It("counts the running workers and those with capacity", func() {
+			Expect(statusErr).NotTo(HaveOccurred())
+			Expect(status.Workers).To(Equal(3))
+			Expect(status.WorkersWithCapacity).To(Equal(2))
+		})
+
+		Context("when builds are queued", func() {
+			BeforeEach(func() {
+				fakeQueue.EntriesReturns([]db.BuildQueueEntry{
+					entry(1, 1, 0),
+					entry(2, 1, 0),
+					entry(3, 1, 0),
+					entry(4, 2, 0),
+					entry(5, 2, 0),
+				}, nil)
+				fakeQueue.RunningBuildsByTeamReturns(map[int]int{2: 1}, nil)
+			})
+
+			It("shares the queue fairly between teams", func() {
+				Expect(buildIDs(status)).To(Equal([]int{1, 2, 4, 3, 5}))
+			})
+
+			It("numbers the entries from 1", func() {
+				Expect(status.Entries[0]).To(Equal(atc.BuildQueueEntry{
+					Position:     1,
+					BuildID:      1,
+					BuildName:    "1",
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					Priority:     0,
+					QueuedAt:     1565703016,
+				}))
+				Expect(status.Entries[4].Position).To(Equal(5))
+			})
+
+			Context("when some jobs have a higher priority", func() {
+				BeforeEach(func() {
+					fakeQueue.EntriesReturns([]db.BuildQueueEntry{
+						entry(1, 1, 0),
+						entry(2, 1, 0),
+						entry(3, 1, 10),
+						entry(4, 2, 0),
+						entry(5, 2, 5),
+					}, nil)
+				})
+
+				It("puts higher priority builds first", func() {
+					Expect(buildIDs(status)).To(Equal([]int{3, 5, 1, 2, 4}))
+				})
+			})
+		})
+
+		Context("when getting the queue fails", func() {
+			BeforeEach(func() {
+				fakeQueue.EntriesReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(statusErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when capacity is not limited", func() {
+			BeforeEach(func() {
+				containersPerWorker = 0
+			})
+
+			It("returns an empty queue", func() {
+				Expect(statusErr).NotTo(HaveOccurred())
+				Expect(status).To(Equal(atc.BuildQueue{Entries: []atc.BuildQueueEntry{}}))
+			})
+		})
+	})
+})
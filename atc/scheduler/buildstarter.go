@@ -29,12 +29,16 @@ type BuildFactory interface {
 func NewBuildStarter(
 	pipeline db.Pipeline,
 	maxInFlightUpdater maxinflight.Updater,
+	teamFactory db.TeamFactory,
+	buildQueue BuildQueue,
 	factory BuildFactory,
 	inputMapper inputmapper.InputMapper,
 ) BuildStarter {
 	return &buildStarter{
 		pipeline:           pipeline,
 		maxInFlightUpdater: maxInFlightUpdater,
+		teamFactory:        teamFactory,
+		buildQueue:         buildQueue,
 		factory:            factory,
 		inputMapper:        inputMapper,
 	}
@@ -43,6 +47,8 @@ func NewBuildStarter(
 type buildStarter struct {
 	pipeline           db.Pipeline
 	maxInFlightUpdater maxinflight.Updater
+	teamFactory        db.TeamFactory
+	buildQueue         BuildQueue
 	factory            BuildFactory
 	inputMapper        inputmapper.InputMapper
 }
@@ -87,78 +93,46 @@ func (s *buildStarter) tryStartNextPendingBuild(
 			return false, err
 		}
 
+		err = s.buildQueue.Remove(logger, nextPendingBuild)
+		if err != nil {
+			return false, err
+		}
+
 		return true, nil
 	}
 
-	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, job, nextPendingBuild.ID())
+	buildInputs, resourceTypes, ready, err := s.readyToStart(logger, nextPendingBuild, job, resources, resourceTypes)
 	if err != nil {
 		return false, err
 	}
-	if reachedMaxInFlight {
-		return false, nil
-	}
-
-	if nextPendingBuild.IsManuallyTriggered() {
-		for _, input := range job.Config().Inputs() {
-			resource, found := resources.Lookup(input.Resource)
-
-			if !found {
-				logger.Debug("failed-to-find-resource")
-				return false, nil
-			}
-
-			if resource.CurrentPinnedVersion() != nil {
-				continue
-			}
-
-			if resource.LastCheckEndTime().Before(nextPendingBuild.CreateTime()) {
-				return false, nil
-			}
-		}
-
-		versions, err := s.pipeline.LoadVersionsDB()
+	if !ready {
+		// the build only keeps its place in the build queue while it is
+		// waiting for worker capacity, so that it does not hold back the
+		// builds queued behind it in the meantime
+		err = s.buildQueue.Remove(logger, nextPendingBuild)
 		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
 			return false, err
 		}
 
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job, resources)
-		if err != nil {
-			return false, err
-		}
-
-		dbResourceTypes, err := s.pipeline.ResourceTypes()
-		if err != nil {
-			return false, err
-		}
-		resourceTypes = dbResourceTypes.Deserialize()
+		return false, nil
 	}
 
-	buildInputs, found, err := job.GetNextBuildInputs()
+	admitted, err := s.buildQueue.Admit(logger, nextPendingBuild)
 	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
 		return false, err
 	}
-	if !found {
+	if !admitted {
 		return false, nil
 	}
 
-	pipelinePaused, err := s.pipeline.CheckPaused()
+	updated, err := nextPendingBuild.Schedule()
 	if err != nil {
-		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		logger.Error("failed-to-update-build-to-scheduled", err)
 		return false, err
 	}
-	if pipelinePaused {
-		return false, nil
-	}
 
-	if job.Paused() {
-		return false, nil
-	}
-
-	updated, err := nextPendingBuild.Schedule()
+	err = s.buildQueue.Remove(logger, nextPendingBuild)
 	if err != nil {
-		logger.Error("failed-to-update-build-to-scheduled", err)
 		return false, err
 	}
 
@@ -206,3 +180,94 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	return true, nil
 }
+
+// readyToStart returns the build's inputs and the resource types to run it
+// with if nothing but the capacity of the workers keeps it from starting.
+func (s *buildStarter) readyToStart(
+	logger lager.Logger,
+	nextPendingBuild db.Build,
+	job db.Job,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) ([]db.BuildInput, atc.VersionedResourceTypes, bool, error) {
+	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, job, nextPendingBuild.ID())
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if reachedMaxInFlight {
+		return nil, nil, false, nil
+	}
+
+	usage, err := s.teamFactory.GetByID(job.TeamID()).Usage()
+	if err != nil {
+		logger.Error("failed-to-get-team-usage", err)
+		return nil, nil, false, err
+	}
+	if usage.RunningBuildsQuotaReached() {
+		logger.Debug("team-max-running-builds-reached", lager.Data{
+			"running-builds":     usage.RunningBuilds,
+			"max-running-builds": usage.Quotas.MaxRunningBuilds,
+		})
+		return nil, nil, false, nil
+	}
+
+	if nextPendingBuild.IsManuallyTriggered() || nextPendingBuild.IsScheduleTriggered() {
+		for _, input := range job.Config().Inputs() {
+			resource, found := resources.Lookup(input.Resource)
+
+			if !found {
+				logger.Debug("failed-to-find-resource")
+				return nil, nil, false, nil
+			}
+
+			if resource.CurrentPinnedVersion() != nil {
+				continue
+			}
+
+			if resource.LastCheckEndTime().Before(nextPendingBuild.CreateTime()) {
+				return nil, nil, false, nil
+			}
+		}
+
+		versions, err := s.pipeline.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			return nil, nil, false, err
+		}
+
+		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job, resources)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		dbResourceTypes, err := s.pipeline.ResourceTypes()
+		if err != nil {
+			return nil, nil, false, err
+		}
+		resourceTypes = dbResourceTypes.Deserialize()
+	}
+
+	buildInputs, found, err := job.GetNextBuildInputs()
+	if err != nil {
+		logger.Error("failed-to-get-next-build-inputs", err)
+		return nil, nil, false, err
+	}
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	pipelinePaused, err := s.pipeline.CheckPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		return nil, nil, false, err
+	}
+	if pipelinePaused {
+		return nil, nil, false, nil
+	}
+
+	if job.Paused() {
+		return nil, nil, false, nil
+	}
+
+	return buildInputs, resourceTypes, true, nil
+}
@@ -23,6 +23,9 @@ var _ = Describe("BuildStarter", func() {
 	var (
 		fakePipeline    *dbfakes.FakePipeline
 		fakeUpdater     *maxinflightfakes.FakeUpdater
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeBuildQueue  *schedulerfakes.FakeBuildQueue
 		fakeFactory     *schedulerfakes.FakeBuildFactory
 		pendingBuilds   []db.Build
 		fakeInputMapper *inputmapperfakes.FakeInputMapper
@@ -35,10 +38,15 @@ var _ = Describe("BuildStarter", func() {
 	BeforeEach(func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakeUpdater = new(maxinflightfakes.FakeUpdater)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+		fakeBuildQueue = new(schedulerfakes.FakeBuildQueue)
+		fakeBuildQueue.AdmitReturns(true, nil)
 		fakeFactory = new(schedulerfakes.FakeBuildFactory)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 
-		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeInputMapper)
+		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeTeamFactory, fakeBuildQueue, fakeFactory, fakeInputMapper)
 
 		disaster = errors.New("bad thing")
 	})
@@ -100,6 +108,17 @@ var _ = Describe("BuildStarter", func() {
 				Expect(abortedBuild.FinishCallCount()).To(Equal(1))
 			})
 
+			It("removes the aborted pending build from the build queue", func() {
+				Expect(fakeBuildQueue.RemoveCallCount()).To(Equal(2))
+				_, removedBuild := fakeBuildQueue.RemoveArgsForCall(0)
+				Expect(removedBuild.ID()).To(Equal(42))
+
+				// the next build has no inputs yet, so it doesn't keep its place
+				// in the queue either
+				_, removedBuild = fakeBuildQueue.RemoveArgsForCall(1)
+				Expect(removedBuild.ID()).To(Equal(66))
+			})
+
 			It("will try to start the next non aborted pending build", func() {
 				Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
 				_, _, buildID := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
@@ -322,6 +341,53 @@ var _ = Describe("BuildStarter", func() {
 			})
 		})
 
+		Context("when triggered by the job's schedule", func() {
+			BeforeEach(func() {
+				job = new(dbfakes.FakeJob)
+				job.NameReturns("some-job")
+				job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "input-1", Resource: "some-resource"}}})
+
+				resources = db.Resources{resource}
+
+				createdBuild.IsManuallyTriggeredReturns(false)
+				createdBuild.IsScheduleTriggeredReturns(true)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					job,
+					resources,
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			Context("when some of the resources are checked before build create time", func() {
+				BeforeEach(func() {
+					createdBuild.CreateTimeReturns(time.Now())
+					resource.LastCheckEndTimeReturns(time.Now().Add(-time.Minute))
+				})
+
+				It("waits for them to be checked, like a manually triggered build", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+					Expect(createdBuild.StartCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when all resources are checked after build create time", func() {
+				BeforeEach(func() {
+					createdBuild.CreateTimeReturns(time.Now().Add(-time.Minute))
+					resource.LastCheckEndTimeReturns(time.Now())
+				})
+
+				It("determines its inputs, like a manually triggered build", func() {
+					Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when not manually triggered", func() {
 			BeforeEach(func() {
 				job = new(dbfakes.FakeJob)
@@ -378,6 +444,18 @@ var _ = Describe("BuildStarter", func() {
 				})
 			}
 
+			itRemovesTheFirstBuildFromTheBuildQueue := func() {
+				It("removes the first build from the build queue", func() {
+					Expect(fakeBuildQueue.RemoveCallCount()).To(Equal(1))
+					_, removedBuild := fakeBuildQueue.RemoveArgsForCall(0)
+					Expect(removedBuild.ID()).To(Equal(99))
+				})
+
+				It("doesn't ask the build queue to admit the build", func() {
+					Expect(fakeBuildQueue.AdmitCallCount()).To(BeZero())
+				})
+			}
+
 			itUpdatedMaxInFlightForTheFirstBuild := func() {
 				It("updated max in flight for the first jobs", func() {
 					Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
@@ -446,6 +524,26 @@ var _ = Describe("BuildStarter", func() {
 							pendingBuild1.ScheduleReturns(true, nil)
 						})
 
+						It("removes the build from the build queue", func() {
+							Expect(fakeBuildQueue.RemoveCallCount()).To(BeNumerically(">=", 1))
+							_, removedBuild := fakeBuildQueue.RemoveArgsForCall(0)
+							Expect(removedBuild.ID()).To(Equal(99))
+						})
+
+						Context("when removing the build from the build queue fails", func() {
+							BeforeEach(func() {
+								fakeBuildQueue.RemoveReturns(disaster)
+							})
+
+							It("returns the error", func() {
+								Expect(tryStartErr).To(Equal(disaster))
+							})
+
+							It("doesn't try to use inputs for build", func() {
+								Expect(pendingBuild1.UseInputsCallCount()).To(BeZero())
+							})
+						})
+
 						Context("when using inputs for build fails", func() {
 							BeforeEach(func() {
 								pendingBuild1.UseInputsReturns(disaster)
@@ -605,6 +703,70 @@ var _ = Describe("BuildStarter", func() {
 						})
 
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itRemovesTheFirstBuildFromTheBuildQueue()
+
+						Context("when removing the build from the build queue fails", func() {
+							BeforeEach(func() {
+								fakeBuildQueue.RemoveReturns(disaster)
+							})
+
+							itReturnsTheError()
+						})
+					})
+
+					Context("when a build waiting in the build queue then reaches max in flight", func() {
+						BeforeEach(func() {
+							fakeBuildQueue.AdmitReturns(false, nil)
+
+							err := buildStarter.TryStartPendingBuildsForJob(
+								lagertest.NewTestLogger("test"),
+								job,
+								db.Resources{resource},
+								versionedResourceTypes,
+								pendingBuilds,
+							)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(fakeBuildQueue.AdmitCallCount()).To(Equal(1))
+							Expect(fakeBuildQueue.RemoveCallCount()).To(BeZero())
+
+							fakeUpdater.UpdateMaxInFlightReachedReturns(true, nil)
+						})
+
+						It("removes the build from the build queue so it doesn't hold back the builds behind it", func() {
+							Expect(tryStartErr).NotTo(HaveOccurred())
+							Expect(fakeBuildQueue.AdmitCallCount()).To(Equal(1))
+							Expect(fakeBuildQueue.RemoveCallCount()).To(Equal(1))
+							_, removedBuild := fakeBuildQueue.RemoveArgsForCall(0)
+							Expect(removedBuild.ID()).To(Equal(99))
+						})
+					})
+
+					Context("when getting the team's usage fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UsageReturns(atc.TeamUsage{}, disaster)
+						})
+
+						itReturnsTheError()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when the team's max running builds is reached", func() {
+						BeforeEach(func() {
+							job.TeamIDReturns(7)
+							fakeTeam.UsageReturns(atc.TeamUsage{
+								Quotas:        atc.TeamQuotas{MaxRunningBuilds: 2},
+								RunningBuilds: 2,
+							}, nil)
+						})
+
+						It("looks up the job's team", func() {
+							Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(7))
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itRemovesTheFirstBuildFromTheBuildQueue()
+						itUpdatedMaxInFlightForTheFirstBuild()
 					})
 
 					Context("when getting the next build inputs fails", func() {
@@ -622,6 +784,7 @@ var _ = Describe("BuildStarter", func() {
 						})
 
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itRemovesTheFirstBuildFromTheBuildQueue()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
 
@@ -640,6 +803,7 @@ var _ = Describe("BuildStarter", func() {
 						})
 
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itRemovesTheFirstBuildFromTheBuildQueue()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
 
@@ -648,6 +812,35 @@ var _ = Describe("BuildStarter", func() {
 							job.PausedReturns(true)
 						})
 
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itRemovesTheFirstBuildFromTheBuildQueue()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when admitting the build to the build queue fails", func() {
+						BeforeEach(func() {
+							fakeBuildQueue.AdmitReturns(false, disaster)
+						})
+
+						itReturnsTheError()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when the build has to wait in the build queue", func() {
+						BeforeEach(func() {
+							fakeBuildQueue.AdmitReturns(false, nil)
+						})
+
+						It("asks the build queue about the first build", func() {
+							Expect(fakeBuildQueue.AdmitCallCount()).To(Equal(1))
+							_, admittedBuild := fakeBuildQueue.AdmitArgsForCall(0)
+							Expect(admittedBuild.ID()).To(Equal(99))
+						})
+
+						It("doesn't remove the build from the build queue", func() {
+							Expect(fakeBuildQueue.RemoveCallCount()).To(BeZero())
+						})
+
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
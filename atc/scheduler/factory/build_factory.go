@@ -99,6 +99,10 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.VersionedResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if planConfig.Across != nil {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
@@ -131,6 +135,90 @@ func (factory *buildFactory) constructPlanFromConfig(
 	})
 }
 
+// across constructs a plan for every combination of the step's across vars,
+// in order, with the last var varying fastest.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	across := atc.AcrossPlan{
+		FailFast: planConfig.FailFast,
+	}
+
+	valuesKnown := true
+	for _, acrossVar := range planConfig.Across {
+		across.Vars = append(across.Vars, atc.AcrossVar{
+			Var:         acrossVar.Var,
+			Values:      acrossVar.Values,
+			ValuesFile:  acrossVar.ValuesFile,
+			ValuesVar:   acrossVar.ValuesVar,
+			MaxInFlight: acrossVar.MaxInFlight,
+		})
+
+		if !acrossVar.ValuesKnown() {
+			valuesKnown = false
+		}
+	}
+
+	stepConfig := planConfig
+	stepConfig.Across = nil
+	stepConfig.FailFast = false
+
+	if !valuesKnown {
+		// the combinations are only known once the build runs, so a single
+		// plan is constructed for all of them
+		step, err := factory.constructPlanFromConfig(
+			stepConfig,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		across.Step = &step
+
+		return factory.planFactory.NewPlan(across), nil
+	}
+
+	combinations := [][]interface{}{{}}
+	for _, acrossVar := range planConfig.Across {
+
+		var next [][]interface{}
+		for _, combination := range combinations {
+			for _, value := range acrossVar.Values {
+				values := make([]interface{}, len(combination), len(combination)+1)
+				copy(values, combination)
+				next = append(next, append(values, value))
+			}
+		}
+
+		combinations = next
+	}
+
+	for _, values := range combinations {
+		step, err := factory.constructPlanFromConfig(
+			stepConfig,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		across.Steps = append(across.Steps, atc.VarScopedPlan{
+			Step:   step,
+			Values: values,
+		})
+	}
+
+	return factory.planFactory.NewPlan(across), nil
+}
+
 func (factory *buildFactory) constructUnhookedPlan(
 	planConfig atc.PlanConfig,
 	resources atc.ResourceConfigs,
@@ -244,6 +332,28 @@ func (factory *buildFactory) constructUnhookedPlan(
 
 			VersionedResourceTypes: resourceTypes,
 		})
+
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name:     planConfig.SetPipeline,
+			File:     planConfig.TaskConfigPath,
+			Vars:     planConfig.TaskVars,
+			VarFiles: planConfig.VarFiles,
+		})
+
+	case planConfig.LoadVar != "":
+		plan = factory.planFactory.NewPlan(atc.LoadVarPlan{
+			Name:      planConfig.LoadVar,
+			File:      planConfig.TaskConfigPath,
+			Format:    planConfig.Format,
+			Sensitive: planConfig.Sensitive,
+		})
+
+	case planConfig.Approve != "":
+		plan = factory.planFactory.NewPlan(atc.ApprovePlan{
+			Name: planConfig.Approve,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
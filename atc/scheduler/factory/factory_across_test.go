@@ -0,0 +1,112 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+	"github.com/concourse/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across", func() {
+	Describe("AcrossPlan", func() {
+		var (
+			buildFactory factory.BuildFactory
+
+			input               atc.JobConfig
+			actualPlanFactory   atc.PlanFactory
+			expectedPlanFactory atc.PlanFactory
+		)
+
+		BeforeEach(func() {
+			actualPlanFactory = atc.NewPlanFactory(123)
+			expectedPlanFactory = atc.NewPlanFactory(123)
+			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "some-task",
+						TaskConfigPath: "some-artifact/task.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 1},
+							{Var: "db", Values: []interface{}{"postgres", "mysql"}},
+						},
+						FailFast: true,
+					},
+				},
+			}
+		})
+
+		It("returns a plan for each combination, with the last var varying fastest", func() {
+			actual, err := buildFactory.Create(input, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskPlan := atc.TaskPlan{
+				Name:       "some-task",
+				ConfigPath: "some-artifact/task.yml",
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 1},
+					{Var: "db", Values: []interface{}{"postgres", "mysql"}},
+				},
+				Steps: []atc.VarScopedPlan{
+					{Step: expectedPlanFactory.NewPlan(taskPlan), Values: []interface{}{"1.12", "postgres"}},
+					{Step: expectedPlanFactory.NewPlan(taskPlan), Values: []interface{}{"1.12", "mysql"}},
+					{Step: expectedPlanFactory.NewPlan(taskPlan), Values: []interface{}{"1.13", "postgres"}},
+					{Step: expectedPlanFactory.NewPlan(taskPlan), Values: []interface{}{"1.13", "mysql"}},
+				},
+				FailFast: true,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		Context("when a var's values are only known when the build runs", func() {
+			BeforeEach(func() {
+				input.Plan[0].Across[1] = atc.AcrossVarConfig{Var: "db", ValuesFile: "some-artifact/dbs.yml"}
+			})
+
+			It("returns a single plan to run for every combination", func() {
+				actual, err := buildFactory.Create(input, nil, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				step := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task",
+					ConfigPath: "some-artifact/task.yml",
+				})
+
+				expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+					Vars: []atc.AcrossVar{
+						{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 1},
+						{Var: "db", ValuesFile: "some-artifact/dbs.yml"},
+					},
+					Step:     &step,
+					FailFast: true,
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
+		Context("when the step has hooks", func() {
+			BeforeEach(func() {
+				input.Plan[0].Failure = &atc.PlanConfig{
+					Task:           "some-hook",
+					TaskConfigPath: "some-artifact/hook.yml",
+				}
+			})
+
+			It("applies them to each combination", func() {
+				actual, err := buildFactory.Create(input, nil, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(actual.Across.Steps).To(HaveLen(4))
+				for _, step := range actual.Across.Steps {
+					Expect(step.Step.OnFailure).ToNot(BeNil())
+					Expect(step.Step.OnFailure.Next.Task.Name).To(Equal("some-hook"))
+				}
+			})
+		})
+	})
+})
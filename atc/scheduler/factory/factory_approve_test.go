@@ -0,0 +1,59 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+	"github.com/concourse/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Approve", func() {
+	Describe("ApprovePlan", func() {
+		var (
+			buildFactory factory.BuildFactory
+
+			actualPlanFactory   atc.PlanFactory
+			expectedPlanFactory atc.PlanFactory
+		)
+
+		BeforeEach(func() {
+			actualPlanFactory = atc.NewPlanFactory(123)
+			expectedPlanFactory = atc.NewPlanFactory(123)
+			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Approve: "deploy-prod"},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.ApprovePlan{
+				Name: "deploy-prod",
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		Context("when it has a timeout", func() {
+			It("wraps the plan in the timeout", func() {
+				actual, err := buildFactory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Approve: "deploy-prod", Timeout: "1h"},
+					},
+				}, nil, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TimeoutPlan{
+					Duration: "1h",
+					Step: expectedPlanFactory.NewPlan(atc.ApprovePlan{
+						Name: "deploy-prod",
+					}),
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+	})
+})
@@ -66,6 +66,28 @@ var _ = Describe("Factory Retry Step", func() {
 		})
 	})
 
+	Context("when there is an approve step annotated with 'attempts'", func() {
+		It("gives each attempt its own plan ID, so that each is decided separately", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Approve:  "deploy",
+						Attempts: 2,
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actual.Retry).ToNot(BeNil())
+			Expect(*actual.Retry).To(HaveLen(2))
+
+			attempts := *actual.Retry
+			Expect(attempts[0].Approve).To(Equal(&atc.ApprovePlan{Name: "deploy"}))
+			Expect(attempts[1].Approve).To(Equal(&atc.ApprovePlan{Name: "deploy"}))
+			Expect(attempts[0].ID).ToNot(Equal(attempts[1].ID))
+		})
+	})
+
 	Context("when there is a task annotated with 'attempts' and 'on_success'", func() {
 		It("builds correctly", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
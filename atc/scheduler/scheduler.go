@@ -3,17 +3,24 @@ package scheduler
 import (
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper"
+	"github.com/gorhill/cronexpr"
 )
 
+// ScheduleGracePeriod is how late a job's scheduled run may be noticed
+// before it is considered missed and subject to the job's catch-up policy.
+const ScheduleGracePeriod = time.Minute
+
 type Scheduler struct {
 	Pipeline     db.Pipeline
 	InputMapper  inputmapper.InputMapper
 	BuildStarter BuildStarter
+	Clock        clock.Clock
 }
 
 func (s *Scheduler) Schedule(
@@ -28,6 +35,10 @@ func (s *Scheduler) Schedule(
 	for _, job := range jobs {
 		jStart := time.Now()
 		err := s.ensurePendingBuildExists(logger, versions, job, resources)
+		if err == nil {
+			err = s.ensureScheduledBuildExists(logger, job, resources)
+		}
+
 		jobSchedulingTime[job.Name()] = time.Since(jStart)
 
 		if err != nil {
@@ -97,3 +108,121 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 	return nil
 }
+
+func (s *Scheduler) scheduleIsPaused(job db.Job) (bool, error) {
+	if job.Paused() {
+		return true, nil
+	}
+
+	return s.Pipeline.CheckPaused()
+}
+
+func (s *Scheduler) ensureScheduledBuildExists(
+	logger lager.Logger,
+	job db.Job,
+	resources db.Resources,
+) error {
+	schedule := job.Config().Schedule
+	if schedule == nil {
+		return nil
+	}
+
+	logger = logger.Session("schedule", lager.Data{"job": job.Name(), "cron": schedule.Cron})
+
+	expr, err := schedule.Expression()
+	if err != nil {
+		logger.Error("failed-to-parse-cron-expression", err)
+		return nil
+	}
+
+	location, err := schedule.Location()
+	if err != nil {
+		logger.Error("failed-to-load-timezone", err)
+		return nil
+	}
+
+	now := s.Clock.Now().In(location)
+
+	lastScheduled := job.LastScheduled()
+	if lastScheduled.IsZero() {
+		// start counting from now rather than treating every run since the
+		// beginning of time as missed
+		return job.SetLastScheduled(now)
+	}
+
+	scheduledAt, due := latestScheduledTime(expr, lastScheduled.In(location), now)
+	if !due {
+		return nil
+	}
+
+	paused, err := s.scheduleIsPaused(job)
+	if err != nil {
+		logger.Error("failed-to-check-if-paused", err)
+		return err
+	}
+
+	if paused {
+		// runs which fall due while paused are skipped rather than missed, so
+		// that unpausing does not trigger a build regardless of the catch-up
+		// policy
+		logger.Debug("skipping-run-while-paused", lager.Data{"scheduled-at": scheduledAt})
+		return job.SetLastScheduled(scheduledAt)
+	}
+
+	if now.Sub(scheduledAt) > ScheduleGracePeriod && schedule.CatchUpPolicy() == atc.ScheduleCatchUpSkip {
+		logger.Info("skipping-missed-run", lager.Data{"scheduled-at": scheduledAt})
+		return job.SetLastScheduled(scheduledAt)
+	}
+
+	_, created, err := job.CreateScheduledBuild(scheduledAt)
+	if err != nil {
+		logger.Error("failed-to-create-scheduled-build", err)
+		return err
+	}
+
+	if !created {
+		return nil
+	}
+
+	logger.Info("created-scheduled-build", lager.Data{"scheduled-at": scheduledAt})
+
+	for _, input := range job.Config().Inputs() {
+		resource, found := resources.Lookup(input.Resource)
+		if !found {
+			continue
+		}
+
+		err := resource.NotifyScan()
+		if err != nil {
+			logger.Error("failed-to-notify-scan", err, lager.Data{"resource": input.Resource})
+		}
+	}
+
+	return nil
+}
+
+// latestScheduledTime returns the most recent time matching the expression
+// that is after since and no later than now.
+//
+// Walking every time since the last run could take a great many steps for a
+// frequent expression after a long outage, so it searches back from now in
+// windows which double in length until one matches.
+func latestScheduledTime(expr *cronexpr.Expression, since time.Time, now time.Time) (time.Time, bool) {
+	for window := time.Second; ; window *= 2 {
+		from := now.Add(-window).In(since.Location())
+
+		exhausted := !from.After(since)
+		if exhausted {
+			from = since
+		}
+
+		var latest time.Time
+		for next := expr.Next(from); !next.IsZero() && !next.After(now); next = expr.Next(next) {
+			latest = next
+		}
+
+		if !latest.IsZero() || exhausted {
+			return latest, !latest.IsZero()
+		}
+	}
+}
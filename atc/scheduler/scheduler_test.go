@@ -2,7 +2,9 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -20,6 +22,7 @@ var _ = Describe("Scheduler", func() {
 		fakePipeline     *dbfakes.FakePipeline
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
+		fakeClock        *fakeclock.FakeClock
 
 		scheduler *Scheduler
 
@@ -30,11 +33,13 @@ var _ = Describe("Scheduler", func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2019, 8, 10, 2, 0, 30, 0, time.UTC))
 
 		scheduler = &Scheduler{
 			Pipeline:     fakePipeline,
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
+			Clock:        fakeClock,
 		}
 
 		disaster = errors.New("bad thing")
@@ -324,5 +329,182 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job has a schedule", func() {
+			var schedule *atc.ScheduleConfig
+
+			BeforeEach(func() {
+				schedule = &atc.ScheduleConfig{Cron: "0 2 * * *"}
+
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigStub = func() atc.JobConfig {
+					return atc.JobConfig{
+						Name:     "some-job",
+						Schedule: schedule,
+						Plan: atc.PlanSequence{
+							{Get: "some-input", Resource: "some-resource"},
+						},
+					}
+				}
+				fakeJob.CreateScheduledBuildReturns(new(dbfakes.FakeBuild), true, nil)
+				fakeJobs = []db.Job{fakeJob}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+			})
+
+			Context("when the schedule has never been considered", func() {
+				It("starts counting from now without creating a build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+					Expect(fakeJob.SetLastScheduledCallCount()).To(Equal(1))
+					Expect(fakeJob.SetLastScheduledArgsForCall(0)).To(BeTemporally("==", fakeClock.Now()))
+				})
+			})
+
+			Context("when a run is due", func() {
+				BeforeEach(func() {
+					fakeJob.LastScheduledReturns(time.Date(2019, 8, 9, 2, 0, 0, 0, time.UTC))
+				})
+
+				It("creates a build for the scheduled time", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+					Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(BeTemporally("==", time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC)))
+				})
+
+				It("notifies the job's inputs to be checked", func() {
+					Expect(fakeResource.NotifyScanCallCount()).To(Equal(1))
+				})
+
+				It("starts the pending builds", func() {
+					Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				})
+
+				Context("when the build had already been created", func() {
+					BeforeEach(func() {
+						fakeJob.CreateScheduledBuildReturns(nil, false, nil)
+					})
+
+					It("does not notify the inputs", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeResource.NotifyScanCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when creating the build fails", func() {
+					BeforeEach(func() {
+						fakeJob.CreateScheduledBuildReturns(nil, false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+
+				Context("when the job is paused", func() {
+					BeforeEach(func() {
+						fakeJob.PausedReturns(true)
+					})
+
+					It("records the run without creating a build", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+						Expect(fakeJob.SetLastScheduledCallCount()).To(Equal(1))
+						Expect(fakeJob.SetLastScheduledArgsForCall(0)).To(BeTemporally("==", time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC)))
+					})
+				})
+
+				Context("when the pipeline is paused", func() {
+					BeforeEach(func() {
+						fakePipeline.CheckPausedReturns(true, nil)
+					})
+
+					It("records the run without creating a build", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+						Expect(fakeJob.SetLastScheduledCallCount()).To(Equal(1))
+						Expect(fakeJob.SetLastScheduledArgsForCall(0)).To(BeTemporally("==", time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC)))
+					})
+				})
+
+				Context("when checking if the pipeline is paused fails", func() {
+					BeforeEach(func() {
+						fakePipeline.CheckPausedReturns(false, disaster)
+					})
+
+					It("returns the error without creating a build", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the schedule is in another timezone", func() {
+					BeforeEach(func() {
+						schedule.Timezone = "America/Toronto"
+						fakeClock.Increment(4 * time.Hour)
+					})
+
+					It("creates a build for the scheduled time in that timezone", func() {
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+						Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(BeTemporally("==", time.Date(2019, 8, 10, 6, 0, 0, 0, time.UTC)))
+					})
+				})
+			})
+
+			Context("when the latest run has already been scheduled", func() {
+				BeforeEach(func() {
+					fakeJob.LastScheduledReturns(time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC))
+				})
+
+				It("does nothing", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+					Expect(fakeJob.SetLastScheduledCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when runs were missed", func() {
+				BeforeEach(func() {
+					fakeJob.LastScheduledReturns(time.Date(2019, 8, 7, 2, 0, 0, 0, time.UTC))
+					fakeClock.Increment(3 * time.Hour)
+				})
+
+				Context("when the catch-up policy is to skip", func() {
+					It("records the latest missed run without creating a build", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+						Expect(fakeJob.SetLastScheduledCallCount()).To(Equal(1))
+						Expect(fakeJob.SetLastScheduledArgsForCall(0)).To(BeTemporally("==", time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC)))
+					})
+				})
+
+				Context("when the catch-up policy is to run once", func() {
+					BeforeEach(func() {
+						schedule.CatchUp = atc.ScheduleCatchUpOnce
+					})
+
+					It("creates a single build for the latest missed run", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+						Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(BeTemporally("==", time.Date(2019, 8, 10, 2, 0, 0, 0, time.UTC)))
+					})
+				})
+
+				Context("when the schedule is every second and runs were missed for years", func() {
+					BeforeEach(func() {
+						schedule.Cron = "* * * * * * *"
+						schedule.CatchUp = atc.ScheduleCatchUpOnce
+						fakeJob.LastScheduledReturns(time.Date(2009, 8, 10, 2, 0, 0, 0, time.UTC))
+					})
+
+					It("creates a build for the latest run without walking every missed one", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+						Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(BeTemporally("==", fakeClock.Now()))
+					})
+				})
+			})
+		})
 	})
 })
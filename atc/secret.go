@@ -0,0 +1,37 @@
+package atc
+
+// Secret is a credential stored in the Concourse database, available to all
+// of a team's pipelines or, if PipelineName is set, to the pipelines of that
+// name.
+type Secret struct {
+	Name         string `json:"name"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+
+	// Value is never returned by the API.
+	Value string `json:"value,omitempty"`
+
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+}
+
+// SecretAccess records a single lookup of a credential, without its value,
+// for the secret access audit trail.
+type SecretAccess struct {
+	ID           int    `json:"id,omitempty"`
+	Path         string `json:"path"`
+	Manager      string `json:"manager"`
+	TeamName     string `json:"team_name,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	BuildID      int    `json:"build_id,omitempty"`
+	UserName     string `json:"user_name,omitempty"`
+	AccessedAt   int64  `json:"accessed_at"`
+}
+
+// SecretCachePurge removes the cached lookups of a team's secrets from the
+// secret caches of every ATC, narrowed down to the secrets of a pipeline
+// and/or the secret at a path, e.g. after rotating the secret.
+type SecretCachePurge struct {
+	ID           int    `json:"id,omitempty"`
+	TeamName     string `json:"team_name"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	Path         string `json:"path,omitempty"`
+}
@@ -1,9 +1,44 @@
 package atc
 
 type Team struct {
-	ID   int      `json:"id,omitempty"`
-	Name string   `json:"name,omitempty"`
-	Auth TeamAuth `json:"auth,omitempty"`
+	ID     int         `json:"id,omitempty"`
+	Name   string      `json:"name,omitempty"`
+	Auth   TeamAuth    `json:"auth,omitempty"`
+	Quotas *TeamQuotas `json:"quotas,omitempty"`
 }
 
 type TeamAuth map[string]map[string][]string
+
+// TeamQuotas limits how much of the cluster a team may use at once. A limit
+// of 0 means unlimited.
+type TeamQuotas struct {
+	MaxRunningBuilds int `json:"max_running_builds,omitempty"`
+	MaxContainers    int `json:"max_containers,omitempty"`
+	MaxVolumes       int `json:"max_volumes,omitempty"`
+}
+
+// TeamUsage is a team's current usage of the resources limited by its
+// quotas.
+type TeamUsage struct {
+	Quotas TeamQuotas `json:"quotas"`
+
+	RunningBuilds int `json:"running_builds"`
+	Containers    int `json:"containers"`
+	Volumes       int `json:"volumes"`
+}
+
+func (usage TeamUsage) RunningBuildsQuotaReached() bool {
+	return quotaReached(usage.RunningBuilds, usage.Quotas.MaxRunningBuilds)
+}
+
+func (usage TeamUsage) ContainersQuotaReached() bool {
+	return quotaReached(usage.Containers, usage.Quotas.MaxContainers)
+}
+
+func (usage TeamUsage) VolumesQuotaReached() bool {
+	return quotaReached(usage.Volumes, usage.Quotas.MaxVolumes)
+}
+
+func quotaReached(used int, limit int) bool {
+	return limit > 0 && used >= limit
+}
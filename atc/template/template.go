@@ -0,0 +1,239 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+)
+
+// Variables is the interface used to resolve ((var)) references; it is
+// compatible with bosh-cli's so that StaticVariables, NewMultiVars, etc. can
+// still be used.
+type Variables = boshtemplate.Variables
+
+var (
+	interpolationRegex         = regexp.MustCompile(`\(\((!?([-/\.\w\pL]+:)?[-/\.\w\pL]+)\)\)`)
+	interpolationAnchoredRegex = regexp.MustCompile("\\A" + interpolationRegex.String() + "\\z")
+)
+
+// Template interpolates ((var)) references in a YAML document. It follows the
+// same semantics as bosh-cli's template package, with the addition of an
+// optional source prefix, e.g. ((source:path.field)). Variables which name a
+// source are looked up with their full "source:path" name.
+type Template struct {
+	bytes []byte
+}
+
+type EvaluateOpts struct {
+	ExpectAllKeys bool
+}
+
+func NewTemplate(bytes []byte) Template {
+	return Template{bytes: bytes}
+}
+
+func (t Template) Evaluate(vars Variables, opts EvaluateOpts) ([]byte, error) {
+	var obj interface{}
+
+	err := yaml.Unmarshal(t.bytes, &obj)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	tracker := varsTracker{
+		vars:    vars,
+		missing: map[string]struct{}{},
+	}
+
+	obj, err = interpolator{}.Interpolate(obj, tracker)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	if opts.ExpectAllKeys {
+		err = tracker.MissingError()
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+
+	return yaml.Marshal(obj)
+}
+
+// VarRef is a parsed ((var)) reference.
+type VarRef struct {
+	Source string
+	Path   string
+	Fields []string
+}
+
+// ParseVarRef splits a var reference of the form [source:]path[.field...].
+func ParseVarRef(name string) VarRef {
+	var ref VarRef
+
+	if i := strings.Index(name, ":"); i != -1 {
+		ref.Source = name[:i]
+		name = name[i+1:]
+	}
+
+	segments := strings.Split(name, ".")
+	ref.Path = segments[0]
+	ref.Fields = segments[1:]
+
+	return ref
+}
+
+// VarRefs returns every ((var)) reference in text, in the order they appear.
+func VarRefs(text string) []VarRef {
+	var refs []VarRef
+	for _, name := range (interpolator{}).extractVarNames(text) {
+		refs = append(refs, ParseVarRef(name))
+	}
+
+	return refs
+}
+
+// Name is the variable name passed to Variables, without any fields.
+func (ref VarRef) Name() string {
+	if ref.Source == "" {
+		return ref.Path
+	}
+
+	return ref.Source + ":" + ref.Path
+}
+
+type interpolator struct{}
+
+func (i interpolator) Interpolate(node interface{}, tracker varsTracker) (interface{}, error) {
+	switch typedNode := node.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range typedNode {
+			evaluatedValue, err := i.Interpolate(v, tracker)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluatedKey, err := i.Interpolate(k, tracker)
+			if err != nil {
+				return nil, err
+			}
+
+			delete(typedNode, k) // delete in case key has changed
+			typedNode[evaluatedKey] = evaluatedValue
+		}
+
+	case []interface{}:
+		for idx, x := range typedNode {
+			var err error
+			typedNode[idx], err = i.Interpolate(x, tracker)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case string:
+		for _, name := range i.extractVarNames(typedNode) {
+			foundVal, found, err := tracker.Get(name)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Finding variable '%s'", name)
+			}
+
+			if found {
+				// ensure that value type is preserved when replacing the entire field
+				if interpolationAnchoredRegex.MatchString(typedNode) {
+					return foundVal, nil
+				}
+
+				switch typedVal := foundVal.(type) {
+				case string, int, int16, int32, int64, uint, uint16, uint32, uint64, float64:
+					// floats are formatted as json/yaml would, as values decoded
+					// from json (e.g. across values) are always float64
+					foundValStr := fmt.Sprintf("%v", foundVal)
+					if f, ok := typedVal.(float64); ok {
+						foundValStr = strconv.FormatFloat(f, 'f', -1, 64)
+					}
+
+					typedNode = strings.Replace(typedNode, fmt.Sprintf("((%s))", name), foundValStr, -1)
+					typedNode = strings.Replace(typedNode, fmt.Sprintf("((!%s))", name), foundValStr, -1)
+				default:
+					errMsg := "Invalid type '%T' for value '%v' and variable '%s'. Supported types for interpolation within a string are integers and strings."
+					return nil, fmt.Errorf(errMsg, foundVal, foundVal, name)
+				}
+			}
+		}
+
+		return typedNode, nil
+	}
+
+	return node, nil
+}
+
+func (i interpolator) extractVarNames(value string) []string {
+	var names []string
+
+	for _, match := range interpolationRegex.FindAllSubmatch([]byte(value), -1) {
+		names = append(names, strings.TrimPrefix(string(match[1]), "!"))
+	}
+
+	return names
+}
+
+type varsTracker struct {
+	vars    Variables
+	missing map[string]struct{}
+}
+
+func (t varsTracker) Get(name string) (interface{}, bool, error) {
+	ref := ParseVarRef(name)
+
+	val, found, err := t.vars.Get(boshtemplate.VariableDefinition{Name: ref.Name()})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		t.missing[ref.Name()] = struct{}{}
+		return nil, false, nil
+	}
+
+	if len(ref.Fields) > 0 {
+		tokens := []patch.Token{patch.RootToken{}}
+
+		for _, field := range ref.Fields {
+			tokens = append(tokens, patch.KeyToken{Key: field})
+		}
+
+		val, err = patch.FindOp{Path: patch.NewPointer(tokens)}.Apply(val)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	return val, true, nil
+}
+
+func (t varsTracker) MissingError() error {
+	if len(t.missing) == 0 {
+		return nil
+	}
+
+	var names []string
+	for name := range t.missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		errs = append(errs, bosherr.Error(name))
+	}
+
+	return bosherr.WrapError(bosherr.NewMultiError(errs...), "Expected to find variables")
+}
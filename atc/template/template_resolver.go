@@ -44,8 +44,8 @@ func (resolver TemplateResolver) Resolve(expectAllKeys bool, allowEmptyInOldStyl
 }
 
 func (resolver TemplateResolver) resolve(expectAllKeys bool) ([]byte, error) {
-	tpl := boshtemplate.NewTemplate(resolver.configPayload)
-	bytes, err := tpl.Evaluate(boshtemplate.NewMultiVars(resolver.params), nil, boshtemplate.EvaluateOpts{ExpectAllKeys: expectAllKeys})
+	tpl := NewTemplate(resolver.configPayload)
+	bytes, err := tpl.Evaluate(boshtemplate.NewMultiVars(resolver.params), EvaluateOpts{ExpectAllKeys: expectAllKeys})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,94 @@
+package template_test
+
+import (
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/template"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template", func() {
+	var vars boshtemplate.StaticVariables
+
+	BeforeEach(func() {
+		vars = boshtemplate.StaticVariables{
+			"some-var":       "some-value",
+			"some-map":       map[interface{}]interface{}{"field": "some-field"},
+			"src:some-path":  map[interface{}]interface{}{"field": "some-sourced-field"},
+			"local:some-var": "some-local-value",
+			"some-int":       42,
+			"some-float":     1.5,
+			"/some/abs/path": "some-abs-value",
+		}
+	})
+
+	evaluate := func(payload string, expectAllKeys bool) (string, error) {
+		bytes, err := template.NewTemplate([]byte(payload)).Evaluate(vars, template.EvaluateOpts{
+			ExpectAllKeys: expectAllKeys,
+		})
+		return string(bytes), err
+	}
+
+	It("interpolates vars", func() {
+		Expect(evaluate(`foo: ((some-var))`, true)).To(MatchYAML(`foo: some-value`))
+		Expect(evaluate(`foo: ((/some/abs/path))`, true)).To(MatchYAML(`foo: some-abs-value`))
+	})
+
+	It("interpolates fields of vars", func() {
+		Expect(evaluate(`foo: ((some-map.field))`, true)).To(MatchYAML(`foo: some-field`))
+	})
+
+	It("interpolates vars from a source", func() {
+		Expect(evaluate(`foo: ((src:some-path.field))`, true)).To(MatchYAML(`foo: some-sourced-field`))
+		Expect(evaluate(`foo: ((local:some-var))`, true)).To(MatchYAML(`foo: some-local-value`))
+	})
+
+	It("interpolates vars within strings", func() {
+		Expect(evaluate(`foo: v((some-int))-((local:some-var))`, true)).To(MatchYAML(`foo: v42-some-local-value`))
+	})
+
+	It("formats floats within strings as json would", func() {
+		Expect(evaluate(`foo: go((some-float))`, true)).To(MatchYAML(`foo: go1.5`))
+	})
+
+	It("preserves the type of vars which replace the entire value", func() {
+		Expect(evaluate(`foo: ((some-map))`, true)).To(MatchYAML(`foo: {field: some-field}`))
+	})
+
+	Context("when vars are missing", func() {
+		It("returns an error if all keys are expected", func() {
+			_, err := evaluate("foo: ((missing))\nbar: ((src:missing.field))", true)
+			Expect(err).To(MatchError("Expected to find variables: missing\nsrc:missing"))
+		})
+
+		It("leaves them in place otherwise", func() {
+			Expect(evaluate(`foo: ((src:missing))`, false)).To(MatchYAML(`foo: ((src:missing))`))
+		})
+	})
+
+	Describe("ParseVarRef", func() {
+		It("parses the source, path, and fields", func() {
+			Expect(template.ParseVarRef("src:some/path.a.b")).To(Equal(template.VarRef{
+				Source: "src",
+				Path:   "some/path",
+				Fields: []string{"a", "b"},
+			}))
+
+			Expect(template.ParseVarRef(".:some-var")).To(Equal(template.VarRef{
+				Source: ".",
+				Path:   "some-var",
+				Fields: []string{},
+			}))
+		})
+	})
+
+	Describe("VarRefs", func() {
+		It("returns every var reference", func() {
+			Expect(template.VarRefs("((a)) and ((src:b.c)) and ((!d))")).To(Equal([]template.VarRef{
+				{Path: "a", Fields: []string{}},
+				{Source: "src", Path: "b", Fields: []string{"c"}},
+				{Path: "d", Fields: []string{}},
+			}))
+		})
+	})
+})
@@ -3,9 +3,12 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/concourse/concourse/atc/template"
 )
 
 func formatErr(groupName string, err error) string {
@@ -49,6 +52,11 @@ func (c Config) Validate() ([]ConfigWarning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	varSourcesErr := validateVarSources(c)
+	if varSourcesErr != nil {
+		errorMessages = append(errorMessages, formatErr("var sources", varSourcesErr))
+	}
+
 	return warnings, errorMessages
 }
 
@@ -178,6 +186,100 @@ func validateResourceTypes(c Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateVarSources(c Config) error {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, source := range c.VarSources {
+		var identifier string
+		if source.Name == "" || source.Name == "." {
+			identifier = fmt.Sprintf("var_sources[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("var_sources.%s", source.Name)
+		}
+
+		if other, exists := names[source.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"var_sources[%d] and var_sources[%d] have the same name ('%s')",
+					other, i, source.Name))
+		} else if source.Name != "" {
+			names[source.Name] = i
+		}
+
+		if source.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		} else if source.Name == "." {
+			errorMessages = append(errorMessages, identifier+" has reserved name '.', which refers to build-local vars")
+		} else if strings.Contains(source.Name, ":") {
+			errorMessages = append(errorMessages, identifier+" has invalid name (must not contain ':')")
+		}
+
+		if source.Type == "" {
+			errorMessages = append(errorMessages, identifier+" has no type")
+		}
+	}
+
+	// var sources can't refer to each other, so only the rest of the config
+	// is checked for references to them
+	withoutVarSources := c
+	withoutVarSources.VarSources = nil
+
+	unknown := map[string]bool{}
+	eachString(reflect.ValueOf(withoutVarSources), func(s string) {
+		for _, ref := range template.VarRefs(s) {
+			if ref.Source == "" || ref.Source == "." {
+				continue
+			}
+
+			if _, found := c.VarSources.Lookup(ref.Source); !found {
+				unknown[ref.Source] = true
+			}
+		}
+	})
+
+	unknownNames := []string{}
+	for name := range unknown {
+		unknownNames = append(unknownNames, name)
+	}
+
+	sort.Strings(unknownNames)
+
+	for _, name := range unknownNames {
+		errorMessages = append(errorMessages, fmt.Sprintf("vars refer to unknown var source '%s'", name))
+	}
+
+	return compositeErr(errorMessages)
+}
+
+// eachString calls f with every string within v, including map keys.
+func eachString(v reflect.Value, f func(string)) {
+	switch v.Kind() {
+	case reflect.String:
+		f(v.String())
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			eachString(v.Elem(), f)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				eachString(v.Field(i), f)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			eachString(v.Index(i), f)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			eachString(key, f)
+			eachString(v.MapIndex(key), f)
+		}
+	}
+}
+
 func validateResourcesUnused(c Config) []string {
 	usedResources := usedResources(c)
 
@@ -207,6 +309,28 @@ func usedResources(c Config) map[string]bool {
 	return usedResources
 }
 
+func validateSchedule(identifier string, schedule ScheduleConfig) []string {
+	errorMessages := []string{}
+
+	if schedule.Cron == "" {
+		errorMessages = append(errorMessages, identifier+" has no cron expression")
+	} else if _, err := schedule.Expression(); err != nil {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s has invalid cron expression '%s': %s", identifier, schedule.Cron, err))
+	}
+
+	if _, err := schedule.Location(); err != nil {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s has unknown timezone '%s'", identifier, schedule.Timezone))
+	}
+
+	switch schedule.CatchUpPolicy() {
+	case ScheduleCatchUpSkip, ScheduleCatchUpOnce:
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("%s has invalid catch_up policy '%s' (must be '%s' or '%s')", identifier, schedule.CatchUp, ScheduleCatchUpSkip, ScheduleCatchUpOnce))
+	}
+
+	return errorMessages
+}
+
 func validateJobs(c Config) ([]ConfigWarning, error) {
 	errorMessages := []string{}
 	warnings := []ConfigWarning{}
@@ -261,6 +385,10 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 			}
 		}
 
+		if job.Schedule != nil {
+			errorMessages = append(errorMessages, validateSchedule(identifier+".schedule", *job.Schedule)...)
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -363,6 +491,18 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		foundTypes.Find("task")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
+	if plan.LoadVar != "" {
+		foundTypes.Find("load_var")
+	}
+
+	if plan.Approve != "" {
+		foundTypes.Find("approve")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -449,6 +589,34 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		}
 
 		for _, job := range plan.Passed {
+			if strings.Contains(job, "/") {
+				// jobs in other pipelines are not part of this config, so they
+				// can only be checked when the config is saved
+				passedPipeline, passedJob := PassedJob(job)
+				if instance := strings.SplitN(passedJob, "/", 2); len(instance) == 2 && strings.Contains(instance[0], ":") {
+					// e.g. pipeline/branch:feature/job
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed references a job in a pipeline instance ('%s'); only pipelines without instance vars can be referenced",
+							identifier,
+							job,
+						),
+					)
+				} else if passedPipeline == "" || passedJob == "" || strings.Contains(passedJob, "/") {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed references a job in another pipeline with an invalid name ('%s'); expected 'pipeline/job'",
+							identifier,
+							job,
+						),
+					)
+				}
+
+				continue
+			}
+
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
 				errorMessages = append(
@@ -555,6 +723,44 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 			plan, identifier)...,
 		)
 
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any pipeline configuration")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any file")
+		}
+
+		switch plan.Format {
+		case "", "raw", "trim", "json", "yaml":
+		default:
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" specifies an unknown format ('%s'); must be one of raw, trim, json, or yaml", plan.Format))
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
+	case plan.Approve != "":
+		identifier = fmt.Sprintf("%s.approve.%s", identifier, plan.Approve)
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "file"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -610,9 +816,59 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if plan.Across != nil {
+		errorMessages = append(errorMessages, validateAcross(identifier, plan)...)
+	} else if plan.FailFast {
+		errorMessages = append(errorMessages, identifier+" specifies fail_fast without across")
+	}
+
 	return warnings, errorMessages
 }
 
+func validateAcross(identifier string, plan PlanConfig) []string {
+	errorMessages := []string{}
+
+	if plan.Get != "" {
+		errorMessages = append(errorMessages, identifier+" cannot run a get step across vars")
+	}
+
+	if len(plan.Across) == 0 {
+		errorMessages = append(errorMessages, identifier+".across does not specify any vars")
+	}
+
+	seen := map[string]bool{}
+	for i, acrossVar := range plan.Across {
+		subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		if acrossVar.Var == "" {
+			errorMessages = append(errorMessages, subIdentifier+" has no var")
+		} else if seen[acrossVar.Var] {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" repeats var '%s'", acrossVar.Var))
+		}
+
+		seen[acrossVar.Var] = true
+
+		sources := 0
+		for _, specified := range []bool{len(acrossVar.Values) > 0, acrossVar.ValuesFile != "", acrossVar.ValuesVar != ""} {
+			if specified {
+				sources++
+			}
+		}
+
+		if sources == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" has no values")
+		} else if sources > 1 {
+			errorMessages = append(errorMessages, subIdentifier+" must specify only one of values, values_file, or values_var")
+		}
+
+		if acrossVar.MaxInFlight < 0 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid max_in_flight (%d)", acrossVar.MaxInFlight))
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
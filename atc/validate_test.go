@@ -484,6 +484,79 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("var sources", func() {
+		BeforeEach(func() {
+			config.VarSources = VarSourceConfigs{
+				{
+					Name: "some-vault",
+					Type: "vault",
+					Config: map[string]interface{}{
+						"url": "https://vault.example.com",
+					},
+				},
+			}
+
+			config.Resources[0].Source["from-vault"] = "((some-vault:some/path.field))"
+			config.Resources[0].Source["local"] = "((.:some-local-var))"
+			config.Resources[0].Source["global"] = "((some-global-var))"
+		})
+
+		It("allows vars from known var sources", func() {
+			Expect(errorMessages).To(BeEmpty())
+		})
+
+		Context("when vars refer to an unknown var source", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Plan[0].Params["nested"] = map[string]interface{}{
+					"value": "prefix-((bogus-source:some-var))",
+				}
+				config.Jobs[0].Plan[2].Params["other"] = "((other-bogus-source:some-var))"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid var sources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("vars refer to unknown var source 'bogus-source'"))
+				Expect(errorMessages[0]).To(ContainSubstring("vars refer to unknown var source 'other-bogus-source'"))
+			})
+		})
+
+		Context("when a var source has no name or type", func() {
+			BeforeEach(func() {
+				config.VarSources = append(config.VarSources, VarSourceConfig{})
+			})
+
+			It("returns an error describing both errors", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid var sources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources[1] has no name"))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources[1] has no type"))
+			})
+		})
+
+		Context("when a var source has a reserved name", func() {
+			BeforeEach(func() {
+				config.VarSources = append(config.VarSources, VarSourceConfig{Name: ".", Type: "vault"})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources[1] has reserved name '.', which refers to build-local vars"))
+			})
+		})
+
+		Context("when two var sources have the same name", func() {
+			BeforeEach(func() {
+				config.VarSources = append(config.VarSources, config.VarSources...)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources[0] and var_sources[1] have the same name ('some-vault')"))
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job JobConfig
 
@@ -764,6 +837,216 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no file specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline: "some-pipeline",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify any pipeline configuration"))
+				})
+			})
+
+			Context("when a set_pipeline plan has inapplicable fields", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-artifact/pipeline.yml",
+						Privileged:     true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (privileged)"))
+				})
+			})
+
+			Context("when a load_var plan has no file specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar: "some-var",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var does not specify any file"))
+				})
+			})
+
+			Context("when a load_var plan has an unknown format", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-artifact/version",
+						Format:         "toml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var specifies an unknown format ('toml'); must be one of raw, trim, json, or yaml"))
+				})
+
+				Context("when the format is named after the .yml extension", func() {
+					BeforeEach(func() {
+						config.Jobs[len(config.Jobs)-1].Plan[len(job.Plan)-1].Format = "yml"
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("specifies an unknown format ('yml')"))
+					})
+				})
+			})
+
+			Context("when an approve plan is valid", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Approve: "deploy-prod",
+						Timeout: "1h",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when an approve plan has inapplicable fields", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Approve:        "deploy-prod",
+						TaskConfigPath: "some-artifact/approval.yml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approve.deploy-prod has invalid fields specified (file)"))
+				})
+			})
+
+			Context("when a step runs across vars", func() {
+				var plan PlanConfig
+
+				BeforeEach(func() {
+					plan = PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some-artifact/task.yml",
+						Across: []AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.12", "1.13"}},
+						},
+					}
+				})
+
+				JustBeforeEach(func() {
+					job.Plan = append(job.Plan, plan)
+					config.Jobs = append(config.Jobs, job)
+					_, errorMessages = config.Validate()
+				})
+
+				It("returns no errors", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+
+				Context("when a var has no values", func() {
+					BeforeEach(func() {
+						plan.Across = append(plan.Across, AcrossVarConfig{Var: "db"})
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] has no values"))
+					})
+				})
+
+				Context("when a var's values are loaded from a file or a var", func() {
+					BeforeEach(func() {
+						plan.Across = append(plan.Across,
+							AcrossVarConfig{Var: "db", ValuesFile: "some-artifact/dbs.yml"},
+							AcrossVarConfig{Var: "os", ValuesVar: "oses"},
+						)
+					})
+
+					It("returns no errors", func() {
+						Expect(errorMessages).To(BeEmpty())
+					})
+				})
+
+				Context("when a var specifies more than one source of values", func() {
+					BeforeEach(func() {
+						plan.Across = append(plan.Across, AcrossVarConfig{
+							Var:        "db",
+							Values:     []interface{}{"postgres"},
+							ValuesFile: "some-artifact/dbs.yml",
+						})
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] must specify only one of values, values_file, or values_var"))
+					})
+				})
+
+				Context("when a var is repeated", func() {
+					BeforeEach(func() {
+						plan.Across = append(plan.Across, AcrossVarConfig{Var: "go", Values: []interface{}{"1.14"}})
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] repeats var 'go'"))
+					})
+				})
+
+				Context("when the step is a get", func() {
+					BeforeEach(func() {
+						plan = PlanConfig{
+							Get: "some-resource",
+							Across: []AcrossVarConfig{
+								{Var: "go", Values: []interface{}{"1.12"}},
+							},
+						}
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource cannot run a get step across vars"))
+					})
+				})
+
+				Context("when fail_fast is specified without across", func() {
+					BeforeEach(func() {
+						plan.Across = nil
+						plan.FailFast = true
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task specifies fail_fast without across"))
+					})
+				})
+			})
+
 			Context("when a task plan is invalid", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1222,6 +1505,53 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/other-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a malformed job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job in another pipeline with an invalid name ('other-pipeline/'); expected 'pipeline/job'"))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job in a pipeline instance", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/branch:feature/other-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job in a pipeline instance ('other-pipeline/branch:feature/other-job'); only pipelines without instance vars can be referenced"))
+				})
+			})
+
 			Context("when a job's input's passed constraints reference a bogus job", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1358,5 +1688,47 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a valid schedule", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &ScheduleConfig{
+					Cron:     "0 2 * * *",
+					Timezone: "America/Toronto",
+					CatchUp:  ScheduleCatchUpOnce,
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job has a schedule with no cron expression", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &ScheduleConfig{}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule has no cron expression"))
+			})
+		})
+
+		Context("when a job has an invalid schedule", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &ScheduleConfig{
+					Cron:     "0 61 * * *",
+					Timezone: "Mars/Olympus_Mons",
+					CatchUp:  "always",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule has invalid cron expression '0 61 * * *'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule has unknown timezone 'Mars/Olympus_Mons'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule has invalid catch_up policy 'always' (must be 'skip' or 'once')"))
+			})
+		})
+
 	})
 })
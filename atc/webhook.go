@@ -0,0 +1,38 @@
+package atc
+
+const (
+	WebhookTypeGitHub  = "github"
+	WebhookTypeGitLab  = "gitlab"
+	WebhookTypeGeneric = "generic"
+)
+
+// WebhookTypes are the kinds of payload a team webhook can receive.
+var WebhookTypes = []string{WebhookTypeGitHub, WebhookTypeGitLab, WebhookTypeGeneric}
+
+func IsValidWebhookType(webhookType string) bool {
+	for _, t := range WebhookTypes {
+		if t == webhookType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Webhook is a team-level webhook which checks every resource in the team's
+// pipelines whose source matches the payload it receives.
+type Webhook struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// Secret verifies the payloads received by the webhook. It is never
+	// returned by the API.
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookCheckedResource is a resource checked as a result of a webhook
+// payload.
+type WebhookCheckedResource struct {
+	PipelineName string `json:"pipeline_name"`
+	ResourceName string `json:"resource_name"`
+}
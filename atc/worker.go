@@ -5,6 +5,11 @@ import (
 	"regexp"
 )
 
+const (
+	WorkerRuntimeGarden     = "garden"
+	WorkerRuntimeKubernetes = "kubernetes"
+)
+
 type Worker struct {
 	// not garden_addr, for backwards-compatibility
 	GardenAddr      string `json:"addr"`
@@ -12,6 +17,14 @@ type Worker struct {
 
 	CertsPath *string `json:"certs_path,omitempty"`
 
+	// Runtime is the backend running the worker's containers. Workers
+	// registered without one run on Garden.
+	Runtime string `json:"runtime,omitempty"`
+
+	// Namespace is the Kubernetes namespace in which the pods and volume
+	// claims of a Kubernetes worker are created.
+	Namespace string `json:"namespace,omitempty"`
+
 	HTTPProxyURL  string `json:"http_proxy_url,omitempty"`
 	HTTPSProxyURL string `json:"https_proxy_url,omitempty"`
 	NoProxy       string `json:"no_proxy,omitempty"`
@@ -19,6 +32,14 @@ type Worker struct {
 	ActiveContainers int `json:"active_containers"`
 	ActiveVolumes    int `json:"active_volumes"`
 
+	// MaxContainers is the number of active containers at which no more
+	// containers are placed on the worker. 0 means no limit.
+	MaxContainers int `json:"max_containers,omitempty"`
+
+	// ActiveTasks is the number of task steps running on the worker. It is
+	// tracked by the ATC and ignored when a worker registers.
+	ActiveTasks int `json:"active_tasks,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
@@ -33,6 +54,8 @@ type Worker struct {
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
+var ErrMissingWorkerNamespace = errors.New("missing kubernetes namespace")
+var ErrUnknownWorkerRuntime = errors.New("unknown worker runtime")
 var ErrNoWorkers = errors.New("no workers available for checking")
 
 func (w Worker) Validate() error {
@@ -40,8 +63,19 @@ func (w Worker) Validate() error {
 		return ErrInvalidWorkerVersion
 	}
 
-	if len(w.GardenAddr) == 0 {
-		return ErrMissingWorkerGardenAddress
+	switch w.Runtime {
+	case "", WorkerRuntimeGarden:
+		if len(w.GardenAddr) == 0 {
+			return ErrMissingWorkerGardenAddress
+		}
+
+	case WorkerRuntimeKubernetes:
+		if len(w.Namespace) == 0 {
+			return ErrMissingWorkerNamespace
+		}
+
+	default:
+		return ErrUnknownWorkerRuntime
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 )
 
 type WorkerSpec struct {
@@ -42,6 +43,10 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Type of step the container is for. Only set for task containers, the
+	// number of which placement strategies may limit per worker.
+	Type db.ContainerType
 }
 
 //go:generate counterfeiter . InputSource
@@ -5,8 +5,11 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
 	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/worker/transport"
 	"github.com/concourse/retryhttp"
@@ -29,6 +32,7 @@ type dbWorkerProvider struct {
 	dbWorkerFactory                   db.WorkerFactory
 	workerVersion                     version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
+	runtimes                          RuntimeFactories
 }
 
 func NewDBWorkerProvider(
@@ -45,6 +49,7 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion version.Version,
 	baggageclaimResponseHeaderTimeout time.Duration,
+	runtimes RuntimeFactories,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -60,6 +65,7 @@ func NewDBWorkerProvider(
 		dbWorkerFactory:                   workerFactory,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		runtimes:                          runtimes,
 	}
 }
 
@@ -84,13 +90,13 @@ func (provider *dbWorkerProvider) RunningWorkers(logger lager.Logger) ([]Worker,
 		}
 
 		workerLog := logger.Session("running-worker")
-		worker := provider.NewGardenWorker(
+		worker, found := provider.newWorker(
 			workerLog,
 			tikTok,
 			savedWorker,
 			buildContainersCountPerWorker[savedWorker.Name()],
 		)
-		if !worker.IsVersionCompatible(workerLog, provider.workerVersion) {
+		if !found || !worker.IsVersionCompatible(workerLog, provider.workerVersion) {
 			continue
 		}
 
@@ -112,8 +118,8 @@ func (provider *dbWorkerProvider) FindWorkersForContainerByOwner(
 
 	var workers []Worker
 	for _, w := range dbWorkers {
-		worker := provider.NewGardenWorker(logger, clock.NewClock(), w, 0)
-		if worker.IsVersionCompatible(logger, provider.workerVersion) {
+		worker, found := provider.newWorker(logger, clock.NewClock(), w, 0)
+		if found && worker.IsVersionCompatible(logger, provider.workerVersion) {
 			workers = append(workers, worker)
 		}
 	}
@@ -138,8 +144,8 @@ func (provider *dbWorkerProvider) FindWorkerForContainer(
 		return nil, false, nil
 	}
 
-	worker := provider.NewGardenWorker(logger, clock.NewClock(), dbWorker, 0)
-	if !worker.IsVersionCompatible(logger, provider.workerVersion) {
+	worker, found := provider.newWorker(logger, clock.NewClock(), dbWorker, 0)
+	if !found || !worker.IsVersionCompatible(logger, provider.workerVersion) {
 		return nil, false, nil
 	}
 	return worker, true, err
@@ -162,8 +168,8 @@ func (provider *dbWorkerProvider) FindWorkerForVolume(
 		return nil, false, nil
 	}
 
-	worker := provider.NewGardenWorker(logger, clock.NewClock(), dbWorker, 0)
-	if !worker.IsVersionCompatible(logger, provider.workerVersion) {
+	worker, found := provider.newWorker(logger, clock.NewClock(), dbWorker, 0)
+	if !found || !worker.IsVersionCompatible(logger, provider.workerVersion) {
 		return nil, false, nil
 	}
 	return worker, true, err
@@ -178,8 +184,6 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.retryBackOffFactory,
 	)
 
-	gClient := gcf.NewClient()
-
 	bClient := bclient.New("", transport.NewBaggageclaimRoundTripper(
 		savedWorker.Name(),
 		savedWorker.BaggageclaimURL(),
@@ -190,6 +194,36 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		},
 	))
 
+	return provider.newWorkerWithClients(gcf.NewClient(), bClient, savedWorker, buildContainersCount)
+}
+
+// newWorker constructs the Worker for a saved worker according to its
+// runtime. Workers whose runtime is not configured on this ATC are not found.
+func (provider *dbWorkerProvider) newWorker(logger lager.Logger, tikTok clock.Clock, savedWorker db.Worker, buildContainersCount int) (Worker, bool) {
+	switch savedWorker.Runtime() {
+	case "", atc.WorkerRuntimeGarden:
+		return provider.NewGardenWorker(logger, tikTok, savedWorker, buildContainersCount), true
+	}
+
+	runtime, found := provider.runtimes[savedWorker.Runtime()]
+	if !found {
+		logger.Info("unsupported-worker-runtime", lager.Data{
+			"worker":  savedWorker.Name(),
+			"runtime": savedWorker.Runtime(),
+		})
+
+		return nil, false
+	}
+
+	return provider.newWorkerWithClients(
+		runtime.NewGardenClient(logger, savedWorker),
+		runtime.NewBaggageclaimClient(logger, savedWorker),
+		savedWorker,
+		buildContainersCount,
+	), true
+}
+
+func (provider *dbWorkerProvider) newWorkerWithClients(gClient garden.Client, bClient baggageclaim.Client, savedWorker db.Worker, buildContainersCount int) Worker {
 	volumeClient := NewVolumeClient(
 		bClient,
 		savedWorker,
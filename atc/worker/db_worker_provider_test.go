@@ -13,6 +13,7 @@ import (
 	"code.cloudfoundry.org/garden/server"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -59,6 +60,9 @@ var _ = Describe("DBProvider", func() {
 
 		fakeDBTeam *dbfakes.FakeTeam
 
+		fakeRuntimeFactory      *workerfakes.FakeRuntimeFactory
+		fakeRuntimeGardenClient *gfakes.FakeClient
+
 		workers    []Worker
 		workersErr error
 
@@ -162,6 +166,11 @@ var _ = Describe("DBProvider", func() {
 		wantWorkerVersion, err = version.NewVersionFromString("1.1.0")
 		Expect(err).ToNot(HaveOccurred())
 
+		fakeRuntimeFactory = new(workerfakes.FakeRuntimeFactory)
+		fakeRuntimeGardenClient = new(gfakes.FakeClient)
+		fakeRuntimeFactory.NewGardenClientReturns(fakeRuntimeGardenClient)
+		fakeRuntimeFactory.NewBaggageclaimClientReturns(new(baggageclaimfakes.FakeClient))
+
 		provider = NewDBWorkerProvider(
 			fakeLockFactory,
 			fakeBackOffFactory,
@@ -176,6 +185,7 @@ var _ = Describe("DBProvider", func() {
 			fakeDBWorkerFactory,
 			wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
+			RuntimeFactories{atc.WorkerRuntimeKubernetes: fakeRuntimeFactory},
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
@@ -221,6 +231,54 @@ var _ = Describe("DBProvider", func() {
 				Expect([]int{workers[0].BuildContainers(), workers[1].BuildContainers()}).To(ConsistOf(57, 68))
 			})
 
+			Context("when a worker runs on another runtime", func() {
+				var kubernetesWorker *dbfakes.FakeWorker
+
+				BeforeEach(func() {
+					kubernetesVersion := "1.2.3"
+
+					kubernetesWorker = new(dbfakes.FakeWorker)
+					kubernetesWorker.NameReturns("kubernetes-worker")
+					kubernetesWorker.RuntimeReturns(atc.WorkerRuntimeKubernetes)
+					kubernetesWorker.NamespaceReturns("some-namespace")
+					kubernetesWorker.StateReturns(db.WorkerStateRunning)
+					kubernetesWorker.VersionReturns(&kubernetesVersion)
+
+					unknownWorker := new(dbfakes.FakeWorker)
+					unknownWorker.NameReturns("unknown-worker")
+					unknownWorker.RuntimeReturns("bogus")
+					unknownWorker.StateReturns(db.WorkerStateRunning)
+					unknownWorker.VersionReturns(&kubernetesVersion)
+
+					fakeDBWorkerFactory.WorkersReturns(
+						[]db.Worker{
+							fakeWorker1,
+							kubernetesWorker,
+							unknownWorker,
+						}, nil)
+				})
+
+				It("constructs the worker with the runtime's clients", func() {
+					Expect(workers).To(HaveLen(2))
+					Expect(workers[1].Name()).To(Equal("kubernetes-worker"))
+					Expect(workers[1].Runtime()).To(Equal(atc.WorkerRuntimeKubernetes))
+					Expect(workers[1].GardenClient()).To(Equal(fakeRuntimeGardenClient))
+
+					Expect(fakeRuntimeFactory.NewGardenClientCallCount()).To(Equal(1))
+					_, savedWorker := fakeRuntimeFactory.NewGardenClientArgsForCall(0)
+					Expect(savedWorker).To(Equal(kubernetesWorker))
+
+					Expect(fakeRuntimeFactory.NewBaggageclaimClientCallCount()).To(Equal(1))
+					_, savedWorker = fakeRuntimeFactory.NewBaggageclaimClientArgsForCall(0)
+					Expect(savedWorker).To(Equal(kubernetesWorker))
+				})
+
+				It("skips workers whose runtime is not configured", func() {
+					Expect(workersErr).NotTo(HaveOccurred())
+					Expect(workers[0].Runtime()).To(Equal(atc.WorkerRuntimeGarden))
+				})
+			})
+
 			Context("when some of the workers returned are stalled or landing", func() {
 				BeforeEach(func() {
 					landingWorker := new(dbfakes.FakeWorker)
@@ -0,0 +1,152 @@
+package worker
+
+import (
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . WorkerDemand
+
+// WorkerDemand reports how many steps are running on or waiting for each
+// kind of worker, and how many workers of that kind would be needed.
+type WorkerDemand interface {
+	Report(lager.Logger) (atc.WorkerDemand, error)
+}
+
+// NewWorkerDemand returns a WorkerDemand which recommends enough workers to
+// run containersPerWorker build containers each, or the current number of
+// workers if containersPerWorker is 0. Pending builds have no plan yet, so
+// each counts as one container for the workers of its team, or the shared
+// workers if the team has none.
+func NewWorkerDemand(
+	workerFactory db.WorkerFactory,
+	buildFactory db.BuildFactory,
+	waitingSteps db.WaitingSteps,
+	containersPerWorker int,
+) WorkerDemand {
+	return &workerDemand{
+		workerFactory:       workerFactory,
+		buildFactory:        buildFactory,
+		waitingSteps:        waitingSteps,
+		containersPerWorker: containersPerWorker,
+	}
+}
+
+type workerDemand struct {
+	workerFactory       db.WorkerFactory
+	buildFactory        db.BuildFactory
+	waitingSteps        db.WaitingSteps
+	containersPerWorker int
+}
+
+type demandKey struct {
+	team     string
+	tags     string
+	platform string
+}
+
+func (demand *workerDemand) Report(logger lager.Logger) (atc.WorkerDemand, error) {
+	logger = logger.Session("report-worker-demand")
+
+	workers, err := demand.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return atc.WorkerDemand{}, err
+	}
+
+	containersByWorker, err := demand.workerFactory.BuildContainersCountPerWorker()
+	if err != nil {
+		logger.Error("failed-to-get-build-containers", err)
+		return atc.WorkerDemand{}, err
+	}
+
+	pendingBuilds, err := demand.buildFactory.ReadyPendingBuildsCountPerTeam()
+	if err != nil {
+		logger.Error("failed-to-get-pending-builds", err)
+		return atc.WorkerDemand{}, err
+	}
+
+	waitingSteps, err := demand.waitingSteps.Steps()
+	if err != nil {
+		logger.Error("failed-to-get-waiting-steps", err)
+		return atc.WorkerDemand{}, err
+	}
+
+	groups := map[demandKey]*atc.WorkerDemandGroup{}
+	group := func(team string, tags []string, platform string) *atc.WorkerDemandGroup {
+		sortedTags := append([]string{}, tags...)
+		sort.Strings(sortedTags)
+
+		key := demandKey{team, strings.Join(sortedTags, ","), platform}
+		if _, found := groups[key]; !found {
+			groups[key] = &atc.WorkerDemandGroup{
+				Team:     team,
+				Tags:     sortedTags,
+				Platform: platform,
+			}
+		}
+
+		return groups[key]
+	}
+
+	teamsWithWorkers := map[int]string{}
+	for _, worker := range workers {
+		g := group(worker.TeamName(), worker.Tags(), worker.Platform())
+		g.RunningSteps += containersByWorker[worker.Name()]
+
+		if worker.State() == db.WorkerStateRunning {
+			g.Workers++
+		}
+
+		if worker.TeamID() != 0 {
+			teamsWithWorkers[worker.TeamID()] = worker.TeamName()
+		}
+	}
+
+	for teamID, count := range pendingBuilds {
+		group(teamsWithWorkers[teamID], nil, "").PendingBuilds += count
+	}
+
+	for _, step := range waitingSteps {
+		group(teamsWithWorkers[step.TeamID], step.Tags, step.Platform).WaitingSteps++
+	}
+
+	report := atc.WorkerDemand{
+		ContainersPerWorker: demand.containersPerWorker,
+		Groups:              []atc.WorkerDemandGroup{},
+	}
+
+	for _, g := range groups {
+		g.RecommendedWorkers = demand.recommendedWorkers(*g)
+		report.Groups = append(report.Groups, *g)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.Team != b.Team {
+			return a.Team < b.Team
+		}
+
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+
+		return strings.Join(a.Tags, ",") < strings.Join(b.Tags, ",")
+	})
+
+	return report, nil
+}
+
+func (demand *workerDemand) recommendedWorkers(group atc.WorkerDemandGroup) int {
+	if demand.containersPerWorker <= 0 {
+		return group.Workers
+	}
+
+	containers := group.RunningSteps + group.PendingBuilds + group.WaitingSteps
+
+	return (containers + demand.containersPerWorker - 1) / demand.containersPerWorker
+}
@@ -0,0 +1,156 @@
+package worker_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerDemand", func() {
+	var (
+		logger            *lagertest.TestLogger
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		fakeBuildFactory  *dbfakes.FakeBuildFactory
+		fakeWaitingSteps  *dbfakes.FakeWaitingSteps
+
+		demand WorkerDemand
+		report atc.WorkerDemand
+		err    error
+	)
+
+	newWorker := func(name string, teamID int, teamName string, tags []string, state db.WorkerState) *dbfakes.FakeWorker {
+		worker := new(dbfakes.FakeWorker)
+		worker.NameReturns(name)
+		worker.TeamIDReturns(teamID)
+		worker.TeamNameReturns(teamName)
+		worker.TagsReturns(tags)
+		worker.PlatformReturns("linux")
+		worker.StateReturns(state)
+		return worker
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeWaitingSteps = new(dbfakes.FakeWaitingSteps)
+
+		demand = NewWorkerDemand(fakeWorkerFactory, fakeBuildFactory, fakeWaitingSteps, 10)
+
+		fakeWorkerFactory.WorkersReturns([]db.Worker{
+			newWorker("shared-1", 0, "", nil, db.WorkerStateRunning),
+			newWorker("shared-2", 0, "", nil, db.WorkerStateLanding),
+			newWorker("team-1", 3, "some-team", []string{"b", "a"}, db.WorkerStateRunning),
+		}, nil)
+
+		fakeWorkerFactory.BuildContainersCountPerWorkerReturns(map[string]int{
+			"shared-1": 12,
+			"shared-2": 3,
+			"team-1":   4,
+		}, nil)
+
+		fakeBuildFactory.ReadyPendingBuildsCountPerTeamReturns(map[int]int{
+			1: 1,
+			3: 1,
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		report, err = demand.Report(logger)
+	})
+
+	It("groups the demand by team, tags and platform", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(Equal(atc.WorkerDemand{
+			ContainersPerWorker: 10,
+			Groups: []atc.WorkerDemandGroup{
+				{
+					Tags:               []string{},
+					Platform:           "",
+					PendingBuilds:      1,
+					RecommendedWorkers: 1,
+				},
+				{
+					Tags:               []string{},
+					Platform:           "linux",
+					Workers:            1,
+					RunningSteps:       15,
+					RecommendedWorkers: 2,
+				},
+				{
+					Team:               "some-team",
+					Tags:               []string{},
+					PendingBuilds:      1,
+					RecommendedWorkers: 1,
+				},
+				{
+					Team:               "some-team",
+					Tags:               []string{"a", "b"},
+					Platform:           "linux",
+					Workers:            1,
+					RunningSteps:       4,
+					RecommendedWorkers: 1,
+				},
+			},
+		}))
+	})
+
+	Context("when steps are waiting for a worker", func() {
+		BeforeEach(func() {
+			fakeWaitingSteps.StepsReturns([]db.WaitingStep{
+				{TeamID: 3, Tags: []string{"b", "a"}, Platform: "linux"},
+				{TeamID: 1, Platform: "linux"},
+			}, nil)
+		})
+
+		It("counts them against the workers they wait for", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Groups[1].WaitingSteps).To(Equal(1))
+			Expect(report.Groups[1].RecommendedWorkers).To(Equal(2))
+			Expect(report.Groups[3].WaitingSteps).To(Equal(1))
+			Expect(report.Groups[3].RecommendedWorkers).To(Equal(1))
+		})
+	})
+
+	Context("when there is no target number of containers per worker", func() {
+		BeforeEach(func() {
+			demand = NewWorkerDemand(fakeWorkerFactory, fakeBuildFactory, fakeWaitingSteps, 0)
+		})
+
+		It("recommends the current number of workers", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Groups[0].RecommendedWorkers).To(Equal(0))
+			Expect(report.Groups[1].RecommendedWorkers).To(Equal(1))
+		})
+	})
+
+	Context("when getting the pending builds fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuildFactory.ReadyPendingBuildsCountPerTeamReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+
+	Context("when getting the waiting steps fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWaitingSteps.StepsReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,622 @@
+package kubernetes
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/DataDog/zstd"
+	"github.com/concourse/baggageclaim"
+	multierror "github.com/hashicorp/go-multierror"
+	uuid "github.com/nu7hatch/gouuid"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+var ErrImageVolumeNotStreamable = errors.New("image volumes cannot be streamed")
+
+type UnsupportedStrategyError struct {
+	Strategy baggageclaim.Strategy
+}
+
+func (err UnsupportedStrategyError) Error() string {
+	return fmt.Sprintf("unsupported volume strategy: %T", err.Strategy)
+}
+
+type baggageclaimClient struct {
+	clientset  kubernetes.Interface
+	executor   Executor
+	namespace  string
+	workerName string
+	config     Config
+}
+
+// NewBaggageclaimClient returns a baggageclaim.Client which backs each volume
+// by a persistent volume claim in the namespace.
+//
+// Volumes imported from a path outside of VolumesRoot are the images of the
+// worker's resource types, which Kubernetes pulls itself; they are recorded
+// as config maps naming the image rather than copied into a claim.
+func NewBaggageclaimClient(
+	clientset kubernetes.Interface,
+	executor Executor,
+	namespace string,
+	workerName string,
+	config Config,
+) baggageclaim.Client {
+	return newBaggageclaimClient(clientset, executor, namespace, workerName, config)
+}
+
+func newBaggageclaimClient(
+	clientset kubernetes.Interface,
+	executor Executor,
+	namespace string,
+	workerName string,
+	config Config,
+) *baggageclaimClient {
+	return &baggageclaimClient{
+		clientset:  clientset,
+		executor:   executor,
+		namespace:  namespace,
+		workerName: workerName,
+		config:     config,
+	}
+}
+
+func (client *baggageclaimClient) CreateVolume(logger lager.Logger, handle string, spec baggageclaim.VolumeSpec) (baggageclaim.Volume, error) {
+	properties, err := json.Marshal(spec.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:   handle,
+		Labels: client.labels(roleVolume),
+		Annotations: map[string]string{
+			propertiesAnnotation: string(properties),
+			privilegedAnnotation: strconv.FormatBool(spec.Privileged),
+		},
+	}
+
+	switch strategy := spec.Strategy.(type) {
+	case baggageclaim.EmptyStrategy:
+		return client.createClaim(meta)
+
+	case baggageclaim.ImportStrategy:
+		parent, _, isVolume := volumeHandle(strategy.Path)
+		if !isVolume {
+			return client.createImage(meta, strategy.Path)
+		}
+
+		return client.createCopy(logger, meta, parent)
+
+	case baggageclaim.COWStrategy:
+		return client.createCopy(logger, meta, strategy.Parent.Handle())
+
+	default:
+		return nil, UnsupportedStrategyError{Strategy: spec.Strategy}
+	}
+}
+
+func (client *baggageclaimClient) ListVolumes(logger lager.Logger, properties baggageclaim.VolumeProperties) (baggageclaim.Volumes, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: client.selector().String(),
+	}
+
+	claims, err := client.clientset.CoreV1().PersistentVolumeClaims(client.namespace).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps, err := client.clientset.CoreV1().ConfigMaps(client.namespace).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	metas := []metav1.ObjectMeta{}
+	for _, claim := range claims.Items {
+		metas = append(metas, claim.ObjectMeta)
+	}
+
+	for _, configMap := range configMaps.Items {
+		metas = append(metas, configMap.ObjectMeta)
+	}
+
+	volumes := baggageclaim.Volumes{}
+	for _, meta := range metas {
+		volumeProperties, err := decodeProperties(meta)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matchProperties(volumeProperties, properties) {
+			continue
+		}
+
+		volumes = append(volumes, client.newVolume(meta.Name))
+	}
+
+	return volumes, nil
+}
+
+func (client *baggageclaimClient) LookupVolume(logger lager.Logger, handle string) (baggageclaim.Volume, bool, error) {
+	meta, _, found, err := client.lookup(handle)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found || meta.Labels[workerLabel] != client.workerName {
+		return nil, false, nil
+	}
+
+	return client.newVolume(handle), true, nil
+}
+
+func (client *baggageclaimClient) DestroyVolumes(logger lager.Logger, handles []string) error {
+	var errs error
+	for _, handle := range handles {
+		err := client.DestroyVolume(logger, handle)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (client *baggageclaimClient) DestroyVolume(logger lager.Logger, handle string) error {
+	err := client.clientset.CoreV1().PersistentVolumeClaims(client.namespace).Delete(handle, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	err = client.clientset.CoreV1().ConfigMaps(client.namespace).Delete(handle, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (client *baggageclaimClient) createClaim(meta metav1.ObjectMeta) (baggageclaim.Volume, error) {
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: meta,
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: client.config.VolumeSize,
+				},
+			},
+		},
+	}
+
+	if client.config.StorageClass != "" {
+		storageClass := client.config.StorageClass
+		claim.Spec.StorageClassName = &storageClass
+	}
+
+	_, err := client.clientset.CoreV1().PersistentVolumeClaims(client.namespace).Create(claim)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.newVolume(meta.Name), nil
+}
+
+func (client *baggageclaimClient) createImage(meta metav1.ObjectMeta, image string) (baggageclaim.Volume, error) {
+	meta.Annotations[imageAnnotation] = image
+
+	_, err := client.clientset.CoreV1().ConfigMaps(client.namespace).Create(&v1.ConfigMap{
+		ObjectMeta: meta,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client.newVolume(meta.Name), nil
+}
+
+// createCopy creates a volume with the contents of its parent. A copy of an
+// image volume names the same image; a copy of a claim is populated by a pod
+// mounting both claims.
+func (client *baggageclaimClient) createCopy(logger lager.Logger, meta metav1.ObjectMeta, parent string) (baggageclaim.Volume, error) {
+	parentMeta, isClaim, found, err := client.lookup(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, baggageclaim.ErrVolumeNotFound
+	}
+
+	if !isClaim {
+		return client.createImage(meta, parentMeta.Annotations[imageAnnotation])
+	}
+
+	volume, err := client.createClaim(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.withStreamer([]string{parent, meta.Name}, func(pod string) error {
+		return runScript(
+			client.executor,
+			client.namespace,
+			pod,
+			mainContainer,
+			"/bin/busybox",
+			`/bin/busybox cp -a "$0/." "$1/"`,
+			[]string{streamerMountPath(parent), streamerMountPath(meta.Name)},
+			nil,
+			nil,
+		)
+	})
+	if err != nil {
+		logger.Error("failed-to-copy-volume", err, lager.Data{"parent": parent, "handle": meta.Name})
+
+		_ = client.DestroyVolume(logger, meta.Name)
+
+		return nil, err
+	}
+
+	return volume, nil
+}
+
+// lookup finds the claim or config map of a volume.
+func (client *baggageclaimClient) lookup(handle string) (metav1.ObjectMeta, bool, bool, error) {
+	claim, err := client.clientset.CoreV1().PersistentVolumeClaims(client.namespace).Get(handle, metav1.GetOptions{})
+	if err == nil {
+		return claim.ObjectMeta, true, true, nil
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return metav1.ObjectMeta{}, false, false, err
+	}
+
+	configMap, err := client.clientset.CoreV1().ConfigMaps(client.namespace).Get(handle, metav1.GetOptions{})
+	if err == nil {
+		return configMap.ObjectMeta, false, true, nil
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return metav1.ObjectMeta{}, false, false, err
+	}
+
+	return metav1.ObjectMeta{}, false, false, nil
+}
+
+// updateAnnotations updates the annotations of a volume's claim or config
+// map, retrying when it was updated concurrently.
+func (client *baggageclaimClient) updateAnnotations(handle string, update func(map[string]string) error) error {
+	for {
+		claims := client.clientset.CoreV1().PersistentVolumeClaims(client.namespace)
+
+		claim, err := claims.Get(handle, metav1.GetOptions{})
+		if err == nil {
+			if claim.Annotations == nil {
+				claim.Annotations = map[string]string{}
+			}
+
+			err = update(claim.Annotations)
+			if err != nil {
+				return err
+			}
+
+			_, err = claims.Update(claim)
+			if k8serrors.IsConflict(err) {
+				continue
+			}
+
+			return err
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		configMaps := client.clientset.CoreV1().ConfigMaps(client.namespace)
+
+		configMap, err := configMaps.Get(handle, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return baggageclaim.ErrVolumeNotFound
+			}
+
+			return err
+		}
+
+		if configMap.Annotations == nil {
+			configMap.Annotations = map[string]string{}
+		}
+
+		err = update(configMap.Annotations)
+		if err != nil {
+			return err
+		}
+
+		_, err = configMaps.Update(configMap)
+		if k8serrors.IsConflict(err) {
+			continue
+		}
+
+		return err
+	}
+}
+
+// withStreamer runs a pod mounting the claims of the given volumes, so that
+// their contents can be read and written, and deletes it once done.
+func (client *baggageclaimClient) withStreamer(handles []string, run func(string) error) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	name := "streamer-" + id.String()
+
+	volumes := []v1.Volume{}
+	mounts := []v1.VolumeMount{}
+	for i, handle := range handles {
+		volumeName := fmt.Sprintf("volume-%d", i)
+
+		volumes = append(volumes, v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: handle,
+				},
+			},
+		})
+
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: streamerMountPath(handle),
+		})
+	}
+
+	pods := client.clientset.CoreV1().Pods(client.namespace)
+
+	_, err = pods.Create(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: client.labels(roleStreamer),
+		},
+		Spec: v1.PodSpec{
+			RestartPolicy: v1.RestartPolicyNever,
+			Containers: []v1.Container{
+				{
+					Name:         mainContainer,
+					Image:        client.config.HelperImage,
+					Command:      append([]string{"/bin/busybox"}, keepAlive...),
+					VolumeMounts: mounts,
+				},
+			},
+			Volumes: volumes,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	defer deletePod(pods, name)
+
+	err = waitForPod(pods, name, client.config.PodStartTimeout)
+	if err != nil {
+		return err
+	}
+
+	return run(name)
+}
+
+func (client *baggageclaimClient) labels(role string) map[string]string {
+	return map[string]string{
+		workerLabel: client.workerName,
+		roleLabel:   role,
+	}
+}
+
+func (client *baggageclaimClient) selector() labels.Selector {
+	return labels.SelectorFromSet(client.labels(roleVolume))
+}
+
+func streamerMountPath(handle string) string {
+	return path.Join(VolumesRoot, handle)
+}
+
+type volume struct {
+	client *baggageclaimClient
+	handle string
+}
+
+func (client *baggageclaimClient) newVolume(handle string) *volume {
+	return &volume{
+		client: client,
+		handle: handle,
+	}
+}
+
+func (v *volume) Handle() string {
+	return v.handle
+}
+
+func (v *volume) Path() string {
+	return path.Join(VolumesRoot, v.handle)
+}
+
+func (v *volume) SetProperty(key string, value string) error {
+	return v.client.updateAnnotations(v.handle, func(annotations map[string]string) error {
+		properties := map[string]string{}
+
+		encoded := annotations[propertiesAnnotation]
+		if encoded != "" {
+			err := json.Unmarshal([]byte(encoded), &properties)
+			if err != nil {
+				return err
+			}
+		}
+
+		if properties == nil {
+			properties = map[string]string{}
+		}
+
+		properties[key] = value
+
+		payload, err := json.Marshal(properties)
+		if err != nil {
+			return err
+		}
+
+		annotations[propertiesAnnotation] = string(payload)
+		return nil
+	})
+}
+
+func (v *volume) SetPrivileged(privileged bool) error {
+	return v.client.updateAnnotations(v.handle, func(annotations map[string]string) error {
+		annotations[privilegedAnnotation] = strconv.FormatBool(privileged)
+		return nil
+	})
+}
+
+func (v *volume) GetPrivileged() (bool, error) {
+	meta, err := v.meta()
+	if err != nil {
+		return false, err
+	}
+
+	return meta.Annotations[privilegedAnnotation] == "true", nil
+}
+
+func (v *volume) StreamIn(destination string, encoding baggageclaim.Encoding, tarStream io.Reader) error {
+	decoded, err := decode(encoding, tarStream)
+	if err != nil {
+		return err
+	}
+
+	defer decoded.Close()
+
+	return v.stream(func(pod string, mountPath string) error {
+		return runScript(
+			v.client.executor,
+			v.client.namespace,
+			pod,
+			mainContainer,
+			"/bin/busybox",
+			`/bin/busybox mkdir -p "$0" && /bin/busybox tar -xf - -C "$0"`,
+			[]string{path.Join(mountPath, destination)},
+			decoded,
+			nil,
+		)
+	})
+}
+
+// StreamOut streams the contents of a directory, or a single file, within the
+// volume.
+func (v *volume) StreamOut(source string, encoding baggageclaim.Encoding) (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+
+	encoder, err := encode(encoding, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		err := v.stream(func(pod string, mountPath string) error {
+			return runScript(
+				v.client.executor,
+				v.client.namespace,
+				pod,
+				mainContainer,
+				"/bin/busybox",
+				`if [ -d "$0/$1" ]; then /bin/busybox tar -cf - -C "$0/$1" .; else /bin/busybox tar -cf - -C "$0/$(/bin/busybox dirname "$1")" "$(/bin/busybox basename "$1")"; fi`,
+				[]string{mountPath, strings.TrimPrefix(path.Clean("/"+source), "/")},
+				nil,
+				encoder,
+			)
+		})
+
+		if err == nil {
+			err = encoder.Close()
+		}
+
+		_ = writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+func (v *volume) Properties() (baggageclaim.VolumeProperties, error) {
+	meta, err := v.meta()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeProperties(meta)
+}
+
+func (v *volume) Destroy() error {
+	return v.client.DestroyVolume(lager.NewLogger("volume"), v.handle)
+}
+
+func (v *volume) meta() (metav1.ObjectMeta, error) {
+	meta, _, found, err := v.client.lookup(v.handle)
+	if err != nil {
+		return metav1.ObjectMeta{}, err
+	}
+
+	if !found {
+		return metav1.ObjectMeta{}, baggageclaim.ErrVolumeNotFound
+	}
+
+	return meta, nil
+}
+
+func (v *volume) stream(run func(pod string, mountPath string) error) error {
+	_, isClaim, found, err := v.client.lookup(v.handle)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return baggageclaim.ErrVolumeNotFound
+	}
+
+	if !isClaim {
+		return ErrImageVolumeNotStreamable
+	}
+
+	return v.client.withStreamer([]string{v.handle}, func(pod string) error {
+		return run(pod, streamerMountPath(v.handle))
+	})
+}
+
+func decode(encoding baggageclaim.Encoding, stream io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case baggageclaim.GzipEncoding:
+		return gzip.NewReader(stream)
+	case baggageclaim.ZstdEncoding:
+		return zstd.NewReader(stream), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
+
+func encode(encoding baggageclaim.Encoding, stream io.Writer) (io.WriteCloser, error) {
+	switch encoding {
+	case baggageclaim.GzipEncoding:
+		return gzip.NewWriter(stream), nil
+	case baggageclaim.ZstdEncoding:
+		return zstd.NewWriter(stream), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
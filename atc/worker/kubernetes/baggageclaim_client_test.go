@@ -0,0 +1,247 @@
+package kubernetes_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/worker/kubernetes"
+	"github.com/concourse/concourse/atc/worker/kubernetes/kubernetesfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("BaggageclaimClient", func() {
+	var (
+		logger       *lagertest.TestLogger
+		clientset    *fake.Clientset
+		fakeExecutor *kubernetesfakes.FakeExecutor
+
+		client baggageclaim.Client
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		clientset = fake.NewSimpleClientset()
+		fakeExecutor = new(kubernetesfakes.FakeExecutor)
+
+		clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*v1.Pod)
+			pod.Status.Phase = v1.PodRunning
+			return false, nil, nil
+		})
+
+		client = kubernetes.NewBaggageclaimClient(
+			clientset,
+			fakeExecutor,
+			"some-namespace",
+			"some-worker",
+			kubernetes.Config{
+				HelperImage:     "some-helper-image",
+				StorageClass:    "some-storage-class",
+				VolumeSize:      resource.MustParse("1Gi"),
+				PodStartTimeout: time.Second,
+			},
+		)
+	})
+
+	getClaim := func(name string) (*v1.PersistentVolumeClaim, error) {
+		return clientset.CoreV1().PersistentVolumeClaims("some-namespace").Get(name, metav1.GetOptions{})
+	}
+
+	streamerPods := func() []v1.Pod {
+		pods, err := clientset.CoreV1().Pods("some-namespace").List(metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pods.Items
+	}
+
+	Describe("CreateVolume", func() {
+		Context("with an empty strategy", func() {
+			It("creates a volume claim", func() {
+				volume, err := client.CreateVolume(logger, "some-handle", baggageclaim.VolumeSpec{
+					Strategy:   baggageclaim.EmptyStrategy{},
+					Properties: baggageclaim.VolumeProperties{"some": "property"},
+					Privileged: true,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(volume.Handle()).To(Equal("some-handle"))
+				Expect(volume.Path()).To(Equal(kubernetes.VolumesRoot + "/some-handle"))
+
+				claim, err := getClaim("some-handle")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claim.Labels).To(Equal(map[string]string{
+					"concourse.ci/worker": "some-worker",
+					"concourse.ci/role":   "volume",
+				}))
+				Expect(*claim.Spec.StorageClassName).To(Equal("some-storage-class"))
+				Expect(claim.Spec.Resources.Requests[v1.ResourceStorage]).To(Equal(resource.MustParse("1Gi")))
+
+				properties, err := volume.Properties()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(properties).To(Equal(baggageclaim.VolumeProperties{"some": "property"}))
+
+				privileged, err := volume.GetPrivileged()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(privileged).To(BeTrue())
+			})
+		})
+
+		Context("when importing a resource type's image", func() {
+			var imageVolume baggageclaim.Volume
+
+			BeforeEach(func() {
+				var err error
+				imageVolume, err = client.CreateVolume(logger, "some-image-handle", baggageclaim.VolumeSpec{
+					Strategy: baggageclaim.ImportStrategy{Path: "some-resource-image"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("records the image rather than creating a claim", func() {
+				_, err := getClaim("some-image-handle")
+				Expect(err).To(HaveOccurred())
+
+				configMap, err := clientset.CoreV1().ConfigMaps("some-namespace").Get("some-image-handle", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configMap.Annotations["concourse.ci/image"]).To(Equal("some-resource-image"))
+			})
+
+			It("copies the image for a copy-on-write volume", func() {
+				_, err := client.CreateVolume(logger, "some-cow-handle", baggageclaim.VolumeSpec{
+					Strategy: baggageclaim.COWStrategy{Parent: imageVolume},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				configMap, err := clientset.CoreV1().ConfigMaps("some-namespace").Get("some-cow-handle", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configMap.Annotations["concourse.ci/image"]).To(Equal("some-resource-image"))
+
+				Expect(fakeExecutor.ExecCallCount()).To(Equal(0))
+			})
+
+			It("cannot be streamed", func() {
+				stream, err := imageVolume.StreamOut(".", baggageclaim.GzipEncoding)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = ioutil.ReadAll(stream)
+				Expect(err).To(Equal(kubernetes.ErrImageVolumeNotStreamable))
+			})
+		})
+
+		Context("with a copy-on-write strategy of a claim", func() {
+			var (
+				parent baggageclaim.Volume
+				err    error
+			)
+
+			BeforeEach(func() {
+				parent, err = client.CreateVolume(logger, "some-parent", baggageclaim.VolumeSpec{
+					Strategy: baggageclaim.EmptyStrategy{},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			JustBeforeEach(func() {
+				_, err = client.CreateVolume(logger, "some-child", baggageclaim.VolumeSpec{
+					Strategy: baggageclaim.COWStrategy{Parent: parent},
+				})
+			})
+
+			It("copies the parent's contents into a new claim with a streamer pod", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err := getClaim("some-child")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeExecutor.ExecCallCount()).To(Equal(1))
+				namespace, _, container, command, _, _ := fakeExecutor.ExecArgsForCall(0)
+				Expect(namespace).To(Equal("some-namespace"))
+				Expect(container).To(Equal("main"))
+				Expect(command[len(command)-2:]).To(Equal([]string{
+					kubernetes.VolumesRoot + "/some-parent",
+					kubernetes.VolumesRoot + "/some-child",
+				}))
+			})
+
+			It("deletes the streamer pod", func() {
+				Expect(streamerPods()).To(BeEmpty())
+			})
+
+			Context("when copying fails", func() {
+				BeforeEach(func() {
+					fakeExecutor.ExecReturns(1, nil)
+				})
+
+				It("destroys the new claim and errors", func() {
+					Expect(err).To(BeAssignableToTypeOf(kubernetes.ScriptFailedError{}))
+
+					_, err := getClaim("some-child")
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("when the exec fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeExecutor.ExecReturns(0, disaster)
+				})
+
+				It("errors", func() {
+					Expect(err).To(Equal(disaster))
+				})
+			})
+		})
+	})
+
+	Describe("ListVolumes", func() {
+		BeforeEach(func() {
+			_, err := client.CreateVolume(logger, "some-handle", baggageclaim.VolumeSpec{
+				Strategy:   baggageclaim.EmptyStrategy{},
+				Properties: baggageclaim.VolumeProperties{"some": "property"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = client.CreateVolume(logger, "some-image-handle", baggageclaim.VolumeSpec{
+				Strategy: baggageclaim.ImportStrategy{Path: "some-image"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lists claims and image volumes matching the properties", func() {
+			volumes, err := client.ListVolumes(logger, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes.Handles()).To(ConsistOf("some-handle", "some-image-handle"))
+
+			volumes, err = client.ListVolumes(logger, baggageclaim.VolumeProperties{"some": "property"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes.Handles()).To(ConsistOf("some-handle"))
+		})
+	})
+
+	Describe("DestroyVolumes", func() {
+		BeforeEach(func() {
+			_, err := client.CreateVolume(logger, "some-handle", baggageclaim.VolumeSpec{
+				Strategy: baggageclaim.EmptyStrategy{},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the volumes, ignoring ones which are already gone", func() {
+			err := client.DestroyVolumes(logger, []string{"some-handle", "bogus-handle"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := client.LookupVolume(logger, "some-handle")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
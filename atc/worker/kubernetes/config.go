@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// WorkerConfig configures a worker whose containers run as pods in a
+// Kubernetes namespace, registered by the ATC itself.
+type WorkerConfig struct {
+	Name      string `long:"name"      description:"The name to register the Kubernetes worker as."`
+	Namespace string `long:"namespace" description:"The namespace in which to run the worker's pods and volume claims."`
+
+	InClusterConfig bool   `long:"in-cluster"  description:"Enables the in-cluster client."`
+	ConfigPath      string `long:"config-path" description:"Path to Kubernetes config when running ATC outside Kubernetes."`
+
+	Tags          []string          `long:"tag"      description:"A tag to set during registration. Can be specified multiple times."`
+	Team          string            `long:"team"     description:"The name of the team that this worker will be assigned to."`
+	ResourceTypes map[string]string `long:"resource" description:"A resource type to advertise for the worker, run from an image. Can be specified multiple times." value-name:"TYPE:IMAGE"`
+
+	HelperImage     string        `long:"helper-image"      default:"busybox:musl" description:"An image providing a static busybox at /bin/busybox, used to run processes and stream volumes."`
+	StorageClass    string        `long:"storage-class"                            description:"The storage class of the volume claims backing volumes. Defaults to the cluster's default."`
+	VolumeSize      string        `long:"volume-size"       default:"10Gi"         description:"The storage requested for each volume."`
+	PodStartTimeout time.Duration `long:"pod-start-timeout" default:"5m"           description:"How long to wait for a pod to start running."`
+}
+
+func (config WorkerConfig) IsConfigured() bool {
+	return config.Name != ""
+}
+
+func (config WorkerConfig) Validate() error {
+	if config.Namespace == "" {
+		return errors.New("kubernetes worker namespace must be specified")
+	}
+
+	if config.InClusterConfig && config.ConfigPath != "" {
+		return errors.New("Either in-cluster or config-path can be used, not both.")
+	}
+
+	_, err := resource.ParseQuantity(config.VolumeSize)
+	return err
+}
+
+// Runtime returns the RuntimeFactory managing the worker's pods and volume
+// claims.
+func (config WorkerConfig) Runtime() (worker.RuntimeFactory, error) {
+	restConfig, err := config.buildConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeSize, err := resource.ParseQuantity(config.VolumeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRuntime(clientset, NewExecutor(restConfig, clientset), Config{
+		HelperImage:     config.HelperImage,
+		StorageClass:    config.StorageClass,
+		VolumeSize:      volumeSize,
+		PodStartTimeout: config.PodStartTimeout,
+	}), nil
+}
+
+// Worker returns the worker to register.
+func (config WorkerConfig) Worker(version string) atc.Worker {
+	resourceTypes := []atc.WorkerResourceType{}
+	for t, image := range config.ResourceTypes {
+		resourceTypes = append(resourceTypes, atc.WorkerResourceType{
+			Type:    t,
+			Image:   image,
+			Version: image,
+		})
+	}
+
+	tags := config.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return atc.Worker{
+		Name:          config.Name,
+		Runtime:       atc.WorkerRuntimeKubernetes,
+		Namespace:     config.Namespace,
+		Platform:      "linux",
+		Tags:          tags,
+		Team:          config.Team,
+		ResourceTypes: resourceTypes,
+		Version:       version,
+	}
+}
+
+func (config WorkerConfig) buildConfig() (*rest.Config, error) {
+	if config.InClusterConfig {
+		return rest.InClusterConfig()
+	}
+
+	return clientcmd.BuildConfigFromFlags("", config.ConfigPath)
+}
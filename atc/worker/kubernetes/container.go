@@ -0,0 +1,323 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	uuid "github.com/nu7hatch/gouuid"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runProcessScript records the process's pid so that it can be signalled,
+// then runs the command in its working directory with its environment. Its
+// arguments are the pid file, the working directory, the environment, and
+// then the command.
+const runProcessScript = `echo $$ > "$0" && cd "${1:-/}" && shift && exec ` + busybox + ` env "$@"`
+
+type container struct {
+	client *gardenClient
+	handle string
+}
+
+func (client *gardenClient) newContainer(handle string) *container {
+	return &container{
+		client: client,
+		handle: handle,
+	}
+}
+
+func (c *container) Handle() string {
+	return c.handle
+}
+
+func (c *container) Stop(kill bool) error {
+	// the processes of a container are stopped when its pod is deleted
+	return nil
+}
+
+func (c *container) Info() (garden.ContainerInfo, error) {
+	pod, err := c.pod()
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	properties, err := decodeProperties(pod.ObjectMeta)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	state := "stopped"
+	if pod.Status.Phase == v1.PodRunning {
+		state = "active"
+	}
+
+	return garden.ContainerInfo{
+		State:       state,
+		HostIP:      pod.Status.HostIP,
+		ContainerIP: pod.Status.PodIP,
+		Properties:  properties,
+	}, nil
+}
+
+func (c *container) StreamIn(spec garden.StreamInSpec) error {
+	return runScript(
+		c.client.executor,
+		c.client.namespace,
+		c.handle,
+		mainContainer,
+		busybox,
+		`mkdir -p "$0" && `+busybox+` tar -xf - -C "$0"`,
+		[]string{spec.Path},
+		spec.TarStream,
+		nil,
+	)
+}
+
+func (c *container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	// like Garden, a trailing slash streams the contents of the directory
+	// rather than the directory itself
+	dir, base := path.Dir(spec.Path), path.Base(spec.Path)
+	if strings.HasSuffix(spec.Path, "/") {
+		dir, base = spec.Path, "."
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		err := runScript(
+			c.client.executor,
+			c.client.namespace,
+			c.handle,
+			mainContainer,
+			busybox,
+			busybox+` tar -cf - -C "$0" "$1"`,
+			[]string{dir, base},
+			nil,
+			writer,
+		)
+
+		_ = writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+func (c *container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	return garden.BandwidthLimits{}, nil
+}
+
+func (c *container) CurrentCPULimits() (garden.CPULimits, error) {
+	return garden.CPULimits{}, nil
+}
+
+func (c *container) CurrentDiskLimits() (garden.DiskLimits, error) {
+	return garden.DiskLimits{}, nil
+}
+
+func (c *container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	return garden.MemoryLimits{}, nil
+}
+
+func (c *container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	return 0, 0, ErrNotSupported
+}
+
+func (c *container) NetOut(netOutRule garden.NetOutRule) error {
+	return ErrNotSupported
+}
+
+func (c *container) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	return ErrNotSupported
+}
+
+func (c *container) Run(spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	id := spec.ID
+	if id == "" {
+		guid, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		id = guid.String()
+	}
+
+	// running a process counts as using the container, so its grace time
+	// starts over
+	err := c.refreshGraceTime()
+	if err != nil {
+		return nil, err
+	}
+
+	pidFile := path.Join(toolsPath, id+".pid")
+
+	command := []string{busybox, "sh", "-c", runProcessScript, pidFile, spec.Dir}
+	command = append(command, spec.Env...)
+	command = append(command, spec.Path)
+	command = append(command, spec.Args...)
+
+	tty := spec.TTY != nil && processIO.Stdin != nil
+
+	proc := &process{
+		id:        id,
+		container: c,
+		pidFile:   pidFile,
+		done:      make(chan struct{}),
+	}
+
+	go func() {
+		defer close(proc.done)
+
+		proc.status, proc.err = c.client.executor.Exec(
+			c.client.namespace,
+			c.handle,
+			mainContainer,
+			command,
+			processIO,
+			tty,
+		)
+	}()
+
+	return proc, nil
+}
+
+func (c *container) Attach(processID string, io garden.ProcessIO) (garden.Process, error) {
+	// the output of a process only streams over the exec which started it,
+	// so processes cannot be reattached
+	return nil, garden.ProcessNotFoundError{ProcessID: processID}
+}
+
+func (c *container) Metrics() (garden.Metrics, error) {
+	return garden.Metrics{}, nil
+}
+
+func (c *container) SetGraceTime(graceTime time.Duration) error {
+	return c.updateAnnotations(func(annotations map[string]string) error {
+		if graceTime == 0 {
+			delete(annotations, graceTimeAnnotation)
+			return nil
+		}
+
+		annotations[graceTimeAnnotation] = graceTime.String()
+		return nil
+	})
+}
+
+func (c *container) Properties() (garden.Properties, error) {
+	pod, err := c.pod()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeProperties(pod.ObjectMeta)
+}
+
+func (c *container) Property(name string) (string, error) {
+	properties, err := c.Properties()
+	if err != nil {
+		return "", err
+	}
+
+	value, found := properties[name]
+	if !found {
+		return "", garden.NewError("property does not exist: " + name)
+	}
+
+	return value, nil
+}
+
+func (c *container) SetProperty(name string, value string) error {
+	return c.updateProperties(func(properties map[string]string) {
+		properties[name] = value
+	})
+}
+
+func (c *container) RemoveProperty(name string) error {
+	return c.updateProperties(func(properties map[string]string) {
+		delete(properties, name)
+	})
+}
+
+func (c *container) pod() (*v1.Pod, error) {
+	pod, err := c.client.clientset.CoreV1().Pods(c.client.namespace).Get(c.handle, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, garden.ContainerNotFoundError{Handle: c.handle}
+		}
+
+		return nil, err
+	}
+
+	return pod, nil
+}
+
+func (c *container) refreshGraceTime() error {
+	return c.updateAnnotations(func(annotations map[string]string) error {
+		return nil
+	})
+}
+
+func (c *container) updateProperties(update func(map[string]string)) error {
+	return c.updateAnnotations(func(annotations map[string]string) error {
+		properties := map[string]string{}
+
+		encoded := annotations[propertiesAnnotation]
+		if encoded != "" {
+			err := json.Unmarshal([]byte(encoded), &properties)
+			if err != nil {
+				return err
+			}
+		}
+
+		if properties == nil {
+			properties = map[string]string{}
+		}
+
+		update(properties)
+
+		payload, err := json.Marshal(properties)
+		if err != nil {
+			return err
+		}
+
+		annotations[propertiesAnnotation] = string(payload)
+		return nil
+	})
+}
+
+// updateAnnotations updates a pod's annotations, retrying when the pod was
+// updated concurrently. Like any use of a Garden container, it starts the
+// container's grace time over.
+func (c *container) updateAnnotations(update func(map[string]string) error) error {
+	pods := c.client.clientset.CoreV1().Pods(c.client.namespace)
+
+	for {
+		pod, err := c.pod()
+		if err != nil {
+			return err
+		}
+
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+
+		err = update(pod.Annotations)
+		if err != nil {
+			return err
+		}
+
+		pod.Annotations[lastUsedAnnotation] = time.Now().Format(time.RFC3339)
+
+		_, err = pods.Update(pod)
+		if k8serrors.IsConflict(err) {
+			continue
+		}
+
+		return err
+	}
+}
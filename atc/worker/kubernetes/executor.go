@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"code.cloudfoundry.org/garden"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+//go:generate counterfeiter . Executor
+
+// Executor runs commands in the containers of pods, returning their exit
+// status.
+type Executor interface {
+	Exec(namespace string, pod string, container string, command []string, io garden.ProcessIO, tty bool) (int, error)
+}
+
+type spdyExecutor struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+}
+
+// NewExecutor returns an Executor which runs commands through the pod exec
+// API.
+func NewExecutor(config *rest.Config, clientset kubernetes.Interface) Executor {
+	return &spdyExecutor{
+		config:    config,
+		clientset: clientset,
+	}
+}
+
+func (executor *spdyExecutor) Exec(namespace string, pod string, container string, command []string, io garden.ProcessIO, tty bool) (int, error) {
+	req := executor.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     io.Stdin != nil,
+			Stdout:    io.Stdout != nil,
+			Stderr:    io.Stderr != nil && !tty,
+			TTY:       tty,
+		}, scheme.ParameterCodec)
+
+	stream, err := remotecommand.NewSPDYExecutor(executor.config, "POST", req.URL())
+	if err != nil {
+		return 0, err
+	}
+
+	options := remotecommand.StreamOptions{
+		Stdin:  io.Stdin,
+		Stdout: io.Stdout,
+		Tty:    tty,
+	}
+
+	if !tty {
+		options.Stderr = io.Stderr
+	}
+
+	err = stream.Stream(options)
+	if err != nil {
+		if exitErr, ok := err.(exec.ExitError); ok && exitErr.Exited() {
+			return exitErr.ExitStatus(), nil
+		}
+
+		return 0, err
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,399 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	uuid "github.com/nu7hatch/gouuid"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+var ErrNotSupported = errors.New("not supported by kubernetes workers")
+
+type UnsupportedBindMountError struct {
+	SrcPath string
+}
+
+func (err UnsupportedBindMountError) Error() string {
+	return fmt.Sprintf("cannot mount '%s': only volumes can be mounted into kubernetes containers", err.SrcPath)
+}
+
+type gardenClient struct {
+	logger     lager.Logger
+	clientset  kubernetes.Interface
+	executor   Executor
+	namespace  string
+	workerName string
+	config     Config
+
+	volumes *baggageclaimClient
+}
+
+// NewGardenClient returns a garden.Client which runs each container as a pod
+// in the namespace. Processes are run and files are streamed with the pod exec
+// API, using the busybox installed into each pod from the helper image.
+func NewGardenClient(
+	logger lager.Logger,
+	clientset kubernetes.Interface,
+	executor Executor,
+	namespace string,
+	workerName string,
+	config Config,
+) garden.Client {
+	return &gardenClient{
+		logger:     logger,
+		clientset:  clientset,
+		executor:   executor,
+		namespace:  namespace,
+		workerName: workerName,
+		config:     config,
+
+		volumes: newBaggageclaimClient(clientset, executor, namespace, workerName, config),
+	}
+}
+
+func (client *gardenClient) Ping() error {
+	_, err := client.clientset.CoreV1().Pods(client.namespace).List(metav1.ListOptions{
+		LabelSelector: client.selector().String(),
+	})
+	return err
+}
+
+func (client *gardenClient) Capacity() (garden.Capacity, error) {
+	// the cluster's capacity is not the worker's to report
+	return garden.Capacity{}, nil
+}
+
+func (client *gardenClient) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	handle := spec.Handle
+	if handle == "" {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		handle = id.String()
+	}
+
+	image, err := client.resolveImage(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, mounts, err := bindMounts(spec.BindMounts)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := json.Marshal(spec.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := map[string]string{
+		propertiesAnnotation: string(properties),
+		lastUsedAnnotation:   time.Now().Format(time.RFC3339),
+	}
+
+	if spec.GraceTime != 0 {
+		annotations[graceTimeAnnotation] = spec.GraceTime.String()
+	}
+
+	privileged := spec.Privileged
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        handle,
+			Labels:      client.labels(roleContainer),
+			Annotations: annotations,
+		},
+		Spec: v1.PodSpec{
+			RestartPolicy: v1.RestartPolicyNever,
+			InitContainers: []v1.Container{
+				{
+					Name:    "install-tools",
+					Image:   client.config.HelperImage,
+					Command: []string{"/bin/busybox", "cp", "/bin/busybox", busybox},
+					VolumeMounts: []v1.VolumeMount{
+						{Name: "tools", MountPath: toolsPath},
+					},
+				},
+			},
+			Containers: []v1.Container{
+				{
+					Name:      mainContainer,
+					Image:     image,
+					Command:   append([]string{busybox}, keepAlive...),
+					Env:       envVars(spec.Env),
+					Resources: resourceRequirements(spec.Limits),
+					SecurityContext: &v1.SecurityContext{
+						Privileged: &privileged,
+					},
+					VolumeMounts: append(mounts, v1.VolumeMount{
+						Name:      "tools",
+						MountPath: toolsPath,
+					}),
+				},
+			},
+			Volumes: append(volumes, v1.Volume{
+				Name: "tools",
+				VolumeSource: v1.VolumeSource{
+					EmptyDir: &v1.EmptyDirVolumeSource{},
+				},
+			}),
+		},
+	}
+
+	pods := client.clientset.CoreV1().Pods(client.namespace)
+
+	_, err = pods.Create(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitForPod(pods, handle, client.config.PodStartTimeout)
+	if err != nil {
+		client.logger.Error("failed-to-start-pod", err, lager.Data{"handle": handle})
+
+		_ = deletePod(pods, handle)
+
+		return nil, err
+	}
+
+	return client.newContainer(handle), nil
+}
+
+func (client *gardenClient) Destroy(handle string) error {
+	err := deletePod(client.clientset.CoreV1().Pods(client.namespace), handle)
+	if k8serrors.IsNotFound(err) {
+		return garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return err
+}
+
+// Containers lists the worker's containers. Like Garden, containers whose
+// grace time has passed since they were last used are destroyed.
+func (client *gardenClient) Containers(properties garden.Properties) ([]garden.Container, error) {
+	pods, err := client.clientset.CoreV1().Pods(client.namespace).List(metav1.ListOptions{
+		LabelSelector: client.selector().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := []garden.Container{}
+	for _, pod := range pods.Items {
+		if expired(pod.ObjectMeta) {
+			err := client.Destroy(pod.Name)
+			if err != nil {
+				client.logger.Error("failed-to-destroy-expired-container", err, lager.Data{"handle": pod.Name})
+			}
+
+			continue
+		}
+
+		podProperties, err := decodeProperties(pod.ObjectMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matchProperties(podProperties, properties) {
+			continue
+		}
+
+		containers = append(containers, client.newContainer(pod.Name))
+	}
+
+	return containers, nil
+}
+
+func (client *gardenClient) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	infos := map[string]garden.ContainerInfoEntry{}
+	for _, handle := range handles {
+		info, err := client.newContainer(handle).Info()
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		infos[handle] = garden.ContainerInfoEntry{Info: info}
+	}
+
+	return infos, nil
+}
+
+func (client *gardenClient) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	return nil, ErrNotSupported
+}
+
+func (client *gardenClient) Lookup(handle string) (garden.Container, error) {
+	pod, err := client.clientset.CoreV1().Pods(client.namespace).Get(handle, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, garden.ContainerNotFoundError{Handle: handle}
+		}
+
+		return nil, err
+	}
+
+	if pod.Labels[workerLabel] != client.workerName || pod.Labels[roleLabel] != roleContainer {
+		return nil, garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return client.newContainer(handle), nil
+}
+
+func (client *gardenClient) labels(role string) map[string]string {
+	return map[string]string{
+		workerLabel: client.workerName,
+		roleLabel:   role,
+	}
+}
+
+func (client *gardenClient) selector() labels.Selector {
+	return labels.SelectorFromSet(client.labels(roleContainer))
+}
+
+// bindMounts mounts the volume claims of the volumes bind mounted into a
+// container.
+func bindMounts(bindMounts []garden.BindMount) ([]v1.Volume, []v1.VolumeMount, error) {
+	volumes := []v1.Volume{}
+	mounts := []v1.VolumeMount{}
+
+	claims := map[string]string{}
+	for _, bindMount := range bindMounts {
+		handle, subPath, ok := volumeHandle(bindMount.SrcPath)
+		if !ok {
+			return nil, nil, UnsupportedBindMountError{SrcPath: bindMount.SrcPath}
+		}
+
+		name, found := claims[handle]
+		if !found {
+			name = fmt.Sprintf("volume-%d", len(claims))
+			claims[handle] = name
+
+			volumes = append(volumes, v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: handle,
+					},
+				},
+			})
+		}
+
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: bindMount.DstPath,
+			SubPath:   subPath,
+			ReadOnly:  bindMount.Mode == garden.BindMountModeRO,
+		})
+	}
+
+	return volumes, mounts, nil
+}
+
+// volumeHandle returns the handle of the volume a path is in, and the path
+// within the volume.
+func volumeHandle(volumePath string) (string, string, bool) {
+	relative := strings.TrimPrefix(path.Clean(volumePath), VolumesRoot+"/")
+	if relative == volumePath || relative == "" {
+		return "", "", false
+	}
+
+	segments := strings.SplitN(relative, "/", 2)
+	if len(segments) == 1 {
+		return segments[0], "", true
+	}
+
+	return segments[0], segments[1], true
+}
+
+func envVars(env []string) []v1.EnvVar {
+	vars := []v1.EnvVar{}
+	for _, e := range env {
+		segments := strings.SplitN(e, "=", 2)
+		if len(segments) != 2 {
+			continue
+		}
+
+		vars = append(vars, v1.EnvVar{
+			Name:  segments[0],
+			Value: segments[1],
+		})
+	}
+
+	return vars
+}
+
+// resourceRequirements requests a container's CPU shares as a share of a core
+// and limits its memory.
+func resourceRequirements(limits garden.Limits) v1.ResourceRequirements {
+	requirements := v1.ResourceRequirements{}
+
+	if limits.CPU.LimitInShares > 0 {
+		requirements.Requests = v1.ResourceList{
+			v1.ResourceCPU: *resource.NewMilliQuantity(int64(limits.CPU.LimitInShares*1000/1024), resource.DecimalSI),
+		}
+	}
+
+	if limits.Memory.LimitInBytes > 0 {
+		requirements.Limits = v1.ResourceList{
+			v1.ResourceMemory: *resource.NewQuantity(int64(limits.Memory.LimitInBytes), resource.BinarySI),
+		}
+	}
+
+	return requirements
+}
+
+func decodeProperties(meta metav1.ObjectMeta) (map[string]string, error) {
+	properties := map[string]string{}
+
+	encoded, found := meta.Annotations[propertiesAnnotation]
+	if !found || encoded == "" {
+		return properties, nil
+	}
+
+	err := json.Unmarshal([]byte(encoded), &properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return properties, nil
+}
+
+func matchProperties(properties map[string]string, filter map[string]string) bool {
+	for key, value := range filter {
+		if properties[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func expired(meta metav1.ObjectMeta) bool {
+	graceTime, err := time.ParseDuration(meta.Annotations[graceTimeAnnotation])
+	if err != nil || graceTime == 0 {
+		return false
+	}
+
+	lastUsed, err := time.Parse(time.RFC3339, meta.Annotations[lastUsedAnnotation])
+	if err != nil {
+		return false
+	}
+
+	return time.Now().After(lastUsed.Add(graceTime))
+}
@@ -0,0 +1,339 @@
+package kubernetes_test
+
+import (
+	"bytes"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/worker/kubernetes"
+	"github.com/concourse/concourse/atc/worker/kubernetes/kubernetesfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("GardenClient", func() {
+	var (
+		clientset    *fake.Clientset
+		fakeExecutor *kubernetesfakes.FakeExecutor
+		podPhase     v1.PodPhase
+
+		client garden.Client
+	)
+
+	BeforeEach(func() {
+		clientset = fake.NewSimpleClientset()
+		fakeExecutor = new(kubernetesfakes.FakeExecutor)
+		podPhase = v1.PodRunning
+
+		clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*v1.Pod)
+			pod.Status.Phase = podPhase
+			return false, nil, nil
+		})
+
+		client = kubernetes.NewGardenClient(
+			lagertest.NewTestLogger("test"),
+			clientset,
+			fakeExecutor,
+			"some-namespace",
+			"some-worker",
+			kubernetes.Config{
+				HelperImage:     "some-helper-image",
+				PodStartTimeout: time.Second,
+			},
+		)
+	})
+
+	getPod := func(name string) *v1.Pod {
+		pod, err := clientset.CoreV1().Pods("some-namespace").Get(name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pod
+	}
+
+	Describe("Create", func() {
+		var (
+			spec      garden.ContainerSpec
+			container garden.Container
+			createErr error
+		)
+
+		BeforeEach(func() {
+			spec = garden.ContainerSpec{
+				Handle:     "some-handle",
+				RootFSPath: "docker:///some-repository#some-tag",
+				Env:        []string{"FOO=bar"},
+				Properties: garden.Properties{"some": "property"},
+				BindMounts: []garden.BindMount{
+					{
+						SrcPath: kubernetes.VolumesRoot + "/some-volume",
+						DstPath: "/tmp/build/some-input",
+						Mode:    garden.BindMountModeRO,
+					},
+					{
+						SrcPath: kubernetes.VolumesRoot + "/some-volume/some/dir",
+						DstPath: "/tmp/build/some-output",
+						Mode:    garden.BindMountModeRW,
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			container, createErr = client.Create(spec)
+		})
+
+		It("runs a pod for the container", func() {
+			Expect(createErr).ToNot(HaveOccurred())
+			Expect(container.Handle()).To(Equal("some-handle"))
+
+			pod := getPod("some-handle")
+			Expect(pod.Labels).To(Equal(map[string]string{
+				"concourse.ci/worker": "some-worker",
+				"concourse.ci/role":   "container",
+			}))
+			Expect(pod.Spec.RestartPolicy).To(Equal(v1.RestartPolicyNever))
+
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			Expect(pod.Spec.InitContainers[0].Image).To(Equal("some-helper-image"))
+
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			main := pod.Spec.Containers[0]
+			Expect(main.Image).To(Equal("some-repository:some-tag"))
+			Expect(main.Env).To(Equal([]v1.EnvVar{{Name: "FOO", Value: "bar"}}))
+		})
+
+		It("mounts the claims of the bind mounted volumes", func() {
+			pod := getPod("some-handle")
+
+			Expect(pod.Spec.Volumes).To(ContainElement(v1.Volume{
+				Name: "volume-0",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "some-volume",
+					},
+				},
+			}))
+
+			mounts := pod.Spec.Containers[0].VolumeMounts
+			Expect(mounts).To(ContainElement(v1.VolumeMount{
+				Name:      "volume-0",
+				MountPath: "/tmp/build/some-input",
+				ReadOnly:  true,
+			}))
+			Expect(mounts).To(ContainElement(v1.VolumeMount{
+				Name:      "volume-0",
+				MountPath: "/tmp/build/some-output",
+				SubPath:   "some/dir",
+			}))
+		})
+
+		It("records the container's properties", func() {
+			properties, err := container.Properties()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(properties).To(Equal(garden.Properties{"some": "property"}))
+		})
+
+		Context("when the image is an image volume", func() {
+			BeforeEach(func() {
+				_, err := clientset.CoreV1().ConfigMaps("some-namespace").Create(&v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "some-image-volume",
+						Annotations: map[string]string{
+							"concourse.ci/image": "some-resource-image",
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				spec.RootFSPath = "raw://" + kubernetes.VolumesRoot + "/some-image-volume"
+			})
+
+			It("runs the volume's image", func() {
+				Expect(createErr).ToNot(HaveOccurred())
+				Expect(getPod("some-handle").Spec.Containers[0].Image).To(Equal("some-resource-image"))
+			})
+		})
+
+		Context("when the image is not supported", func() {
+			BeforeEach(func() {
+				spec.RootFSPath = "raw:///some/rootfs"
+			})
+
+			It("errors", func() {
+				Expect(createErr).To(Equal(kubernetes.UnsupportedImageError{URI: "raw:///some/rootfs"}))
+			})
+		})
+
+		Context("when a path outside of a volume is bind mounted", func() {
+			BeforeEach(func() {
+				spec.BindMounts = []garden.BindMount{
+					{SrcPath: "/some/host/path", DstPath: "/some/path"},
+				}
+			})
+
+			It("errors", func() {
+				Expect(createErr).To(Equal(kubernetes.UnsupportedBindMountError{SrcPath: "/some/host/path"}))
+			})
+		})
+
+		Context("when the pod fails", func() {
+			BeforeEach(func() {
+				podPhase = v1.PodFailed
+			})
+
+			It("deletes the pod and errors", func() {
+				Expect(createErr).To(Equal(kubernetes.PodNotRunningError{Name: "some-handle", Phase: v1.PodFailed}))
+
+				_, err := client.Lookup("some-handle")
+				Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+			})
+		})
+	})
+
+	Describe("Containers", func() {
+		BeforeEach(func() {
+			_, err := client.Create(garden.ContainerSpec{
+				Handle:     "some-handle",
+				RootFSPath: "docker:///some-image",
+				Properties: garden.Properties{"some": "property"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = client.Create(garden.ContainerSpec{
+				Handle:     "other-handle",
+				RootFSPath: "docker:///some-image",
+				Properties: garden.Properties{"some": "other-property"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lists the containers matching the properties", func() {
+			containers, err := client.Containers(garden.Properties{"some": "property"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Handle()).To(Equal("some-handle"))
+
+			containers, err = client.Containers(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(containers).To(HaveLen(2))
+		})
+
+		Context("when a container's grace time has passed", func() {
+			BeforeEach(func() {
+				pod := getPod("some-handle")
+				pod.Annotations["concourse.ci/grace-time"] = "1m0s"
+				pod.Annotations["concourse.ci/last-used"] = time.Now().Add(-2 * time.Minute).Format(time.RFC3339)
+
+				_, err := clientset.CoreV1().Pods("some-namespace").Update(pod)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("destroys it", func() {
+				containers, err := client.Containers(nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(containers).To(HaveLen(1))
+				Expect(containers[0].Handle()).To(Equal("other-handle"))
+
+				_, err = client.Lookup("some-handle")
+				Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+			})
+		})
+	})
+
+	Describe("Destroy", func() {
+		It("returns ContainerNotFoundError for an unknown container", func() {
+			err := client.Destroy("bogus-handle")
+			Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "bogus-handle"}))
+		})
+	})
+
+	Describe("running a process", func() {
+		var container garden.Container
+
+		BeforeEach(func() {
+			var err error
+			container, err = client.Create(garden.ContainerSpec{
+				Handle:     "some-handle",
+				RootFSPath: "docker:///some-image",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeExecutor.ExecReturns(42, nil)
+		})
+
+		It("execs it in the pod and returns its exit status", func() {
+			stdout := new(bytes.Buffer)
+
+			process, err := container.Run(garden.ProcessSpec{
+				ID:   "some-process",
+				Path: "some-path",
+				Args: []string{"some", "args"},
+				Dir:  "/some/dir",
+				Env:  []string{"FOO=bar"},
+			}, garden.ProcessIO{
+				Stdout: stdout,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.ID()).To(Equal("some-process"))
+
+			status, err := process.Wait()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(42))
+
+			Expect(fakeExecutor.ExecCallCount()).To(Equal(1))
+			namespace, pod, containerName, command, processIO, tty := fakeExecutor.ExecArgsForCall(0)
+			Expect(namespace).To(Equal("some-namespace"))
+			Expect(pod).To(Equal("some-handle"))
+			Expect(containerName).To(Equal("main"))
+			Expect(command[0]).To(Equal("/.concourse/busybox"))
+			Expect(command[4:]).To(Equal([]string{
+				"/.concourse/some-process.pid",
+				"/some/dir",
+				"FOO=bar",
+				"some-path",
+				"some",
+				"args",
+			}))
+			Expect(processIO.Stdout).To(Equal(stdout))
+			Expect(tty).To(BeFalse())
+		})
+
+		It("cannot be attached to", func() {
+			_, err := container.Attach("some-process", garden.ProcessIO{})
+			Expect(err).To(Equal(garden.ProcessNotFoundError{ProcessID: "some-process"}))
+		})
+	})
+
+	Describe("properties", func() {
+		var container garden.Container
+
+		BeforeEach(func() {
+			var err error
+			container, err = client.Create(garden.ContainerSpec{
+				Handle:     "some-handle",
+				RootFSPath: "docker:///some-image",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can be set and removed", func() {
+			Expect(container.SetProperty("some", "value")).To(Succeed())
+			Expect(container.SetProperty("other", "value")).To(Succeed())
+			Expect(container.RemoveProperty("other")).To(Succeed())
+
+			value, err := container.Property("some")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("value"))
+
+			properties, err := container.Properties()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(properties).To(Equal(garden.Properties{"some": "value"}))
+		})
+	})
+})
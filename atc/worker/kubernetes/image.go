@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/baggageclaim"
+)
+
+type UnsupportedImageError struct {
+	URI string
+}
+
+func (err UnsupportedImageError) Error() string {
+	return fmt.Sprintf("unsupported image for kubernetes containers: '%s'", err.URI)
+}
+
+// resolveImage determines the image a container's pod runs, as Kubernetes
+// pulls images itself rather than using a root filesystem on the worker.
+//
+// A docker:// URI names the image directly. A raw:// URI is a volume: either
+// an image volume naming its image, or a volume fetched by an image resource,
+// which records the repository along with the digest or tag it fetched.
+func (client *gardenClient) resolveImage(spec garden.ContainerSpec) (string, error) {
+	uri := spec.Image.URI
+	if uri == "" {
+		uri = spec.RootFSPath
+	}
+
+	imageURL, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	switch imageURL.Scheme {
+	case "docker":
+		repository := strings.TrimPrefix(path.Join(imageURL.Host, imageURL.Path), "/")
+		if imageURL.Fragment == "" {
+			return repository, nil
+		}
+
+		return repository + ":" + imageURL.Fragment, nil
+
+	case "raw":
+		handle, _, isVolume := volumeHandle(imageURL.Path)
+		if !isVolume {
+			return "", UnsupportedImageError{URI: uri}
+		}
+
+		return client.volumeImage(handle)
+
+	default:
+		return "", UnsupportedImageError{URI: uri}
+	}
+}
+
+func (client *gardenClient) volumeImage(handle string) (string, error) {
+	meta, isClaim, found, err := client.volumes.lookup(handle)
+	if err != nil {
+		return "", err
+	}
+
+	if !found {
+		return "", baggageclaim.ErrVolumeNotFound
+	}
+
+	if !isClaim {
+		return meta.Annotations[imageAnnotation], nil
+	}
+
+	image := new(bytes.Buffer)
+
+	err = client.volumes.withStreamer([]string{handle}, func(pod string) error {
+		return runScript(
+			client.executor,
+			client.namespace,
+			pod,
+			mainContainer,
+			"/bin/busybox",
+			`cd "$0" && if [ -s digest ]; then echo "$(/bin/busybox cat repository)@$(/bin/busybox cat digest)"; else echo "$(/bin/busybox cat repository):$(/bin/busybox cat tag)"; fi`,
+			[]string{streamerMountPath(handle)},
+			nil,
+			image,
+		)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(image.String()), nil
+}
@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// VolumesRoot prefixes the paths of volumes, so that the garden client can
+	// tell which volume a bind mount or image refers to.
+	VolumesRoot = "/concourse/volumes"
+
+	// toolsPath is where the helper image's busybox is installed in every
+	// container, so that processes can be run and files streamed regardless
+	// of what the container's image provides.
+	toolsPath = "/.concourse"
+	busybox   = toolsPath + "/busybox"
+
+	mainContainer = "main"
+
+	workerLabel = "concourse.ci/worker"
+	roleLabel   = "concourse.ci/role"
+
+	roleContainer = "container"
+	roleVolume    = "volume"
+	roleStreamer  = "streamer"
+
+	propertiesAnnotation = "concourse.ci/properties"
+	privilegedAnnotation = "concourse.ci/privileged"
+	graceTimeAnnotation  = "concourse.ci/grace-time"
+	lastUsedAnnotation   = "concourse.ci/last-used"
+	imageAnnotation      = "concourse.ci/image"
+)
+
+// Config configures the pods and volume claims created for a Kubernetes
+// worker.
+type Config struct {
+	// HelperImage is an image providing a statically linked busybox at
+	// /bin/busybox. It is used to install the tools needed to run processes
+	// in containers and to stream volumes in and out.
+	HelperImage string
+
+	// StorageClass is the storage class of the volume claims backing
+	// volumes. The cluster's default is used when empty.
+	StorageClass string
+
+	// VolumeSize is the storage requested for each volume.
+	VolumeSize resource.Quantity
+
+	// PodStartTimeout is how long to wait for a pod to start running.
+	PodStartTimeout time.Duration
+}
+
+type kubernetesRuntime struct {
+	clientset kubernetes.Interface
+	executor  Executor
+	config    Config
+}
+
+// NewRuntime returns a RuntimeFactory which runs the containers of a worker
+// as pods in the worker's namespace, and backs its volumes by volume claims.
+func NewRuntime(clientset kubernetes.Interface, executor Executor, config Config) worker.RuntimeFactory {
+	return &kubernetesRuntime{
+		clientset: clientset,
+		executor:  executor,
+		config:    config,
+	}
+}
+
+func (r *kubernetesRuntime) NewGardenClient(logger lager.Logger, savedWorker db.Worker) garden.Client {
+	return NewGardenClient(
+		logger.Session("kubernetes-garden"),
+		r.clientset,
+		r.executor,
+		savedWorker.Namespace(),
+		savedWorker.Name(),
+		r.config,
+	)
+}
+
+func (r *kubernetesRuntime) NewBaggageclaimClient(logger lager.Logger, savedWorker db.Worker) baggageclaim.Client {
+	return NewBaggageclaimClient(
+		r.clientset,
+		r.executor,
+		savedWorker.Namespace(),
+		savedWorker.Name(),
+		r.config,
+	)
+}
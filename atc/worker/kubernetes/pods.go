@@ -0,0 +1,108 @@
+package kubernetes
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const podPollInterval = 500 * time.Millisecond
+
+// keepAlive is the command of the containers which processes are executed
+// in; the pods must keep running until they are deleted.
+var keepAlive = []string{"sleep", "2147483647"}
+
+type PodNotRunningError struct {
+	Name  string
+	Phase v1.PodPhase
+}
+
+func (err PodNotRunningError) Error() string {
+	return fmt.Sprintf("pod '%s' is not running: %s", err.Name, err.Phase)
+}
+
+type ScriptFailedError struct {
+	ExitStatus int
+	Stderr     string
+}
+
+func (err ScriptFailedError) Error() string {
+	return fmt.Sprintf("exit status %d: %s", err.ExitStatus, err.Stderr)
+}
+
+// waitForPod waits for a pod's containers to start running.
+func waitForPod(pods corev1.PodInterface, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		pod, err := pods.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		switch pod.Status.Phase {
+		case v1.PodRunning:
+			return nil
+		case v1.PodSucceeded, v1.PodFailed:
+			return PodNotRunningError{Name: name, Phase: pod.Status.Phase}
+		}
+
+		if time.Now().After(deadline) {
+			return PodNotRunningError{Name: name, Phase: pod.Status.Phase}
+		}
+
+		time.Sleep(podPollInterval)
+	}
+}
+
+// deletePod deletes a pod without waiting for its processes to exit.
+func deletePod(pods corev1.PodInterface, name string) error {
+	gracePeriod := int64(0)
+
+	return pods.Delete(name, &metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+	})
+}
+
+// runScript runs a shell script with busybox in a pod's container, returning
+// a ScriptFailedError with its stderr if it exits non-zero. The script's
+// arguments start at $0.
+func runScript(
+	executor Executor,
+	namespace string,
+	pod string,
+	container string,
+	busybox string,
+	script string,
+	args []string,
+	stdin io.Reader,
+	stdout io.Writer,
+) error {
+	stderr := new(bytes.Buffer)
+
+	command := append([]string{busybox, "sh", "-c", script}, args...)
+
+	status, err := executor.Exec(namespace, pod, container, command, garden.ProcessIO{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	if status != 0 {
+		return ScriptFailedError{
+			ExitStatus: status,
+			Stderr:     stderr.String(),
+		}
+	}
+
+	return nil
+}
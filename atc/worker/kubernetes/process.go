@@ -0,0 +1,50 @@
+package kubernetes
+
+import (
+	"code.cloudfoundry.org/garden"
+)
+
+type process struct {
+	id        string
+	container *container
+	pidFile   string
+
+	done   chan struct{}
+	status int
+	err    error
+}
+
+func (p *process) ID() string {
+	return p.id
+}
+
+func (p *process) Wait() (int, error) {
+	<-p.done
+	return p.status, p.err
+}
+
+func (p *process) SetTTY(garden.TTYSpec) error {
+	// the terminal of an exec cannot be resized once it has started
+	return nil
+}
+
+func (p *process) Signal(signal garden.Signal) error {
+	name := "TERM"
+	if signal == garden.SignalKill {
+		name = "KILL"
+	}
+
+	c := p.container
+
+	return runScript(
+		c.client.executor,
+		c.client.namespace,
+		c.handle,
+		mainContainer,
+		busybox,
+		busybox+` kill -s "$0" "$(`+busybox+` cat "$1")"`,
+		[]string{name, p.pidFile},
+		nil,
+		nil,
+	)
+}
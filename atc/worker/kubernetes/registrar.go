@@ -0,0 +1,48 @@
+package kubernetes
+
+import (
+	"os"
+	"time"
+
+	c "code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// NewRegistrar keeps a Kubernetes worker registered for as long as it runs.
+// Unlike Garden workers there is no beacon, so the ATC heartbeats the worker
+// itself.
+func NewRegistrar(
+	logger lager.Logger,
+	workerFactory db.WorkerFactory,
+	clock c.Clock,
+	workerInfo atc.Worker,
+) ifrit.RunFunc {
+	return func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		_, err := workerFactory.SaveWorker(workerInfo, 30*time.Second)
+		if err != nil {
+			logger.Error("could-not-save-kubernetes-worker", err)
+			return err
+		}
+
+		ticker := clock.NewTicker(10 * time.Second)
+
+		close(ready)
+
+		for {
+			select {
+			case <-ticker.C():
+				_, err = workerFactory.SaveWorker(workerInfo, 30*time.Second)
+				if err != nil {
+					logger.Error("could-not-save-kubernetes-worker", err)
+				}
+			case <-signals:
+				ticker.Stop()
+				return nil
+			}
+		}
+	}
+}
@@ -1,30 +1,108 @@
 package worker
 
 import (
+	"errors"
 	"math/rand"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
 )
 
+// ErrWorkersAtContainerLimit is returned by a ContainerPlacementStrategy when
+// every candidate worker already has as many active containers as it allows.
+var ErrWorkersAtContainerLimit = errors.New("all compatible workers have reached their container limit")
+
+// ErrWorkersAtActiveTaskLimit is returned by the limit-active-tasks strategy
+// when every candidate worker is already running as many tasks as allowed.
+var ErrWorkersAtActiveTaskLimit = errors.New("all compatible workers have reached the active task limit")
+
 type ContainerPlacementStrategy interface {
 	//TODO: Don't pass around container metadata since it's not guaranteed to be deterministic.
 	// Change this after check containers stop being reused
 	Choose(lager.Logger, []Worker, ContainerSpec) (Worker, error)
 }
 
+// ContainerPlacementFilter is a ContainerPlacementStrategy which can narrow
+// the workers down to the ones it considers equally good, so that several
+// strategies can be applied in sequence.
+type ContainerPlacementFilter interface {
+	ContainerPlacementStrategy
+
+	Candidates(lager.Logger, []Worker, ContainerSpec) ([]Worker, error)
+}
+
+// workersWithCapacity leaves out the workers which have reached their
+// configured maximum number of active containers.
+func workersWithCapacity(logger lager.Logger, workers []Worker) ([]Worker, error) {
+	available := []Worker{}
+	for _, w := range workers {
+		if w.MaxContainers() > 0 && w.ActiveContainers() >= w.MaxContainers() {
+			logger.Debug("skipping-worker-at-container-limit", lager.Data{
+				"worker":            w.Name(),
+				"active-containers": w.ActiveContainers(),
+				"max-containers":    w.MaxContainers(),
+			})
+			continue
+		}
+
+		available = append(available, w)
+	}
+
+	if len(available) == 0 {
+		return nil, ErrWorkersAtContainerLimit
+	}
+
+	return available, nil
+}
+
+func chooseRandomly(logger lager.Logger, r *rand.Rand, candidates []Worker) Worker {
+	chosen := candidates[r.Intn(len(candidates))]
+
+	logger.Debug("chose-worker", lager.Data{
+		"worker":     chosen.Name(),
+		"candidates": workerNames(candidates),
+	})
+
+	return chosen
+}
+
+func workerNames(workers []Worker) []string {
+	names := []string{}
+	for _, w := range workers {
+		names = append(names, w.Name())
+	}
+
+	return names
+}
+
 type VolumeLocalityPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+func NewVolumeLocalityPlacementStrategy() ContainerPlacementFilter {
 	return &VolumeLocalityPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 func (strategy *VolumeLocalityPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return chooseRandomly(logger, strategy.rand, candidates), nil
+}
+
+func (strategy *VolumeLocalityPlacementStrategy) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	workers, err := workersWithCapacity(logger, workers)
+	if err != nil {
+		return nil, err
+	}
+
 	workersByCount := map[int][]Worker{}
+	inputsByRuntime := map[string]int{}
 	var highestCount int
 	for _, w := range workers {
 		candidateInputCount := 0
@@ -41,28 +119,79 @@ func (strategy *VolumeLocalityPlacementStrategy) Choose(logger lager.Logger, wor
 		}
 
 		workersByCount[candidateInputCount] = append(workersByCount[candidateInputCount], w)
+		inputsByRuntime[w.Runtime()] += candidateInputCount
 
 		if candidateInputCount >= highestCount {
 			highestCount = candidateInputCount
 		}
 	}
 
-	highestLocalityWorkers := workersByCount[highestCount]
+	highestLocalityWorkers := preferredRuntimeWorkers(workersByCount[highestCount], inputsByRuntime)
+
+	logger.Debug("found-workers-with-most-local-inputs", lager.Data{
+		"local-inputs": highestCount,
+		"inputs":       len(spec.Inputs),
+		"workers":      workerNames(highestLocalityWorkers),
+	})
 
-	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+	return highestLocalityWorkers, nil
+}
+
+// preferredRuntimeWorkers narrows equally local workers down to those of the
+// runtime holding the most inputs, as volumes are streamed between runtimes
+// less efficiently than between workers of the same runtime.
+func preferredRuntimeWorkers(workers []Worker, inputsByRuntime map[string]int) []Worker {
+	var preferredRuntime string
+	var preferredCount int
+	for _, w := range workers {
+		count := inputsByRuntime[w.Runtime()]
+		if count > preferredCount {
+			preferredRuntime = w.Runtime()
+			preferredCount = count
+		} else if count == preferredCount && w.Runtime() != preferredRuntime {
+			preferredRuntime = ""
+		}
+	}
+
+	if preferredCount == 0 || preferredRuntime == "" {
+		return workers
+	}
+
+	preferred := []Worker{}
+	for _, w := range workers {
+		if w.Runtime() == preferredRuntime {
+			preferred = append(preferred, w)
+		}
+	}
+
+	return preferred
 }
 
 type FewestBuildContainersPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
+func NewFewestBuildContainersPlacementStrategy() ContainerPlacementFilter {
 	return &FewestBuildContainersPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 func (strategy *FewestBuildContainersPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return chooseRandomly(logger, strategy.rand, candidates), nil
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	workers, err := workersWithCapacity(logger, workers)
+	if err != nil {
+		return nil, err
+	}
+
 	workersByWork := map[int][]Worker{}
 	var minWork int
 
@@ -75,19 +204,140 @@ func (strategy *FewestBuildContainersPlacementStrategy) Choose(logger lager.Logg
 	}
 
 	leastBusyWorkers := workersByWork[minWork]
-	return leastBusyWorkers[strategy.rand.Intn(len(leastBusyWorkers))], nil
+
+	logger.Debug("found-workers-with-fewest-build-containers", lager.Data{
+		"build-containers": minWork,
+		"workers":          workerNames(leastBusyWorkers),
+	})
+
+	return leastBusyWorkers, nil
+}
+
+// LimitActiveTasksPlacementStrategy places task containers on the workers
+// running the fewest tasks, leaving out the workers which already run
+// maxTasks of them. Any other container may be placed on any worker.
+type LimitActiveTasksPlacementStrategy struct {
+	maxTasks int
+	rand     *rand.Rand
+}
+
+// NewLimitActiveTasksPlacementStrategy returns a strategy which limits the
+// number of tasks per worker to maxTasks, or not at all if maxTasks is 0.
+func NewLimitActiveTasksPlacementStrategy(maxTasks int) ContainerPlacementFilter {
+	return &LimitActiveTasksPlacementStrategy{
+		maxTasks: maxTasks,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *LimitActiveTasksPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return chooseRandomly(logger, strategy.rand, candidates), nil
+}
+
+func (strategy *LimitActiveTasksPlacementStrategy) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	workers, err := workersWithCapacity(logger, workers)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Type != db.ContainerTypeTask {
+		return workers, nil
+	}
+
+	workersByTasks := map[int][]Worker{}
+	minTasks := -1
+
+	for _, w := range workers {
+		tasks := w.ActiveTasks()
+		if strategy.maxTasks > 0 && tasks >= strategy.maxTasks {
+			logger.Debug("skipping-worker-at-active-task-limit", lager.Data{
+				"worker":       w.Name(),
+				"active-tasks": tasks,
+				"max-tasks":    strategy.maxTasks,
+			})
+			continue
+		}
+
+		workersByTasks[tasks] = append(workersByTasks[tasks], w)
+		if minTasks == -1 || tasks < minTasks {
+			minTasks = tasks
+		}
+	}
+
+	if minTasks == -1 {
+		return nil, ErrWorkersAtActiveTaskLimit
+	}
+
+	leastBusyWorkers := workersByTasks[minTasks]
+
+	logger.Debug("found-workers-with-fewest-active-tasks", lager.Data{
+		"active-tasks": minTasks,
+		"workers":      workerNames(leastBusyWorkers),
+	})
+
+	return leastBusyWorkers, nil
 }
 
 type RandomPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+func NewRandomPlacementStrategy() ContainerPlacementFilter {
 	return &RandomPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 func (strategy *RandomPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
-	return workers[strategy.rand.Intn(len(workers))], nil
+	candidates, err := strategy.Candidates(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return chooseRandomly(logger, strategy.rand, candidates), nil
+}
+
+func (strategy *RandomPlacementStrategy) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return workersWithCapacity(logger, workers)
+}
+
+// ChainedPlacementStrategy applies several strategies in sequence, each one
+// choosing among the workers the previous one considered equally good.
+type ChainedPlacementStrategy struct {
+	filters []ContainerPlacementFilter
+	rand    *rand.Rand
+}
+
+func NewChainedPlacementStrategy(filters ...ContainerPlacementFilter) ContainerPlacementFilter {
+	return &ChainedPlacementStrategy{
+		filters: filters,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *ChainedPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return chooseRandomly(logger, strategy.rand, candidates), nil
+}
+
+func (strategy *ChainedPlacementStrategy) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	candidates := workers
+	for _, filter := range strategy.filters {
+		var err error
+		candidates, err = filter.Candidates(logger, candidates, spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return candidates, nil
 }
@@ -3,15 +3,18 @@ package worker_test
 import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 //go:generate counterfeiter . ContainerPlacementStrategy
+//go:generate counterfeiter . ContainerPlacementFilter
 
 var (
 	strategy ContainerPlacementStrategy
@@ -232,6 +235,60 @@ var _ = Describe("VolumeLocalityPlacementStrategy", func() {
 			})
 		})
 
+		Context("with multiple with the same amount of local caches on different runtimes", func() {
+			var compatibleKubernetesWorkerOneCache *workerfakes.FakeWorker
+
+			BeforeEach(func() {
+				compatibleKubernetesWorkerOneCache = new(workerfakes.FakeWorker)
+				compatibleKubernetesWorkerOneCache.SatisfiesReturns(true)
+				compatibleKubernetesWorkerOneCache.RuntimeReturns(atc.WorkerRuntimeKubernetes)
+
+				compatibleWorkerOneCache1.RuntimeReturns(atc.WorkerRuntimeKubernetes)
+				compatibleWorkerOneCache2.RuntimeReturns(atc.WorkerRuntimeGarden)
+				compatibleWorkerNoCaches1.RuntimeReturns(atc.WorkerRuntimeGarden)
+
+				fakeInput3 := new(workerfakes.FakeInputSource)
+				fakeInput3AS := new(workerfakes.FakeArtifactSource)
+				fakeInput3AS.VolumeOnStub = func(logger lager.Logger, worker Worker) (Volume, bool, error) {
+					switch worker {
+					case compatibleKubernetesWorkerOneCache:
+						return new(workerfakes.FakeVolume), true, nil
+					default:
+						return nil, false, nil
+					}
+				}
+				fakeInput3.SourceReturns(fakeInput3AS)
+
+				spec.Inputs = append(spec.Inputs, fakeInput3)
+
+				workers = []Worker{
+					compatibleWorkerOneCache1,
+					compatibleWorkerOneCache2,
+					compatibleKubernetesWorkerOneCache,
+					compatibleWorkerNoCaches1,
+				}
+			})
+
+			It("creates it on one of the workers of the runtime with the most inputs", func() {
+				workerChoiceCounts := map[Worker]int{}
+
+				for i := 0; i < 100; i++ {
+					worker, err := strategy.Choose(
+						logger,
+						workers,
+						spec,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					workerChoiceCounts[worker]++
+				}
+
+				Expect(workerChoiceCounts[compatibleWorkerOneCache1]).ToNot(BeZero())
+				Expect(workerChoiceCounts[compatibleKubernetesWorkerOneCache]).ToNot(BeZero())
+				Expect(workerChoiceCounts[compatibleWorkerOneCache2]).To(BeZero())
+				Expect(workerChoiceCounts[compatibleWorkerNoCaches1]).To(BeZero())
+			})
+		})
+
 		Context("with none having any local caches", func() {
 			BeforeEach(func() {
 				workers = []Worker{
@@ -277,6 +334,9 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		BeforeEach(func() {
 			strategy = NewRandomPlacementStrategy()
 
+			compatibleWorkerNoCaches1 = new(workerfakes.FakeWorker)
+			compatibleWorkerNoCaches2 = new(workerfakes.FakeWorker)
+
 			workers = []Worker{
 				compatibleWorkerNoCaches1,
 				compatibleWorkerNoCaches2,
@@ -303,5 +363,242 @@ var _ = Describe("RandomPlacementStrategy", func() {
 			Expect(workerChoiceCounts[compatibleWorkerNoCaches1]).ToNot(BeZero())
 			Expect(workerChoiceCounts[compatibleWorkerNoCaches2]).ToNot(BeZero())
 		})
+
+		Context("when a worker has reached its container limit", func() {
+			BeforeEach(func() {
+				compatibleWorkerNoCaches1.MaxContainersReturns(5)
+				compatibleWorkerNoCaches1.ActiveContainersReturns(5)
+			})
+
+			It("never picks it", func() {
+				for i := 0; i < 100; i++ {
+					worker, err := strategy.Choose(
+						logger,
+						workers,
+						spec,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(worker).To(Equal(compatibleWorkerNoCaches2))
+				}
+			})
+
+			Context("when every worker has reached its container limit", func() {
+				BeforeEach(func() {
+					compatibleWorkerNoCaches2.MaxContainersReturns(3)
+					compatibleWorkerNoCaches2.ActiveContainersReturns(4)
+				})
+
+				It("returns ErrWorkersAtContainerLimit", func() {
+					Expect(chooseErr).To(Equal(ErrWorkersAtContainerLimit))
+				})
+			})
+		})
+
+		Context("when a worker has no container limit", func() {
+			BeforeEach(func() {
+				compatibleWorkerNoCaches1.ActiveContainersReturns(500)
+				compatibleWorkerNoCaches2.ActiveContainersReturns(500)
+			})
+
+			It("may still pick it", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+			})
+		})
+	})
+})
+
+var _ = Describe("LimitActiveTasksPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			maxTasks int
+
+			compatibleWorker1 *workerfakes.FakeWorker
+			compatibleWorker2 *workerfakes.FakeWorker
+			compatibleWorker3 *workerfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("active-tasks-placement-test")
+			maxTasks = 0
+
+			compatibleWorker1 = new(workerfakes.FakeWorker)
+			compatibleWorker1.NameReturns("worker-1")
+			compatibleWorker1.ActiveTasksReturns(2)
+			compatibleWorker2 = new(workerfakes.FakeWorker)
+			compatibleWorker2.NameReturns("worker-2")
+			compatibleWorker2.ActiveTasksReturns(1)
+			compatibleWorker3 = new(workerfakes.FakeWorker)
+			compatibleWorker3.NameReturns("worker-3")
+			compatibleWorker3.ActiveTasksReturns(1)
+
+			workers = []Worker{compatibleWorker1, compatibleWorker2, compatibleWorker3}
+
+			spec = ContainerSpec{
+				TeamID: 4567,
+				Type:   db.ContainerTypeTask,
+			}
+		})
+
+		JustBeforeEach(func() {
+			strategy = NewLimitActiveTasksPlacementStrategy(maxTasks)
+
+			chosenWorker, chooseErr = strategy.Choose(
+				logger,
+				workers,
+				spec,
+			)
+		})
+
+		It("picks one of the workers with the fewest active tasks", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(SatisfyAny(Equal(compatibleWorker2), Equal(compatibleWorker3)))
+		})
+
+		It("logs the reason for the choice", func() {
+			Expect(logger).To(gbytes.Say("found-workers-with-fewest-active-tasks.*active-tasks\":1.*worker-2.*worker-3"))
+		})
+
+		Context("when some workers have reached the limit", func() {
+			BeforeEach(func() {
+				maxTasks = 2
+				compatibleWorker2.ActiveTasksReturns(2)
+			})
+
+			It("picks one of the others", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker3))
+			})
+
+			It("logs the workers it skipped", func() {
+				Expect(logger).To(gbytes.Say("skipping-worker-at-active-task-limit.*worker-1"))
+			})
+
+			Context("when every worker has reached the limit", func() {
+				BeforeEach(func() {
+					compatibleWorker3.ActiveTasksReturns(3)
+				})
+
+				It("returns ErrWorkersAtActiveTaskLimit", func() {
+					Expect(chooseErr).To(Equal(ErrWorkersAtActiveTaskLimit))
+				})
+
+				Context("when the container is not for a task", func() {
+					BeforeEach(func() {
+						spec.Type = db.ContainerTypeGet
+					})
+
+					It("picks any of them", func() {
+						Expect(chooseErr).ToNot(HaveOccurred())
+						Expect(chosenWorker).To(SatisfyAny(
+							Equal(compatibleWorker1),
+							Equal(compatibleWorker2),
+							Equal(compatibleWorker3),
+						))
+					})
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("ChainedPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			filter1 *workerfakes.FakeContainerPlacementFilter
+			filter2 *workerfakes.FakeContainerPlacementFilter
+
+			compatibleWorker1 *workerfakes.FakeWorker
+			compatibleWorker2 *workerfakes.FakeWorker
+			compatibleWorker3 *workerfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("chained-placement-test")
+
+			compatibleWorker1 = new(workerfakes.FakeWorker)
+			compatibleWorker1.NameReturns("worker-1")
+			compatibleWorker2 = new(workerfakes.FakeWorker)
+			compatibleWorker2.NameReturns("worker-2")
+			compatibleWorker3 = new(workerfakes.FakeWorker)
+			compatibleWorker3.NameReturns("worker-3")
+
+			workers = []Worker{compatibleWorker1, compatibleWorker2, compatibleWorker3}
+
+			filter1 = new(workerfakes.FakeContainerPlacementFilter)
+			filter1.CandidatesReturns([]Worker{compatibleWorker2, compatibleWorker3}, nil)
+			filter2 = new(workerfakes.FakeContainerPlacementFilter)
+			filter2.CandidatesReturns([]Worker{compatibleWorker3}, nil)
+
+			spec = ContainerSpec{TeamID: 4567}
+
+			strategy = NewChainedPlacementStrategy(filter1, filter2)
+		})
+
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(
+				logger,
+				workers,
+				spec,
+			)
+		})
+
+		It("applies the filters in order", func() {
+			Expect(filter1.CandidatesCallCount()).To(Equal(1))
+			_, filterWorkers, filterSpec := filter1.CandidatesArgsForCall(0)
+			Expect(filterWorkers).To(Equal(workers))
+			Expect(filterSpec).To(Equal(spec))
+
+			Expect(filter2.CandidatesCallCount()).To(Equal(1))
+			_, filterWorkers, filterSpec = filter2.CandidatesArgsForCall(0)
+			Expect(filterWorkers).To(Equal([]Worker{compatibleWorker2, compatibleWorker3}))
+			Expect(filterSpec).To(Equal(spec))
+		})
+
+		It("picks one of the workers left by the last filter", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(Equal(compatibleWorker3))
+		})
+
+		It("logs the chosen worker", func() {
+			Expect(logger).To(gbytes.Say("chose-worker.*worker-3"))
+		})
+
+		Context("when a filter fails", func() {
+			BeforeEach(func() {
+				filter1.CandidatesReturns(nil, ErrWorkersAtActiveTaskLimit)
+			})
+
+			It("returns the error without applying the rest", func() {
+				Expect(chooseErr).To(Equal(ErrWorkersAtActiveTaskLimit))
+				Expect(filter2.CandidatesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("with fewest-build-containers then volume-locality", func() {
+			BeforeEach(func() {
+				compatibleWorker1.BuildContainersReturns(1)
+				compatibleWorker2.BuildContainersReturns(5)
+				compatibleWorker3.BuildContainersReturns(1)
+
+				fakeInputSource := new(workerfakes.FakeInputSource)
+				fakeArtifactSource := new(workerfakes.FakeArtifactSource)
+				fakeInputSource.SourceReturns(fakeArtifactSource)
+				fakeArtifactSource.VolumeOnStub = func(logger lager.Logger, w Worker) (Volume, bool, error) {
+					return new(workerfakes.FakeVolume), w == compatibleWorker2 || w == compatibleWorker3, nil
+				}
+
+				spec.Inputs = []InputSource{fakeInputSource}
+
+				strategy = NewChainedPlacementStrategy(
+					NewFewestBuildContainersPlacementStrategy(),
+					NewVolumeLocalityPlacementStrategy(),
+				)
+			})
+
+			It("picks the least busy worker with the most local inputs", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker3))
+			})
+		})
 	})
 })
@@ -10,8 +10,18 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
 )
 
+// WaitForWorkerPollingInterval is how often a container waiting for a
+// compatible worker looks for one again.
+const WaitForWorkerPollingInterval = 5 * time.Second
+
+// A waiting step is refreshed every polling interval, and stops counting
+// towards the demand for workers if it misses a few, e.g. because the ATC
+// running it went away.
+const waitingStepTTL = 3 * WaitForWorkerPollingInterval
+
 //go:generate counterfeiter . WorkerProvider
 
 type WorkerProvider interface {
@@ -55,12 +65,35 @@ func (err NoCompatibleWorkersError) Error() string {
 	return fmt.Sprintf("no workers satisfying: %s", err.Spec.Description())
 }
 
+// TeamQuotaReachedError is returned when creating a container would exceed
+// one of the team's quotas.
+type TeamQuotaReachedError struct {
+	Quota string
+	Limit int
+}
+
+func (err TeamQuotaReachedError) Error() string {
+	return fmt.Sprintf("team has reached its quota of %d %s", err.Limit, err.Quota)
+}
+
+//go:generate counterfeiter . WaitingForWorkerDelegate
+
+// WaitingForWorkerDelegate is told when a container has to wait for a
+// compatible worker to appear or free up.
+type WaitingForWorkerDelegate interface {
+	WaitingForWorker(lager.Logger)
+}
+
 //go:generate counterfeiter . Pool
 
 type Pool interface {
+	// FindOrChooseWorkerForContainer waits for a compatible worker if there is
+	// none and the pool was given a timeout to wait for. Only callers passing
+	// a delegate wait; others get the error straight away.
 	FindOrChooseWorkerForContainer(
 		context.Context,
 		lager.Logger,
+		WaitingForWorkerDelegate,
 		db.ContainerOwner,
 		ContainerSpec,
 		WorkerSpec,
@@ -75,15 +108,33 @@ type Pool interface {
 
 type pool struct {
 	provider    WorkerProvider
+	teamFactory db.TeamFactory
+	clock       clock.Clock
 	rand *rand.Rand
+
+	waitForWorkerTimeout time.Duration
+	waitingSteps         db.WaitingSteps
 }
 
+// NewPool returns a Pool which, if waitForWorkerTimeout is non-zero, waits up
+// to that long for a compatible worker with capacity instead of failing
+// straight away. Waiting steps are recorded in waitingSteps, so that the demand
+// for workers can be reported by any ATC.
 func NewPool(
 	provider WorkerProvider,
+	teamFactory db.TeamFactory,
+	clock clock.Clock,
+	waitForWorkerTimeout time.Duration,
+	waitingSteps db.WaitingSteps,
 ) Pool {
 	return &pool{
 		provider:    provider,
+		teamFactory: teamFactory,
+		clock:       clock,
 		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+
+		waitForWorkerTimeout: waitForWorkerTimeout,
+		waitingSteps:         waitingSteps,
 	}
 }
 
@@ -125,6 +176,110 @@ func (pool *pool) allSatisfying(logger lager.Logger, spec WorkerSpec) ([]Worker,
 
 func (pool *pool) FindOrChooseWorkerForContainer(
 	ctx context.Context,
+	logger lager.Logger,
+	delegate WaitingForWorkerDelegate,
+	owner db.ContainerOwner,
+	containerSpec ContainerSpec,
+	workerSpec WorkerSpec,
+	strategy ContainerPlacementStrategy,
+) (Worker, error) {
+	worker, err := pool.findOrChooseWorkerForContainer(logger, owner, containerSpec, workerSpec, strategy)
+	if err == nil || delegate == nil || pool.waitForWorkerTimeout == 0 || !noWorkerAvailable(err) {
+		return worker, err
+	}
+
+	logger = logger.Session("wait-for-worker", lager.Data{
+		"reason":  err.Error(),
+		"timeout": pool.waitForWorkerTimeout.String(),
+	})
+
+	delegate.WaitingForWorker(logger)
+
+	metric.StepsWaitingForWorker.Inc()
+	defer metric.StepsWaitingForWorker.Dec()
+
+	waitingStepID := pool.addWaitingStep(logger, workerSpec)
+	defer pool.removeWaitingStep(logger, waitingStepID)
+
+	timeout := pool.clock.NewTimer(pool.waitForWorkerTimeout)
+	defer timeout.Stop()
+
+	ticker := pool.clock.NewTicker(WaitForWorkerPollingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case <-timeout.C():
+			logger.Info("timed-out")
+			return nil, err
+
+		case <-ticker.C():
+			pool.refreshWaitingStep(logger, waitingStepID)
+
+			worker, err = pool.findOrChooseWorkerForContainer(logger, owner, containerSpec, workerSpec, strategy)
+			if err == nil || !noWorkerAvailable(err) {
+				return worker, err
+			}
+		}
+	}
+}
+
+// addWaitingStep records the step as waiting for a worker, returning 0 if it
+// could not be. Failing to record it only affects the reported demand for
+// workers, so the step waits regardless.
+func (pool *pool) addWaitingStep(logger lager.Logger, spec WorkerSpec) int {
+	id, err := pool.waitingSteps.Add(db.WaitingStep{
+		TeamID:   spec.TeamID,
+		Tags:     spec.Tags,
+		Platform: spec.Platform,
+	}, waitingStepTTL)
+	if err != nil {
+		logger.Error("failed-to-add-waiting-step", err)
+		return 0
+	}
+
+	return id
+}
+
+func (pool *pool) refreshWaitingStep(logger lager.Logger, id int) {
+	if id == 0 {
+		return
+	}
+
+	err := pool.waitingSteps.Refresh(id, waitingStepTTL)
+	if err != nil {
+		logger.Error("failed-to-refresh-waiting-step", err)
+	}
+}
+
+func (pool *pool) removeWaitingStep(logger lager.Logger, id int) {
+	if id == 0 {
+		return
+	}
+
+	err := pool.waitingSteps.Remove(id)
+	if err != nil {
+		logger.Error("failed-to-remove-waiting-step", err)
+	}
+}
+
+// noWorkerAvailable returns true for the errors which may go away once
+// another worker registers or a busy one frees up.
+func noWorkerAvailable(err error) bool {
+	switch err.(type) {
+	case NoCompatibleWorkersError:
+		return true
+	}
+
+	return err == ErrNoWorkers ||
+		err == ErrWorkersAtContainerLimit ||
+		err == ErrWorkersAtActiveTaskLimit
+}
+
+func (pool *pool) findOrChooseWorkerForContainer(
 	logger lager.Logger,
 	owner db.ContainerOwner,
 	containerSpec ContainerSpec,
@@ -156,6 +311,11 @@ dance:
 	}
 
 		if worker == nil {
+			err = pool.checkTeamQuotas(logger, containerSpec.TeamID)
+			if err != nil {
+				return nil, err
+			}
+
 			worker, err = strategy.Choose(logger, compatibleWorkers, containerSpec)
 			if err != nil {
 				return nil, err
@@ -165,6 +325,30 @@ dance:
 	return worker, nil
 }
 
+// checkTeamQuotas returns a TeamQuotaReachedError if the team may not create
+// any more containers, or the volumes which come with them.
+func (pool *pool) checkTeamQuotas(logger lager.Logger, teamID int) error {
+	if teamID == 0 {
+		return nil
+	}
+
+	usage, err := pool.teamFactory.GetByID(teamID).Usage()
+	if err != nil {
+		logger.Error("failed-to-get-team-usage", err)
+		return err
+	}
+
+	if usage.ContainersQuotaReached() {
+		return TeamQuotaReachedError{Quota: "containers", Limit: usage.Quotas.MaxContainers}
+	}
+
+	if usage.VolumesQuotaReached() {
+		return TeamQuotaReachedError{Quota: "volumes", Limit: usage.Quotas.MaxVolumes}
+	}
+
+	return nil
+}
+
 func (pool *pool) FindOrChooseWorker(
 	logger lager.Logger,
 	workerSpec WorkerSpec,
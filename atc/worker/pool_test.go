@@ -3,10 +3,13 @@ package worker_test
 import (
 	"context"
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
@@ -16,16 +19,27 @@ import (
 
 var _ = Describe("Pool", func() {
 	var (
-		logger          *lagertest.TestLogger
-		fakeProvider    *workerfakes.FakeWorkerProvider
-		pool            Pool
+		logger           *lagertest.TestLogger
+		fakeProvider     *workerfakes.FakeWorkerProvider
+		fakeTeamFactory  *dbfakes.FakeTeamFactory
+		fakeTeam         *dbfakes.FakeTeam
+		fakeClock        *fakeclock.FakeClock
+		fakeWaitingSteps *dbfakes.FakeWaitingSteps
+		pool             Pool
 	)
 
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		fakeWaitingSteps = new(dbfakes.FakeWaitingSteps)
+
+		pool = NewPool(fakeProvider, fakeTeamFactory, fakeClock, 0, fakeWaitingSteps)
 	})
 
 	Describe("FindOrChooseWorkerForContainer", func() {
@@ -41,10 +55,12 @@ var _ = Describe("Pool", func() {
 			incompatibleWorker *workerfakes.FakeWorker
 			compatibleWorker   *workerfakes.FakeWorker
 			fakeStrategy       *workerfakes.FakeContainerPlacementStrategy
+			fakeDelegate       *workerfakes.FakeWaitingForWorkerDelegate
 		)
 
 		BeforeEach(func() {
 			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+			fakeDelegate = new(workerfakes.FakeWaitingForWorkerDelegate)
 
 			fakeOwner = new(dbfakes.FakeContainerOwner)
 
@@ -112,6 +128,7 @@ var _ = Describe("Pool", func() {
 			chosenWorker, chooseErr = pool.FindOrChooseWorkerForContainer(
 				context.TODO(),
 				logger,
+				fakeDelegate,
 				fakeOwner,
 				spec,
 				workerSpec,
@@ -356,6 +373,10 @@ var _ = Describe("Pool", func() {
 				It("returns ErrNoWorkers", func() {
 					Expect(chooseErr).To(Equal(ErrNoWorkers))
 				})
+
+				It("does not wait for a worker", func() {
+					Expect(fakeDelegate.WaitingForWorkerCallCount()).To(BeZero())
+				})
 			})
 
 			Context("when getting the workers fails", func() {
@@ -426,6 +447,219 @@ var _ = Describe("Pool", func() {
 						Expect(chooseErr).To(Equal(strategyError))
 					})
 				})
+
+				Context("when the team has reached its containers quota", func() {
+					BeforeEach(func() {
+						fakeTeam.UsageReturns(atc.TeamUsage{
+							Quotas:     atc.TeamQuotas{MaxContainers: 10},
+							Containers: 10,
+						}, nil)
+					})
+
+					It("checks the usage of the container's team", func() {
+						Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(4567))
+					})
+
+					It("returns a TeamQuotaReachedError without choosing a worker", func() {
+						Expect(chooseErr).To(Equal(TeamQuotaReachedError{Quota: "containers", Limit: 10}))
+						Expect(fakeStrategy.ChooseCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the team has reached its volumes quota", func() {
+					BeforeEach(func() {
+						fakeTeam.UsageReturns(atc.TeamUsage{
+							Quotas:  atc.TeamQuotas{MaxVolumes: 20},
+							Volumes: 25,
+						}, nil)
+					})
+
+					It("returns a TeamQuotaReachedError", func() {
+						Expect(chooseErr).To(Equal(TeamQuotaReachedError{Quota: "volumes", Limit: 20}))
+					})
+				})
+
+				Context("when getting the team's usage fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeTeam.UsageReturns(atc.TeamUsage{}, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(chooseErr).To(Equal(disaster))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("FindOrChooseWorkerForContainer while waiting for workers", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+
+			delegate     WaitingForWorkerDelegate
+			fakeDelegate *workerfakes.FakeWaitingForWorkerDelegate
+
+			fakeOwner        *dbfakes.FakeContainerOwner
+			fakeStrategy     *workerfakes.FakeContainerPlacementStrategy
+			compatibleWorker *workerfakes.FakeWorker
+			spec             ContainerSpec
+			workerSpec       WorkerSpec
+
+			chosen chan Worker
+			errs   chan error
+		)
+
+		BeforeEach(func() {
+			pool = NewPool(fakeProvider, fakeTeamFactory, fakeClock, time.Minute, fakeWaitingSteps)
+			fakeWaitingSteps.AddReturns(42, nil)
+
+			ctx, cancel = context.WithCancel(context.Background())
+
+			fakeDelegate = new(workerfakes.FakeWaitingForWorkerDelegate)
+			delegate = fakeDelegate
+
+			fakeOwner = new(dbfakes.FakeContainerOwner)
+			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+
+			compatibleWorker = new(workerfakes.FakeWorker)
+			compatibleWorker.SatisfiesReturns(true)
+
+			spec = ContainerSpec{TeamID: 4567}
+			workerSpec = WorkerSpec{TeamID: 4567}
+
+			chosen = make(chan Worker, 1)
+			errs = make(chan error, 1)
+
+			fakeProvider.RunningWorkersReturns([]Worker{}, nil)
+			fakeStrategy.ChooseReturns(compatibleWorker, nil)
+		})
+
+		JustBeforeEach(func() {
+			// the step may still be waiting once the spec is over, so it must
+			// not see the next spec's values
+			pool, ctx, logger, delegate, owner, spec, workerSpec, strategy := pool, ctx, logger, delegate, fakeOwner, spec, workerSpec, fakeStrategy
+			chosen, errs := chosen, errs
+
+			go func() {
+				defer GinkgoRecover()
+
+				worker, err := pool.FindOrChooseWorkerForContainer(
+					ctx,
+					logger,
+					delegate,
+					owner,
+					spec,
+					workerSpec,
+					strategy,
+				)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				chosen <- worker
+			}()
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("tells the delegate it is waiting", func() {
+			Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+			Consistently(errs).ShouldNot(Receive())
+		})
+
+		Context("when a compatible worker appears", func() {
+			It("chooses it on the next poll", func() {
+				Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+				fakeProvider.RunningWorkersReturns([]Worker{compatibleWorker}, nil)
+				fakeClock.WaitForNWatchersAndIncrement(WaitForWorkerPollingInterval, 2)
+
+				Eventually(chosen).Should(Receive(Equal(compatibleWorker)))
+			})
+		})
+
+		It("records the step as waiting until it stops waiting", func() {
+			Eventually(fakeWaitingSteps.AddCallCount).Should(Equal(1))
+			step, ttl := fakeWaitingSteps.AddArgsForCall(0)
+			Expect(step).To(Equal(db.WaitingStep{TeamID: 4567}))
+			Expect(ttl).To(BeNumerically(">", WaitForWorkerPollingInterval))
+
+			fakeClock.WaitForNWatchersAndIncrement(WaitForWorkerPollingInterval, 2)
+
+			Eventually(fakeWaitingSteps.RefreshCallCount).Should(Equal(1))
+			id, _ := fakeWaitingSteps.RefreshArgsForCall(0)
+			Expect(id).To(Equal(42))
+
+			fakeClock.Increment(time.Minute)
+
+			Eventually(errs).Should(Receive())
+			Expect(fakeWaitingSteps.RemoveCallCount()).To(Equal(1))
+			Expect(fakeWaitingSteps.RemoveArgsForCall(0)).To(Equal(42))
+		})
+
+		Context("when recording the step as waiting fails", func() {
+			BeforeEach(func() {
+				fakeWaitingSteps.AddReturns(0, errors.New("nope"))
+			})
+
+			It("waits regardless", func() {
+				Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+				fakeProvider.RunningWorkersReturns([]Worker{compatibleWorker}, nil)
+				fakeClock.WaitForNWatchersAndIncrement(WaitForWorkerPollingInterval, 2)
+
+				Eventually(chosen).Should(Receive(Equal(compatibleWorker)))
+				Expect(fakeWaitingSteps.RefreshCallCount()).To(BeZero())
+				Expect(fakeWaitingSteps.RemoveCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when no compatible worker appears before the timeout", func() {
+			It("returns the original error", func() {
+				Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+				fakeClock.WaitForNWatchersAndIncrement(time.Minute, 2)
+
+				Eventually(errs).Should(Receive(Equal(ErrNoWorkers)))
+			})
+		})
+
+		Context("when the context is canceled", func() {
+			It("stops waiting", func() {
+				Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+				cancel()
+
+				Eventually(errs).Should(Receive(Equal(context.Canceled)))
+			})
+		})
+
+		Context("when no delegate is given", func() {
+			BeforeEach(func() {
+				delegate = nil
+			})
+
+			It("returns the error straight away", func() {
+				Eventually(errs).Should(Receive(Equal(ErrNoWorkers)))
+			})
+		})
+
+		Context("when the error is not about worker availability", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeProvider.RunningWorkersReturns(nil, disaster)
+			})
+
+			It("returns the error without waiting", func() {
+				Eventually(errs).Should(Receive(Equal(disaster)))
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(BeZero())
 			})
 		})
 	})
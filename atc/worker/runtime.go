@@ -0,0 +1,20 @@
+package worker
+
+import (
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . RuntimeFactory
+
+// RuntimeFactory provides the clients used to manage the containers and
+// volumes of workers which do not run Garden and Baggageclaim themselves,
+// keyed by atc.Worker's Runtime in a RuntimeFactories.
+type RuntimeFactory interface {
+	NewGardenClient(lager.Logger, db.Worker) garden.Client
+	NewBaggageclaimClient(lager.Logger, db.Worker) baggageclaim.Client
+}
+
+type RuntimeFactories map[string]RuntimeFactory
@@ -28,6 +28,11 @@ var ResourceConfigCheckSessionExpiredError = errors.New("no db container was fou
 
 type Worker interface {
 	BuildContainers() int
+	ActiveContainers() int
+	MaxContainers() int
+	ActiveTasks() int
+	IncreaseActiveTasks() error
+	DecreaseActiveTasks() error
 
 	Description() string
 	Name() string
@@ -36,6 +41,7 @@ type Worker interface {
 	Uptime() time.Duration
 	IsOwnedByTeam() bool
 	Ephemeral() bool
+	Runtime() string
 	IsVersionCompatible(lager.Logger, version.Version) bool
 	Satisfies(lager.Logger, WorkerSpec) bool
 
@@ -672,10 +678,38 @@ func (worker *gardenWorker) Ephemeral() bool {
 	return worker.dbWorker.Ephemeral()
 }
 
+func (worker *gardenWorker) Runtime() string {
+	if worker.dbWorker.Runtime() == "" {
+		return atc.WorkerRuntimeGarden
+	}
+
+	return worker.dbWorker.Runtime()
+}
+
 func (worker *gardenWorker) BuildContainers() int {
 	return worker.buildContainers
 }
 
+func (worker *gardenWorker) ActiveContainers() int {
+	return worker.dbWorker.ActiveContainers()
+}
+
+func (worker *gardenWorker) MaxContainers() int {
+	return worker.dbWorker.MaxContainers()
+}
+
+func (worker *gardenWorker) ActiveTasks() int {
+	return worker.dbWorker.ActiveTasks()
+}
+
+func (worker *gardenWorker) IncreaseActiveTasks() error {
+	return worker.dbWorker.IncreaseActiveTasks()
+}
+
+func (worker *gardenWorker) DecreaseActiveTasks() error {
+	return worker.dbWorker.DecreaseActiveTasks()
+}
+
 func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool {
 	workerTeamID := worker.dbWorker.TeamID()
 	workerResourceTypes := worker.dbWorker.ResourceTypes()
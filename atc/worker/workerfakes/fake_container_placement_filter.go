@@ -0,0 +1,212 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package workerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type FakeContainerPlacementFilter struct {
+	CandidatesStub        func(lager.Logger, []worker.Worker, worker.ContainerSpec) ([]worker.Worker, error)
+	candidatesMutex       sync.RWMutex
+	candidatesArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []worker.Worker
+		arg3 worker.ContainerSpec
+	}
+	candidatesReturns struct {
+		result1 []worker.Worker
+		result2 error
+	}
+	candidatesReturnsOnCall map[int]struct {
+		result1 []worker.Worker
+		result2 error
+	}
+	ChooseStub        func(lager.Logger, []worker.Worker, worker.ContainerSpec) (worker.Worker, error)
+	chooseMutex       sync.RWMutex
+	chooseArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []worker.Worker
+		arg3 worker.ContainerSpec
+	}
+	chooseReturns struct {
+		result1 worker.Worker
+		result2 error
+	}
+	chooseReturnsOnCall map[int]struct {
+		result1 worker.Worker
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeContainerPlacementFilter) Candidates(arg1 lager.Logger, arg2 []worker.Worker, arg3 worker.ContainerSpec) ([]worker.Worker, error) {
+	var arg2Copy []worker.Worker
+	if arg2 != nil {
+		arg2Copy = make([]worker.Worker, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.candidatesMutex.Lock()
+	ret, specificReturn := fake.candidatesReturnsOnCall[len(fake.candidatesArgsForCall)]
+	fake.candidatesArgsForCall = append(fake.candidatesArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []worker.Worker
+		arg3 worker.ContainerSpec
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("Candidates", []interface{}{arg1, arg2Copy, arg3})
+	fake.candidatesMutex.Unlock()
+	if fake.CandidatesStub != nil {
+		return fake.CandidatesStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.candidatesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeContainerPlacementFilter) CandidatesCallCount() int {
+	fake.candidatesMutex.RLock()
+	defer fake.candidatesMutex.RUnlock()
+	return len(fake.candidatesArgsForCall)
+}
+
+func (fake *FakeContainerPlacementFilter) CandidatesCalls(stub func(lager.Logger, []worker.Worker, worker.ContainerSpec) ([]worker.Worker, error)) {
+	fake.candidatesMutex.Lock()
+	defer fake.candidatesMutex.Unlock()
+	fake.CandidatesStub = stub
+}
+
+func (fake *FakeContainerPlacementFilter) CandidatesArgsForCall(i int) (lager.Logger, []worker.Worker, worker.ContainerSpec) {
+	fake.candidatesMutex.RLock()
+	defer fake.candidatesMutex.RUnlock()
+	argsForCall := fake.candidatesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeContainerPlacementFilter) CandidatesReturns(result1 []worker.Worker, result2 error) {
+	fake.candidatesMutex.Lock()
+	defer fake.candidatesMutex.Unlock()
+	fake.CandidatesStub = nil
+	fake.candidatesReturns = struct {
+		result1 []worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerPlacementFilter) CandidatesReturnsOnCall(i int, result1 []worker.Worker, result2 error) {
+	fake.candidatesMutex.Lock()
+	defer fake.candidatesMutex.Unlock()
+	fake.CandidatesStub = nil
+	if fake.candidatesReturnsOnCall == nil {
+		fake.candidatesReturnsOnCall = make(map[int]struct {
+			result1 []worker.Worker
+			result2 error
+		})
+	}
+	fake.candidatesReturnsOnCall[i] = struct {
+		result1 []worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerPlacementFilter) Choose(arg1 lager.Logger, arg2 []worker.Worker, arg3 worker.ContainerSpec) (worker.Worker, error) {
+	var arg2Copy []worker.Worker
+	if arg2 != nil {
+		arg2Copy = make([]worker.Worker, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.chooseMutex.Lock()
+	ret, specificReturn := fake.chooseReturnsOnCall[len(fake.chooseArgsForCall)]
+	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []worker.Worker
+		arg3 worker.ContainerSpec
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("Choose", []interface{}{arg1, arg2Copy, arg3})
+	fake.chooseMutex.Unlock()
+	if fake.ChooseStub != nil {
+		return fake.ChooseStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.chooseReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeContainerPlacementFilter) ChooseCallCount() int {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return len(fake.chooseArgsForCall)
+}
+
+func (fake *FakeContainerPlacementFilter) ChooseCalls(stub func(lager.Logger, []worker.Worker, worker.ContainerSpec) (worker.Worker, error)) {
+	fake.chooseMutex.Lock()
+	defer fake.chooseMutex.Unlock()
+	fake.ChooseStub = stub
+}
+
+func (fake *FakeContainerPlacementFilter) ChooseArgsForCall(i int) (lager.Logger, []worker.Worker, worker.ContainerSpec) {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	argsForCall := fake.chooseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeContainerPlacementFilter) ChooseReturns(result1 worker.Worker, result2 error) {
+	fake.chooseMutex.Lock()
+	defer fake.chooseMutex.Unlock()
+	fake.ChooseStub = nil
+	fake.chooseReturns = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerPlacementFilter) ChooseReturnsOnCall(i int, result1 worker.Worker, result2 error) {
+	fake.chooseMutex.Lock()
+	defer fake.chooseMutex.Unlock()
+	fake.ChooseStub = nil
+	if fake.chooseReturnsOnCall == nil {
+		fake.chooseReturnsOnCall = make(map[int]struct {
+			result1 worker.Worker
+			result2 error
+		})
+	}
+	fake.chooseReturnsOnCall[i] = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerPlacementFilter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.candidatesMutex.RLock()
+	defer fake.candidatesMutex.RUnlock()
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeContainerPlacementFilter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.ContainerPlacementFilter = new(FakeContainerPlacementFilter)
@@ -0,0 +1,191 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package workerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type FakeRuntimeFactory struct {
+	NewBaggageclaimClientStub        func(lager.Logger, db.Worker) baggageclaim.Client
+	newBaggageclaimClientMutex       sync.RWMutex
+	newBaggageclaimClientArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}
+	newBaggageclaimClientReturns struct {
+		result1 baggageclaim.Client
+	}
+	newBaggageclaimClientReturnsOnCall map[int]struct {
+		result1 baggageclaim.Client
+	}
+	NewGardenClientStub        func(lager.Logger, db.Worker) garden.Client
+	newGardenClientMutex       sync.RWMutex
+	newGardenClientArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}
+	newGardenClientReturns struct {
+		result1 garden.Client
+	}
+	newGardenClientReturnsOnCall map[int]struct {
+		result1 garden.Client
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRuntimeFactory) NewBaggageclaimClient(arg1 lager.Logger, arg2 db.Worker) baggageclaim.Client {
+	fake.newBaggageclaimClientMutex.Lock()
+	ret, specificReturn := fake.newBaggageclaimClientReturnsOnCall[len(fake.newBaggageclaimClientArgsForCall)]
+	fake.newBaggageclaimClientArgsForCall = append(fake.newBaggageclaimClientArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}{arg1, arg2})
+	fake.recordInvocation("NewBaggageclaimClient", []interface{}{arg1, arg2})
+	fake.newBaggageclaimClientMutex.Unlock()
+	if fake.NewBaggageclaimClientStub != nil {
+		return fake.NewBaggageclaimClientStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.newBaggageclaimClientReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRuntimeFactory) NewBaggageclaimClientCallCount() int {
+	fake.newBaggageclaimClientMutex.RLock()
+	defer fake.newBaggageclaimClientMutex.RUnlock()
+	return len(fake.newBaggageclaimClientArgsForCall)
+}
+
+func (fake *FakeRuntimeFactory) NewBaggageclaimClientCalls(stub func(lager.Logger, db.Worker) baggageclaim.Client) {
+	fake.newBaggageclaimClientMutex.Lock()
+	defer fake.newBaggageclaimClientMutex.Unlock()
+	fake.NewBaggageclaimClientStub = stub
+}
+
+func (fake *FakeRuntimeFactory) NewBaggageclaimClientArgsForCall(i int) (lager.Logger, db.Worker) {
+	fake.newBaggageclaimClientMutex.RLock()
+	defer fake.newBaggageclaimClientMutex.RUnlock()
+	argsForCall := fake.newBaggageclaimClientArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRuntimeFactory) NewBaggageclaimClientReturns(result1 baggageclaim.Client) {
+	fake.newBaggageclaimClientMutex.Lock()
+	defer fake.newBaggageclaimClientMutex.Unlock()
+	fake.NewBaggageclaimClientStub = nil
+	fake.newBaggageclaimClientReturns = struct {
+		result1 baggageclaim.Client
+	}{result1}
+}
+
+func (fake *FakeRuntimeFactory) NewBaggageclaimClientReturnsOnCall(i int, result1 baggageclaim.Client) {
+	fake.newBaggageclaimClientMutex.Lock()
+	defer fake.newBaggageclaimClientMutex.Unlock()
+	fake.NewBaggageclaimClientStub = nil
+	if fake.newBaggageclaimClientReturnsOnCall == nil {
+		fake.newBaggageclaimClientReturnsOnCall = make(map[int]struct {
+			result1 baggageclaim.Client
+		})
+	}
+	fake.newBaggageclaimClientReturnsOnCall[i] = struct {
+		result1 baggageclaim.Client
+	}{result1}
+}
+
+func (fake *FakeRuntimeFactory) NewGardenClient(arg1 lager.Logger, arg2 db.Worker) garden.Client {
+	fake.newGardenClientMutex.Lock()
+	ret, specificReturn := fake.newGardenClientReturnsOnCall[len(fake.newGardenClientArgsForCall)]
+	fake.newGardenClientArgsForCall = append(fake.newGardenClientArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}{arg1, arg2})
+	fake.recordInvocation("NewGardenClient", []interface{}{arg1, arg2})
+	fake.newGardenClientMutex.Unlock()
+	if fake.NewGardenClientStub != nil {
+		return fake.NewGardenClientStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.newGardenClientReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRuntimeFactory) NewGardenClientCallCount() int {
+	fake.newGardenClientMutex.RLock()
+	defer fake.newGardenClientMutex.RUnlock()
+	return len(fake.newGardenClientArgsForCall)
+}
+
+func (fake *FakeRuntimeFactory) NewGardenClientCalls(stub func(lager.Logger, db.Worker) garden.Client) {
+	fake.newGardenClientMutex.Lock()
+	defer fake.newGardenClientMutex.Unlock()
+	fake.NewGardenClientStub = stub
+}
+
+func (fake *FakeRuntimeFactory) NewGardenClientArgsForCall(i int) (lager.Logger, db.Worker) {
+	fake.newGardenClientMutex.RLock()
+	defer fake.newGardenClientMutex.RUnlock()
+	argsForCall := fake.newGardenClientArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRuntimeFactory) NewGardenClientReturns(result1 garden.Client) {
+	fake.newGardenClientMutex.Lock()
+	defer fake.newGardenClientMutex.Unlock()
+	fake.NewGardenClientStub = nil
+	fake.newGardenClientReturns = struct {
+		result1 garden.Client
+	}{result1}
+}
+
+func (fake *FakeRuntimeFactory) NewGardenClientReturnsOnCall(i int, result1 garden.Client) {
+	fake.newGardenClientMutex.Lock()
+	defer fake.newGardenClientMutex.Unlock()
+	fake.NewGardenClientStub = nil
+	if fake.newGardenClientReturnsOnCall == nil {
+		fake.newGardenClientReturnsOnCall = make(map[int]struct {
+			result1 garden.Client
+		})
+	}
+	fake.newGardenClientReturnsOnCall[i] = struct {
+		result1 garden.Client
+	}{result1}
+}
+
+func (fake *FakeRuntimeFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newBaggageclaimClientMutex.RLock()
+	defer fake.newBaggageclaimClientMutex.RUnlock()
+	fake.newGardenClientMutex.RLock()
+	defer fake.newGardenClientMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRuntimeFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.RuntimeFactory = new(FakeRuntimeFactory)
@@ -0,0 +1,25 @@
+package atc
+
+// WorkerDemand reports the work running on or waiting for workers, grouped
+// by the kind of worker it needs, so that an autoscaler can size each group
+// of workers.
+type WorkerDemand struct {
+	ContainersPerWorker int `json:"containers_per_worker"`
+
+	Groups []WorkerDemandGroup `json:"groups"`
+}
+
+// WorkerDemandGroup is the demand for workers of one team, set of tags and
+// platform. An empty team is the demand for workers shared by every team.
+type WorkerDemandGroup struct {
+	Team     string   `json:"team,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Platform string   `json:"platform,omitempty"`
+
+	Workers       int `json:"workers"`
+	RunningSteps  int `json:"running_steps"`
+	PendingBuilds int `json:"pending_builds"`
+	WaitingSteps  int `json:"waiting_steps"`
+
+	RecommendedWorkers int `json:"recommended_workers"`
+}
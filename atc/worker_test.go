@@ -60,5 +60,37 @@ var _ = Describe("Worker", func() {
 				Expect(err.Error()).To(ContainSubstring("missing garden address"))
 			})
 		})
+
+		Context("when the runtime is kubernetes", func() {
+			BeforeEach(func() {
+				worker.Runtime = atc.WorkerRuntimeKubernetes
+				worker.GardenAddr = ""
+				worker.Namespace = "concourse-workers"
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+
+			Context("when the namespace is missing", func() {
+				BeforeEach(func() {
+					worker.Namespace = ""
+				})
+
+				It("returns errors", func() {
+					Expect(worker.Validate()).To(Equal(atc.ErrMissingWorkerNamespace))
+				})
+			})
+		})
+
+		Context("when the runtime is unknown", func() {
+			BeforeEach(func() {
+				worker.Runtime = "bogus"
+			})
+
+			It("returns errors", func() {
+				Expect(worker.Validate()).To(Equal(atc.ErrUnknownWorkerRuntime))
+			})
+		})
 	})
 })
@@ -154,7 +154,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPlan:        checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:   checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.ApproveBuild: checkWritePermissionForBuild(inputHandlers[atc.ApproveBuild]),
 
 				// resource belongs to authorized team
 				atc.PruneWorker:              checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
@@ -168,6 +169,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
 				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobBuild]),
+				atc.SearchJobBuildLogs:            openForPublicPipelineOrAuthorized(inputHandlers[atc.SearchJobBuildLogs]),
 				atc.PipelineBadge:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.PipelineBadge]),
 				atc.JobBadge:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.JobBadge]),
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
@@ -191,6 +193,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
 				atc.ListTeamBuilds:  authenticated(inputHandlers[atc.ListTeamBuilds]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
+				atc.GetWorkerDemand: authenticated(inputHandlers[atc.GetWorkerDemand]),
+				atc.ListBuildQueue:  authenticated(inputHandlers[atc.ListBuildQueue]),
 				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
 				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
 				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
@@ -198,11 +202,13 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
 				atc.RenameTeam:      authenticated(inputHandlers[atc.RenameTeam]),
 				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
+				atc.GetInfoRBAC:     authenticated(inputHandlers[atc.GetInfoRBAC]),
 
 				//authenticateIfTokenProvided / delegating to handler
 				atc.GetInfo:              authenticateIfTokenProvided(inputHandlers[atc.GetInfo]),
 				atc.DownloadCLI:          authenticateIfTokenProvided(inputHandlers[atc.DownloadCLI]),
 				atc.CheckResourceWebHook: authenticateIfTokenProvided(inputHandlers[atc.CheckResourceWebHook]),
+				atc.ReceiveWebhook:       authenticateIfTokenProvided(inputHandlers[atc.ReceiveWebhook]),
 				atc.ListAllPipelines:     authenticateIfTokenProvided(inputHandlers[atc.ListAllPipelines]),
 				atc.ListBuilds:           authenticateIfTokenProvided(inputHandlers[atc.ListBuilds]),
 				atc.ListPipelines:        authenticateIfTokenProvided(inputHandlers[atc.ListPipelines]),
@@ -212,37 +218,51 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.MainJobBadge:         authenticateIfTokenProvided(inputHandlers[atc.MainJobBadge]),
 
 				// authenticated and is admin
-				atc.GetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
-				atc.GetInfoCreds: authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
+				atc.GetLogLevel:        authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:        authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetInfoCreds:       authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
+				atc.ListSecretAccesses: authenticatedAndAdmin(inputHandlers[atc.ListSecretAccesses]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
-				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
-				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:   authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.PinResourceVersion:      authorized(inputHandlers[atc.PinResourceVersion]),
-				atc.UnpinResource:           authorized(inputHandlers[atc.UnpinResource]),
-				atc.SetPinCommentOnResource: authorized(inputHandlers[atc.SetPinCommentOnResource]),
-				atc.GetConfig:               authorized(inputHandlers[atc.GetConfig]),
-				atc.GetCC:                   authorized(inputHandlers[atc.GetCC]),
-				atc.GetVersionsDB:           authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:           authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:          authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:                authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:           authorized(inputHandlers[atc.PausePipeline]),
-				atc.RenamePipeline:          authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:              authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:              authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:         authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.ExposePipeline:          authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:            authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:     authorized(inputHandlers[atc.CreatePipelineBuild]),
-				atc.ClearTaskCache:          authorized(inputHandlers[atc.ClearTaskCache]),
-				atc.CreateArtifact:          authorized(inputHandlers[atc.CreateArtifact]),
-				atc.GetArtifact:             authorized(inputHandlers[atc.GetArtifact]),
+				atc.CheckResource:             authorized(inputHandlers[atc.CheckResource]),
+				atc.CheckResourceType:         authorized(inputHandlers[atc.CheckResourceType]),
+				atc.CreateJobBuild:            authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.DeletePipeline:            authorized(inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion:    authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:     authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.PinResourceVersion:        authorized(inputHandlers[atc.PinResourceVersion]),
+				atc.UnpinResource:             authorized(inputHandlers[atc.UnpinResource]),
+				atc.SetPinCommentOnResource:   authorized(inputHandlers[atc.SetPinCommentOnResource]),
+				atc.GetConfig:                 authorized(inputHandlers[atc.GetConfig]),
+				atc.ListPipelineConfigHistory: authorized(inputHandlers[atc.ListPipelineConfigHistory]),
+				atc.GetPipelineConfigVersion:  authorized(inputHandlers[atc.GetPipelineConfigVersion]),
+				atc.DiffPipelineConfigVersion: authorized(inputHandlers[atc.DiffPipelineConfigVersion]),
+				atc.GetCC:                     authorized(inputHandlers[atc.GetCC]),
+				atc.GetVersionsDB:             authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.ListJobInputs:             authorized(inputHandlers[atc.ListJobInputs]),
+				atc.OrderPipelines:            authorized(inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:                  authorized(inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:             authorized(inputHandlers[atc.PausePipeline]),
+				atc.RenamePipeline:            authorized(inputHandlers[atc.RenamePipeline]),
+				atc.SaveConfig:                authorized(inputHandlers[atc.SaveConfig]),
+				atc.UnpauseJob:                authorized(inputHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:           authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.ArchivePipeline:           authorized(inputHandlers[atc.ArchivePipeline]),
+				atc.ExposePipeline:            authorized(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:              authorized(inputHandlers[atc.HidePipeline]),
+				atc.CreatePipelineBuild:       authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.ClearTaskCache:            authorized(inputHandlers[atc.ClearTaskCache]),
+				atc.CreateArtifact:            authorized(inputHandlers[atc.CreateArtifact]),
+				atc.GetArtifact:               authorized(inputHandlers[atc.GetArtifact]),
+				atc.SetWebhook:                authorized(inputHandlers[atc.SetWebhook]),
+				atc.DestroyWebhook:            authorized(inputHandlers[atc.DestroyWebhook]),
+				atc.ListSecrets:               authorized(inputHandlers[atc.ListSecrets]),
+				atc.SetSecret:                 authorized(inputHandlers[atc.SetSecret]),
+				atc.DestroySecret:             authorized(inputHandlers[atc.DestroySecret]),
+				atc.SetPipelineSecret:         authorized(inputHandlers[atc.SetPipelineSecret]),
+				atc.DestroyPipelineSecret:     authorized(inputHandlers[atc.DestroyPipelineSecret]),
+				atc.PurgeSecretCache:          authorized(inputHandlers[atc.PurgeSecretCache]),
+				atc.GetTeamUsage:              authorized(inputHandlers[atc.GetTeamUsage]),
 			}
 		})
 
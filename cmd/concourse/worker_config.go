@@ -17,6 +17,8 @@ type WorkerConfig struct {
 
 	Ephemeral bool `long:"ephemeral" description:"If set, the worker will be immediately removed upon stalling."`
 
+	MaxContainers int `long:"max-containers" description:"Number of active containers at which no more containers are placed on the worker. 0 means no limit."`
+
 	Version string `long:"version" hidden:"true" description:"Version of the worker. This is normally baked in to the binary, so this flag is hidden."`
 }
 
@@ -31,5 +33,6 @@ func (c WorkerConfig) Worker() atc.Worker {
 		HTTPSProxyURL: c.HTTPSProxy,
 		NoProxy:       c.NoProxy,
 		Ephemeral:     c.Ephemeral,
+		MaxContainers: c.MaxContainers,
 	}
 }
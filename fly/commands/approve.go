@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ApproveCommand struct {
+	Job    flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job the build belongs to"`
+	Build  string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to approve. If job not specified: build id"`
+	Step   string              `short:"s" long:"step" description:"Name of the approve step to decide, if the build has more than one"`
+	Reject bool                `long:"reject" description:"Reject the step instead of approving it, failing the build"`
+}
+
+func (command *ApproveCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineName == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineName, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	buildPlan, found, err := target.Client().BuildPlan(build.ID)
+	if err != nil {
+		return err
+	}
+
+	if !found || buildPlan.Plan == nil {
+		return errors.New("build has no plan")
+	}
+
+	planID, err := command.findApproveStep(*buildPlan.Plan)
+	if err != nil {
+		return err
+	}
+
+	found, err = target.Client().ApproveBuild(strconv.Itoa(build.ID), planID, !command.Reject)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("approve step not found")
+	}
+
+	if command.Reject {
+		fmt.Println("step rejected")
+	} else {
+		fmt.Println("step approved")
+	}
+
+	return nil
+}
+
+func (command *ApproveCommand) findApproveStep(plan json.RawMessage) (atc.PlanID, error) {
+	var tree interface{}
+	err := json.Unmarshal(plan, &tree)
+	if err != nil {
+		return "", err
+	}
+
+	steps := map[string][]atc.PlanID{}
+	var names []string
+	collectApproveSteps(tree, func(name string, id atc.PlanID) {
+		if _, seen := steps[name]; !seen {
+			names = append(names, name)
+		}
+
+		steps[name] = append(steps[name], id)
+	})
+
+	sort.Strings(names)
+
+	name := command.Step
+	if name == "" {
+		switch len(names) {
+		case 0:
+			return "", errors.New("build has no approve steps")
+		case 1:
+			name = names[0]
+		default:
+			return "", fmt.Errorf("build has more than one approve step; specify one with --step (%s)", strings.Join(names, ", "))
+		}
+	}
+
+	ids, found := steps[name]
+	if !found {
+		return "", fmt.Errorf("build has no approve step named '%s'", name)
+	}
+
+	if len(ids) > 1 {
+		return "", fmt.Errorf("build has more than one approve step named '%s'", name)
+	}
+
+	return ids[0], nil
+}
+
+// collectApproveSteps walks a public plan, calling f with the name and plan
+// ID of every approve step in it.
+func collectApproveSteps(node interface{}, f func(string, atc.PlanID)) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if approve, ok := n["approve"].(map[string]interface{}); ok {
+			name, _ := approve["name"].(string)
+			id, _ := n["id"].(string)
+			f(name, atc.PlanID(id))
+		}
+
+		for _, child := range n {
+			collectApproveSteps(child, f)
+		}
+
+	case []interface{}:
+		for _, child := range n {
+			collectApproveSteps(child, f)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type DeleteSecretCommand struct {
+	Secret   string                   `short:"s" long:"secret" required:"true" description:"Name of the secret to delete"`
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Delete the secret of this pipeline rather than the team secret"`
+}
+
+func (command *DeleteSecretCommand) Validate() error {
+	if strings.Contains(command.Secret, "/") {
+		return errors.New("secret name cannot contain '/'")
+	}
+
+	return command.Pipeline.Validate()
+}
+
+func (command *DeleteSecretCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Team().DestroySecret(string(command.Pipeline), command.Secret)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("secret '%s' not found\n", command.Secret)
+	}
+
+	fmt.Printf("deleted secret '%s'\n", command.Secret)
+
+	return nil
+}
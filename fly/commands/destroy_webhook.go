@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type DestroyWebhookCommand struct {
+	Webhook string `short:"w" long:"webhook" required:"true" description:"Name of the webhook to destroy"`
+}
+
+func (command *DestroyWebhookCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Team().DestroyWebhook(command.Webhook)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("webhook '%s' not found\n", command.Webhook)
+	}
+
+	fmt.Printf("destroyed webhook '%s'\n", command.Webhook)
+
+	return nil
+}
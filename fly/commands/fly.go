@@ -21,7 +21,8 @@ type FlyCommand struct {
 	Status StatusCommand `command:"status" description:"Login status"`
 	Sync   SyncCommand   `command:"sync"  alias:"s" description:"Download and replace the current fly from the target"`
 
-	Userinfo UserinfoCommand `command:"userinfo" description:"User information"`
+	Userinfo    UserinfoCommand    `command:"userinfo" description:"User information"`
+	Permissions PermissionsCommand `command:"permissions" description:"Print the actions the current user is permitted to perform on each team"`
 
 	Teams       TeamsCommand       `command:"teams" alias:"t" description:"List the configured teams"`
 	GetTeam     GetTeamCommand     `command:"get-team"  alias:"gt" description:"Show team configuration"`
@@ -40,6 +41,7 @@ type FlyCommand struct {
 	Jobs       JobsCommand       `command:"jobs"      alias:"js" description:"List the jobs in the pipelines"`
 	PauseJob   PauseJobCommand   `command:"pause-job" alias:"pj" description:"Pause a job"`
 	UnpauseJob UnpauseJobCommand `command:"unpause-job" alias:"uj" description:"Unpause a job"`
+	SearchLogs SearchLogsCommand `command:"search-logs" alias:"sl" description:"Search the logs of a job's recent builds"`
 
 	Pipelines        PipelinesCommand        `command:"pipelines"           alias:"ps"   description:"List the configured pipelines"`
 	DestroyPipeline  DestroyPipelineCommand  `command:"destroy-pipeline"    alias:"dp"   description:"Destroy a pipeline"`
@@ -47,12 +49,15 @@ type FlyCommand struct {
 	SetPipeline      SetPipelineCommand      `command:"set-pipeline"        alias:"sp"   description:"Create or update a pipeline's configuration"`
 	PausePipeline    PausePipelineCommand    `command:"pause-pipeline"      alias:"pp"   description:"Pause a pipeline"`
 	UnpausePipeline  UnpausePipelineCommand  `command:"unpause-pipeline"    alias:"up"   description:"Un-pause a pipeline"`
+	ArchivePipeline  ArchivePipelineCommand  `command:"archive-pipeline"    alias:"ap"   description:"Archive a pipeline, keeping its build history"`
 	ExposePipeline   ExposePipelineCommand   `command:"expose-pipeline"     alias:"ep"   description:"Make a pipeline publicly viewable"`
 	HidePipeline     HidePipelineCommand     `command:"hide-pipeline"       alias:"hp"   description:"Hide a pipeline from the public"`
 	RenamePipeline   RenamePipelineCommand   `command:"rename-pipeline"     alias:"rp"   description:"Rename a pipeline"`
 	ValidatePipeline ValidatePipelineCommand `command:"validate-pipeline"   alias:"vp"   description:"Validate a pipeline config"`
 	FormatPipeline   FormatPipelineCommand   `command:"format-pipeline"     alias:"fp"   description:"Format a pipeline config"`
 	OrderPipelines   OrderPipelinesCommand   `command:"order-pipelines"     alias:"op"   description:"Orders pipelines"`
+	PipelineHistory  PipelineHistoryCommand  `command:"pipeline-history"    alias:"ph"   description:"List a pipeline's config history, or show the changes made by a version"`
+	RollbackPipeline RollbackPipelineCommand `command:"rollback-pipeline"   alias:"rbp"  description:"Roll a pipeline's config back to a version from its config history"`
 
 	Resources        ResourcesCommand        `command:"resources"           alias:"rs"   description:"List the resources in the pipeline"`
 	ResourceVersions ResourceVersionsCommand `command:"resource-versions"   alias:"rvs"  description:"List the versions of a resource"`
@@ -60,10 +65,20 @@ type FlyCommand struct {
 
 	CheckResourceType CheckResourceTypeCommand `command:"check-resource-type" alias:"crt"  description:"Check a resource-type"`
 
+	SetWebhook     SetWebhookCommand     `command:"set-webhook"     alias:"sw" description:"Create or update a team webhook which checks the resources matching its payloads"`
+	DestroyWebhook DestroyWebhookCommand `command:"destroy-webhook" alias:"dw" description:"Destroy a team webhook"`
+
+	Secrets          SecretsCommand          `command:"secrets"            alias:"ss"  description:"List the secrets stored for the team, without their values"`
+	SetSecret        SetSecretCommand        `command:"set-secret"         alias:"sst" description:"Create or update a team or pipeline secret stored in the database"`
+	DeleteSecret     DeleteSecretCommand     `command:"delete-secret"      alias:"dst" description:"Delete a team or pipeline secret stored in the database"`
+	PurgeSecretCache PurgeSecretCacheCommand `command:"purge-secret-cache" alias:"psc" description:"Purge the team's cached secrets, e.g. after rotating a secret in a credential manager"`
+
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
 	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
 	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
+	Approve    ApproveCommand    `command:"approve"     alias:"apb" description:"Approve or reject a build's approve step"`
+	Queue      QueueCommand      `command:"queue"       alias:"q"   description:"List the pending builds waiting for worker capacity"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
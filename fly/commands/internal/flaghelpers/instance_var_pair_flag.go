@@ -0,0 +1,76 @@
+package flaghelpers
+
+import (
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type InstanceVarPairFlag struct {
+	Name  string
+	Value interface{}
+}
+
+func (pair *InstanceVarPairFlag) UnmarshalFlag(value string) error {
+	vs := strings.SplitN(value, "=", 2)
+	if len(vs) != 2 {
+		return fmt.Errorf("invalid input pair '%s' (must be name=value)", value)
+	}
+
+	pair.Name = vs[0]
+
+	var val interface{}
+	err := yaml.Unmarshal([]byte(vs[1]), &val)
+	if err != nil {
+		return err
+	}
+
+	// instance vars are sent to the ATC as JSON, which only supports string
+	// keys
+	pair.Value, err = stringifyKeys(val)
+	if err != nil {
+		return fmt.Errorf("invalid value for instance var '%s': %s", pair.Name, err)
+	}
+
+	return nil
+}
+
+func stringifyKeys(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		stringified := map[string]interface{}{}
+
+		for key, sub := range v {
+			str, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("non-string key '%v'", key)
+			}
+
+			subVal, err := stringifyKeys(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			stringified[str] = subVal
+		}
+
+		return stringified, nil
+
+	case []interface{}:
+		stringified := make([]interface{}, len(v))
+		for i, sub := range v {
+			subVal, err := stringifyKeys(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			stringified[i] = subVal
+		}
+
+		return stringified, nil
+
+	default:
+		return v, nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jessevdk/go-flags"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/rc"
 )
 
@@ -45,3 +46,17 @@ func (flag *PipelineFlag) Complete(match string) []flags.Completion {
 
 	return comps
 }
+
+// Ref identifies the pipeline instance with the given instance vars.
+func (flag PipelineFlag) Ref(instanceVars []InstanceVarPairFlag) atc.PipelineRef {
+	ref := atc.PipelineRef{Name: string(flag)}
+
+	if len(instanceVars) > 0 {
+		ref.InstanceVars = atc.InstanceVars{}
+		for _, iv := range instanceVars {
+			ref.InstanceVars[iv.Name] = iv.Value
+		}
+	}
+
+	return ref
+}
@@ -49,6 +49,14 @@ func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool,
 	if output {
 		fmt.Println(string(evaluatedTemplate))
 	} else {
+		if len(unmarshalledTemplate.VarSources) > 0 {
+			fmt.Println("var sources:")
+			for _, varSource := range unmarshalledTemplate.VarSources {
+				fmt.Printf("  - %s (%s)\n", varSource.Name, varSource.Type)
+			}
+			fmt.Println("")
+		}
+
 		fmt.Println("looks good")
 	}
 
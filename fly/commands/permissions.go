@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PermissionsCommand struct {
+	Team string `short:"n" long:"team" description:"Only show permissions on this team"`
+	Json bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *PermissionsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	userinfo, err := target.Client().UserInfo()
+	if err != nil {
+		return err
+	}
+
+	rbac, err := target.Client().GetRBACInfo()
+	if err != nil {
+		return err
+	}
+
+	permissions := map[string][]string{}
+
+	teams, ok := userinfo["teams"].(map[string]interface{})
+	if !ok && userinfo["teams"] != nil {
+		return fmt.Errorf("unexpected teams in user info: %v", userinfo["teams"])
+	}
+
+	for team, teamRoles := range teams {
+		if command.Team != "" && team != command.Team {
+			continue
+		}
+
+		roleList, ok := teamRoles.([]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected roles for team '%s' in user info: %v", team, teamRoles)
+		}
+
+		var roles []string
+		for _, r := range roleList {
+			role, ok := r.(string)
+			if !ok {
+				return fmt.Errorf("unexpected role for team '%s' in user info: %v", team, r)
+			}
+
+			roles = append(roles, role)
+		}
+
+		actions := rbac.PermittedActions(roles)
+		sort.Strings(actions)
+
+		permissions[team] = actions
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(permissions)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	var teamNames []string
+	for team := range permissions {
+		teamNames = append(teamNames, team)
+	}
+	sort.Strings(teamNames)
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "action", Color: color.New(color.Bold)},
+			{Contents: "required role", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, team := range teamNames {
+		for _, action := range permissions[team] {
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: team},
+				{Contents: action},
+				{Contents: rbac.Actions[action]},
+			})
+		}
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PipelineHistoryCommand struct {
+	Pipeline     flaghelpers.PipelineFlag          `short:"p" long:"pipeline" required:"true" description:"Pipeline whose config history to show"`
+	InstanceVars []flaghelpers.InstanceVarPairFlag `short:"i" long:"instance-var" value-name:"[NAME=YAML]" description:"Instance var identifying the pipeline instance"`
+	Diff         int                               `short:"d" long:"diff" value-name:"VERSION" description:"Show the changes made by a version, rather than listing the history"`
+	Json         bool                              `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *PipelineHistoryCommand) Validate() error {
+	return command.Pipeline.Validate()
+}
+
+func (command *PipelineHistoryCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref(command.InstanceVars)
+
+	if command.Diff != 0 {
+		diff, found, err := target.Team().PipelineConfigDiff(pipelineRef, command.Diff, 0)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return fmt.Errorf("version %d of pipeline %s not found", command.Diff, pipelineRef)
+		}
+
+		if command.Json {
+			return displayhelpers.JsonPrint(diff)
+		}
+
+		stdout, _ := ui.ForTTY(os.Stdout)
+		for _, change := range diff.Changes {
+			change.Render(stdout)
+		}
+
+		return nil
+	}
+
+	history, found, err := target.Team().PipelineConfigHistory(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(history)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "author", Color: color.New(color.Bold)},
+			{Contents: "saved", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, configVersion := range history {
+		authorCell := ui.TableCell{Contents: configVersion.Author}
+		if configVersion.Author == "" {
+			authorCell.Contents = "n/a"
+			authorCell.Color = color.New(color.Faint)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(configVersion.Version)},
+			authorCell,
+			{Contents: time.Unix(configVersion.CreatedAt, 0).Format(timeDateLayout)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type PurgeSecretCacheCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only purge the cached secrets of this pipeline"`
+	Path     string                   `long:"path" description:"Only purge the cached secret at this path, e.g. the name of a ((var))"`
+}
+
+func (command *PurgeSecretCacheCommand) Execute([]string) error {
+	err := command.Pipeline.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = target.Team().PurgeSecretCache(string(command.Pipeline), command.Path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("purged cached secrets of team '%s'\n", target.Team().Name())
+
+	return nil
+}
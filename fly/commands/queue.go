@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type QueueCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *QueueCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	queue, err := target.Client().BuildQueue()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(queue)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "position", Color: color.New(color.Bold)},
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "pipeline/job", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "priority", Color: color.New(color.Bold)},
+			{Contents: "queued", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, entry := range queue.Entries {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(entry.Position)},
+			{Contents: strconv.Itoa(entry.BuildID)},
+			{Contents: entry.TeamName},
+			{Contents: entry.PipelineName + "/" + entry.JobName},
+			{Contents: entry.BuildName},
+			{Contents: strconv.Itoa(entry.Priority)},
+			{Contents: time.Unix(entry.QueuedAt, 0).Format(timeDateLayout)},
+		})
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	dst, isTTY := ui.ForTTY(os.Stdout)
+	if isTTY {
+		fmt.Fprintln(dst, "")
+		fmt.Fprintf(dst, "%d of %d workers have capacity\n", queue.WorkersWithCapacity, queue.Workers)
+	}
+
+	return nil
+}
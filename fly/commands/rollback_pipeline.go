@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/vito/go-interact/interact"
+)
+
+type RollbackPipelineCommand struct {
+	Pipeline        flaghelpers.PipelineFlag          `short:"p" long:"pipeline" required:"true" description:"Pipeline to roll back"`
+	InstanceVars    []flaghelpers.InstanceVarPairFlag `short:"i" long:"instance-var" value-name:"[NAME=YAML]" description:"Instance var identifying the pipeline instance to roll back"`
+	To              int                               `long:"to" required:"true" value-name:"VERSION" description:"Version from the pipeline's config history to roll back to"`
+	SkipInteractive bool                              `short:"n" long:"non-interactive" description:"Roll back the pipeline without confirmation"`
+}
+
+func (command *RollbackPipelineCommand) Validate() error {
+	return command.Pipeline.Validate()
+}
+
+func (command *RollbackPipelineCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref(command.InstanceVars)
+
+	existingConfig, existingConfigVersion, found, err := target.Team().PipelineConfig(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	configVersion, found, err := target.Team().PipelineConfigVersion(pipelineRef, command.To)
+	if err != nil {
+		return err
+	}
+
+	if !found || configVersion.Config == nil {
+		return fmt.Errorf("version %d not found in the config history of pipeline %s", command.To, pipelineRef)
+	}
+
+	stdout, _ := ui.ForTTY(os.Stdout)
+
+	diffExists := existingConfig.Diff(stdout, *configVersion.Config)
+	if !diffExists {
+		fmt.Println("no changes to apply")
+		return nil
+	}
+
+	confirm := command.SkipInteractive
+	if !confirm {
+		err := interact.NewInteraction(fmt.Sprintf("roll back to version %d?", command.To)).Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	payload, err := yaml.Marshal(configVersion.Config)
+	if err != nil {
+		return err
+	}
+
+	_, _, warnings, err := target.Team().CreateOrUpdatePipelineConfig(
+		pipelineRef,
+		existingConfigVersion,
+		payload,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		displayhelpers.ShowWarnings(warnings)
+	}
+
+	fmt.Printf("rolled back to version %d\n", command.To)
+
+	return nil
+}
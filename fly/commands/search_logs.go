@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SearchLogsCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job whose build logs to search"`
+	Regex bool                `short:"e" long:"regex" description:"Treat the query as a regular expression rather than a substring"`
+	Count int                 `short:"c" long:"count" description:"Number of the job's most recent builds to search (default and maximum set by the server)"`
+	Json  bool                `long:"json" description:"Print command result as JSON"`
+
+	Positional struct {
+		Query string `positional-arg-name:"QUERY" required:"true" description:"Text to search for in the build logs"`
+	} `positional-args:"yes"`
+}
+
+func (command *SearchLogsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	results, found, err := target.Team().SearchJobBuildLogs(
+		command.Job.PipelineName,
+		command.Job.JobName,
+		command.Positional.Query,
+		command.Regex,
+		command.Count,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%s/%s not found\n", command.Job.PipelineName, command.Job.JobName)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(results)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if results.TimedOut {
+		displayhelpers.PrintWarningHeader()
+		fmt.Fprintf(ui.Stderr, "search timed out after %d builds; results are incomplete\n\n", results.Searched)
+	}
+
+	if results.Truncated {
+		displayhelpers.PrintWarningHeader()
+		fmt.Fprintf(ui.Stderr, "search stopped at the maximum number of matches after %d builds; results are incomplete\n\n", results.Searched)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "line", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, build := range results.Builds {
+		for _, match := range build.Matches {
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: build.BuildName},
+				stepCell(match.Origin),
+				{Contents: match.Line},
+			})
+		}
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func stepCell(origin atc.BuildLogMatchOrigin) ui.TableCell {
+	if origin.StepName == "" {
+		return ui.TableCell{Contents: origin.ID, Color: color.New(color.Faint)}
+	}
+
+	return ui.TableCell{Contents: origin.StepType + " " + origin.StepName}
+}
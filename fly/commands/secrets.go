@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SecretsCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SecretsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	secrets, err := target.Team().Secrets()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(secrets)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "updated", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, secret := range secrets {
+		pipelineCell := ui.TableCell{Contents: secret.PipelineName}
+		if secret.PipelineName == "" {
+			pipelineCell = ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: secret.Name},
+			pipelineCell,
+			{Contents: time.Unix(secret.UpdatedAt, 0).Format(timeDateLayout)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -22,6 +22,8 @@ type SetPipelineCommand struct {
 	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
 
 	VarsFrom []atc.PathFlag `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
+
+	InstanceVars []flaghelpers.InstanceVarPairFlag `short:"i"  long:"instance-var"  value-name:"[NAME=YAML]"  description:"Specify a YAML value to set for an instance var, distinguishing this pipeline from others with the same name. Instance vars are also available as variables in the pipeline"`
 }
 
 func (command *SetPipelineCommand) Validate() error {
@@ -35,7 +37,19 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 	}
 	configPath := command.Config
 	templateVariablesFiles := command.VarsFrom
-	pipelineName := string(command.Pipeline)
+	pipelineRef := atc.PipelineRef{Name: string(command.Pipeline)}
+
+	yamlVars := command.YAMLVar
+	if len(command.InstanceVars) > 0 {
+		pipelineRef.InstanceVars = atc.InstanceVars{}
+		for _, iv := range command.InstanceVars {
+			pipelineRef.InstanceVars[iv.Name] = iv.Value
+			yamlVars = append(yamlVars, flaghelpers.YAMLVariablePairFlag{
+				Name:  iv.Name,
+				Value: iv.Value,
+			})
+		}
+	}
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -51,13 +65,13 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 
 	atcConfig := setpipelinehelpers.ATCConfig{
 		Team:             target.Team(),
-		PipelineName:     pipelineName,
+		PipelineRef:      pipelineRef,
 		TargetName:       Fly.Target,
 		Target:           target.Client().URL(),
 		SkipInteraction:  command.SkipInteractive,
 		CheckCredentials: command.CheckCredentials,
 	}
 
-	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(configPath, templateVariablesFiles, command.Var, command.YAMLVar)
+	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(configPath, templateVariablesFiles, command.Var, yamlVars)
 	return atcConfig.Set(yamlTemplateWithParams)
 }
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/vito/go-interact/interact"
+)
+
+type SetSecretCommand struct {
+	Secret    string                   `short:"s" long:"secret" required:"true" description:"Name of the secret, referenced as ((name)) in pipelines"`
+	Pipeline  flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only make the secret available to the pipelines of this name"`
+	Value     string                   `long:"value" description:"Value of the secret. Prompted for if neither this nor --value-file is given"`
+	ValueFile atc.PathFlag             `long:"value-file" description:"File containing the value of the secret"`
+}
+
+func (command *SetSecretCommand) Validate() error {
+	if strings.Contains(command.Secret, "/") {
+		return errors.New("secret name cannot contain '/'")
+	}
+
+	return command.Pipeline.Validate()
+}
+
+func (command *SetSecretCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	value := command.Value
+	if command.ValueFile != "" {
+		contents, err := ioutil.ReadFile(string(command.ValueFile))
+		if err != nil {
+			return err
+		}
+		value = string(contents)
+	}
+
+	if value == "" {
+		var interactiveValue interact.Password
+		err := interact.NewInteraction("value").Resolve(interact.Required(&interactiveValue))
+		if err != nil {
+			return err
+		}
+		value = string(interactiveValue)
+	}
+
+	err = target.Team().SetSecret(atc.Secret{
+		Name:         command.Secret,
+		PipelineName: string(command.Pipeline),
+		Value:        value,
+	})
+	if err != nil {
+		return err
+	}
+
+	if command.Pipeline != "" {
+		fmt.Printf("secret '%s' set for pipeline '%s'\n", command.Secret, command.Pipeline)
+	} else {
+		fmt.Printf("secret '%s' set\n", command.Secret)
+	}
+
+	return nil
+}
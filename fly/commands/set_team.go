@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/jessevdk/go-flags"
 	"github.com/vito/go-interact/interact"
@@ -27,6 +28,10 @@ type SetTeamCommand struct {
 	TeamName        string               `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
 	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
 	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+
+	MaxRunningBuilds *int `long:"max-running-builds" value-name:"N" description:"Maximum number of builds the team may run at once (0 for unlimited)"`
+	MaxContainers    *int `long:"max-containers" value-name:"N" description:"Maximum number of containers the team may have at once (0 for unlimited)"`
+	MaxVolumes       *int `long:"max-volumes" value-name:"N" description:"Maximum number of volumes the team may have at once (0 for unlimited)"`
 }
 
 func (command *SetTeamCommand) Execute([]string) error {
@@ -80,6 +85,19 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	quotas, err := command.quotas(target.Client().Team(command.TeamName))
+	if err != nil {
+		return err
+	}
+
+	if quotas != nil {
+		fmt.Println()
+		fmt.Println("quotas:")
+		fmt.Printf("  max running builds: %s\n", quotaLimit(quotas.MaxRunningBuilds))
+		fmt.Printf("  max containers:     %s\n", quotaLimit(quotas.MaxContainers))
+		fmt.Printf("  max volumes:        %s\n", quotaLimit(quotas.MaxVolumes))
+	}
+
 	confirm := true
 	if !command.SkipInteractive {
 		confirm = false
@@ -93,7 +111,7 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: atc.TeamAuth(authRoles)}
+	team := atc.Team{Auth: atc.TeamAuth(authRoles), Quotas: quotas}
 
 	_, created, updated, err := target.Client().Team(command.TeamName).CreateOrUpdate(team)
 	if err != nil {
@@ -109,6 +127,45 @@ func (command *SetTeamCommand) Execute([]string) error {
 	return nil
 }
 
+// quotas returns the team's quotas if any of them were specified, leaving the
+// team's current quotas untouched otherwise. Quotas which were not specified
+// keep their current value.
+func (command *SetTeamCommand) quotas(team concourse.Team) (*atc.TeamQuotas, error) {
+	if command.MaxRunningBuilds == nil && command.MaxContainers == nil && command.MaxVolumes == nil {
+		return nil, nil
+	}
+
+	current, found, err := team.Team(command.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	quotas := &atc.TeamQuotas{}
+	if found && current.Quotas != nil {
+		*quotas = *current.Quotas
+	}
+
+	if command.MaxRunningBuilds != nil {
+		quotas.MaxRunningBuilds = *command.MaxRunningBuilds
+	}
+	if command.MaxContainers != nil {
+		quotas.MaxContainers = *command.MaxContainers
+	}
+	if command.MaxVolumes != nil {
+		quotas.MaxVolumes = *command.MaxVolumes
+	}
+
+	return quotas, nil
+}
+
+func quotaLimit(limit int) string {
+	if limit == 0 {
+		return ui.OffColor.Sprint("unlimited")
+	}
+
+	return fmt.Sprintf("%d", limit)
+}
+
 func (command *SetTeamCommand) ErrorAuthNotConfigured(err error) {
 	switch err {
 	case skycmd.ErrAuthNotConfiguredFromFile:
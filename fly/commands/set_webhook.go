@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/vito/go-interact/interact"
+)
+
+type SetWebhookCommand struct {
+	Webhook string `short:"w" long:"webhook" required:"true" description:"Name of the webhook"`
+	Type    string `long:"type" required:"true" choice:"github" choice:"gitlab" choice:"generic" description:"Kind of payload the webhook receives, used to find the resources to check"`
+	Secret  string `short:"s" long:"secret" description:"Secret used to verify payloads. Prompted for if not given"`
+}
+
+func (command *SetWebhookCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	secret := command.Secret
+	if secret == "" {
+		var interactiveSecret interact.Password
+		err := interact.NewInteraction("secret").Resolve(interact.Required(&interactiveSecret))
+		if err != nil {
+			return err
+		}
+		secret = string(interactiveSecret)
+	}
+
+	err = target.Team().SetWebhook(atc.Webhook{
+		Name:   command.Webhook,
+		Type:   command.Type,
+		Secret: secret,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("webhook '%s' set\n\n", command.Webhook)
+	fmt.Printf("configure it to POST payloads to:\n\n")
+	fmt.Printf("  %s/api/v1/teams/%s/webhooks/%s\n", strings.TrimRight(target.URL(), "/"), target.Team().Name(), command.Webhook)
+
+	return nil
+}
@@ -51,6 +51,27 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 		case event.FinishTask:
 			exitStatus = e.ExitStatus
 
+		case event.WaitForApproval:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mwaiting for approval\x1b[0m\n")
+
+		case event.FinishApproval:
+			decision := "rejected"
+			if e.Approved {
+				decision = "approved"
+			}
+
+			if e.ApprovedBy != "" {
+				decision += " by " + e.ApprovedBy
+			}
+
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1m%s\x1b[0m\n", decision)
+
+		case event.WaitingForWorker:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mwaiting for worker\x1b[0m\n")
+
 		case event.Error:
 			errCol := ui.ErroredColor.SprintFunc()
 			dstImpl.SetTimestamp(0)
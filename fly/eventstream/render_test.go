@@ -119,6 +119,58 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a WaitForApproval event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.WaitForApproval{
+				Time: time.Now().Unix(),
+			}
+		})
+
+		It("prints waiting for approval", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mwaiting for approval\x1b[0m\n"))
+		})
+	})
+
+	Context("when a WaitingForWorker event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.WaitingForWorker{
+				Time: time.Now().Unix(),
+			}
+		})
+
+		It("prints waiting for worker", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mwaiting for worker\x1b[0m\n"))
+		})
+	})
+
+	Context("when an approving FinishApproval event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.FinishApproval{
+				Time:       time.Now().Unix(),
+				Approved:   true,
+				ApprovedBy: "some-user",
+			}
+		})
+
+		It("prints who approved", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mapproved by some-user\x1b[0m\n"))
+		})
+	})
+
+	Context("when a rejecting FinishApproval event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.FinishApproval{
+				Time:       time.Now().Unix(),
+				Approved:   false,
+				ApprovedBy: "some-user",
+			}
+		})
+
+		It("prints who rejected", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mrejected by some-user\x1b[0m\n"))
+		})
+	})
+
 	Context("and a StartTask event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.StartTask{
@@ -0,0 +1,185 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("Approve", func() {
+	var (
+		expectedBuild atc.Build
+		plan          atc.Plan
+		args          []string
+	)
+
+	BeforeEach(func() {
+		expectedBuild = atc.Build{
+			ID:      23,
+			Name:    "42",
+			Status:  "started",
+			JobName: "myjob",
+			APIURL:  "api/v1/builds/23",
+		}
+
+		plan = atc.Plan{
+			ID: "1",
+			Do: &atc.DoPlan{
+				{ID: "2", Task: &atc.TaskPlan{Name: "deploy-staging"}},
+				{
+					ID: "3",
+					Timeout: &atc.TimeoutPlan{
+						Duration: "1h",
+						Step: atc.Plan{
+							ID:      "4",
+							Approve: &atc.ApprovePlan{Name: "deploy-prod"},
+						},
+					},
+				},
+			},
+		}
+
+		args = []string{"-t", targetName, "approve", "-b", "23"}
+	})
+
+	JustBeforeEach(func() {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23/plan"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PublicBuildPlan{
+					Schema: "exec.v2",
+					Plan:   plan.Public(),
+				}),
+			),
+		)
+	})
+
+	Context("when the build has one approve step", func() {
+		var approval atc.BuildApproval
+
+		BeforeEach(func() {
+			approval = atc.BuildApproval{Approved: true}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approvals/4"),
+					ghttp.VerifyJSONRepresenting(approval),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("approves the step", func() {
+			sess, err := gexec.Start(exec.Command(flyPath, args...), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("step approved"))
+		})
+
+		Context("when rejecting", func() {
+			BeforeEach(func() {
+				approval = atc.BuildApproval{Approved: false}
+				args = append(args, "--reject")
+			})
+
+			It("rejects the step", func() {
+				sess, err := gexec.Start(exec.Command(flyPath, args...), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("step rejected"))
+			})
+		})
+	})
+
+	Context("when the build has more than one approve step", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "1",
+				Do: &atc.DoPlan{
+					{ID: "2", Approve: &atc.ApprovePlan{Name: "deploy-staging"}},
+					{ID: "3", Approve: &atc.ApprovePlan{Name: "deploy-prod"}},
+				},
+			}
+		})
+
+		It("asks which step to decide", func() {
+			sess, err := gexec.Start(exec.Command(flyPath, args...), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("specify one with --step \\(deploy-prod, deploy-staging\\)"))
+		})
+
+		Context("when the step is specified", func() {
+			BeforeEach(func() {
+				args = append(args, "--step", "deploy-prod")
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approvals/3"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("approves that step", func() {
+				sess, err := gexec.Start(exec.Command(flyPath, args...), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("step approved"))
+			})
+		})
+	})
+
+	Context("when the build has no approve steps", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{ID: "1", Task: &atc.TaskPlan{Name: "deploy"}}
+		})
+
+		It("errors", func() {
+			sess, err := gexec.Start(exec.Command(flyPath, args...), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("build has no approve steps"))
+		})
+	})
+
+	Context("when the step has already been decided", func() {
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approvals/4"),
+					ghttp.RespondWith(http.StatusConflict, ""),
+				),
+			)
+		})
+
+		It("errors", func() {
+			sess, err := gexec.Start(exec.Command(flyPath, args...), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("step has already been decided or build is not running"))
+		})
+	})
+})
@@ -0,0 +1,134 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("archive-pipeline", func() {
+		var (
+			stdin io.Writer
+			args  []string
+			sess  *gexec.Session
+		)
+
+		BeforeEach(func() {
+			stdin = nil
+			args = []string{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "archive-pipeline"}, args...)...)
+			stdin, err = flyCmd.StdinPipe()
+			Expect(err).NotTo(HaveOccurred())
+
+			sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when a pipeline name is not specified", func() {
+			It("asks the user to specify a pipeline name", func() {
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("p", "pipeline") + "' was not specified"))
+			})
+		})
+
+		Context("when a pipeline name is specified", func() {
+			BeforeEach(func() {
+				args = append(args, "-p", "some-pipeline")
+			})
+
+			yes := func() {
+				Eventually(sess).Should(gbytes.Say(`archive pipeline 'some-pipeline'\? \[yN\]: `))
+				fmt.Fprintf(stdin, "y\n")
+			}
+
+			no := func() {
+				Eventually(sess).Should(gbytes.Say(`archive pipeline 'some-pipeline'\? \[yN\]: `))
+				fmt.Fprintf(stdin, "n\n")
+			}
+
+			It("bails out if the user says no", func() {
+				no()
+				Eventually(sess).Should(gbytes.Say(`bailing out`))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the pipeline exists", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/archive"),
+							ghttp.RespondWith(http.StatusOK, nil),
+						),
+					)
+				})
+
+				It("archives the pipeline if the user says yes", func() {
+					yes()
+					Eventually(sess).Should(gbytes.Say(`archived 'some-pipeline'`))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+
+				Context("when run noninteractively", func() {
+					BeforeEach(func() {
+						args = append(args, "-n")
+					})
+
+					It("archives the pipeline without confirming", func() {
+						Eventually(sess).Should(gbytes.Say(`archived 'some-pipeline'`))
+						Eventually(sess).Should(gexec.Exit(0))
+					})
+				})
+			})
+
+			Context("when the pipeline does not exist", func() {
+				BeforeEach(func() {
+					args = append(args, "-n")
+
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/archive"),
+							ghttp.RespondWith(http.StatusNotFound, nil),
+						),
+					)
+				})
+
+				It("prints an error message", func() {
+					Eventually(sess.Err).Should(gbytes.Say(`pipeline 'some-pipeline' not found`))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+
+			Context("when the pipeline is an instance", func() {
+				BeforeEach(func() {
+					args = append(args, "-i", "branch=feature", "-n")
+
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/archive", `instance_vars=%7B%22branch%22%3A%22feature%22%7D`),
+							ghttp.RespondWith(http.StatusOK, nil),
+						),
+					)
+				})
+
+				It("archives the instance", func() {
+					Eventually(sess).Should(gbytes.Say(`archived 'some-pipeline/branch:"feature"'`))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,162 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("permissions", func() {
+		var (
+			flyCmd *exec.Cmd
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "permissions")
+		})
+
+		Context("when userinfo and the RBAC policy are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/sky/userinfo"),
+						ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+							"user_name": "test_user",
+							"teams": map[string][]string{
+								"other_team": {"owner"},
+								"test_team":  {"viewer"},
+							},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/info/rbac"),
+						ghttp.RespondWithJSONEncoded(200, atc.RBACInfo{
+							Roles: atc.Roles,
+							Actions: map[string]string{
+								"SetTeam":   "owner",
+								"GetConfig": "viewer",
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the permitted actions for each team", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "action", Color: color.New(color.Bold)},
+						{Contents: "required role", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "other_team"}, {Contents: "GetConfig"}, {Contents: "viewer"}},
+						{{Contents: "other_team"}, {Contents: "SetTeam"}, {Contents: "owner"}},
+						{{Contents: "test_team"}, {Contents: "GetConfig"}, {Contents: "viewer"}},
+					},
+				}))
+			})
+
+			Context("when --team is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--team", "test_team")
+				})
+
+				It("only shows the permitted actions for that team", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(PrintTable(ui.Table{
+						Headers: ui.TableRow{
+							{Contents: "team", Color: color.New(color.Bold)},
+							{Contents: "action", Color: color.New(color.Bold)},
+							{Contents: "required role", Color: color.New(color.Bold)},
+						},
+						Data: []ui.TableRow{
+							{{Contents: "test_team"}, {Contents: "GetConfig"}, {Contents: "viewer"}},
+						},
+					}))
+				})
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints the permitted actions in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+						"other_team": ["GetConfig", "SetTeam"],
+						"test_team": ["GetConfig"]
+					}`))
+				})
+			})
+		})
+
+		Context("when the userinfo has malformed roles", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/sky/userinfo"),
+						ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+							"user_name": "test_user",
+							"teams": map[string]interface{}{
+								"test_team": "viewer",
+							},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/info/rbac"),
+						ghttp.RespondWithJSONEncoded(200, atc.RBACInfo{
+							Roles:   atc.Roles,
+							Actions: map[string]string{"GetConfig": "viewer"},
+						}),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("unexpected roles for team 'test_team' in user info: viewer"))
+			})
+		})
+
+		Context("and the api returns an internal server error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/sky/userinfo"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
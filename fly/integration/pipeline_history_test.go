@@ -0,0 +1,274 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("pipeline-history", func() {
+		var (
+			flyCmd  *exec.Cmd
+			savedAt time.Time
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline")
+			savedAt = time.Date(2019, 8, 7, 14, 14, 25, 0, time.UTC)
+		})
+
+		Context("when the pipeline exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/history"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.PipelineConfigVersion{
+							{Version: 5, Author: "some-user", CreatedAt: savedAt.Unix()},
+							{Version: 3, CreatedAt: savedAt.Unix()},
+						}),
+					),
+				)
+			})
+
+			It("lists the saved versions", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "version", Color: color.New(color.Bold)},
+						{Contents: "author", Color: color.New(color.Bold)},
+						{Contents: "saved", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "5"}, {Contents: "some-user"}, {Contents: savedAt.Local().Format("2006-01-02@15:04:05-0700")}},
+						{{Contents: "3"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: savedAt.Local().Format("2006-01-02@15:04:05-0700")}},
+					},
+				}))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/history"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline not found"))
+			})
+		})
+
+		Context("when --diff is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--diff", "5")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/history/5/diff"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineConfigDiff{
+							From: 3,
+							To:   5,
+							Changes: []atc.ConfigChange{
+								{
+									Kind:   "job",
+									Name:   "some-job",
+									Before: "name: some-job\n",
+									After:  "name: some-job\npublic: true\n",
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the changes made by the version", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("job some-job has changed:"))
+				Expect(sess.Out).To(gbytes.Say("public: true"))
+			})
+		})
+	})
+
+	Describe("rollback-pipeline", func() {
+		var (
+			stdin io.Writer
+			args  []string
+			sess  *gexec.Session
+
+			currentConfig  atc.Config
+			previousConfig atc.Config
+		)
+
+		BeforeEach(func() {
+			args = []string{"-p", "some-pipeline", "--to", "3"}
+
+			currentConfig = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job", Public: true}},
+			}
+
+			previousConfig = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: currentConfig}, http.Header{atc.ConfigVersionHeader: {"5"}}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "rollback-pipeline"}, args...)...)
+			stdin, err = flyCmd.StdinPipe()
+			Expect(err).NotTo(HaveOccurred())
+
+			sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the version exists", func() {
+			var status int
+
+			BeforeEach(func() {
+				status = http.StatusOK
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/history/3"),
+						ghttp.RespondWithJSONEncodedPtr(&status, &atc.PipelineConfigVersion{
+							Version: 3,
+							Config:  &previousConfig,
+						}),
+					),
+				)
+			})
+
+			yes := func() {
+				Eventually(sess).Should(gbytes.Say(`roll back to version 3\? \[yN\]: `))
+				fmt.Fprintf(stdin, "y\n")
+			}
+
+			no := func() {
+				Eventually(sess).Should(gbytes.Say(`roll back to version 3\? \[yN\]: `))
+				fmt.Fprintf(stdin, "n\n")
+			}
+
+			It("shows the changes to be rolled back", func() {
+				Eventually(sess).Should(gbytes.Say("job some-job has changed:"))
+				Eventually(sess).Should(gbytes.Say("public: true"))
+				no()
+			})
+
+			It("bails out if the user says no", func() {
+				no()
+				Eventually(sess).Should(gbytes.Say("bailing out"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the config is saved", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+							ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "5"),
+							func(w http.ResponseWriter, r *http.Request) {
+								receivedConfig := atc.Config{}
+								err := yaml.Unmarshal(getConfig(r), &receivedConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(receivedConfig.Jobs).To(HaveLen(1))
+								Expect(receivedConfig.Jobs[0].Name).To(Equal("some-job"))
+								Expect(receivedConfig.Jobs[0].Public).To(BeFalse())
+							},
+							ghttp.RespondWith(http.StatusOK, "{}"),
+						),
+					)
+				})
+
+				It("saves the version's config if the user says yes", func() {
+					yes()
+					Eventually(sess).Should(gbytes.Say("rolled back to version 3"))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+
+				Context("when run noninteractively", func() {
+					BeforeEach(func() {
+						args = append(args, "-n")
+					})
+
+					It("saves the version's config without confirming", func() {
+						Eventually(sess).Should(gbytes.Say("rolled back to version 3"))
+						Eventually(sess).Should(gexec.Exit(0))
+					})
+				})
+			})
+
+			Context("when the version matches the current config", func() {
+				BeforeEach(func() {
+					previousConfig = currentConfig
+				})
+
+				It("has nothing to do", func() {
+					Eventually(sess).Should(gbytes.Say("no changes to apply"))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+			})
+		})
+
+		Context("when the version is not in the history", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/history/3"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("version 3 not found in the config history of pipeline some-pipeline"))
+			})
+		})
+
+		Context("when --to is not given", func() {
+			BeforeEach(func() {
+				args = []string{"-p", "some-pipeline"}
+			})
+
+			It("errors", func() {
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("error: the required flag `--to' was not specified"))
+			})
+		})
+	})
+})
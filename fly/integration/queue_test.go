@@ -0,0 +1,142 @@
+package integration_test
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("queue", func() {
+		var (
+			flyCmd *exec.Cmd
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "queue")
+		})
+
+		Context("when the queue is returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/queue"),
+						ghttp.RespondWithJSONEncoded(200, atc.BuildQueue{
+							Workers:             4,
+							WorkersWithCapacity: 0,
+							Entries: []atc.BuildQueueEntry{
+								{
+									Position:     1,
+									BuildID:      42,
+									BuildName:    "7",
+									TeamName:     "main",
+									PipelineName: "some-pipeline",
+									JobName:      "deploy",
+									Priority:     10,
+									QueuedAt:     1565703016,
+								},
+								{
+									Position:     3,
+									BuildID:      38,
+									BuildName:    "12",
+									TeamName:     "main",
+									PipelineName: "other-pipeline",
+									JobName:      "unit",
+									QueuedAt:     1565702900,
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+              "workers": 4,
+              "workers_with_capacity": 0,
+              "entries": [
+                {
+                  "position": 1,
+                  "build_id": 42,
+                  "build_name": "7",
+                  "team_name": "main",
+                  "pipeline_name": "some-pipeline",
+                  "job_name": "deploy",
+                  "priority": 10,
+                  "queued_at": 1565703016
+                },
+                {
+                  "position": 3,
+                  "build_id": 38,
+                  "build_name": "12",
+                  "team_name": "main",
+                  "pipeline_name": "other-pipeline",
+                  "job_name": "unit",
+                  "priority": 0,
+                  "queued_at": 1565702900
+                }
+              ]
+            }`))
+				})
+			})
+
+			It("lists the queued builds in the order they will start", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "position", Color: color.New(color.Bold)},
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "pipeline/job", Color: color.New(color.Bold)},
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "priority", Color: color.New(color.Bold)},
+						{Contents: "queued", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "1"}, {Contents: "42"}, {Contents: "main"}, {Contents: "some-pipeline/deploy"}, {Contents: "7"}, {Contents: "10"}, {Contents: time.Unix(1565703016, 0).Format("2006-01-02@15:04:05-0700")}},
+						{{Contents: "3"}, {Contents: "38"}, {Contents: "main"}, {Contents: "other-pipeline/unit"}, {Contents: "12"}, {Contents: "0"}, {Contents: time.Unix(1565702900, 0).Format("2006-01-02@15:04:05-0700")}},
+					},
+				}))
+			})
+		})
+
+		Context("and the api returns an internal server error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/queue"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
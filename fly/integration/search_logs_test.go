@@ -0,0 +1,210 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("search-logs", func() {
+		var (
+			flyCmd      *exec.Cmd
+			expectedURL string
+			results     atc.BuildLogSearchResults
+		)
+
+		BeforeEach(func() {
+			expectedURL = "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/builds/search"
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "-j", "some-pipeline/some-job", "some error")
+
+			results = atc.BuildLogSearchResults{
+				Builds: []atc.BuildLogMatches{
+					{
+						BuildID:   3,
+						BuildName: "3",
+						Status:    "failed",
+						Matches: []atc.BuildLogMatch{
+							{
+								Origin: atc.BuildLogMatchOrigin{ID: "some-task-id", Source: "stderr", StepType: "task", StepName: "unit"},
+								Line:   "some error occurred",
+							},
+						},
+					},
+					{
+						BuildID:   1,
+						BuildName: "1",
+						Status:    "errored",
+						Matches: []atc.BuildLogMatch{
+							{
+								Origin: atc.BuildLogMatchOrigin{ID: "some-unknown-id", Source: "stdout"},
+								Line:   "another error",
+							},
+						},
+					},
+				},
+				Searched: 3,
+			}
+		})
+
+		Context("when the job exists", func() {
+			var query string
+
+			BeforeEach(func() {
+				query = "q=some+error"
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, query),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, results),
+					),
+				)
+			})
+
+			It("prints the matching lines of each build", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "step", Color: color.New(color.Bold)},
+						{Contents: "line", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "3"}, {Contents: "task unit"}, {Contents: "some error occurred"}},
+						{{Contents: "1"}, {Contents: "some-unknown-id", Color: color.New(color.Faint)}, {Contents: "another error"}},
+					},
+				}))
+			})
+
+			Context("when --regex and --count are given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--regex", "--count", "5")
+					query = "limit=5&q=some+error&regex=true"
+				})
+
+				It("passes them to the search", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(gbytes.Say("some error occurred"))
+				})
+			})
+
+			Context("when the search timed out", func() {
+				BeforeEach(func() {
+					results.TimedOut = true
+				})
+
+				It("warns that the results are incomplete", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Err).To(gbytes.Say("search timed out after 3 builds"))
+				})
+			})
+
+			Context("when the search stopped at the maximum number of matches", func() {
+				BeforeEach(func() {
+					results.Truncated = true
+				})
+
+				It("warns that the results are incomplete", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Err).To(gbytes.Say("search stopped at the maximum number of matches after 3 builds"))
+				})
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints the results in json", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+						"builds": [
+							{
+								"build_id": 3,
+								"build_name": "3",
+								"status": "failed",
+								"matches": [
+									{
+										"origin": {"id": "some-task-id", "source": "stderr", "step_type": "task", "step_name": "unit"},
+										"line": "some error occurred"
+									}
+								]
+							},
+							{
+								"build_id": 1,
+								"build_name": "1",
+								"status": "errored",
+								"matches": [
+									{
+										"origin": {"id": "some-unknown-id", "source": "stdout"},
+										"line": "another error"
+									}
+								]
+							}
+						],
+						"searched": 3
+					}`))
+				})
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("some-pipeline/some-job not found"))
+			})
+		})
+
+		Context("when no query is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "-j", "some-pipeline/some-job")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("QUERY"))
+			})
+		})
+	})
+})
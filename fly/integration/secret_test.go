@@ -0,0 +1,251 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("secrets", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "secrets")
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Secret{
+						{Name: "some-secret", UpdatedAt: 1565957019},
+						{Name: "other-secret", PipelineName: "some-pipeline", UpdatedAt: 1565957020},
+					}),
+				),
+			)
+		})
+
+		It("lists the secrets", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say(`some-secret\s+none`))
+			Expect(sess.Out).To(gbytes.Say(`other-secret\s+some-pipeline`))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "secrets", "--json")
+			})
+
+			It("prints the secrets as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{"name": "some-secret", "updated_at": 1565957019},
+					{"name": "other-secret", "pipeline_name": "some-pipeline", "updated_at": 1565957020}
+				]`))
+			})
+		})
+	})
+
+	Describe("set-secret", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "set-secret", "-s", "some-secret", "--value", "some-value")
+		})
+
+		Context("when the secret is saved", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/secrets/some-secret"),
+						ghttp.VerifyJSON(`{"name":"some-secret","value":"some-value"}`),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("does not print the value", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("secret 'some-secret' set"))
+				Expect(sess.Out.Contents()).ToNot(ContainSubstring("some-value"))
+			})
+		})
+
+		Context("when setting a pipeline secret from a file", func() {
+			var tmpdir string
+
+			BeforeEach(func() {
+				var err error
+				tmpdir, err = ioutil.TempDir("", "fly-secret")
+				Expect(err).NotTo(HaveOccurred())
+
+				valueFile := filepath.Join(tmpdir, "value")
+				err = ioutil.WriteFile(valueFile, []byte("some-file-value"), 0600)
+				Expect(err).NotTo(HaveOccurred())
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "set-secret", "-s", "some-secret", "-p", "some-pipeline", "--value-file", valueFile)
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/secrets/some-secret"),
+						ghttp.VerifyJSON(`{"name":"some-secret","pipeline_name":"some-pipeline","value":"some-file-value"}`),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tmpdir)
+			})
+
+			It("saves it", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("secret 'some-secret' set for pipeline 'some-pipeline'"))
+			})
+		})
+
+		Context("when the secret name contains a slash", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "set-secret", "-s", "some/secret", "--value", "some-value")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("secret name cannot contain '/'"))
+			})
+		})
+
+		Context("when the api returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/secrets/some-secret"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+
+	Describe("delete-secret", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "delete-secret", "-s", "some-secret")
+		})
+
+		Context("when the secret exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/secrets/some-secret"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("deletes it", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("deleted secret 'some-secret'"))
+			})
+		})
+
+		Context("when the secret does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/secrets/some-secret"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("secret 'some-secret' not found"))
+			})
+		})
+	})
+
+	Describe("purge-secret-cache", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "purge-secret-cache", "-p", "some-pipeline", "--path", "some-secret")
+		})
+
+		Context("when the cache is purged", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/secret-cache", "path=some-secret&pipeline_name=some-pipeline"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("says so", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("purged cached secrets of team 'main'"))
+			})
+		})
+
+		Context("when purging the cache fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/secret-cache"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+	})
+})
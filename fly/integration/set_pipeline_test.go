@@ -1040,6 +1040,34 @@ this is super secure
 				})
 			})
 
+			Context("when instance vars are given", func() {
+				BeforeEach(func() {
+					path, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("PUT", path, ghttp.CombineHandlers(
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.URL.Query().Get("instance_vars")).To(MatchJSON(`{"branch":"feature","version":2}`))
+						},
+						ghttp.RespondWith(http.StatusCreated, "{}"),
+					))
+					config.Resources[0].Name = "updated-name"
+				})
+
+				It("sets the pipeline instance", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-n", "-p", "awesome-pipeline", "-c", configFile.Name(), "-i", "branch=feature", "--instance-var", "version=2")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say("pipeline created!"))
+					Eventually(sess).Should(gbytes.Say(`fly -t ` + targetName + ` unpause-pipeline -p awesome-pipeline -i 'branch="feature"' -i 'version=2'`))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
 			Context("when the server returns warnings", func() {
 				BeforeEach(func() {
 					path, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
@@ -64,6 +64,43 @@ var _ = Describe("Fly CLI", func() {
 			Expect(sess.ExitCode()).To(Equal(0))
 		})
 
+		It("shows the var sources of a valid configuration", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"validate-pipeline",
+				"-c", "fixtures/testConfigVarSources.yml",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gbytes.Say("var sources:"))
+			Eventually(sess).Should(gbytes.Say(`  - some-vault \(vault\)`))
+			Eventually(sess).Should(gbytes.Say("looks good"))
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+		})
+
+		It("returns invalid when vars refer to an unknown var source", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"validate-pipeline",
+				"-c", "fixtures/testConfigUnknownVarSource.yml",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess.Err).Should(gbytes.Say("invalid var sources:"))
+			Eventually(sess.Err).Should(gbytes.Say("vars refer to unknown var source 'some-vault'"))
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+
+			Expect(sess.Err).To(gbytes.Say("configuration invalid"))
+		})
+
 		It("returns invalid on validation error", func() {
 			flyCmd := exec.Command(
 				flyPath,
@@ -0,0 +1,49 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Queue", func() {
+	Describe("BuildQueue", func() {
+		var expectedQueue atc.BuildQueue
+
+		BeforeEach(func() {
+			expectedQueue = atc.BuildQueue{
+				Workers:             2,
+				WorkersWithCapacity: 1,
+				Entries: []atc.BuildQueueEntry{
+					{
+						Position:     1,
+						BuildID:      42,
+						BuildName:    "3",
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Priority:     5,
+						QueuedAt:     1565703016,
+					},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/queue"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedQueue),
+				),
+			)
+		})
+
+		It("returns the build queue", func() {
+			queue, err := client.BuildQueue()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queue).To(Equal(expectedQueue))
+		})
+	})
+})
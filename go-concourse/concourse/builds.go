@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -79,6 +81,41 @@ func (team *team) JobBuild(pipelineName, jobName, buildName string) (atc.Build,
 	}
 }
 
+func (team *team) SearchJobBuildLogs(pipelineName, jobName, pattern string, regex bool, limit int) (atc.BuildLogSearchResults, bool, error) {
+	params := rata.Params{
+		"job_name":      jobName,
+		"pipeline_name": pipelineName,
+		"team_name":     team.name,
+	}
+
+	query := url.Values{atc.BuildLogSearchQueryPattern: {pattern}}
+	if regex {
+		query.Set(atc.BuildLogSearchQueryRegex, "true")
+	}
+
+	if limit > 0 {
+		query.Set(atc.BuildLogSearchQueryLimit, strconv.Itoa(limit))
+	}
+
+	var results atc.BuildLogSearchResults
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SearchJobBuildLogs,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &results,
+	})
+
+	switch err.(type) {
+	case nil:
+		return results, true, nil
+	case internal.ResourceNotFoundError:
+		return results, false, nil
+	default:
+		return results, false, err
+	}
+}
+
 func (client *client) Build(buildID string) (atc.Build, bool, error) {
 	params := rata.Params{
 		"build_id": buildID,
@@ -138,6 +175,40 @@ func (client *client) AbortBuild(buildID string) error {
 	}, nil)
 }
 
+func (client *client) ApproveBuild(buildID string, planID atc.PlanID, approved bool) (bool, error) {
+	params := rata.Params{
+		"build_id": buildID,
+		"plan_id":  string(planID),
+	}
+
+	jsonBytes, err := json.Marshal(atc.BuildApproval{Approved: approved})
+	if err != nil {
+		return false, err
+	}
+
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.ApproveBuild,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, nil)
+
+	switch e := err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	case internal.UnexpectedResponseError:
+		if e.StatusCode == http.StatusConflict {
+			return false, ErrApprovalConflict
+		}
+
+		return false, err
+	default:
+		return false, err
+	}
+}
+
 func (team *team) Builds(page Page) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
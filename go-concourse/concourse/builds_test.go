@@ -150,6 +150,86 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("SearchJobBuildLogs", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds/search"
+
+		Context("when the job exists", func() {
+			var expectedResults atc.BuildLogSearchResults
+
+			BeforeEach(func() {
+				expectedResults = atc.BuildLogSearchResults{
+					Builds: []atc.BuildLogMatches{
+						{
+							BuildID:   123,
+							BuildName: "mybuild",
+							Status:    "failed",
+							Matches: []atc.BuildLogMatch{
+								{
+									Origin: atc.BuildLogMatchOrigin{ID: "some-id", Source: "stderr", StepType: "task", StepName: "unit"},
+									Line:   "some error",
+								},
+							},
+						},
+					},
+					Searched: 5,
+				}
+			})
+
+			Context("when searching by substring", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", expectedURL, "q=some+error"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+						),
+					)
+				})
+
+				It("returns the results", func() {
+					results, found, err := team.SearchJobBuildLogs("mypipeline", "myjob", "some error", false, 0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(results).To(Equal(expectedResults))
+				})
+			})
+
+			Context("when searching by regular expression with a limit", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", expectedURL, "limit=5&q=err.%2A&regex=true"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+						),
+					)
+				})
+
+				It("passes them as query parameters", func() {
+					results, found, err := team.SearchJobBuildLogs("mypipeline", "myjob", "err.*", true, 5)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(results).To(Equal(expectedResults))
+				})
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.SearchJobBuildLogs("mypipeline", "myjob", "some error", false, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Build", func() {
 		Context("when build exists", func() {
 			expectedBuild := atc.Build{
@@ -423,6 +503,57 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("ApproveBuild", func() {
+		var (
+			status int
+			found  bool
+			err    error
+		)
+
+		BeforeEach(func() {
+			status = http.StatusNoContent
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approvals/some-plan-id"),
+					ghttp.VerifyJSONRepresenting(atc.BuildApproval{Approved: true}),
+					ghttp.RespondWithPtr(&status, nil),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			found, err = client.ApproveBuild("123", "some-plan-id", true)
+		})
+
+		It("sends the decision to ATC", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the build or step is not found", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("returns not found", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the step has already been decided", func() {
+			BeforeEach(func() {
+				status = http.StatusConflict
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(Equal(concourse.ErrApprovalConflict))
+			})
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
@@ -23,6 +23,21 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStub        func(string, atc.PlanID, bool) (bool, error)
+	approveBuildMutex       sync.RWMutex
+	approveBuildArgsForCall []struct {
+		arg1 string
+		arg2 atc.PlanID
+		arg3 bool
+	}
+	approveBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	approveBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -66,6 +81,18 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildQueueStub        func() (atc.BuildQueue, error)
+	buildQueueMutex       sync.RWMutex
+	buildQueueArgsForCall []struct {
+	}
+	buildQueueReturns struct {
+		result1 atc.BuildQueue
+		result2 error
+	}
+	buildQueueReturnsOnCall map[int]struct {
+		result1 atc.BuildQueue
+		result2 error
+	}
 	BuildResourcesStub        func(int) (atc.BuildInputsOutputs, bool, error)
 	buildResourcesMutex       sync.RWMutex
 	buildResourcesArgsForCall []struct {
@@ -124,6 +151,18 @@ type FakeClient struct {
 		result1 atc.Info
 		result2 error
 	}
+	GetRBACInfoStub        func() (atc.RBACInfo, error)
+	getRBACInfoMutex       sync.RWMutex
+	getRBACInfoArgsForCall []struct {
+	}
+	getRBACInfoReturns struct {
+		result1 atc.RBACInfo
+		result2 error
+	}
+	getRBACInfoReturnsOnCall map[int]struct {
+		result1 atc.RBACInfo
+		result2 error
+	}
 	HTTPClientStub        func() *http.Client
 	hTTPClientMutex       sync.RWMutex
 	hTTPClientArgsForCall []struct {
@@ -316,6 +355,71 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuild(arg1 string, arg2 atc.PlanID, arg3 bool) (bool, error) {
+	fake.approveBuildMutex.Lock()
+	ret, specificReturn := fake.approveBuildReturnsOnCall[len(fake.approveBuildArgsForCall)]
+	fake.approveBuildArgsForCall = append(fake.approveBuildArgsForCall, struct {
+		arg1 string
+		arg2 atc.PlanID
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ApproveBuild", []interface{}{arg1, arg2, arg3})
+	fake.approveBuildMutex.Unlock()
+	if fake.ApproveBuildStub != nil {
+		return fake.ApproveBuildStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.approveBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ApproveBuildCallCount() int {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	return len(fake.approveBuildArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildCalls(stub func(string, atc.PlanID, bool) (bool, error)) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildArgsForCall(i int) (string, atc.PlanID, bool) {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	argsForCall := fake.approveBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) ApproveBuildReturns(result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	fake.approveBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ApproveBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	if fake.approveBuildReturnsOnCall == nil {
+		fake.approveBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.approveBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -511,6 +615,61 @@ func (fake *FakeClient) BuildPlanReturnsOnCall(i int, result1 atc.PublicBuildPla
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildQueue() (atc.BuildQueue, error) {
+	fake.buildQueueMutex.Lock()
+	ret, specificReturn := fake.buildQueueReturnsOnCall[len(fake.buildQueueArgsForCall)]
+	fake.buildQueueArgsForCall = append(fake.buildQueueArgsForCall, struct {
+	}{})
+	fake.recordInvocation("BuildQueue", []interface{}{})
+	fake.buildQueueMutex.Unlock()
+	if fake.BuildQueueStub != nil {
+		return fake.BuildQueueStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildQueueReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) BuildQueueCallCount() int {
+	fake.buildQueueMutex.RLock()
+	defer fake.buildQueueMutex.RUnlock()
+	return len(fake.buildQueueArgsForCall)
+}
+
+func (fake *FakeClient) BuildQueueCalls(stub func() (atc.BuildQueue, error)) {
+	fake.buildQueueMutex.Lock()
+	defer fake.buildQueueMutex.Unlock()
+	fake.BuildQueueStub = stub
+}
+
+func (fake *FakeClient) BuildQueueReturns(result1 atc.BuildQueue, result2 error) {
+	fake.buildQueueMutex.Lock()
+	defer fake.buildQueueMutex.Unlock()
+	fake.BuildQueueStub = nil
+	fake.buildQueueReturns = struct {
+		result1 atc.BuildQueue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) BuildQueueReturnsOnCall(i int, result1 atc.BuildQueue, result2 error) {
+	fake.buildQueueMutex.Lock()
+	defer fake.buildQueueMutex.Unlock()
+	fake.BuildQueueStub = nil
+	if fake.buildQueueReturnsOnCall == nil {
+		fake.buildQueueReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildQueue
+			result2 error
+		})
+	}
+	fake.buildQueueReturnsOnCall[i] = struct {
+		result1 atc.BuildQueue
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) BuildResources(arg1 int) (atc.BuildInputsOutputs, bool, error) {
 	fake.buildResourcesMutex.Lock()
 	ret, specificReturn := fake.buildResourcesReturnsOnCall[len(fake.buildResourcesArgsForCall)]
@@ -765,6 +924,61 @@ func (fake *FakeClient) GetInfoReturnsOnCall(i int, result1 atc.Info, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeClient) GetRBACInfo() (atc.RBACInfo, error) {
+	fake.getRBACInfoMutex.Lock()
+	ret, specificReturn := fake.getRBACInfoReturnsOnCall[len(fake.getRBACInfoArgsForCall)]
+	fake.getRBACInfoArgsForCall = append(fake.getRBACInfoArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetRBACInfo", []interface{}{})
+	fake.getRBACInfoMutex.Unlock()
+	if fake.GetRBACInfoStub != nil {
+		return fake.GetRBACInfoStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getRBACInfoReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetRBACInfoCallCount() int {
+	fake.getRBACInfoMutex.RLock()
+	defer fake.getRBACInfoMutex.RUnlock()
+	return len(fake.getRBACInfoArgsForCall)
+}
+
+func (fake *FakeClient) GetRBACInfoCalls(stub func() (atc.RBACInfo, error)) {
+	fake.getRBACInfoMutex.Lock()
+	defer fake.getRBACInfoMutex.Unlock()
+	fake.GetRBACInfoStub = stub
+}
+
+func (fake *FakeClient) GetRBACInfoReturns(result1 atc.RBACInfo, result2 error) {
+	fake.getRBACInfoMutex.Lock()
+	defer fake.getRBACInfoMutex.Unlock()
+	fake.GetRBACInfoStub = nil
+	fake.getRBACInfoReturns = struct {
+		result1 atc.RBACInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetRBACInfoReturnsOnCall(i int, result1 atc.RBACInfo, result2 error) {
+	fake.getRBACInfoMutex.Lock()
+	defer fake.getRBACInfoMutex.Unlock()
+	fake.GetRBACInfoStub = nil
+	if fake.getRBACInfoReturnsOnCall == nil {
+		fake.getRBACInfoReturnsOnCall = make(map[int]struct {
+			result1 atc.RBACInfo
+			result2 error
+		})
+	}
+	fake.getRBACInfoReturnsOnCall[i] = struct {
+		result1 atc.RBACInfo
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) HTTPClient() *http.Client {
 	fake.hTTPClientMutex.Lock()
 	ret, specificReturn := fake.hTTPClientReturnsOnCall[len(fake.hTTPClientArgsForCall)]
@@ -1401,12 +1615,16 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
 	defer fake.buildEventsMutex.RUnlock()
 	fake.buildPlanMutex.RLock()
 	defer fake.buildPlanMutex.RUnlock()
+	fake.buildQueueMutex.RLock()
+	defer fake.buildQueueMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
 	fake.buildsMutex.RLock()
@@ -1415,6 +1633,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.getCLIReaderMutex.RUnlock()
 	fake.getInfoMutex.RLock()
 	defer fake.getInfoMutex.RUnlock()
+	fake.getRBACInfoMutex.RLock()
+	defer fake.getRBACInfoMutex.RUnlock()
 	fake.hTTPClientMutex.RLock()
 	defer fake.hTTPClientMutex.RUnlock()
 	fake.landWorkerMutex.RLock()
@@ -0,0 +1,98 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) PipelineConfigHistory(pipelineRef atc.PipelineRef) ([]atc.PipelineConfigVersion, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.name,
+	}
+
+	var history []atc.PipelineConfigVersion
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListPipelineConfigHistory,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &history,
+	})
+
+	switch err.(type) {
+	case nil:
+		return history, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (team *team) PipelineConfigVersion(pipelineRef atc.PipelineRef, version int) (atc.PipelineConfigVersion, bool, error) {
+	params := rata.Params{
+		"pipeline_name":  pipelineRef.Name,
+		"team_name":      team.name,
+		"config_version": strconv.Itoa(version),
+	}
+
+	var configVersion atc.PipelineConfigVersion
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetPipelineConfigVersion,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &configVersion,
+	})
+
+	switch err.(type) {
+	case nil:
+		return configVersion, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.PipelineConfigVersion{}, false, nil
+	default:
+		return atc.PipelineConfigVersion{}, false, err
+	}
+}
+
+// PipelineConfigDiff diffs a version of the pipeline's config against the
+// version from, or against the version saved before it if from is 0.
+func (team *team) PipelineConfigDiff(pipelineRef atc.PipelineRef, version int, from int) (atc.PipelineConfigDiff, bool, error) {
+	params := rata.Params{
+		"pipeline_name":  pipelineRef.Name,
+		"team_name":      team.name,
+		"config_version": strconv.Itoa(version),
+	}
+
+	query := url.Values{}
+	for key, values := range pipelineRef.QueryParams() {
+		query[key] = values
+	}
+
+	if from != 0 {
+		query.Set(atc.PipelineConfigDiffQueryFrom, strconv.Itoa(from))
+	}
+
+	var diff atc.PipelineConfigDiff
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DiffPipelineConfigVersion,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &diff,
+	})
+
+	switch err.(type) {
+	case nil:
+		return diff, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.PipelineConfigDiff{}, false, nil
+	default:
+		return atc.PipelineConfigDiff{}, false, err
+	}
+}
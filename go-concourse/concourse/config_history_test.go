@@ -0,0 +1,172 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Config History", func() {
+	pipelineRef := atc.PipelineRef{Name: "some-pipeline"}
+
+	Describe("PipelineConfigHistory", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/some-pipeline/config/history"
+
+		Context("when the pipeline exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.PipelineConfigVersion{
+							{Version: 2, Author: "some-user", CreatedAt: 1565187265},
+							{Version: 1, CreatedAt: 1565187200},
+						}),
+					),
+				)
+			})
+
+			It("returns the saved versions", func() {
+				history, found, err := team.PipelineConfigHistory(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(history).To(Equal([]atc.PipelineConfigVersion{
+					{Version: 2, Author: "some-user", CreatedAt: 1565187265},
+					{Version: 1, CreatedAt: 1565187200},
+				}))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PipelineConfigHistory(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PipelineConfigVersion", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/some-pipeline/config/history/2"
+
+		Context("when the version exists", func() {
+			config := atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineConfigVersion{
+							Version: 2,
+							Author:  "some-user",
+							Config:  &config,
+						}),
+					),
+				)
+			})
+
+			It("returns the version with its config", func() {
+				configVersion, found, err := team.PipelineConfigVersion(pipelineRef, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(configVersion.Version).To(Equal(2))
+				Expect(configVersion.Author).To(Equal("some-user"))
+				Expect(configVersion.Config).To(Equal(&config))
+			})
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PipelineConfigVersion(pipelineRef, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PipelineConfigDiff", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/some-pipeline/config/history/3/diff"
+
+		diff := atc.PipelineConfigDiff{
+			From: 1,
+			To:   3,
+			Changes: []atc.ConfigChange{
+				{Kind: "job", Name: "some-job", After: "name: some-job\n"},
+			},
+		}
+
+		Context("when diffing against the previous version", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, diff),
+					),
+				)
+			})
+
+			It("returns the diff", func() {
+				actualDiff, found, err := team.PipelineConfigDiff(pipelineRef, 3, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(actualDiff).To(Equal(diff))
+			})
+		})
+
+		Context("when diffing from a given version", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "from=1"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, diff),
+					),
+				)
+			})
+
+			It("passes the version along", func() {
+				_, found, err := team.PipelineConfigDiff(pipelineRef, 3, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PipelineConfigDiff(pipelineRef, 3, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package concourse
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -49,3 +50,7 @@ type InvalidConfigError struct {
 func (c InvalidConfigError) Error() string {
 	return fmt.Sprintf("invalid pipeline config:\n%s", strings.Join(c.Errors, "\n"))
 }
+
+// ErrApprovalConflict is returned when approving a build step that has
+// already been decided, or whose build is no longer running.
+var ErrApprovalConflict = errors.New("step has already been decided or build is not running")
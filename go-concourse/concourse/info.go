@@ -16,3 +16,15 @@ func (client *client) GetInfo() (atc.Info, error) {
 
 	return info, err
 }
+
+func (client *client) GetRBACInfo() (atc.RBACInfo, error) {
+	var info atc.RBACInfo
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetInfoRBAC,
+	}, &internal.Response{
+		Result: &info,
+	})
+
+	return info, err
+}
@@ -31,4 +31,28 @@ var _ = Describe("ATC Info", func() {
 			Expect(info.Version).To(Equal("12.3.4"))
 		})
 	})
+
+	Describe("GetRBACInfo", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/info/rbac"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.RBACInfo{
+						Roles:   []string{"owner", "viewer"},
+						Actions: map[string]string{"SaveConfig": "owner"},
+					}),
+				),
+			)
+		})
+
+		It("returns the policy that was returned from the server", func() {
+			info, err := client.GetRBACInfo()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(info).To(Equal(atc.RBACInfo{
+				Roles:   []string{"owner", "viewer"},
+				Actions: map[string]string{"SaveConfig": "owner"},
+			}))
+		})
+	})
 })
@@ -0,0 +1,102 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) Secrets() ([]atc.Secret, error) {
+	params := rata.Params{
+		"team_name": team.name,
+	}
+
+	var secrets []atc.Secret
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListSecrets,
+		Params:      params,
+	}, &internal.Response{
+		Result: &secrets,
+	})
+
+	return secrets, err
+}
+
+// SetSecret saves a team secret, or a pipeline secret if the secret has a
+// pipeline name.
+func (team *team) SetSecret(secret atc.Secret) error {
+	params := rata.Params{
+		"team_name":   team.name,
+		"secret_name": secret.Name,
+	}
+
+	requestName := atc.SetSecret
+	if secret.PipelineName != "" {
+		params["pipeline_name"] = secret.PipelineName
+		requestName = atc.SetPipelineSecret
+	}
+
+	jsonBytes, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+
+	return team.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, nil)
+}
+
+func (team *team) DestroySecret(pipelineName string, secretName string) (bool, error) {
+	params := rata.Params{
+		"team_name":   team.name,
+		"secret_name": secretName,
+	}
+
+	requestName := atc.DestroySecret
+	if pipelineName != "" {
+		params["pipeline_name"] = pipelineName
+		requestName = atc.DestroyPipelineSecret
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// PurgeSecretCache removes the team's cached secrets from the secret caches of
+// every ATC, narrowed down to a pipeline's secrets and/or a secret path if
+// given.
+func (team *team) PurgeSecretCache(pipelineName string, path string) error {
+	query := url.Values{}
+	if pipelineName != "" {
+		query.Set("pipeline_name", pipelineName)
+	}
+
+	if path != "" {
+		query.Set("path", path)
+	}
+
+	return team.connection.Send(internal.Request{
+		RequestName: atc.PurgeSecretCache,
+		Params:      rata.Params{"team_name": team.name},
+		Query:       query,
+	}, nil)
+}
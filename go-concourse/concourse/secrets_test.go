@@ -0,0 +1,160 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Secrets", func() {
+	Describe("Secrets", func() {
+		expectedURL := "/api/v1/teams/some-team/secrets"
+
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Secret{
+						{Name: "some-secret", UpdatedAt: 1565957019},
+						{Name: "some-secret", PipelineName: "some-pipeline", UpdatedAt: 1565957020},
+					}),
+				),
+			)
+		})
+
+		It("returns the team's secrets", func() {
+			secrets, err := team.Secrets()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secrets).To(Equal([]atc.Secret{
+				{Name: "some-secret", UpdatedAt: 1565957019},
+				{Name: "some-secret", PipelineName: "some-pipeline", UpdatedAt: 1565957020},
+			}))
+		})
+	})
+
+	Describe("SetSecret", func() {
+		Context("when setting a team secret", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/secrets/some-secret"),
+						ghttp.VerifyJSON(`{"name":"some-secret","value":"some-value"}`),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				err := team.SetSecret(atc.Secret{Name: "some-secret", Value: "some-value"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when setting a pipeline secret", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/secrets/some-secret"),
+						ghttp.VerifyJSON(`{"name":"some-secret","pipeline_name":"some-pipeline","value":"some-value"}`),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				err := team.SetSecret(atc.Secret{Name: "some-secret", PipelineName: "some-pipeline", Value: "some-value"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the secret is invalid", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/secrets/some-secret"),
+						ghttp.RespondWith(http.StatusBadRequest, "secret value must not be empty"),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				err := team.SetSecret(atc.Secret{Name: "some-secret"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("secret value must not be empty"))
+			})
+		})
+	})
+
+	Describe("DestroySecret", func() {
+		Context("when the secret exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/pipelines/some-pipeline/secrets/some-secret"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.DestroySecret("some-pipeline", "some-secret")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the secret does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/secrets/some-secret"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.DestroySecret("", "some-secret")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PurgeSecretCache", func() {
+		Context("when purging the team's cached secrets", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/secret-cache", ""),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				err := team.PurgeSecretCache("", "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when purging a pipeline's cached secret", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/secret-cache", "path=some-secret&pipeline_name=some-pipeline"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				err := team.PurgeSecretCache("some-pipeline", "some-secret")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
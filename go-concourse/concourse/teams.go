@@ -73,6 +73,27 @@ func (team *team) CreateOrUpdate(passedTeam atc.Team) (atc.Team, bool, bool, err
 	return savedTeam, created, updated, nil
 }
 
+// Usage returns the team's current usage of the resources limited by its
+// quotas.
+func (team *team) Usage() (atc.TeamUsage, bool, error) {
+	var usage atc.TeamUsage
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetTeamUsage,
+		Params:      rata.Params{"team_name": team.name},
+	}, &internal.Response{
+		Result: &usage,
+	})
+
+	switch err.(type) {
+	case nil:
+		return usage, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.TeamUsage{}, false, nil
+	default:
+		return atc.TeamUsage{}, false, err
+	}
+}
+
 // DestroyTeam destroys the team with the name given as argument.
 func (team *team) DestroyTeam(teamName string) error {
 	params := rata.Params{"team_name": teamName}
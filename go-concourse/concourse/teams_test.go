@@ -81,6 +81,54 @@ var _ = Describe("ATC Handler Teams", func() {
 			})
 		})
 	})
+	Describe("Usage", func() {
+		expectedURL := "/api/v1/teams/some-team/usage"
+
+		Context("when the team is found", func() {
+			var expectedUsage atc.TeamUsage
+
+			BeforeEach(func() {
+				expectedUsage = atc.TeamUsage{
+					Quotas:        atc.TeamQuotas{MaxRunningBuilds: 5},
+					RunningBuilds: 3,
+					Containers:    20,
+					Volumes:       40,
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsage),
+					),
+				)
+			})
+
+			It("returns the usage of the team", func() {
+				usage, found, err := team.Usage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(usage).To(Equal(expectedUsage))
+			})
+		})
+
+		Context("when the team is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.Usage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("CreateOrUpdate", func() {
 		var expectedURL = "/api/v1/teams/team venture"
 		var expectedTeam, desiredTeam atc.Team
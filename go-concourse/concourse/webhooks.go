@@ -0,0 +1,51 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) SetWebhook(webhook atc.Webhook) error {
+	params := rata.Params{
+		"team_name":    team.name,
+		"webhook_name": webhook.Name,
+	}
+
+	jsonBytes, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+
+	return team.connection.Send(internal.Request{
+		RequestName: atc.SetWebhook,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, nil)
+}
+
+func (team *team) DestroyWebhook(webhookName string) (bool, error) {
+	params := rata.Params{
+		"team_name":    team.name,
+		"webhook_name": webhookName,
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DestroyWebhook,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,96 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Webhooks", func() {
+	Describe("SetWebhook", func() {
+		expectedURL := "/api/v1/teams/some-team/webhooks/some-webhook"
+
+		Context("when the webhook is saved", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.VerifyJSON(`{"name":"some-webhook","type":"github","secret":"some-secret"}`),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				err := team.SetWebhook(atc.Webhook{
+					Name:   "some-webhook",
+					Type:   "github",
+					Secret: "some-secret",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the webhook is invalid", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusBadRequest, "unknown webhook type 'bogus'"),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				err := team.SetWebhook(atc.Webhook{
+					Name:   "some-webhook",
+					Type:   "bogus",
+					Secret: "some-secret",
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unknown webhook type 'bogus'"))
+			})
+		})
+	})
+
+	Describe("DestroyWebhook", func() {
+		expectedURL := "/api/v1/teams/some-team/webhooks/some-webhook"
+
+		Context("when the webhook exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", expectedURL),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.DestroyWebhook("some-webhook")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the webhook does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.DestroyWebhook("some-webhook")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
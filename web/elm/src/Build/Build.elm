@@ -1186,6 +1186,7 @@ viewBuildPrep buildPrep =
                             ++ viewBuildPrepInputs prep.inputs
                             ++ [ viewBuildPrepLi "waiting for a suitable set of input versions" prep.inputsSatisfied prep.missingInputReasons
                                , viewBuildPrepLi "checking max-in-flight is not reached" prep.maxRunningBuilds Dict.empty
+                               , viewBuildPrepLi "checking team max running builds is not reached" prep.teamMaxRunningBuilds Dict.empty
                                ]
                         )
                     ]
@@ -78,3 +78,5 @@ type StepHeaderType
     = StepHeaderPut
     | StepHeaderGet Bool
     | StepHeaderTask
+    | StepHeaderSetPipeline
+    | StepHeaderLoadVar
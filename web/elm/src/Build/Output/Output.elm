@@ -211,6 +211,46 @@ handleEvent event ( model, effects, outmsg ) =
             , outmsg
             )
 
+        WaitForApproval origin time ->
+            ( updateStep origin.id (setStart time) model
+            , effects
+            , outmsg
+            )
+
+        WaitingForWorker origin time ->
+            ( updateStep origin.id (appendStepLog "waiting for worker...\n" (Just time)) model
+            , effects
+            , outmsg
+            )
+
+        FinishApproval origin approved approvedBy time ->
+            let
+                decision =
+                    if approved then
+                        "approved"
+
+                    else
+                        "rejected"
+
+                exitStatus =
+                    if approved then
+                        0
+
+                    else
+                        1
+
+                output =
+                    if approvedBy == "" then
+                        decision ++ "\n"
+
+                    else
+                        decision ++ " by " ++ approvedBy ++ "\n"
+            in
+            ( updateStep origin.id (finishStep exitStatus (Just time) << appendStepLog output (Just time)) model
+            , effects
+            , outmsg
+            )
+
         BuildStatus status date ->
             let
                 newSt =
@@ -40,6 +40,8 @@ type alias StepTreeModel =
 
 type StepTree
     = Task Step
+    | SetPipeline Step
+    | LoadVar Step
     | ArtifactInput Step
     | Get Step
     | ArtifactOutput Step
@@ -130,6 +132,9 @@ type BuildEvent
     | InitializePut Origin Time.Posix
     | StartPut Origin Time.Posix
     | FinishPut Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
+    | WaitForApproval Origin Time.Posix
+    | FinishApproval Origin Bool String Time.Posix
+    | WaitingForWorker Origin Time.Posix
     | Log Origin String (Maybe Time.Posix)
     | Error Origin String Time.Posix
     | End
@@ -175,6 +180,12 @@ map f tree =
         Task step ->
             Task (f step)
 
+        SetPipeline step ->
+            SetPipeline (f step)
+
+        LoadVar step ->
+            LoadVar (f step)
+
         Get step ->
             Get (f step)
 
@@ -317,6 +328,12 @@ finishTree root =
         Task step ->
             Task (finishStep step)
 
+        SetPipeline step ->
+            SetPipeline (finishStep step)
+
+        LoadVar step ->
+            LoadVar (finishStep step)
+
         ArtifactInput step ->
             ArtifactInput (finishStep step)
 
@@ -62,6 +62,12 @@ init hl resources buildPlan =
         Concourse.BuildStepTask name ->
             initBottom hl Task buildPlan.id name
 
+        Concourse.BuildStepSetPipeline name ->
+            initBottom hl SetPipeline buildPlan.id name
+
+        Concourse.BuildStepLoadVar name ->
+            initBottom hl LoadVar buildPlan.id name
+
         Concourse.BuildStepArtifactInput name ->
             initBottom hl
                 (\s ->
@@ -272,6 +278,12 @@ treeIsActive stepTree =
         Task step ->
             stepIsActive step
 
+        SetPipeline step ->
+            stepIsActive step
+
+        LoadVar step ->
+            stepIsActive step
+
         ArtifactInput _ ->
             False
 
@@ -432,6 +444,12 @@ viewTree session model tree =
         Task step ->
             viewStep model session step StepHeaderTask
 
+        SetPipeline step ->
+            viewStep model session step StepHeaderSetPipeline
+
+        LoadVar step ->
+            viewStep model session step StepHeaderLoadVar
+
         ArtifactInput step ->
             viewStep model session step (StepHeaderGet False)
 
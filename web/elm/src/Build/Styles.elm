@@ -164,6 +164,12 @@ stepHeaderIcon icon =
 
                 StepHeaderTask ->
                     "terminal"
+
+                StepHeaderSetPipeline ->
+                    "breadcrumb-pipeline"
+
+                StepHeaderLoadVar ->
+                    "arrow-downward"
     in
     [ style "height" "28px"
     , style "width" "28px"
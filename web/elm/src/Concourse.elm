@@ -231,6 +231,7 @@ type alias BuildPrep =
     { pausedPipeline : BuildPrepStatus
     , pausedJob : BuildPrepStatus
     , maxRunningBuilds : BuildPrepStatus
+    , teamMaxRunningBuilds : BuildPrepStatus
     , inputs : Dict String BuildPrepStatus
     , inputsSatisfied : BuildPrepStatus
     , missingInputReasons : Dict String String
@@ -249,6 +250,7 @@ decodeBuildPrep =
         |> andMap (Json.Decode.field "paused_pipeline" decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "paused_job" decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "max_running_builds" decodeBuildPrepStatus)
+        |> andMap (defaultTo BuildPrepStatusNotBlocking <| Json.Decode.field "team_max_running_builds" decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "inputs" <| Json.Decode.dict decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "inputs_satisfied" decodeBuildPrepStatus)
         |> andMap (defaultTo Dict.empty <| Json.Decode.field "missing_input_reasons" <| Json.Decode.dict Json.Decode.string)
@@ -333,6 +335,8 @@ type alias StepName =
 
 type BuildStep
     = BuildStepTask StepName
+    | BuildStepSetPipeline StepName
+    | BuildStepLoadVar StepName
     | BuildStepArtifactInput StepName
     | BuildStepGet StepName (Maybe Version)
     | BuildStepArtifactOutput StepName
@@ -371,6 +375,12 @@ decodeBuildPlan_ =
                 -- buckle up
                 [ Json.Decode.field "task" <|
                     lazy (\_ -> decodeBuildStepTask)
+                , Json.Decode.field "set_pipeline" <|
+                    lazy (\_ -> decodeBuildStepSetPipeline)
+                , Json.Decode.field "load_var" <|
+                    lazy (\_ -> decodeBuildStepLoadVar)
+                , Json.Decode.field "approve" <|
+                    lazy (\_ -> decodeBuildStepTask)
                 , Json.Decode.field "get" <|
                     lazy (\_ -> decodeBuildStepGet)
                 , Json.Decode.field "artifact_input" <|
@@ -385,6 +395,8 @@ decodeBuildPlan_ =
                     lazy (\_ -> decodeBuildStepAggregate)
                 , Json.Decode.field "in_parallel" <|
                     lazy (\_ -> decodeBuildStepInParallel)
+                , Json.Decode.field "across" <|
+                    lazy (\_ -> decodeBuildStepAcross)
                 , Json.Decode.field "do" <|
                     lazy (\_ -> decodeBuildStepDo)
                 , Json.Decode.field "on_success" <|
@@ -413,6 +425,18 @@ decodeBuildStepTask =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepSetPipeline : Json.Decode.Decoder BuildStep
+decodeBuildStepSetPipeline =
+    Json.Decode.succeed BuildStepSetPipeline
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
+decodeBuildStepLoadVar : Json.Decode.Decoder BuildStep
+decodeBuildStepLoadVar =
+    Json.Decode.succeed BuildStepLoadVar
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
 decodeBuildStepArtifactInput : Json.Decode.Decoder BuildStep
 decodeBuildStepArtifactInput =
     Json.Decode.succeed BuildStepArtifactInput
@@ -450,6 +474,12 @@ decodeBuildStepInParallel =
         |> andMap (Json.Decode.field "steps" <| Json.Decode.array (lazy (\_ -> decodeBuildPlan_)))
 
 
+decodeBuildStepAcross : Json.Decode.Decoder BuildStep
+decodeBuildStepAcross =
+    Json.Decode.succeed BuildStepInParallel
+        |> andMap (Json.Decode.field "steps" <| Json.Decode.array (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan_)))
+
+
 decodeBuildStepDo : Json.Decode.Decoder BuildStep
 decodeBuildStepDo =
     Json.Decode.succeed BuildStepDo
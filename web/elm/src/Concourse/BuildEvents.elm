@@ -151,6 +151,32 @@ decodeBuildEvent =
                     "finish-put" ->
                         Json.Decode.field "data" (decodeFinishResource FinishPut)
 
+                    "wait-for-approval" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 WaitForApproval
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "finish-approval" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map4 FinishApproval
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "approved" Json.Decode.bool)
+                                (Json.Decode.map (Maybe.withDefault "") <| Json.Decode.maybe <| Json.Decode.field "approved_by" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "waiting-for-worker" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 WaitingForWorker
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     unknown ->
                         Json.Decode.fail ("unknown event type: " ++ unknown)
             )
@@ -2142,6 +2142,7 @@ all =
                             { pausedPipeline = BuildPrepStatusNotBlocking
                             , pausedJob = BuildPrepStatusNotBlocking
                             , maxRunningBuilds = BuildPrepStatusNotBlocking
+                            , teamMaxRunningBuilds = BuildPrepStatusNotBlocking
                             , inputs = Dict.empty
                             , inputsSatisfied = BuildPrepStatusNotBlocking
                             , missingInputReasons = Dict.empty
@@ -2184,6 +2185,7 @@ all =
                             { pausedPipeline = BuildPrepStatusBlocking
                             , pausedJob = BuildPrepStatusNotBlocking
                             , maxRunningBuilds = BuildPrepStatusNotBlocking
+                            , teamMaxRunningBuilds = BuildPrepStatusNotBlocking
                             , inputs = Dict.empty
                             , inputsSatisfied = BuildPrepStatusNotBlocking
                             , missingInputReasons = Dict.empty
@@ -2221,6 +2223,7 @@ all =
                             { pausedPipeline = BuildPrepStatusUnknown
                             , pausedJob = BuildPrepStatusNotBlocking
                             , maxRunningBuilds = BuildPrepStatusNotBlocking
+                            , teamMaxRunningBuilds = BuildPrepStatusNotBlocking
                             , inputs = Dict.empty
                             , inputsSatisfied = BuildPrepStatusNotBlocking
                             , missingInputReasons = Dict.empty
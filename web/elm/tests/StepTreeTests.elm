@@ -6,9 +6,11 @@ module StepTreeTests exposing
     , initGet
     , initInParallel
     , initInParallelNested
+    , initLoadVar
     , initOnFailure
     , initOnSuccess
     , initPut
+    , initSetPipeline
     , initTask
     , initTimeout
     , initTry
@@ -29,6 +31,8 @@ all : Test
 all =
     describe "StepTree"
         [ initTask
+        , initSetPipeline
+        , initLoadVar
         , initGet
         , initPut
         , initAggregate
@@ -97,6 +101,58 @@ initTask =
         ]
 
 
+initSetPipeline : Test
+initSetPipeline =
+    let
+        { tree, foci } =
+            StepTree.init Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = BuildStepSetPipeline "some-name"
+                }
+    in
+    describe "init with SetPipeline"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal
+                    (Models.SetPipeline (someStep "some-id" "some-name" Models.StepStatePending))
+                    tree
+        , test "using the focus" <|
+            \_ ->
+                assertFocus "some-id"
+                    foci
+                    tree
+                    (\s -> { s | state = Models.StepStateSucceeded })
+                    (Models.SetPipeline (someStep "some-id" "some-name" Models.StepStateSucceeded))
+        ]
+
+
+initLoadVar : Test
+initLoadVar =
+    let
+        { tree, foci } =
+            StepTree.init Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = BuildStepLoadVar "some-name"
+                }
+    in
+    describe "init with LoadVar"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal
+                    (Models.LoadVar (someStep "some-id" "some-name" Models.StepStatePending))
+                    tree
+        , test "using the focus" <|
+            \_ ->
+                assertFocus "some-id"
+                    foci
+                    tree
+                    (\s -> { s | state = Models.StepStateSucceeded })
+                    (Models.LoadVar (someStep "some-id" "some-name" Models.StepStateSucceeded))
+        ]
+
+
 initGet : Test
 initGet =
     let